@@ -24,6 +24,8 @@ import (
 
 	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	"github.com/kubevirt/vm-import-operator/pkg/metrics"
+	"github.com/kubevirt/vm-import-operator/pkg/requester"
+	"github.com/kubevirt/vm-import-operator/pkg/tracing"
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	kubemetrics "github.com/operator-framework/operator-sdk/pkg/kube-metrics"
 	"github.com/operator-framework/operator-sdk/pkg/leader"
@@ -36,6 +38,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 // Change below variables to serve metrics on different host or port.
@@ -70,6 +73,13 @@ func main() {
 
 	printVersion()
 
+	stopTracing, err := tracing.Init()
+	if err != nil {
+		log.Error(err, "Failed to set up tracing")
+		os.Exit(1)
+	}
+	defer stopTracing()
+
 	namespace, err := k8sutil.GetWatchNamespace()
 	if err != nil {
 		log.Error(err, "Failed to get watch namespace")
@@ -93,10 +103,24 @@ func main() {
 		}
 	}
 
+	kubevirtNamespace, err := clientutil.GetNamespace()
+	if err != nil {
+		log.Error(err, "Cannot get operator's namespace")
+		os.Exit(1)
+	}
+
+	certDir, err := requester.ServingCertDir(cfg, kubevirtNamespace)
+	if err != nil {
+		log.Error(err, "Cannot get the serving certificate of the webhook")
+		os.Exit(1)
+	}
+
 	// Create a new Cmd to provide shared dependencies and start components
 	mgr, err := manager.New(cfg, manager.Options{
 		Namespace:          namespace,
 		MetricsBindAddress: fmt.Sprintf("%s:%d", metrics.MetricsHost, metrics.MetricsPort),
+		Port:               requester.WebhookPort,
+		CertDir:            certDir,
 	})
 	if err != nil {
 		log.Error(err, "")
@@ -129,12 +153,6 @@ func main() {
 		os.Exit(1)
 	}
 
-	kubevirtNamespace, err := clientutil.GetNamespace()
-	if err != nil {
-		log.Error(err, "Cannot get operator's namespace")
-		os.Exit(1)
-	}
-
 	k8sClient := kubernetes.NewForConfigOrDie(mgr.GetConfig())
 	stop := make(chan struct{})
 	defer close(stop)
@@ -148,6 +166,9 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Record the requester of the imports, who must be allowed to create VMs in their target namespace
+	mgr.GetWebhookServer().Register(requester.WebhookPath, &webhook.Admission{Handler: &requester.Annotator{}})
+
 	if err = serveCRMetrics(cfg); err != nil {
 		log.Info("Could not generate and serve custom resource metrics", "error", err.Error())
 	}
@@ -42,6 +42,10 @@ type OvirtMappings struct {
 	// DiskMappings is respected only when provided in context of a single VM import within VirtualMachineImport
 	// +optional
 	DiskMappings *[]StorageResourceMappingItem `json:"diskMappings,omitempty"`
+
+	// UnmappedNetworks defines what happens to the NICs connected to networks without a mapping
+	// +optional
+	UnmappedNetworks *UnmappedNetworkPolicy `json:"unmappedNetworks,omitempty"`
 }
 
 // VmwareMappings defines the mappings of vmware resources to kubevirt
@@ -63,8 +67,70 @@ type VmwareMappings struct {
 	// DiskMappings.Source.Name represents the disk name in vCenter
 	// DiskMappings.Source.ID represents the `DiskObjectId` or `vDiskID` of the VirtualDisk in vCenter
 	DiskMappings *[]StorageResourceMappingItem `json:"diskMappings,omitempty"`
+
+	// InterfaceModel is the model of the interfaces of the target VM, virtio by default
+	// It is overridden by the InterfaceModel of the network mapping of a NIC
+	// +optional
+	InterfaceModel *string `json:"interfaceModel,omitempty"`
+
+	// InterfaceBinding defines how the interfaces of the target VM are connected to their networks:
+	// bridge for multus networks and masquerade for the pod network by default
+	// It is overridden by the InterfaceBinding of the network mapping of a NIC
+	// +optional
+	InterfaceBinding *InterfaceBinding `json:"interfaceBinding,omitempty"`
+
+	// DiskBus is the bus of the disks of the target VM, virtio by default
+	// It is overridden by the DiskBus of the disk or storage mapping of a disk
+	// +optional
+	DiskBus *string `json:"diskBus,omitempty"`
+
+	// UnmappedNetworks defines what happens to the NICs connected to networks without a mapping
+	// +optional
+	UnmappedNetworks *UnmappedNetworkPolicy `json:"unmappedNetworks,omitempty"`
+}
+
+// UnmappedNetworkPolicy defines what happens to the NICs of the source VM connected to networks without a mapping
+// +k8s:openapi-gen=true
+type UnmappedNetworkPolicy struct {
+	// Action is what happens to the NICs, Fail by default
+	// +optional
+	Action UnmappedNetworkAction `json:"action,omitempty"`
+
+	// Target is the network attachment definition the NICs are connected to with the Multus action
+	// +optional
+	Target *ObjectIdentifier `json:"target,omitempty"`
 }
 
+// UnmappedNetworkAction defines what happens to a NIC of the source VM connected to a network without a mapping
+type UnmappedNetworkAction string
+
+// These are the actions taken on the NICs connected to networks without a mapping
+const (
+	// UnmappedNetworkFail fails the validation of the import
+	UnmappedNetworkFail UnmappedNetworkAction = "Fail"
+	// UnmappedNetworkDrop imports the VM without the NIC
+	UnmappedNetworkDrop UnmappedNetworkAction = "Drop"
+	// UnmappedNetworkPod connects the NIC to the pod network
+	UnmappedNetworkPod UnmappedNetworkAction = "Pod"
+	// UnmappedNetworkMultus connects the NIC to the network attachment definition of the policy
+	UnmappedNetworkMultus UnmappedNetworkAction = "Multus"
+	// UnmappedNetworkGenerate connects the NIC to a network attachment definition generated from the VLAN of its source network
+	UnmappedNetworkGenerate UnmappedNetworkAction = "Generate"
+)
+
+// InterfaceBinding defines how an interface of the target VM is connected to its network
+type InterfaceBinding string
+
+// These are the supported interface bindings
+const (
+	// InterfaceBindingBridge connects the interface to its network through a bridge
+	InterfaceBindingBridge InterfaceBinding = "bridge"
+	// InterfaceBindingMasquerade connects the interface to the pod network through NAT
+	InterfaceBindingMasquerade InterfaceBinding = "masquerade"
+	// InterfaceBindingSRIOV passes a virtual function of the SR-IOV network through to the guest
+	InterfaceBindingSRIOV InterfaceBinding = "sriov"
+)
+
 // Source defines how to identify a resource on the provider, either by ID or by name
 // +k8s:openapi-gen=true
 type Source struct {
@@ -83,6 +149,18 @@ type NetworkResourceMappingItem struct {
 
 	// +optional
 	Type *string `json:"type,omitempty"`
+
+	// MACPolicy overrides the MAC policy of the import for the NICs using this mapping
+	// +optional
+	MACPolicy *MACPolicy `json:"macPolicy,omitempty"`
+
+	// InterfaceModel is the model of the interfaces connected to the mapped network. Only used for VMware.
+	// +optional
+	InterfaceModel *string `json:"interfaceModel,omitempty"`
+
+	// InterfaceBinding defines how the interfaces are connected to the mapped network. Only used for VMware.
+	// +optional
+	InterfaceBinding *InterfaceBinding `json:"interfaceBinding,omitempty"`
 }
 
 // StorageResourceMappingItem defines the storage mapping of a single resource from the provider to kubevirt
@@ -95,6 +173,10 @@ type StorageResourceMappingItem struct {
 	VolumeMode *corev1.PersistentVolumeMode `json:"volumeMode,omitempty"`
 	// +optional
 	AccessMode *corev1.PersistentVolumeAccessMode `json:"accessMode,omitempty"`
+
+	// DiskBus is the bus of the mapped disks. Only used for VMware.
+	// +optional
+	DiskBus *string `json:"diskBus,omitempty"`
 }
 
 // ResourceMappingStatus defines the observed state of ResourceMapping
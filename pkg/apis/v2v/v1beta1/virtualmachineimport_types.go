@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	sdkapi "kubevirt.io/controller-lifecycle-operator-sdk/pkg/sdk/api"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -32,8 +34,463 @@ type VirtualMachineImportSpec struct {
 
 	// +optional
 	StartVM *bool `json:"startVm,omitempty"`
+
+	// +optional
+	GuestConversion *GuestConversionSpec `json:"guestConversion,omitempty"`
+
+	// +optional
+	GuestInspection *GuestInspectionSpec `json:"guestInspection,omitempty"`
+
+	// +optional
+	Customization *CustomizationSpec `json:"customization,omitempty"`
+
+	// MACPolicy defines what happens to the MAC addresses of the source VM. The policy of a network mapping takes
+	// precedence for the NICs it maps. Preserve by default.
+	// +optional
+	MACPolicy MACPolicy `json:"macPolicy,omitempty"`
+
+	// SourceVMPolicy defines what happens to the source VM once the import succeeded. Stop by default.
+	// +optional
+	SourceVMPolicy SourceVMPolicy `json:"sourceVMPolicy,omitempty"`
+
+	// Rollback, once set on a successful import, deletes the imported VM and its data volumes and starts the source VM again
+	// +optional
+	Rollback bool `json:"rollback,omitempty"`
+
+	// BootVerification defines the checks the started VM has to pass before the import is declared successful. It
+	// requires StartVM.
+	// +optional
+	BootVerification *BootVerificationSpec `json:"bootVerification,omitempty"`
+
+	// Template pins the template the imported VM is created from, or constrains its search
+	// +optional
+	Template *TemplateSpec `json:"template,omitempty"`
+
+	// VMPatches are applied in order to the mapped VM before it's created
+	// +optional
+	VMPatches []ResourcePatch `json:"vmPatches,omitempty"`
+
+	// DataVolumePatches are applied in order to each mapped data volume before it's created
+	// +optional
+	DataVolumePatches []ResourcePatch `json:"dataVolumePatches,omitempty"`
+
+	// Propagation defines the labels and annotations set on the resources created by the import
+	// +optional
+	Propagation *PropagationSpec `json:"propagation,omitempty"`
+
+	// Naming defines the names of the data volumes and of the network interfaces of the imported VM
+	// +optional
+	Naming *NamingSpec `json:"naming,omitempty"`
+
+	// TargetNamespace is the namespace the VM, its data volumes and the guest conversion pod are created in. It
+	// defaults to the namespace of the import. The user who creates the import must be allowed to create VMs in it, and
+	// the import must be created with the vmimport.v2v.kubevirt.io/record-requester label to record that user.
+	// +optional
+	TargetNamespace *string `json:"targetNamespace,omitempty"`
+}
+
+// NamingSpec defines the Go templates the names of the objects created by the import are built with. The names are
+// normalized to DNS-1123 and suffixed with their index when already taken.
+// +k8s:openapi-gen=true
+type NamingSpec struct {
+	// DataVolume is the template of the data volume names, executed with the VMName, DiskName, DiskID and Index of
+	// each disk of the source VM, e.g. {{.VMName}}-disk{{.Index}}. The names must depend on the VMName.
+	// +optional
+	DataVolume *string `json:"dataVolume,omitempty"`
+
+	// Interface is the template of the network interface and network names of the VM, executed with the NICName and
+	// Index of each NIC of the source VM, e.g. net{{.Index}}
+	// +optional
+	Interface *string `json:"interface,omitempty"`
+}
+
+// PropagationSpec defines the labels and annotations set on the resources created by the import
+// +k8s:openapi-gen=true
+type PropagationSpec struct {
+	// Labels set on the resources
+	// +optional
+	Labels []PropagationRule `json:"labels,omitempty"`
+
+	// Annotations set on the resources
+	// +optional
+	Annotations []PropagationRule `json:"annotations,omitempty"`
+}
+
+// PropagationRule defines a label or annotation and where its value is taken from. Exactly one of Value,
+// FromImportLabel, FromImportAnnotation and FromSource must be set. The label or annotation is left out when its value
+// can't be found.
+// +k8s:openapi-gen=true
+type PropagationRule struct {
+	// Key of the label or annotation, outside of the kubevirt.io domain and its subdomains
+	Key string `json:"key"`
+
+	// Value of the label or annotation
+	// +optional
+	Value *string `json:"value,omitempty"`
+
+	// FromImportLabel takes the value from the label of the import with this key
+	// +optional
+	FromImportLabel *string `json:"fromImportLabel,omitempty"`
+
+	// FromImportAnnotation takes the value from the annotation of the import with this key
+	// +optional
+	FromImportAnnotation *string `json:"fromImportAnnotation,omitempty"`
+
+	// FromSource takes the value from the metadata of the source VM
+	// +optional
+	FromSource *SourceMetadataSelector `json:"fromSource,omitempty"`
+
+	// Targets the label or annotation is set on, all of them by default
+	// +optional
+	Targets []PropagationTarget `json:"targets,omitempty"`
+}
+
+// SourceMetadataSelector selects a piece of metadata of the source VM. Exactly one of Tag, CustomAttribute and Folder
+// must be set.
+// +k8s:openapi-gen=true
+type SourceMetadataSelector struct {
+	// Tag sets the value to true when the source VM carries the oVirt tag with this name
+	// +optional
+	Tag *string `json:"tag,omitempty"`
+
+	// CustomAttribute takes the value of the vSphere custom attribute, or of the oVirt custom property, with this name
+	// +optional
+	CustomAttribute *string `json:"customAttribute,omitempty"`
+
+	// Folder takes the path of the vSphere folder of the source VM
+	// +optional
+	Folder bool `json:"folder,omitempty"`
+}
+
+// PropagationTarget defines a kind of resource created by the import labels and annotations are propagated to
+type PropagationTarget string
+
+const (
+	// PropagationTargetVirtualMachine is the imported VM
+	PropagationTargetVirtualMachine PropagationTarget = "VirtualMachine"
+	// PropagationTargetVirtualMachineInstance is the template of the VMIs of the imported VM
+	PropagationTargetVirtualMachineInstance PropagationTarget = "VirtualMachineInstance"
+	// PropagationTargetDataVolume is the data volumes of the imported VM
+	PropagationTargetDataVolume PropagationTarget = "DataVolume"
+	// PropagationTargetPersistentVolumeClaim is the PVCs of the data volumes of the imported VM, labeled once the
+	// data volumes are imported
+	PropagationTargetPersistentVolumeClaim PropagationTarget = "PersistentVolumeClaim"
+	// PropagationTargetGuestConversionPod is the virt-v2v pod converting the guest of the imported VM
+	PropagationTargetGuestConversionPod PropagationTarget = "GuestConversionPod"
+)
+
+// ResourcePatch defines a patch applied to a resource created by the import. Exactly one of Patch and ConfigMap must
+// be set.
+// +k8s:openapi-gen=true
+type ResourcePatch struct {
+	// Type of the patch, StrategicMerge by default
+	// +optional
+	Type PatchType `json:"type,omitempty"`
+
+	// Patch inline, in JSON or YAML
+	// +optional
+	Patch *string `json:"patch,omitempty"`
+
+	// ConfigMap holding the patch, in JSON or YAML
+	// +optional
+	ConfigMap *ConfigMapPatchSource `json:"configMap,omitempty"`
+}
+
+// ConfigMapPatchSource references the key of a config map in the namespace of the import holding a patch
+// +k8s:openapi-gen=true
+type ConfigMapPatchSource struct {
+	// Name of the config map
+	Name string `json:"name"`
+
+	// Key of the patch in the config map
+	Key string `json:"key"`
+}
+
+// PatchType defines how a patch is applied
+type PatchType string
+
+const (
+	// PatchTypeStrategicMerge merges the patch into the resource like kubectl patch --type strategic
+	PatchTypeStrategicMerge PatchType = "StrategicMerge"
+	// PatchTypeJSON applies the RFC 6902 JSON patch operations to the resource
+	PatchTypeJSON PatchType = "JSON"
+)
+
+// TemplateSpec pins the template the imported VM is created from, or constrains the search of the newest template
+// matching the operating system of the source VM
+// +k8s:openapi-gen=true
+type TemplateSpec struct {
+	// Namespace of the template, openshift by default
+	// +optional
+	Namespace *string `json:"namespace,omitempty"`
+
+	// Name pins the template, skipping the search
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Labels the searched templates must carry besides the operating system, workload and flavor ones
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Workload is searched before the workloads derived from the source VM, e.g. server
+	// +optional
+	Workload *string `json:"workload,omitempty"`
+
+	// Flavor is searched before the default flavors, e.g. large
+	// +optional
+	Flavor *string `json:"flavor,omitempty"`
+}
+
+// SourceVMPolicy defines what happens to the source VM once the import succeeded
+type SourceVMPolicy string
+
+const (
+	// SourceVMPolicyStop leaves the source VM stopped
+	SourceVMPolicyStop SourceVMPolicy = "Stop"
+	// SourceVMPolicyRestore brings the source VM back to the power state it had before the import
+	SourceVMPolicyRestore SourceVMPolicy = "Restore"
+	// SourceVMPolicyDelete deletes the source VM and its disks. It requires BootVerification.
+	SourceVMPolicyDelete SourceVMPolicy = "Delete"
+	// SourceVMPolicyMark leaves the source VM stopped and records in the source that it was migrated to the imported
+	// VM: with a tag and in the description on oVirt, and with a custom attribute and in the notes on vSphere
+	SourceVMPolicyMark SourceVMPolicy = "Mark"
+)
+
+// MACPolicy defines whether the NICs of the imported VM keep the MAC addresses of the source VM
+type MACPolicy string
+
+const (
+	// MACPolicyPreserve keeps the MAC addresses of the source VM. The import is blocked when one of them is already
+	// used in the cluster.
+	MACPolicyPreserve MACPolicy = "Preserve"
+	// MACPolicyRegenerate lets KubeVirt assign new MAC addresses
+	MACPolicyRegenerate MACPolicy = "Regenerate"
+	// MACPolicyPreserveIfUnique keeps the MAC addresses of the source VM that aren't used in the cluster, and
+	// regenerates the others
+	MACPolicyPreserveIfUnique MACPolicy = "PreserveIfUnique"
+)
+
+// CustomizationSpec defines the data the guest of the imported VM is customized with on its first boot. Only one of
+// CloudInit and Sysprep may be set.
+// +k8s:openapi-gen=true
+type CustomizationSpec struct {
+	// CloudInit attaches cloud-init user data and network data to the VM
+	// +optional
+	CloudInit *CloudInitCustomization `json:"cloudInit,omitempty"`
+
+	// Sysprep attaches a Windows unattend file to the VM
+	// +optional
+	Sysprep *SysprepCustomization `json:"sysprep,omitempty"`
+
+	// RemoveAfterFirstBoot detaches the customization volume from the VM once it has started successfully. The
+	// running VM keeps the volume until it is restarted.
+	// +optional
+	RemoveAfterFirstBoot bool `json:"removeAfterFirstBoot,omitempty"`
+
+	// RestoreNetwork re-applies the static IP configuration reported by the source guest tools on the interfaces of
+	// the imported VM, matched by MAC address. Linux guests get it as cloud-init network data, unless CloudInit
+	// references its own; Windows guests run a netsh script on their first boot.
+	// +optional
+	RestoreNetwork bool `json:"restoreNetwork,omitempty"`
+}
+
+// CloudInitCustomization defines the cloud-init data source attached to the VM
+// +k8s:openapi-gen=true
+type CloudInitCustomization struct {
+	// Type of the cloud-init data source, NoCloud by default
+	// +optional
+	Type CloudInitType `json:"type,omitempty"`
+
+	// UserDataSecretRef references a secret in the namespace of the import holding the user data under the
+	// userdata key
+	// +optional
+	UserDataSecretRef *k8sv1.LocalObjectReference `json:"userDataSecretRef,omitempty"`
+
+	// NetworkDataSecretRef references a secret in the namespace of the import holding the network data under the
+	// networkdata key
+	// +optional
+	NetworkDataSecretRef *k8sv1.LocalObjectReference `json:"networkDataSecretRef,omitempty"`
+}
+
+// CloudInitType defines the cloud-init data source
+type CloudInitType string
+
+const (
+	// CloudInitNoCloud attaches the data as a NoCloud data source
+	CloudInitNoCloud CloudInitType = "NoCloud"
+	// CloudInitConfigDrive attaches the data as an OpenStack config drive
+	CloudInitConfigDrive CloudInitType = "ConfigDrive"
+)
+
+// SysprepCustomization defines where the Windows unattend file is taken from. The config map or secret must hold it
+// under the unattend.xml or autounattend.xml key. Only one of ConfigMap and Secret may be set.
+// +k8s:openapi-gen=true
+type SysprepCustomization struct {
+	// ConfigMap references a config map in the namespace of the import
+	// +optional
+	ConfigMap *k8sv1.LocalObjectReference `json:"configMap,omitempty"`
+
+	// Secret references a secret in the namespace of the import
+	// +optional
+	Secret *k8sv1.LocalObjectReference `json:"secret,omitempty"`
+}
+
+// BootVerificationSpec defines how the boot of the imported VM is verified. The VMI must reach the Running phase
+// in any case.
+// +k8s:openapi-gen=true
+type BootVerificationSpec struct {
+	// TimeoutSeconds is how long the started VM has to pass the checks. 600 seconds by default.
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+
+	// GuestAgent waits for the guest agent of the VM to connect
+	// +optional
+	GuestAgent bool `json:"guestAgent,omitempty"`
+
+	// ReadinessProbe is set on the VM before it is started, and the VM has to become ready
+	// +optional
+	ReadinessProbe *BootReadinessProbe `json:"readinessProbe,omitempty"`
+
+	// RollbackOnFailure deletes the imported VM and its data volumes and restores the initial power state of the
+	// source VM when the verification fails, like any other failed import. Otherwise the imported VM is kept for
+	// troubleshooting and the source VM is left stopped.
+	// +optional
+	RollbackOnFailure bool `json:"rollbackOnFailure,omitempty"`
+}
+
+// BootReadinessProbe defines the readiness probe of the imported VM. Exactly one of HTTPGet, TCPSocket and Exec must
+// be set.
+// +k8s:openapi-gen=true
+type BootReadinessProbe struct {
+	// HTTPGet probes the VM with an HTTP GET request
+	// +optional
+	HTTPGet *HTTPGetProbe `json:"httpGet,omitempty"`
+
+	// TCPSocket probes the VM by opening a TCP connection
+	// +optional
+	TCPSocket *TCPSocketProbe `json:"tcpSocket,omitempty"`
+
+	// Exec probes the VM by running a command in the guest through the guest agent
+	// +optional
+	Exec *ExecProbe `json:"exec,omitempty"`
+
+	// PeriodSeconds is how often the probe is performed. 10 seconds by default.
+	// +optional
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+}
+
+// HTTPGetProbe defines an HTTP GET request sent to the VM. Any status code between 200 and 399 passes.
+// +k8s:openapi-gen=true
+type HTTPGetProbe struct {
+	// Path of the request, / by default
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Port of the request
+	Port int32 `json:"port"`
+}
+
+// TCPSocketProbe defines a TCP connection opened to the VM
+// +k8s:openapi-gen=true
+type TCPSocketProbe struct {
+	// Port of the connection
+	Port int32 `json:"port"`
+}
+
+// ExecProbe defines a command run in the guest through the guest agent. Exit status 0 passes.
+// +k8s:openapi-gen=true
+type ExecProbe struct {
+	// Command line to run, not interpreted by a shell
+	Command []string `json:"command"`
+}
+
+// GuestInspectionSpec defines whether the guest of the imported VM is inspected once its disks are copied
+// +k8s:openapi-gen=true
+type GuestInspectionSpec struct {
+	// Enabled runs virt-inspector against the copied disks. The operating system it finds replaces the one reported
+	// by the source provider when matching the template and labeling the VM.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// GuestConversionSpec defines how the guest of the imported VM is converted by virt-v2v
+// +k8s:openapi-gen=true
+type GuestConversionSpec struct {
+	// Enabled forces the guest conversion on or off. When not set, the conversion of oVirt VMs is decided based on
+	// the source VM devices; VMware VMs are always converted.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// VirtioWin is the source of the virtio-win ISO the Windows drivers are installed from. When not set, the ISO
+	// shipped with the virt-v2v image is used.
+	// +optional
+	VirtioWin *VirtioWinSource `json:"virtioWin,omitempty"`
+
+	// ExtraArgs are appended to the arguments of virt-v2v
+	// +optional
+	ExtraArgs []string `json:"extraArgs,omitempty"`
+
+	// FirstBootScripts references a config map in the namespace of the import. Each of its keys is a script run
+	// in the guest on its first boot, in the alphabetical order of the keys.
+	// +optional
+	FirstBootScripts *k8sv1.LocalObjectReference `json:"firstBootScripts,omitempty"`
+
+	// VMwareTools defines whether the VMware Tools are kept in the guest, Keep by default
+	// +optional
+	VMwareTools VMwareToolsPolicy `json:"vmwareTools,omitempty"`
+
+	// TimeoutMinutes is how long the conversion may run before it is failed
+	// +optional
+	TimeoutMinutes *int32 `json:"timeoutMinutes,omitempty"`
+
+	// Pod overrides the resources and placement of the virt-v2v pod configured in VMImportConfig
+	// +optional
+	Pod *GuestConversionPodConfig `json:"pod,omitempty"`
+}
+
+// GuestConversionPodConfig defines the resources and placement of the virt-v2v pod
+// +k8s:openapi-gen=true
+type GuestConversionPodConfig struct {
+	// Resources of the virt-v2v container. The limit of the KVM device is always added.
+	// +optional
+	Resources *k8sv1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Placement restricts the nodes the pod is scheduled on, on top of the nodes labeled kubevirt.io/schedulable
+	// +optional
+	Placement *sdkapi.NodePlacement `json:"placement,omitempty"`
+
+	// PriorityClassName is the priority class of the pod
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// VirtioWinSource defines where the virtio-win ISO is taken from. When both Image and PersistentVolumeClaim are
+// set, the PVC is used.
+// +k8s:openapi-gen=true
+type VirtioWinSource struct {
+	// Image is a container image holding the ISO. The image must provide the cp command.
+	// +optional
+	Image *string `json:"image,omitempty"`
+
+	// PersistentVolumeClaim is the name of a PVC in the namespace of the import holding the ISO
+	// +optional
+	PersistentVolumeClaim *string `json:"persistentVolumeClaim,omitempty"`
+
+	// Path of the ISO in the image, /virtio-win.iso by default, or on the PVC, virtio-win.iso by default
+	// +optional
+	Path *string `json:"path,omitempty"`
 }
 
+// VMwareToolsPolicy defines whether the VMware Tools are kept in the guest
+type VMwareToolsPolicy string
+
+const (
+	// VMwareToolsKeep keeps open-vm-tools in the guest. virt-v2v removes the legacy VMware Tools regardless.
+	VMwareToolsKeep VMwareToolsPolicy = "Keep"
+	// VMwareToolsRemove removes open-vm-tools from Linux guests, along with the legacy VMware Tools
+	VMwareToolsRemove VMwareToolsPolicy = "Remove"
+)
+
 // VirtualMachineImportSourceSpec defines the source provider and the internal mapping resources
 // +k8s:openapi-gen=true
 type VirtualMachineImportSourceSpec struct {
@@ -122,6 +579,202 @@ type VirtualMachineImportStatus struct {
 
 	// +optional
 	WarmImport VirtualMachineWarmImportStatus `json:"warmImport"`
+
+	// +optional
+	GuestConversion *GuestConversionStatus `json:"guestConversion,omitempty"`
+
+	// +optional
+	GuestInspection *GuestInspectionStatus `json:"guestInspection,omitempty"`
+
+	// +optional
+	GuestNetwork *GuestNetworkStatus `json:"guestNetwork,omitempty"`
+
+	// UnmappedNetworks records what happened to the NICs of the source VM connected to networks without a mapping
+	// +optional
+	UnmappedNetworks []UnmappedNetworkStatus `json:"unmappedNetworks,omitempty"`
+
+	// GeneratedNetworkMappings records the mappings to the network attachment definitions generated for the networks without a mapping
+	// +optional
+	GeneratedNetworkMappings []NetworkResourceMappingItem `json:"generatedNetworkMappings,omitempty"`
+
+	// BootVerification records the progress of the verification of the boot of the imported VM
+	// +optional
+	BootVerification *BootVerificationStatus `json:"bootVerification,omitempty"`
+
+	// Template records the template the imported VM was created from and why it was chosen
+	// +optional
+	Template *TemplateStatus `json:"template,omitempty"`
+
+	// SourceMetadata records the metadata of the source VM the propagation rules take their values from, read once
+	// per import
+	// +optional
+	SourceMetadata *SourceMetadataStatus `json:"sourceMetadata,omitempty"`
+}
+
+// SourceMetadataStatus records the metadata of the source VM the propagation rules take their values from
+// +k8s:openapi-gen=true
+type SourceMetadataStatus struct {
+	// Tags of the oVirt VM
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// CustomAttributes of the vSphere VM, or custom properties of the oVirt VM, by name
+	// +optional
+	CustomAttributes map[string]string `json:"customAttributes,omitempty"`
+
+	// Folder path of the vSphere VM
+	// +optional
+	Folder string `json:"folder,omitempty"`
+}
+
+// TemplateStatus records the template the imported VM was created from and why it was chosen
+// +k8s:openapi-gen=true
+type TemplateStatus struct {
+	// Name of the template, empty when the VM was created without a template
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Namespace of the template
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Reason the template was chosen
+	Reason string `json:"reason"`
+}
+
+// BootVerificationStatus records the progress of the verification of the boot of the imported VM
+// +k8s:openapi-gen=true
+type BootVerificationStatus struct {
+	// StartTime is when the VMI was first seen running and the verification began
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// Failure explains why the verification failed
+	// +optional
+	Failure string `json:"failure,omitempty"`
+}
+
+// UnmappedNetworkStatus records what happened to a NIC of the source VM connected to a network without a mapping
+// +k8s:openapi-gen=true
+type UnmappedNetworkStatus struct {
+	// NIC is the name of the NIC of the source VM
+	NIC string `json:"nic"`
+
+	// Network identifies the source network of the NIC
+	Network string `json:"network"`
+
+	// Action is what happened to the NIC
+	Action UnmappedNetworkAction `json:"action"`
+
+	// Target is the network attachment definition the NIC is connected to
+	// +optional
+	Target *ObjectIdentifier `json:"target,omitempty"`
+
+	// VLAN is the VLAN ID of the source network, 0 when untagged, recorded for the networks to generate
+	// +optional
+	VLAN *int32 `json:"vlan,omitempty"`
+}
+
+// GuestNetworkStatus defines the IP configuration reported by the guest tools of the source VM
+type GuestNetworkStatus struct {
+	// Interfaces are the network interfaces of the guest
+	// +optional
+	Interfaces []GuestNetworkInterface `json:"interfaces,omitempty"`
+
+	// Routes are the routes of the guest going through a gateway
+	// +optional
+	Routes []GuestNetworkRoute `json:"routes,omitempty"`
+
+	// DNSServers are the addresses of the name servers of the guest
+	// +optional
+	DNSServers []string `json:"dnsServers,omitempty"`
+
+	// DNSSearchDomains are the domains the guest appends to the names it resolves
+	// +optional
+	DNSSearchDomains []string `json:"dnsSearchDomains,omitempty"`
+}
+
+// GuestNetworkInterface defines the IP configuration of a network interface of the guest
+type GuestNetworkInterface struct {
+	// MAC is the MAC address of the interface
+	MAC string `json:"mac"`
+
+	// DHCP tells that the interface gets its IPv4 address from a DHCP server
+	// +optional
+	DHCP bool `json:"dhcp,omitempty"`
+
+	// IPAddresses are the addresses of the interface in CIDR notation, e.g. 10.0.0.5/24. Link-local addresses are
+	// left out.
+	// +optional
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+}
+
+// GuestNetworkRoute defines a route of the guest
+type GuestNetworkRoute struct {
+	// Destination is the network the route leads to in CIDR notation, 0.0.0.0/0 for the default route
+	Destination string `json:"destination"`
+
+	// Gateway is the address of the next hop
+	Gateway string `json:"gateway"`
+
+	// MAC is the MAC address of the interface the route goes through, when known
+	// +optional
+	MAC string `json:"mac,omitempty"`
+}
+
+// GuestInspectionStatus defines what the inspection found in the guest
+type GuestInspectionStatus struct {
+	// OperatingSystem is the libosinfo ID of the operating system, e.g. rhel8.2 or win2k19
+	// +optional
+	OperatingSystem string `json:"operatingSystem,omitempty"`
+
+	// ProductName is the full name of the operating system
+	// +optional
+	ProductName string `json:"productName,omitempty"`
+
+	// Distro is the distribution of the operating system, e.g. rhel or windows
+	// +optional
+	Distro string `json:"distro,omitempty"`
+
+	// Version is the major and minor version of the operating system
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Firmware the guest boots with, bios or uefi
+	// +optional
+	Firmware string `json:"firmware,omitempty"`
+
+	// InstalledDrivers are the virtio drivers installed in the guest
+	// +optional
+	InstalledDrivers []string `json:"installedDrivers,omitempty"`
+
+	// Failure describes why the guest couldn't be inspected
+	// +optional
+	Failure string `json:"failure,omitempty"`
+}
+
+// GuestConversionStatus defines the outcome of the guest conversion, as reported by virt-v2v
+type GuestConversionStatus struct {
+	// OperatingSystem is the operating system virt-v2v found in the guest
+	// +optional
+	OperatingSystem string `json:"operatingSystem,omitempty"`
+
+	// InstalledDrivers are the drivers of the devices the converted guest uses
+	// +optional
+	InstalledDrivers []string `json:"installedDrivers,omitempty"`
+
+	// Warnings reported by virt-v2v
+	// +optional
+	Warnings []string `json:"warnings,omitempty"`
+
+	// LogTail is the end of the virt-v2v log
+	// +optional
+	LogTail string `json:"logTail,omitempty"`
+
+	// LogError is why the virt-v2v log couldn't be read to the end. The summary covers the part read so far and the
+	// virt-v2v pod is kept for its complete log.
+	// +optional
+	LogError string `json:"logError,omitempty"`
 }
 
 type VirtualMachineWarmImportStatus struct {
@@ -153,6 +806,12 @@ const (
 
 	// Processing represents the status of the VM import process while in progress
 	Processing VirtualMachineImportConditionType = "Processing"
+
+	// RolledBack represents the status of the rollback of a successful VM import
+	RolledBack VirtualMachineImportConditionType = "RolledBack"
+
+	// SourceVMPolicyApplied represents the status of the source VM policy applied once the VM import succeeded
+	SourceVMPolicyApplied VirtualMachineImportConditionType = "SourceVMPolicyApplied"
 )
 
 // SucceededConditionReason defines the reasons for the Succeeded condition of VM import
@@ -188,6 +847,9 @@ const (
 
 	// VirtualMachineRunning represents the completion of the vm import and vm in running state
 	VirtualMachineRunning SucceededConditionReason = "VirtualMachineRunning"
+
+	// BootVerificationFailed represents a failure of the imported VM to pass the boot verification
+	BootVerificationFailed SucceededConditionReason = "BootVerificationFailed"
 )
 
 // ValidConditionReason defines the reasons for the Valid condition of VM import
@@ -225,6 +887,47 @@ const (
 
 	// DuplicateTargetVMName
 	DuplicateTargetVMName ValidConditionReason = "DuplicateTargetVMName"
+
+	// InvalidCustomization represents the guest customization naming no source of data, or more than one, or restoring
+	// the network of an interface that may not keep its MAC address
+	InvalidCustomization ValidConditionReason = "InvalidCustomization"
+
+	// MACAddressConflict represents a preserved MAC address of the source VM already used in the cluster
+	MACAddressConflict ValidConditionReason = "MACAddressConflict"
+
+	// InvalidKubeMacPoolRange represents a KubeMacPool range that can't be parsed, so the MAC addresses can't be checked
+	InvalidKubeMacPoolRange ValidConditionReason = "InvalidKubeMacPoolRange"
+
+	// InvalidPropagation represents a label or annotation propagation rule without a single source of value, or with
+	// an unknown target
+	InvalidPropagation ValidConditionReason = "InvalidPropagation"
+
+	// InvalidPatch represents a VM or data volume patch of an unknown type, or naming no source or more than one
+	InvalidPatch ValidConditionReason = "InvalidPatch"
+
+	// InvalidNamingTemplate represents a data volume or interface naming template that can't be parsed or executed
+	InvalidNamingTemplate ValidConditionReason = "InvalidNamingTemplate"
+
+	// InvalidTargetNamespace represents a target namespace that isn't a valid namespace name
+	InvalidTargetNamespace ValidConditionReason = "InvalidTargetNamespace"
+
+	// TargetNamespaceForbidden represents a target namespace the user who created the import may not create VMs in
+	TargetNamespaceForbidden ValidConditionReason = "TargetNamespaceForbidden"
+
+	// UnmappedNetwork represents a NIC of the source VM connected to a network without a mapping, while the policy
+	// for such NICs is to fail
+	UnmappedNetwork ValidConditionReason = "UnmappedNetwork"
+
+	// InvalidSourceVMPolicy represents a source VM policy that can't be applied safely, e.g. deleting the source VM of an
+	// import whose boot isn't verified
+	InvalidSourceVMPolicy ValidConditionReason = "InvalidSourceVMPolicy"
+
+	// InvalidBootVerification represents a boot verification of a VM that isn't started, or a readiness probe that
+	// doesn't set exactly one check
+	InvalidBootVerification ValidConditionReason = "InvalidBootVerification"
+
+	// PinnedTemplateNotFound represents a template pinned by the import that doesn't exist
+	PinnedTemplateNotFound ValidConditionReason = "PinnedTemplateNotFound"
 )
 
 // MappingRulesVerifiedReason defines the reasons for the MappingRulesVerified condition of VM import
@@ -264,9 +967,15 @@ const (
 	// CopyingPaused represents waiting for the next warm migration stage
 	CopyingPaused ProcessingConditionReason = "CopyingPaused"
 
+	// InspectingGuest represents the guest inspection process
+	InspectingGuest ProcessingConditionReason = "InspectingGuest"
+
 	// ConvertingGuest represents the guest conversion process
 	ConvertingGuest ProcessingConditionReason = "ConvertingGuest"
 
+	// VerifyingBoot represents the verification of the boot of the started VM
+	VerifyingBoot ProcessingConditionReason = "VerifyingBoot"
+
 	// ProcessingCompleted represents the successful import processing
 	ProcessingCompleted ProcessingConditionReason = "ProcessingCompleted"
 
@@ -277,6 +986,32 @@ const (
 	Pending ProcessingConditionReason = "Pending"
 )
 
+// RolledBackConditionReason defines the reasons for the RolledBack condition of VM import
+// +k8s:openapi-gen=true
+type RolledBackConditionReason string
+
+// These are valid reasons for the RolledBack conditions of VM import.
+const (
+	// RollbackCompleted represents the removal of the imported VM and the restart of the source VM
+	RollbackCompleted RolledBackConditionReason = "RollbackCompleted"
+
+	// RollbackFailed represents a failure to roll back the VM import
+	RollbackFailed RolledBackConditionReason = "RollbackFailed"
+)
+
+// SourceVMPolicyAppliedConditionReason defines the reasons for the SourceVMPolicyApplied condition of VM import
+// +k8s:openapi-gen=true
+type SourceVMPolicyAppliedConditionReason string
+
+// These are valid reasons for the SourceVMPolicyApplied conditions of VM import.
+const (
+	// SourceVMPolicyCompleted represents the source VM being restored, deleted or marked according to the policy
+	SourceVMPolicyCompleted SourceVMPolicyAppliedConditionReason = "SourceVMPolicyCompleted"
+
+	// SourceVMPolicyPending represents a failure to apply the source VM policy, which is retried
+	SourceVMPolicyPending SourceVMPolicyAppliedConditionReason = "SourceVMPolicyPending"
+)
+
 // VirtualMachineImportCondition defines the observed state of VirtualMachineImport conditions
 // +k8s:openapi-gen=true
 type VirtualMachineImportCondition struct {
@@ -314,6 +1049,62 @@ func (cond VirtualMachineImportCondition) String() string {
 // +k8s:openapi-gen=true
 type DataVolumeItem struct {
 	Name string `json:"name"`
+
+	// The ID of the source disk imported to the data volume
+	// +optional
+	SourceDiskID string `json:"sourceDiskID,omitempty"`
+
+	// The name of the source disk imported to the data volume
+	// +optional
+	SourceDiskName string `json:"sourceDiskName,omitempty"`
+
+	// The name of the persistent volume claim backing the data volume
+	// +optional
+	PVCName string `json:"pvcName,omitempty"`
+
+	// The requested size of the data volume
+	// +optional
+	Size *resource.Quantity `json:"size,omitempty"`
+
+	// The phase of the data volume
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// The import progress of the data volume in percents, e.g. "45.00%"
+	// +optional
+	Progress string `json:"progress,omitempty"`
+
+	// The number of bytes transferred so far, estimated from the progress and the size of the data volume
+	// +optional
+	BytesTransferred int64 `json:"bytesTransferred,omitempty"`
+
+	// The average transfer rate of the data volume import in bytes per second
+	// +optional
+	Throughput int64 `json:"throughput,omitempty"`
+
+	// The time when the data volume import started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// The time when the data volume import completed
+	// +optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// The estimated time when the data volume import completes
+	// +optional
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
+
+	// The number of times the import of the data volume has been retried
+	// +optional
+	RetryCount int `json:"retryCount,omitempty"`
+
+	// The reason of the last failed import attempt
+	// +optional
+	LastFailure *string `json:"lastFailure,omitempty"`
+
+	// The time when the data volume import is going to be retried
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -29,6 +29,43 @@ type VMImportConfigSpec struct {
 	// Rules on which nodes controller pod(s) will be scheduled
 	// +optional
 	Infra sdkapi.NodePlacement `json:"infra,omitempty"`
+
+	// Thresholds of the alerts deployed by the operator
+	// +optional
+	Monitoring *MonitoringConfig `json:"monitoring,omitempty"`
+
+	// Export of the controller traces
+	// +optional
+	Tracing *TracingConfig `json:"tracing,omitempty"`
+
+	// Resources and placement of the virt-v2v pods converting the guests
+	// +optional
+	GuestConversionPod *GuestConversionPodConfig `json:"guestConversionPod,omitempty"`
+}
+
+// MonitoringConfig defines the thresholds of the VM import alerts
+type MonitoringConfig struct {
+	// How long, in minutes, an import can stay in one phase before it's reported as stuck. Defaults to 240.
+	// +optional
+	StuckPhaseMinutes *int32 `json:"stuckPhaseMinutes,omitempty"`
+
+	// How many warm import stages can fail within an hour before it's reported. Defaults to 3.
+	// +optional
+	WarmImportStageFailures *int32 `json:"warmImportStageFailures,omitempty"`
+
+	// Percentage of failed imports out of the imports finished within an hour that is reported as a high failure rate. Defaults to 25.
+	// +optional
+	FailureRatePercent *int32 `json:"failureRatePercent,omitempty"`
+}
+
+// TracingConfig defines where the controller exports its traces
+type TracingConfig struct {
+	// Address, host:port, of the OTLP gRPC receiver the traces are exported to
+	Endpoint string `json:"endpoint"`
+
+	// Disables TLS of the connection to the receiver
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
 }
 
 // VMImportConfigStatus defines the observed state of VMImportConfig
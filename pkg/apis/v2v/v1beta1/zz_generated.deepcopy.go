@@ -24,9 +24,180 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootReadinessProbe) DeepCopyInto(out *BootReadinessProbe) {
+	*out = *in
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(HTTPGetProbe)
+		**out = **in
+	}
+	if in.TCPSocket != nil {
+		in, out := &in.TCPSocket, &out.TCPSocket
+		*out = new(TCPSocketProbe)
+		**out = **in
+	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(ExecProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootReadinessProbe.
+func (in *BootReadinessProbe) DeepCopy() *BootReadinessProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(BootReadinessProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootVerificationSpec) DeepCopyInto(out *BootVerificationSpec) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(BootReadinessProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootVerificationSpec.
+func (in *BootVerificationSpec) DeepCopy() *BootVerificationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BootVerificationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootVerificationStatus) DeepCopyInto(out *BootVerificationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootVerificationStatus.
+func (in *BootVerificationStatus) DeepCopy() *BootVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BootVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudInitCustomization) DeepCopyInto(out *CloudInitCustomization) {
+	*out = *in
+	if in.UserDataSecretRef != nil {
+		in, out := &in.UserDataSecretRef, &out.UserDataSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.NetworkDataSecretRef != nil {
+		in, out := &in.NetworkDataSecretRef, &out.NetworkDataSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudInitCustomization.
+func (in *CloudInitCustomization) DeepCopy() *CloudInitCustomization {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudInitCustomization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapPatchSource) DeepCopyInto(out *ConfigMapPatchSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapPatchSource.
+func (in *ConfigMapPatchSource) DeepCopy() *ConfigMapPatchSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapPatchSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomizationSpec) DeepCopyInto(out *CustomizationSpec) {
+	*out = *in
+	if in.CloudInit != nil {
+		in, out := &in.CloudInit, &out.CloudInit
+		*out = new(CloudInitCustomization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sysprep != nil {
+		in, out := &in.Sysprep, &out.Sysprep
+		*out = new(SysprepCustomization)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomizationSpec.
+func (in *CustomizationSpec) DeepCopy() *CustomizationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomizationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DataVolumeItem) DeepCopyInto(out *DataVolumeItem) {
 	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EstimatedCompletionTime != nil {
+		in, out := &in.EstimatedCompletionTime, &out.EstimatedCompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailure != nil {
+		in, out := &in.LastFailure, &out.LastFailure
+		*out = new(string)
+		**out = **in
+	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -40,6 +211,309 @@ func (in *DataVolumeItem) DeepCopy() *DataVolumeItem {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecProbe) DeepCopyInto(out *ExecProbe) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecProbe.
+func (in *ExecProbe) DeepCopy() *ExecProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestConversionPodConfig) DeepCopyInto(out *GuestConversionPodConfig) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestConversionPodConfig.
+func (in *GuestConversionPodConfig) DeepCopy() *GuestConversionPodConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestConversionPodConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestConversionSpec) DeepCopyInto(out *GuestConversionSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VirtioWin != nil {
+		in, out := &in.VirtioWin, &out.VirtioWin
+		*out = new(VirtioWinSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FirstBootScripts != nil {
+		in, out := &in.FirstBootScripts, &out.FirstBootScripts
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.TimeoutMinutes != nil {
+		in, out := &in.TimeoutMinutes, &out.TimeoutMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Pod != nil {
+		in, out := &in.Pod, &out.Pod
+		*out = new(GuestConversionPodConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestConversionSpec.
+func (in *GuestConversionSpec) DeepCopy() *GuestConversionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestConversionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestConversionStatus) DeepCopyInto(out *GuestConversionStatus) {
+	*out = *in
+	if in.InstalledDrivers != nil {
+		in, out := &in.InstalledDrivers, &out.InstalledDrivers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestConversionStatus.
+func (in *GuestConversionStatus) DeepCopy() *GuestConversionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestConversionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestInspectionSpec) DeepCopyInto(out *GuestInspectionSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestInspectionSpec.
+func (in *GuestInspectionSpec) DeepCopy() *GuestInspectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestInspectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestInspectionStatus) DeepCopyInto(out *GuestInspectionStatus) {
+	*out = *in
+	if in.InstalledDrivers != nil {
+		in, out := &in.InstalledDrivers, &out.InstalledDrivers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestInspectionStatus.
+func (in *GuestInspectionStatus) DeepCopy() *GuestInspectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestInspectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestNetworkInterface) DeepCopyInto(out *GuestNetworkInterface) {
+	*out = *in
+	if in.IPAddresses != nil {
+		in, out := &in.IPAddresses, &out.IPAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestNetworkInterface.
+func (in *GuestNetworkInterface) DeepCopy() *GuestNetworkInterface {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestNetworkInterface)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestNetworkRoute) DeepCopyInto(out *GuestNetworkRoute) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestNetworkRoute.
+func (in *GuestNetworkRoute) DeepCopy() *GuestNetworkRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestNetworkRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuestNetworkStatus) DeepCopyInto(out *GuestNetworkStatus) {
+	*out = *in
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]GuestNetworkInterface, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]GuestNetworkRoute, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNSSearchDomains != nil {
+		in, out := &in.DNSSearchDomains, &out.DNSSearchDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuestNetworkStatus.
+func (in *GuestNetworkStatus) DeepCopy() *GuestNetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GuestNetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPGetProbe) DeepCopyInto(out *HTTPGetProbe) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPGetProbe.
+func (in *HTTPGetProbe) DeepCopy() *HTTPGetProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPGetProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringConfig) DeepCopyInto(out *MonitoringConfig) {
+	*out = *in
+	if in.StuckPhaseMinutes != nil {
+		in, out := &in.StuckPhaseMinutes, &out.StuckPhaseMinutes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WarmImportStageFailures != nil {
+		in, out := &in.WarmImportStageFailures, &out.WarmImportStageFailures
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureRatePercent != nil {
+		in, out := &in.FailureRatePercent, &out.FailureRatePercent
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringConfig.
+func (in *MonitoringConfig) DeepCopy() *MonitoringConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamingSpec) DeepCopyInto(out *NamingSpec) {
+	*out = *in
+	if in.DataVolume != nil {
+		in, out := &in.DataVolume, &out.DataVolume
+		*out = new(string)
+		**out = **in
+	}
+	if in.Interface != nil {
+		in, out := &in.Interface, &out.Interface
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamingSpec.
+func (in *NamingSpec) DeepCopy() *NamingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkResourceMappingItem) DeepCopyInto(out *NetworkResourceMappingItem) {
 	*out = *in
@@ -50,6 +524,21 @@ func (in *NetworkResourceMappingItem) DeepCopyInto(out *NetworkResourceMappingIt
 		*out = new(string)
 		**out = **in
 	}
+	if in.MACPolicy != nil {
+		in, out := &in.MACPolicy, &out.MACPolicy
+		*out = new(MACPolicy)
+		**out = **in
+	}
+	if in.InterfaceModel != nil {
+		in, out := &in.InterfaceModel, &out.InterfaceModel
+		*out = new(string)
+		**out = **in
+	}
+	if in.InterfaceBinding != nil {
+		in, out := &in.InterfaceBinding, &out.InterfaceBinding
+		*out = new(InterfaceBinding)
+		**out = **in
+	}
 	return
 }
 
@@ -120,6 +609,11 @@ func (in *OvirtMappings) DeepCopyInto(out *OvirtMappings) {
 			}
 		}
 	}
+	if in.UnmappedNetworks != nil {
+		in, out := &in.UnmappedNetworks, &out.UnmappedNetworks
+		*out = new(UnmappedNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -128,7 +622,78 @@ func (in *OvirtMappings) DeepCopy() *OvirtMappings {
 	if in == nil {
 		return nil
 	}
-	out := new(OvirtMappings)
+	out := new(OvirtMappings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PropagationRule) DeepCopyInto(out *PropagationRule) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+	if in.FromImportLabel != nil {
+		in, out := &in.FromImportLabel, &out.FromImportLabel
+		*out = new(string)
+		**out = **in
+	}
+	if in.FromImportAnnotation != nil {
+		in, out := &in.FromImportAnnotation, &out.FromImportAnnotation
+		*out = new(string)
+		**out = **in
+	}
+	if in.FromSource != nil {
+		in, out := &in.FromSource, &out.FromSource
+		*out = new(SourceMetadataSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]PropagationTarget, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropagationRule.
+func (in *PropagationRule) DeepCopy() *PropagationRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PropagationRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PropagationSpec) DeepCopyInto(out *PropagationSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]PropagationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make([]PropagationRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PropagationSpec.
+func (in *PropagationSpec) DeepCopy() *PropagationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PropagationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -236,6 +801,32 @@ func (in *ResourceMappingStatus) DeepCopy() *ResourceMappingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcePatch) DeepCopyInto(out *ResourcePatch) {
+	*out = *in
+	if in.Patch != nil {
+		in, out := &in.Patch, &out.Patch
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ConfigMapPatchSource)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcePatch.
+func (in *ResourcePatch) DeepCopy() *ResourcePatch {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcePatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Source) DeepCopyInto(out *Source) {
 	*out = *in
@@ -262,6 +853,60 @@ func (in *Source) DeepCopy() *Source {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceMetadataSelector) DeepCopyInto(out *SourceMetadataSelector) {
+	*out = *in
+	if in.Tag != nil {
+		in, out := &in.Tag, &out.Tag
+		*out = new(string)
+		**out = **in
+	}
+	if in.CustomAttribute != nil {
+		in, out := &in.CustomAttribute, &out.CustomAttribute
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceMetadataSelector.
+func (in *SourceMetadataSelector) DeepCopy() *SourceMetadataSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceMetadataSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceMetadataStatus) DeepCopyInto(out *SourceMetadataStatus) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomAttributes != nil {
+		in, out := &in.CustomAttributes, &out.CustomAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceMetadataStatus.
+func (in *SourceMetadataStatus) DeepCopy() *SourceMetadataStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceMetadataStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageResourceMappingItem) DeepCopyInto(out *StorageResourceMappingItem) {
 	*out = *in
@@ -277,6 +922,11 @@ func (in *StorageResourceMappingItem) DeepCopyInto(out *StorageResourceMappingIt
 		*out = new(v1.PersistentVolumeAccessMode)
 		**out = **in
 	}
+	if in.DiskBus != nil {
+		in, out := &in.DiskBus, &out.DiskBus
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -290,6 +940,170 @@ func (in *StorageResourceMappingItem) DeepCopy() *StorageResourceMappingItem {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SysprepCustomization) DeepCopyInto(out *SysprepCustomization) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SysprepCustomization.
+func (in *SysprepCustomization) DeepCopy() *SysprepCustomization {
+	if in == nil {
+		return nil
+	}
+	out := new(SysprepCustomization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPSocketProbe) DeepCopyInto(out *TCPSocketProbe) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPSocketProbe.
+func (in *TCPSocketProbe) DeepCopy() *TCPSocketProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPSocketProbe)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateSpec) DeepCopyInto(out *TemplateSpec) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(string)
+		**out = **in
+	}
+	if in.Flavor != nil {
+		in, out := &in.Flavor, &out.Flavor
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateSpec.
+func (in *TemplateSpec) DeepCopy() *TemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateStatus) DeepCopyInto(out *TemplateStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateStatus.
+func (in *TemplateStatus) DeepCopy() *TemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingConfig) DeepCopyInto(out *TracingConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingConfig.
+func (in *TracingConfig) DeepCopy() *TracingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnmappedNetworkPolicy) DeepCopyInto(out *UnmappedNetworkPolicy) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(ObjectIdentifier)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnmappedNetworkPolicy.
+func (in *UnmappedNetworkPolicy) DeepCopy() *UnmappedNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(UnmappedNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnmappedNetworkStatus) DeepCopyInto(out *UnmappedNetworkStatus) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(ObjectIdentifier)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VLAN != nil {
+		in, out := &in.VLAN, &out.VLAN
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnmappedNetworkStatus.
+func (in *UnmappedNetworkStatus) DeepCopy() *UnmappedNetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UnmappedNetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMImportConfig) DeepCopyInto(out *VMImportConfig) {
 	*out = *in
@@ -355,6 +1169,21 @@ func (in *VMImportConfigList) DeepCopyObject() runtime.Object {
 func (in *VMImportConfigSpec) DeepCopyInto(out *VMImportConfigSpec) {
 	*out = *in
 	in.Infra.DeepCopyInto(&out.Infra)
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(TracingConfig)
+		**out = **in
+	}
+	if in.GuestConversionPod != nil {
+		in, out := &in.GuestConversionPod, &out.GuestConversionPod
+		*out = new(GuestConversionPodConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -385,6 +1214,37 @@ func (in *VMImportConfigStatus) DeepCopy() *VMImportConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtioWinSource) DeepCopyInto(out *VirtioWinSource) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(string)
+		**out = **in
+	}
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtioWinSource.
+func (in *VirtioWinSource) DeepCopy() *VirtioWinSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtioWinSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineImport) DeepCopyInto(out *VirtualMachineImport) {
 	*out = *in
@@ -609,6 +1469,60 @@ func (in *VirtualMachineImportSpec) DeepCopyInto(out *VirtualMachineImportSpec)
 		*out = new(bool)
 		**out = **in
 	}
+	if in.GuestConversion != nil {
+		in, out := &in.GuestConversion, &out.GuestConversion
+		*out = new(GuestConversionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GuestInspection != nil {
+		in, out := &in.GuestInspection, &out.GuestInspection
+		*out = new(GuestInspectionSpec)
+		**out = **in
+	}
+	if in.Customization != nil {
+		in, out := &in.Customization, &out.Customization
+		*out = new(CustomizationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BootVerification != nil {
+		in, out := &in.BootVerification, &out.BootVerification
+		*out = new(BootVerificationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(TemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VMPatches != nil {
+		in, out := &in.VMPatches, &out.VMPatches
+		*out = make([]ResourcePatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DataVolumePatches != nil {
+		in, out := &in.DataVolumePatches, &out.DataVolumePatches
+		*out = make([]ResourcePatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Propagation != nil {
+		in, out := &in.Propagation, &out.Propagation
+		*out = new(PropagationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Naming != nil {
+		in, out := &in.Naming, &out.Naming
+		*out = new(NamingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TargetNamespace != nil {
+		in, out := &in.TargetNamespace, &out.TargetNamespace
+		*out = new(string)
+		**out = **in
+	}
 	return
 }
 
@@ -635,9 +1549,55 @@ func (in *VirtualMachineImportStatus) DeepCopyInto(out *VirtualMachineImportStat
 	if in.DataVolumes != nil {
 		in, out := &in.DataVolumes, &out.DataVolumes
 		*out = make([]DataVolumeItem, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	in.WarmImport.DeepCopyInto(&out.WarmImport)
+	if in.GuestConversion != nil {
+		in, out := &in.GuestConversion, &out.GuestConversion
+		*out = new(GuestConversionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GuestInspection != nil {
+		in, out := &in.GuestInspection, &out.GuestInspection
+		*out = new(GuestInspectionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GuestNetwork != nil {
+		in, out := &in.GuestNetwork, &out.GuestNetwork
+		*out = new(GuestNetworkStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UnmappedNetworks != nil {
+		in, out := &in.UnmappedNetworks, &out.UnmappedNetworks
+		*out = make([]UnmappedNetworkStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GeneratedNetworkMappings != nil {
+		in, out := &in.GeneratedNetworkMappings, &out.GeneratedNetworkMappings
+		*out = make([]NetworkResourceMappingItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BootVerification != nil {
+		in, out := &in.BootVerification, &out.BootVerification
+		*out = new(BootVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(TemplateStatus)
+		**out = **in
+	}
+	if in.SourceMetadata != nil {
+		in, out := &in.SourceMetadata, &out.SourceMetadata
+		*out = new(SourceMetadataStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -760,6 +1720,26 @@ func (in *VmwareMappings) DeepCopyInto(out *VmwareMappings) {
 			}
 		}
 	}
+	if in.InterfaceModel != nil {
+		in, out := &in.InterfaceModel, &out.InterfaceModel
+		*out = new(string)
+		**out = **in
+	}
+	if in.InterfaceBinding != nil {
+		in, out := &in.InterfaceBinding, &out.InterfaceBinding
+		*out = new(InterfaceBinding)
+		**out = **in
+	}
+	if in.DiskBus != nil {
+		in, out := &in.DiskBus, &out.DiskBus
+		*out = new(string)
+		**out = **in
+	}
+	if in.UnmappedNetworks != nil {
+		in, out := &in.UnmappedNetworks, &out.UnmappedNetworks
+		*out = new(UnmappedNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"context"
+
 	ovirtclient "github.com/kubevirt/vm-import-operator/pkg/providers/ovirt/client"
 	vmwareclient "github.com/kubevirt/vm-import-operator/pkg/providers/vmware/client"
 )
@@ -11,12 +13,16 @@ type Factory interface {
 	NewVmwareClient(dataMap map[string]string) (VMClient, error)
 }
 
-// VMClient provides interface how source virtual machines should be fetched
+// VMClient provides interface how source virtual machines should be fetched. The spans of the calls to the source
+// provider are started from the context given to each call.
 type VMClient interface {
-	TestConnection() error
-	GetVM(id *string, name *string, cluster *string, clusterID *string) (interface{}, error)
-	StopVM(id string) error
-	StartVM(id string) error
+	TestConnection(ctx context.Context) error
+	GetVM(ctx context.Context, id *string, name *string, cluster *string, clusterID *string) (interface{}, error)
+	StopVM(ctx context.Context, id string) error
+	StartVM(ctx context.Context, id string) error
+	DeleteVM(ctx context.Context, id string) error
+	MarkVMMigrated(ctx context.Context, id string, target string) error
+	UnmarkVMMigrated(ctx context.Context, id string, target string) error
 	Close() error
 }
 
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/kubevirt/vm-import-operator/pkg/config"
 )
@@ -22,8 +23,49 @@ const (
 	// WarmImportIntervalMinutesKey defines how long to wait between warm import iterations
 	WarmImportIntervalMinutesKey     = "warmImport.intervalMinutes"
 	warmImportIntervalMinutesDefault = 60
+	// DiskImportMaxRetriesKey defines how many times a failed disk import is retried before failing the whole import
+	DiskImportMaxRetriesKey     = "diskImport.maxRetries"
+	diskImportMaxRetriesDefault = 3
+	// DiskImportRetryBackoffSecondsKey defines the initial delay before retrying a failed disk import, doubled after every attempt
+	DiskImportRetryBackoffSecondsKey     = "diskImport.retryBackoffSeconds"
+	diskImportRetryBackoffSecondsDefault = 30
+	// DiskImportRetryableFailuresKey defines the comma-separated list of disk import failure classes that are retried
+	DiskImportRetryableFailuresKey = "diskImport.retryableFailures"
+	// KubeMacPoolNamespaceKey defines the namespace of the KubeMacPool MAC range config map
+	KubeMacPoolNamespaceKey     = "kubeMacPool.namespace"
+	kubeMacPoolNamespaceDefault = "kubemacpool-system"
+	// KubeMacPoolConfigMapNameKey defines the name of the KubeMacPool MAC range config map
+	KubeMacPoolConfigMapNameKey     = "kubeMacPool.configMapName"
+	kubeMacPoolConfigMapNameDefault = "kubemacpool-mac-range-config"
+	// NetworkGenerationCNITypeKey defines the CNI plugin of the generated network attachment definitions: bridge or ovs
+	NetworkGenerationCNITypeKey     = "networkGeneration.cniType"
+	networkGenerationCNITypeDefault = "bridge"
+	// NetworkGenerationBridgeKey defines the node bridge the generated network attachment definitions are attached to
+	NetworkGenerationBridgeKey     = "networkGeneration.bridge"
+	networkGenerationBridgeDefault = "br1"
+	// TemplatesBackendKey defines where the templates of the imported VMs are read from: openshift or configMap
+	TemplatesBackendKey     = "templates.backend"
+	templatesBackendDefault = "openshift"
+	// TemplatesNamespaceKey defines the namespace the templates of the imported VMs are searched in
+	TemplatesNamespaceKey     = "templates.namespace"
+	templatesNamespaceDefault = "openshift"
+	// ClusterNameKey defines the name of the cluster recorded on the source VMs marked as migrated
+	ClusterNameKey = "clusterName"
+
+	// TransientFailure represents a disk import failure caused by a network or source provider error that may go away
+	TransientFailure = "TransientFailure"
+	// AuthenticationFailure represents a disk import failure caused by the source provider rejecting the credentials
+	AuthenticationFailure = "AuthenticationFailure"
+	// DiskNotFound represents a disk import failure caused by the source disk missing in the source provider
+	DiskNotFound = "DiskNotFound"
+	// DataVolumeFailed represents an unclassified disk import failure caused by the DataVolume reaching the Failed phase
+	DataVolumeFailed = "DataVolumeFailed"
+	// ImporterPodCrashLoop represents an unclassified disk import failure caused by the importer pod exceeding its restart tolerance
+	ImporterPodCrashLoop = "ImporterPodCrashLoop"
 )
 
+var diskImportRetryableFailuresDefault = []string{TransientFailure}
+
 // ControllerConfig stores controller runtime configuration
 type ControllerConfig struct {
 	config.Config
@@ -58,6 +100,74 @@ func (c ControllerConfig) WarmImportIntervalMinutes() int {
 	return c.getKeyAsInt(WarmImportIntervalMinutesKey, warmImportIntervalMinutesDefault, 0)
 }
 
+// DiskImportMaxRetries provides the number of times a failed disk import is retried. Zero disables retries.
+func (c ControllerConfig) DiskImportMaxRetries() int {
+	return c.getKeyAsInt(DiskImportMaxRetriesKey, diskImportMaxRetriesDefault, 0)
+}
+
+// DiskImportRetryBackoffSeconds provides the delay before the first retry of a failed disk import
+func (c ControllerConfig) DiskImportRetryBackoffSeconds() int {
+	return c.getKeyAsInt(DiskImportRetryBackoffSecondsKey, diskImportRetryBackoffSecondsDefault, 0)
+}
+
+// DiskImportRetryableFailures provides the disk import failure classes that should be retried
+func (c ControllerConfig) DiskImportRetryableFailures() []string {
+	raw, ok := c.ConfigMap.Data[DiskImportRetryableFailuresKey]
+	if !ok {
+		return diskImportRetryableFailuresDefault
+	}
+	failures := []string{}
+	for _, failure := range strings.Split(raw, ",") {
+		failure = strings.TrimSpace(failure)
+		if failure != "" {
+			failures = append(failures, failure)
+		}
+	}
+	return failures
+}
+
+// KubeMacPoolNamespace provides the namespace of the KubeMacPool MAC range config map
+func (c ControllerConfig) KubeMacPoolNamespace() string {
+	return c.getKey(KubeMacPoolNamespaceKey, kubeMacPoolNamespaceDefault)
+}
+
+// KubeMacPoolConfigMapName provides the name of the KubeMacPool MAC range config map
+func (c ControllerConfig) KubeMacPoolConfigMapName() string {
+	return c.getKey(KubeMacPoolConfigMapNameKey, kubeMacPoolConfigMapNameDefault)
+}
+
+// NetworkGenerationCNIType provides the CNI plugin of the generated network attachment definitions
+func (c ControllerConfig) NetworkGenerationCNIType() string {
+	return c.getKey(NetworkGenerationCNITypeKey, networkGenerationCNITypeDefault)
+}
+
+// NetworkGenerationBridge provides the node bridge the generated network attachment definitions are attached to
+func (c ControllerConfig) NetworkGenerationBridge() string {
+	return c.getKey(NetworkGenerationBridgeKey, networkGenerationBridgeDefault)
+}
+
+// TemplatesBackend provides where the templates of the imported VMs are read from
+func (c ControllerConfig) TemplatesBackend() string {
+	return c.getKey(TemplatesBackendKey, templatesBackendDefault)
+}
+
+// TemplatesNamespace provides the namespace the templates of the imported VMs are searched in
+func (c ControllerConfig) TemplatesNamespace() string {
+	return c.getKey(TemplatesNamespaceKey, templatesNamespaceDefault)
+}
+
+// ClusterName provides the name of the cluster recorded on the source VMs marked as migrated. Empty string is returned when the name is not present.
+func (c ControllerConfig) ClusterName() string {
+	return c.ConfigMap.Data[ClusterNameKey]
+}
+
+func (c ControllerConfig) getKey(key string, default_ string) string {
+	if value, ok := c.ConfigMap.Data[key]; ok && value != "" {
+		return value
+	}
+	return default_
+}
+
 func (c ControllerConfig) getKeyAsInt(key string, default_ int, floor int) int {
 	raw := c.ConfigMap.Data[key]
 	parsed, err := strconv.Atoi(raw)
@@ -33,3 +33,105 @@ var _ = Describe("Controller config creator", func() {
 		Expect(cfg.OsConfigMapNamespace()).To(BeEquivalentTo(configMapNamespace))
 	})
 })
+
+var _ = Describe("Controller config disk import retries", func() {
+	It("should provide defaults when not configured", func() {
+		cfg := controller.NewControllerConfigFrom(config.Config{})
+
+		Expect(cfg.DiskImportMaxRetries()).To(Equal(3))
+		Expect(cfg.DiskImportRetryBackoffSeconds()).To(Equal(30))
+		Expect(cfg.DiskImportRetryableFailures()).To(ConsistOf(controller.TransientFailure))
+	})
+
+	It("should provide configured values", func() {
+		configMap := corev1.ConfigMap{
+			Data: map[string]string{
+				controller.DiskImportMaxRetriesKey:          "5",
+				controller.DiskImportRetryBackoffSecondsKey: "10",
+				controller.DiskImportRetryableFailuresKey:   " DataVolumeFailed ,,",
+			},
+		}
+		cfg := controller.NewControllerConfigFrom(config.Config{ConfigMap: configMap})
+
+		Expect(cfg.DiskImportMaxRetries()).To(Equal(5))
+		Expect(cfg.DiskImportRetryBackoffSeconds()).To(Equal(10))
+		Expect(cfg.DiskImportRetryableFailures()).To(ConsistOf(controller.DataVolumeFailed))
+	})
+
+	It("should disable retries with an empty list of retryable failures", func() {
+		configMap := corev1.ConfigMap{
+			Data: map[string]string{
+				controller.DiskImportRetryableFailuresKey: "",
+			},
+		}
+		cfg := controller.NewControllerConfigFrom(config.Config{ConfigMap: configMap})
+
+		Expect(cfg.DiskImportRetryableFailures()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Controller config KubeMacPool", func() {
+	It("should provide defaults when not configured", func() {
+		cfg := controller.NewControllerConfigFrom(config.Config{})
+
+		Expect(cfg.KubeMacPoolNamespace()).To(Equal("kubemacpool-system"))
+		Expect(cfg.KubeMacPoolConfigMapName()).To(Equal("kubemacpool-mac-range-config"))
+	})
+
+	It("should provide configured values", func() {
+		configMap := corev1.ConfigMap{
+			Data: map[string]string{
+				controller.KubeMacPoolNamespaceKey:     "openshift-cnv",
+				controller.KubeMacPoolConfigMapNameKey: "mac-range",
+			},
+		}
+		cfg := controller.NewControllerConfigFrom(config.Config{ConfigMap: configMap})
+
+		Expect(cfg.KubeMacPoolNamespace()).To(Equal("openshift-cnv"))
+		Expect(cfg.KubeMacPoolConfigMapName()).To(Equal("mac-range"))
+	})
+})
+
+var _ = Describe("Controller config network generation", func() {
+	It("should provide defaults when not configured", func() {
+		cfg := controller.NewControllerConfigFrom(config.Config{})
+
+		Expect(cfg.NetworkGenerationCNIType()).To(Equal("bridge"))
+		Expect(cfg.NetworkGenerationBridge()).To(Equal("br1"))
+	})
+
+	It("should provide configured values", func() {
+		configMap := corev1.ConfigMap{
+			Data: map[string]string{
+				controller.NetworkGenerationCNITypeKey: "ovs",
+				controller.NetworkGenerationBridgeKey:  "br-vlans",
+			},
+		}
+		cfg := controller.NewControllerConfigFrom(config.Config{ConfigMap: configMap})
+
+		Expect(cfg.NetworkGenerationCNIType()).To(Equal("ovs"))
+		Expect(cfg.NetworkGenerationBridge()).To(Equal("br-vlans"))
+	})
+})
+
+var _ = Describe("Controller config templates", func() {
+	It("should provide defaults when not configured", func() {
+		cfg := controller.NewControllerConfigFrom(config.Config{})
+
+		Expect(cfg.TemplatesBackend()).To(Equal("openshift"))
+		Expect(cfg.TemplatesNamespace()).To(Equal("openshift"))
+	})
+
+	It("should provide configured values", func() {
+		configMap := corev1.ConfigMap{
+			Data: map[string]string{
+				controller.TemplatesBackendKey:   "configMap",
+				controller.TemplatesNamespaceKey: "vm-templates",
+			},
+		}
+		cfg := controller.NewControllerConfigFrom(config.Config{ConfigMap: configMap})
+
+		Expect(cfg.TemplatesBackend()).To(Equal("configMap"))
+		Expect(cfg.TemplatesNamespace()).To(Equal("vm-templates"))
+	})
+})
@@ -4,10 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	"github.com/kubevirt/vm-import-operator/pkg/utils"
 
-	"k8s.io/apimachinery/pkg/types"
-
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -27,14 +26,12 @@ func NewManager(client client.Client) Manager {
 	return Manager{client: client}
 }
 
-// FindFor retrieves config map matching given labels. If none can be found, both error and pointer will be nil. When there is more than 1 matching config map, error will be returned.
-func (m *Manager) FindFor(vmiCrName types.NamespacedName) (*corev1.ConfigMap, error) {
+// FindFor retrieves the config map of the VM import in its target namespace. If none can be found, both error and pointer will be nil. When there is more than 1 matching config map, error will be returned.
+func (m *Manager) FindFor(cr *v2vv1.VirtualMachineImport) (*corev1.ConfigMap, error) {
 	mapList := corev1.ConfigMapList{}
-	labels := client.MatchingLabels{
-		vmiNameLabel: utils.EnsureLabelValueLength(vmiCrName.Name),
-	}
+	selector := utils.ImportSelector(cr, map[string]string{vmiNameLabel: utils.EnsureLabelValueLength(cr.Name)})
 
-	err := m.client.List(context.TODO(), &mapList, labels, client.InNamespace(vmiCrName.Namespace))
+	err := m.client.List(context.TODO(), &mapList, client.MatchingLabelsSelector{Selector: selector}, client.InNamespace(utils.TargetNamespace(cr)))
 	if err != nil {
 		return nil, err
 	}
@@ -44,13 +41,13 @@ func (m *Manager) FindFor(vmiCrName types.NamespacedName) (*corev1.ConfigMap, er
 	case 0:
 		return nil, nil
 	default:
-		return nil, fmt.Errorf("too many config maps matching given labels: %v", labels)
+		return nil, fmt.Errorf("too many config maps matching given labels: %v", selector)
 	}
 }
 
-// CreateFor creates given config map, overriding given Name with a generated one. The config map will be associated with vmiCrName.
-func (m *Manager) CreateFor(configMap *corev1.ConfigMap, vmiCrName types.NamespacedName) error {
-	configMap.Namespace = vmiCrName.Namespace
+// CreateFor creates given config map in the target namespace of the VM import, overriding given Name with a generated one. The config map will be associated with the VM import.
+func (m *Manager) CreateFor(configMap *corev1.ConfigMap, cr *v2vv1.VirtualMachineImport) error {
+	configMap.Namespace = utils.TargetNamespace(cr)
 	// Force generation
 	configMap.GenerateName = prefix
 	configMap.Name = ""
@@ -58,14 +55,22 @@ func (m *Manager) CreateFor(configMap *corev1.ConfigMap, vmiCrName types.Namespa
 	if configMap.Labels == nil {
 		configMap.Labels = make(map[string]string)
 	}
-	configMap.Labels[vmiNameLabel] = utils.EnsureLabelValueLength(vmiCrName.Name)
+	configMap.Labels[vmiNameLabel] = utils.EnsureLabelValueLength(cr.Name)
+	if utils.IsCrossNamespace(cr) {
+		utils.AppendMap(configMap.Labels, utils.ImportTrackingLabels(cr))
+	}
 
 	return m.client.Create(context.TODO(), configMap)
 }
 
-// DeleteFor removes config map created for vmiCrName
-func (m *Manager) DeleteFor(vmiCrName types.NamespacedName) error {
-	configMap, err := m.FindFor(vmiCrName)
+// Update updates given config map
+func (m *Manager) Update(configMap *corev1.ConfigMap) error {
+	return m.client.Update(context.TODO(), configMap)
+}
+
+// DeleteFor removes config map created for the VM import
+func (m *Manager) DeleteFor(cr *v2vv1.VirtualMachineImport) error {
+	configMap, err := m.FindFor(cr)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,76 @@
+package configmaps_test
+
+import (
+	"context"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/configmaps"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config Maps Manager", func() {
+	var (
+		rclient client.Client
+		manager configmaps.Manager
+	)
+	targetNamespace := "db"
+
+	newImport := func(namespace string) *v2vv1.VirtualMachineImport {
+		return &v2vv1.VirtualMachineImport{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-import", Namespace: namespace},
+			Spec:       v2vv1.VirtualMachineImportSpec{TargetNamespace: &targetNamespace},
+		}
+	}
+
+	BeforeEach(func() {
+		rclient = fake.NewFakeClient()
+		manager = configmaps.NewManager(rclient)
+	})
+
+	It("should create the config map in the target namespace", func() {
+		instance := newImport("migration")
+
+		err := manager.CreateFor(&corev1.ConfigMap{}, instance)
+		Expect(err).ToNot(HaveOccurred())
+
+		configMap, err := manager.FindFor(instance)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configMap).ToNot(BeNil())
+		Expect(configMap.Namespace).To(Equal(targetNamespace))
+		Expect(configMap.Labels).To(HaveKeyWithValue("vmimport.v2v.kubevirt.io/import-namespace", "migration"))
+	})
+
+	It("should not find the config map of an import of the same name from another namespace", func() {
+		err := manager.CreateFor(&corev1.ConfigMap{}, newImport("migration"))
+		Expect(err).ToNot(HaveOccurred())
+
+		configMap, err := manager.FindFor(newImport("staging"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configMap).To(BeNil())
+
+		configMap, err = manager.FindFor(newImport(targetNamespace))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configMap).To(BeNil())
+	})
+
+	It("should only delete the config map of the import", func() {
+		instance := newImport("migration")
+		other := newImport("staging")
+		Expect(manager.CreateFor(&corev1.ConfigMap{}, instance)).To(Succeed())
+		Expect(manager.CreateFor(&corev1.ConfigMap{}, other)).To(Succeed())
+
+		err := manager.DeleteFor(instance)
+		Expect(err).ToNot(HaveOccurred())
+
+		configMapList := corev1.ConfigMapList{}
+		Expect(rclient.List(context.TODO(), &configMapList, client.InNamespace(targetNamespace))).To(Succeed())
+		Expect(configMapList.Items).To(HaveLen(1))
+		Expect(configMapList.Items[0].Labels).To(HaveKeyWithValue("vmimport.v2v.kubevirt.io/import-namespace", "staging"))
+	})
+})
@@ -0,0 +1,275 @@
+package virtualmachineimport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/conditions"
+	"github.com/kubevirt/vm-import-operator/pkg/metrics"
+	provider "github.com/kubevirt/vm-import-operator/pkg/providers"
+	"github.com/kubevirt/vm-import-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const defaultBootVerificationTimeoutSeconds = 600
+
+func shouldVerifyBoot(instance *v2vv1.VirtualMachineImport) bool {
+	return instance.Spec.BootVerification != nil
+}
+
+// guestReadinessProbe is the readiness probe of a KubeVirt VMI. The exec probe KubeVirt runs through the guest agent is
+// newer than the vendored KubeVirt API, so it's added here and the probe is written to the VM with a raw patch.
+type guestReadinessProbe struct {
+	kubevirtv1.Probe `json:",inline"`
+	Exec             *corev1.ExecAction `json:"exec,omitempty"`
+}
+
+// validateBootVerification returns why the boot verification can't be performed, or an empty string otherwise
+func validateBootVerification(instance *v2vv1.VirtualMachineImport) string {
+	if !shouldVerifyBoot(instance) {
+		return ""
+	}
+	if instance.Spec.StartVM == nil || !*instance.Spec.StartVM {
+		return "bootVerification requires startVm, since only a started VM can be verified"
+	}
+	if probe := instance.Spec.BootVerification.ReadinessProbe; probe != nil {
+		handlers := 0
+		for _, set := range []bool{probe.HTTPGet != nil, probe.TCPSocket != nil, probe.Exec != nil} {
+			if set {
+				handlers++
+			}
+		}
+		if handlers != 1 {
+			return "the readiness probe must set exactly one of httpGet, tcpSocket and exec"
+		}
+		if probe.Exec != nil && len(probe.Exec.Command) == 0 {
+			return "the exec readiness probe has no command"
+		}
+	}
+	return ""
+}
+
+// readinessProbe returns the readiness probe the boot verification sets on the imported VM, or nil
+func readinessProbe(instance *v2vv1.VirtualMachineImport) *guestReadinessProbe {
+	if !shouldVerifyBoot(instance) || instance.Spec.BootVerification.ReadinessProbe == nil {
+		return nil
+	}
+	spec := instance.Spec.BootVerification.ReadinessProbe
+	probe := &guestReadinessProbe{Probe: kubevirtv1.Probe{PeriodSeconds: spec.PeriodSeconds}}
+	if spec.HTTPGet != nil {
+		probe.HTTPGet = &corev1.HTTPGetAction{
+			Path: spec.HTTPGet.Path,
+			Port: intstr.FromInt(int(spec.HTTPGet.Port)),
+		}
+	}
+	if spec.TCPSocket != nil {
+		probe.TCPSocket = &corev1.TCPSocketAction{
+			Port: intstr.FromInt(int(spec.TCPSocket.Port)),
+		}
+	}
+	if spec.Exec != nil {
+		probe.Exec = &corev1.ExecAction{
+			Command: spec.Exec.Command,
+		}
+	}
+	return probe
+}
+
+// removeReadinessProbe removes the readiness probe of the boot verification from the VM once the verification is over,
+// so that it doesn't outlive the import. The running VM keeps probing until it is restarted.
+func (r *ReconcileVirtualMachineImport) removeReadinessProbe(instance *v2vv1.VirtualMachineImport, vmName types.NamespacedName) error {
+	if readinessProbe(instance) == nil {
+		return nil
+	}
+	vm := &kubevirtv1.VirtualMachine{}
+	err := r.client.Get(context.TODO(), vmName, vm)
+	if err != nil {
+		return err
+	}
+	if vm.Spec.Template == nil {
+		return nil
+	}
+	patch := []byte(`{"spec":{"template":{"spec":{"readinessProbe":null}}}}`)
+	return r.client.Patch(context.TODO(), vm, client.RawPatch(types.MergePatchType, patch))
+}
+
+// verifyBoot checks the started VM against the boot verification of the import. It returns whether the VM passed it,
+// and otherwise whether the verification is still pending and the import must be requeued. The import is ended in
+// failure once the VM stopped or the timeout expired.
+func (r *ReconcileVirtualMachineImport) verifyBoot(provider provider.Provider, instance *v2vv1.VirtualMachineImport, vmName types.NamespacedName, vmi *kubevirtv1.VirtualMachineInstance) (bool, bool, error) {
+	if instance.Status.BootVerification == nil || instance.Status.BootVerification.StartTime == nil {
+		now := metav1.Now()
+		if err := r.storeBootVerification(instance, &v2vv1.BootVerificationStatus{StartTime: &now}); err != nil {
+			return false, false, err
+		}
+	}
+
+	if vmi.Status.Phase == kubevirtv1.Failed || vmi.Status.Phase == kubevirtv1.Succeeded {
+		requeue, err := r.endBootVerificationFailed(provider, instance, vmName, fmt.Sprintf("the VM stopped in the %s phase", vmi.Status.Phase))
+		return false, requeue, err
+	}
+
+	pending := pendingBootCheck(instance.Spec.BootVerification, vmi)
+	if pending == "" {
+		return true, false, nil
+	}
+
+	timeout := time.Duration(defaultBootVerificationTimeoutSeconds) * time.Second
+	if instance.Spec.BootVerification.TimeoutSeconds != nil {
+		timeout = time.Duration(*instance.Spec.BootVerification.TimeoutSeconds) * time.Second
+	}
+	if time.Since(instance.Status.BootVerification.StartTime.Time) > timeout {
+		requeue, err := r.endBootVerificationFailed(provider, instance, vmName, fmt.Sprintf("timed out after %v waiting for %s", timeout, pending))
+		return false, requeue, err
+	}
+
+	processingCond := conditions.NewProcessingCondition(string(v2vv1.VerifyingBoot), "Waiting for "+pending, corev1.ConditionTrue)
+	if err := r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, processingCond); err != nil {
+		return false, false, err
+	}
+	return false, true, nil
+}
+
+// pendingBootCheck describes the first check the VM hasn't passed yet, or returns an empty string
+func pendingBootCheck(spec *v2vv1.BootVerificationSpec, vmi *kubevirtv1.VirtualMachineInstance) string {
+	if vmi.Status.Phase != kubevirtv1.Running {
+		return "the VM to be running"
+	}
+	if spec.GuestAgent && !hasVMICondition(vmi, kubevirtv1.VirtualMachineInstanceAgentConnected) {
+		return "the guest agent to connect"
+	}
+	if spec.ReadinessProbe != nil && !hasVMICondition(vmi, kubevirtv1.VirtualMachineInstanceReady) {
+		return "the VM to become ready"
+	}
+	return ""
+}
+
+func hasVMICondition(vmi *kubevirtv1.VirtualMachineInstance, conditionType kubevirtv1.VirtualMachineInstanceConditionType) bool {
+	for _, condition := range vmi.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// endBootVerificationFailed ends the import in failure. The imported VM is deleted and the source VM restored only
+// when the boot verification asks for a rollback; otherwise the imported VM is kept for troubleshooting. It returns
+// whether the import must be requeued while the imported VM stops before the rollback.
+func (r *ReconcileVirtualMachineImport) endBootVerificationFailed(provider provider.Provider, instance *v2vv1.VirtualMachineImport, vmName types.NamespacedName, failure string) (bool, error) {
+	message := fmt.Sprintf("Boot verification failed: %s", failure)
+	r.recorder.Event(instance, corev1.EventTypeWarning, EventBootVerificationFailed, message)
+
+	status := instance.Status.BootVerification.DeepCopy()
+	status.Failure = message
+	if err := r.storeBootVerification(instance, status); err != nil {
+		return false, err
+	}
+
+	if instance.Spec.BootVerification.RollbackOnFailure {
+		return r.rollbackFailedBoot(provider, instance, vmName)
+	}
+	if err := r.removeReadinessProbe(instance, vmName); err != nil {
+		return false, err
+	}
+
+	instanceNamespacedName := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+	processingCond := conditions.NewProcessingCondition(string(v2vv1.ProcessingFailed), message, corev1.ConditionFalse)
+	succeededCond := conditions.NewSucceededCondition(string(v2vv1.BootVerificationFailed), message, corev1.ConditionFalse)
+	if err := r.upsertStatusConditions(instanceNamespacedName, processingCond, succeededCond); err != nil {
+		return false, err
+	}
+	if err := r.updateProgress(instance, progressDone); err != nil {
+		return false, err
+	}
+	return false, r.afterBootVerificationFailure(vmName, provider, instance)
+}
+
+// shouldRollbackFailedBoot returns whether the boot verification failed and asked for a rollback the import hasn't
+// completed yet
+func shouldRollbackFailedBoot(instance *v2vv1.VirtualMachineImport) bool {
+	return shouldVerifyBoot(instance) && instance.Spec.BootVerification.RollbackOnFailure &&
+		instance.Status.BootVerification != nil && instance.Status.BootVerification.Failure != ""
+}
+
+// rollbackFailedBoot stops the imported VM and, once its instance terminated, ends the import in failure, which
+// deletes the imported VM and restores the source VM. The source VM never runs together with the imported VM, which
+// may have the same MAC addresses. It returns whether the import must be requeued while the imported VM stops.
+func (r *ReconcileVirtualMachineImport) rollbackFailedBoot(provider provider.Provider, instance *v2vv1.VirtualMachineImport, vmName types.NamespacedName) (bool, error) {
+	vm := &kubevirtv1.VirtualMachine{}
+	err := r.client.Get(context.TODO(), vmName, vm)
+	if err == nil && (vm.Spec.Running == nil || *vm.Spec.Running) {
+		patch := []byte(`{"spec":{"running":false}}`)
+		err = r.client.Patch(context.TODO(), vm, client.RawPatch(types.MergePatchType, patch))
+	}
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return false, err
+	}
+
+	err = r.client.Get(context.TODO(), vmName, &kubevirtv1.VirtualMachineInstance{})
+	if err == nil {
+		processingCond := conditions.NewProcessingCondition(string(v2vv1.VerifyingBoot), "Waiting for the VM to stop before rolling back", corev1.ConditionTrue)
+		if err := r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, processingCond); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return false, err
+	}
+	return false, r.fail(provider, instance, v2vv1.BootVerificationFailed, instance.Status.BootVerification.Failure)
+}
+
+// afterBootVerificationFailure cleans up after a failed import like afterFailure, but keeps the imported VM and its
+// data volumes, released from the import, and leaves the source VM stopped
+func (r *ReconcileVirtualMachineImport) afterBootVerificationFailure(vmName types.NamespacedName, p provider.Provider, instance *v2vv1.VirtualMachineImport) error {
+	r.removeFinalizer(utils.CancelledImportFinalizer, instance)
+
+	metrics.ImportMetrics.IncFailed(providerName(instance))
+	metrics.ImportMetrics.SaveDurationFailed(providerName(instance), calculateImportDuration(instance))
+	endImportMetrics(instance)
+
+	vmiName := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+	var errs []error
+	err := p.CleanUp(false, instance, r.client)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	err = r.inspectionPodsManager.DeleteFor(instance)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	e := r.ownerreferencesmgr.PurgeOwnerReferences(vmName)
+	if len(e) > 0 {
+		errs = append(errs, e...)
+	}
+
+	if len(errs) > 0 {
+		return foldErrors(errs, "Import failure", vmiName)
+	}
+	return nil
+}
+
+func (r *ReconcileVirtualMachineImport) storeBootVerification(instance *v2vv1.VirtualMachineImport, bootVerification *v2vv1.BootVerificationStatus) error {
+	var current v2vv1.VirtualMachineImport
+	err := r.apiReader.Get(context.TODO(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, &current)
+	if err != nil {
+		return err
+	}
+	copy := current.DeepCopy()
+	copy.Status.BootVerification = bootVerification
+	err = r.client.Status().Update(context.TODO(), copy)
+	if err != nil {
+		return err
+	}
+	instance.Status.BootVerification = bootVerification
+	return nil
+}
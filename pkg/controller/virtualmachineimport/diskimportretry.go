@@ -0,0 +1,161 @@
+package virtualmachineimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	ctrlConfig "github.com/kubevirt/vm-import-operator/pkg/config/controller"
+	provider "github.com/kubevirt/vm-import-operator/pkg/providers"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+)
+
+// maxDiskImportRetryBackoff caps the exponential backoff between disk import attempts
+const maxDiskImportRetryBackoff = 30 * time.Minute
+
+// diskImportFailurePatterns maps the failure classes to the patterns of the lowercase importer errors they match. The
+// permanent failures are matched first, so that an error mentioning both kinds isn't retried in vain. The patterns are
+// word-bounded, and the HTTP status codes are only matched as the importer reports them, so that a disk ID, an address
+// or a size containing the digits of a status code doesn't change the class.
+var diskImportFailurePatterns = []struct {
+	failure string
+	pattern *regexp.Regexp
+}{
+	{ctrlConfig.AuthenticationFailure, matchAny(httpStatus(401, "unauthorized"), httpStatus(403, "forbidden"), `\bunauthorized\b`, `\bauthentication\b`, `\bforbidden\b`, `\binvalid credentials\b`, `\bpermission denied\b`)},
+	{ctrlConfig.DiskNotFound, matchAny(httpStatus(404, "not found"), `\bnot found\b`, `\bno such file\b`)},
+	{ctrlConfig.TransientFailure, matchAny(httpStatus(502, "bad gateway"), httpStatus(503, "service unavailable"), httpStatus(504, "gateway timeout"), `\bconnection refused\b`, `\bconnection reset\b`, `\btimeout\b`, `\btimed out\b`, `\beof\b`, `\bunavailable\b`, `\btoo many requests\b`, `\btemporar(y|ily)\b`)},
+}
+
+// httpStatus matches an HTTP status code reported as "status code 503", "status: 503", "HTTP/1.1 503" or along with its
+// status text, as in "503 Service Unavailable"
+func httpStatus(code int, text string) string {
+	return fmt.Sprintf(`(\bstatus( code)?|\bhttp/[0-9.]+):?\s*%d\b|\b%d %s\b`, code, code, text)
+}
+
+func matchAny(patterns ...string) *regexp.Regexp {
+	return regexp.MustCompile(strings.Join(patterns, "|"))
+}
+
+// classifyDiskImportFailure returns the failure class matching the importer termination message and the reasons and
+// messages of the data volume conditions, or the fallback class when none of them is recognized
+func classifyDiskImportFailure(dv *cdiv1.DataVolume, terminationMessage string, fallback string) string {
+	texts := []string{terminationMessage}
+	for _, condition := range dv.Status.Conditions {
+		texts = append(texts, condition.Reason, condition.Message)
+	}
+	text := strings.ToLower(strings.Join(texts, " "))
+	for _, class := range diskImportFailurePatterns {
+		if class.pattern.MatchString(text) {
+			return class.failure
+		}
+	}
+	return fallback
+}
+
+// retryOrFailDiskImport recreates the failed data volume when the failure is retryable and the retry limit
+// hasn't been reached yet, otherwise it ends the whole import as failed.
+func (r *ReconcileVirtualMachineImport) retryOrFailDiskImport(provider provider.Provider, instance *v2vv1.VirtualMachineImport, dv *cdiv1.DataVolume, failure string, message string) error {
+	log := log.WithValues("Request.Namespace", instance.Namespace, "Request.Name", instance.Name)
+
+	retryCount := 0
+	item := findDataVolumeItem(instance.Status.DataVolumes, dv.Name)
+	if item != nil {
+		retryCount = item.RetryCount
+	}
+	recordDiskImportSpan(instance, item, errors.New(message))
+	if !r.isRetryableDiskImportFailure(failure) || retryCount >= r.ctrlConfig.DiskImportMaxRetries() {
+		return r.endDiskImportFailed(provider, instance, dv, message)
+	}
+
+	backoff := diskImportRetryBackoff(r.ctrlConfig.DiskImportRetryBackoffSeconds(), retryCount)
+	log.Info("Retrying data volume import", "DataVolume.Name", dv.Name, "Failure", failure, "Attempt", retryCount+1, "Backoff", backoff)
+	r.recorder.Eventf(instance, corev1.EventTypeWarning, EventDiskImportRetry, "Import of disk %s failed (%s), retrying in %v", dv.Name, message, backoff)
+
+	// Only the failed data volume is removed, the disks that are already imported are kept:
+	if err := r.client.Delete(context.TODO(), dv); err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	nextRetryTime := metav1.NewTime(time.Now().Add(backoff))
+	return r.updateDVRetry(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, dv.Name, message, nextRetryTime)
+}
+
+func (r *ReconcileVirtualMachineImport) isRetryableDiskImportFailure(failure string) bool {
+	for _, retryable := range r.ctrlConfig.DiskImportRetryableFailures() {
+		if retryable == failure {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ReconcileVirtualMachineImport) updateDVRetry(vmiName types.NamespacedName, dvName string, message string, nextRetryTime metav1.Time) error {
+	var instance v2vv1.VirtualMachineImport
+	err := r.apiReader.Get(context.TODO(), vmiName, &instance)
+	if err != nil {
+		return err
+	}
+
+	copy := instance.DeepCopy()
+	item := findDataVolumeItem(copy.Status.DataVolumes, dvName)
+	if item == nil {
+		copy.Status.DataVolumes = append(copy.Status.DataVolumes, v2vv1.DataVolumeItem{Name: dvName})
+		item = &copy.Status.DataVolumes[len(copy.Status.DataVolumes)-1]
+	}
+	resetDataVolumeItemProgress(item)
+	item.RetryCount++
+	item.LastFailure = &message
+	item.NextRetryTime = &nextRetryTime
+
+	return r.client.Status().Update(context.TODO(), copy)
+}
+
+// isDiskImportRetryPending returns whether the data volume is waiting for its next import attempt
+func isDiskImportRetryPending(instance *v2vv1.VirtualMachineImport, dvName string) bool {
+	item := findDataVolumeItem(instance.Status.DataVolumes, dvName)
+	return item != nil && item.NextRetryTime != nil && item.NextRetryTime.After(time.Now())
+}
+
+// nextDiskImportRetry returns the time left until the earliest pending disk import retry, or zero if there's none
+func nextDiskImportRetry(instance *v2vv1.VirtualMachineImport) time.Duration {
+	next := NoReQ
+	for _, item := range instance.Status.DataVolumes {
+		if item.NextRetryTime == nil {
+			continue
+		}
+		left := time.Until(item.NextRetryTime.Time)
+		if left > 0 && (next == NoReQ || left < next) {
+			next = left
+		}
+	}
+	return next
+}
+
+// diskImportRetryBackoff doubles the initial backoff with every attempt, up to maxDiskImportRetryBackoff
+func diskImportRetryBackoff(initialSeconds int, retryCount int) time.Duration {
+	backoff := time.Duration(initialSeconds) * time.Second
+	for i := 0; i < retryCount && backoff < maxDiskImportRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxDiskImportRetryBackoff {
+		return maxDiskImportRetryBackoff
+	}
+	return backoff
+}
+
+func findDataVolumeItem(items []v2vv1.DataVolumeItem, name string) *v2vv1.DataVolumeItem {
+	for i := range items {
+		if items[i].Name == name {
+			return &items[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package virtualmachineimport
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/metrics"
+	"github.com/kubevirt/vm-import-operator/pkg/tracing"
+	"github.com/kubevirt/vm-import-operator/pkg/utils"
+	"go.opentelemetry.io/otel/label"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+)
+
+// newDataVolumeItem creates the status entry describing the import of the data volume
+func newDataVolumeItem(dv *cdiv1.DataVolume) v2vv1.DataVolumeItem {
+	item := v2vv1.DataVolumeItem{
+		Name:           dv.Name,
+		SourceDiskID:   dv.Annotations[utils.SourceDiskIDAnnotation],
+		SourceDiskName: dv.Annotations[utils.SourceDiskNameAnnotation],
+		PVCName:        dv.Name,
+	}
+	if dv.Spec.PVC != nil {
+		if size, ok := dv.Spec.PVC.Resources.Requests[corev1.ResourceStorage]; ok {
+			item.Size = &size
+		}
+	}
+	return item
+}
+
+// updateDVsProgress stores the import progress of the data volumes in the VM import status
+func (r *ReconcileVirtualMachineImport) updateDVsProgress(vmiName types.NamespacedName, dvs []*cdiv1.DataVolume) error {
+	if len(dvs) == 0 {
+		return nil
+	}
+	var instance v2vv1.VirtualMachineImport
+	err := r.apiReader.Get(context.TODO(), vmiName, &instance)
+	if err != nil {
+		return err
+	}
+
+	copy := instance.DeepCopy()
+	now := metav1.Now()
+	changed := false
+	var finished []string
+	for _, dv := range dvs {
+		item := findDataVolumeItem(copy.Status.DataVolumes, dv.Name)
+		if item == nil {
+			copy.Status.DataVolumes = append(copy.Status.DataVolumes, newDataVolumeItem(dv))
+			item = &copy.Status.DataVolumes[len(copy.Status.DataVolumes)-1]
+			changed = true
+		}
+		wasFinished := item.EndTime != nil
+		if updateDataVolumeItemProgress(item, dv, now) {
+			changed = true
+		}
+		if !wasFinished && item.EndTime != nil {
+			finished = append(finished, item.Name)
+		}
+	}
+	// Update the status only when the progress has moved, so the reconcile loop isn't triggered needlessly:
+	if !changed {
+		return nil
+	}
+
+	var bytesTransferred, throughput int64
+	for _, item := range copy.Status.DataVolumes {
+		bytesTransferred += item.BytesTransferred
+		throughput += item.Throughput
+	}
+	metrics.ImportMetrics.SetTransfer(providerName(copy), copy.Namespace, copy.Name, bytesTransferred, throughput)
+
+	err = r.client.Status().Update(context.TODO(), copy)
+	if err != nil {
+		return err
+	}
+	for _, name := range finished {
+		recordDiskImportSpan(copy, findDataVolumeItem(copy.Status.DataVolumes, name), nil)
+	}
+	return nil
+}
+
+// updateDataVolumeItemProgress updates the progress details of the status entry from the data volume status
+// and returns whether anything has changed
+func updateDataVolumeItemProgress(item *v2vv1.DataVolumeItem, dv *cdiv1.DataVolume, now metav1.Time) bool {
+	phase := string(dv.Status.Phase)
+	progress := parseDataVolumeProgress(dv.Status.Progress)
+	if dv.Status.Phase == cdiv1.Succeeded {
+		progress = 100.0
+	}
+	formattedProgress := fmt.Sprintf("%.2f%%", progress)
+	if item.Phase == phase && item.Progress == formattedProgress {
+		return false
+	}
+	item.Phase = phase
+	item.Progress = formattedProgress
+
+	if item.StartTime == nil && (dv.Status.Phase == cdiv1.ImportInProgress || dv.Status.Phase == cdiv1.Succeeded) {
+		startTime := now
+		item.StartTime = &startTime
+	}
+	if dv.Status.Phase == cdiv1.Succeeded && item.EndTime == nil {
+		endTime := now
+		item.EndTime = &endTime
+	}
+
+	if item.Size == nil {
+		return true
+	}
+	size := item.Size.Value()
+	item.BytesTransferred = int64(float64(size) * progress / 100.0)
+
+	item.Throughput = 0
+	item.EstimatedCompletionTime = nil
+	if item.StartTime == nil {
+		return true
+	}
+	end := now.Time
+	if item.EndTime != nil {
+		end = item.EndTime.Time
+	}
+	elapsed := end.Sub(item.StartTime.Time).Seconds()
+	if elapsed <= 0 || item.BytesTransferred == 0 {
+		return true
+	}
+	item.Throughput = int64(float64(item.BytesTransferred) / elapsed)
+	if item.EndTime == nil && item.Throughput > 0 {
+		remaining := time.Duration(float64(size-item.BytesTransferred) / float64(item.Throughput) * float64(time.Second))
+		eta := metav1.NewTime(now.Add(remaining))
+		item.EstimatedCompletionTime = &eta
+	}
+	return true
+}
+
+// parseDataVolumeProgress converts the data volume progress, e.g. "45.00%", to a number
+func parseDataVolumeProgress(progress cdiv1.DataVolumeProgress) float64 {
+	progressFloat, err := strconv.ParseFloat(strings.TrimRight(string(progress), "%"), 64)
+	if err != nil {
+		return 0.0
+	}
+	return progressFloat
+}
+
+// resetDataVolumeItemProgress clears the progress details of a data volume which is going to be imported again
+func resetDataVolumeItemProgress(item *v2vv1.DataVolumeItem) {
+	item.Phase = string(cdiv1.Failed)
+	item.Progress = ""
+	item.BytesTransferred = 0
+	item.Throughput = 0
+	item.StartTime = nil
+	item.EndTime = nil
+	item.EstimatedCompletionTime = nil
+}
+
+// recordDiskImportSpan records the span of the data volume import, from the start of the import until its end, or until
+// now when the import has failed
+func recordDiskImportSpan(instance *v2vv1.VirtualMachineImport, item *v2vv1.DataVolumeItem, err error) {
+	if item == nil || item.StartTime == nil {
+		return
+	}
+	end := time.Now()
+	if item.EndTime != nil {
+		end = item.EndTime.Time
+	}
+	tracing.RecordSpan(instance.UID, "ImportDisk", item.StartTime.Time, end, err,
+		label.String("datavolume.name", item.Name),
+		label.String("disk.id", item.SourceDiskID),
+		label.Int64("disk.bytes_transferred", item.BytesTransferred),
+	)
+}
@@ -0,0 +1,54 @@
+package virtualmachineimport
+
+import (
+	"context"
+	"io"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/guestconversion"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// podLogReader streams the logs of pods
+type podLogReader interface {
+	Stream(pod *corev1.Pod, container string) (io.ReadCloser, error)
+}
+
+// storeGuestConversionLog stores the summary and the end of the virt-v2v log in the VM import status, so that the
+// conversion pod can be removed without losing them. When the log can't be read to the end, the summary of the part
+// read so far is stored and the pod is kept for debugging.
+func (r *ReconcileVirtualMachineImport) storeGuestConversionLog(instance *v2vv1.VirtualMachineImport, pod *corev1.Pod) error {
+	if instance.Status.GuestConversion != nil {
+		return nil
+	}
+	reqLogger := log.WithValues("Request.Namespace", instance.Namespace, "Request.Name", instance.Name)
+
+	logs, err := r.podLogReader.Stream(pod, guestconversion.ContainerName)
+	if err != nil {
+		reqLogger.Error(err, "Cannot read the log of the conversion pod.", "Pod.Name", pod.Name)
+		return nil
+	}
+	defer logs.Close()
+	status, err := guestconversion.ParseLog(logs)
+	if err != nil {
+		// the summary of the part read so far is still stored, the pod is kept for the rest of the log
+		reqLogger.Error(err, "Cannot read the log of the conversion pod to the end.", "Pod.Name", pod.Name)
+		status.LogError = err.Error()
+	}
+
+	var current v2vv1.VirtualMachineImport
+	err = r.apiReader.Get(context.TODO(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, &current)
+	if err != nil {
+		return err
+	}
+	copy := current.DeepCopy()
+	copy.Status.GuestConversion = status
+	err = r.client.Status().Update(context.TODO(), copy)
+	if err != nil {
+		return err
+	}
+	// the providers decide whether to remove the pod on clean up based on the stored log
+	instance.Status.GuestConversion = status
+	return nil
+}
@@ -0,0 +1,139 @@
+package virtualmachineimport
+
+import (
+	"context"
+	"fmt"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/conditions"
+	"github.com/kubevirt/vm-import-operator/pkg/guestconversion"
+	provider "github.com/kubevirt/vm-import-operator/pkg/providers"
+	"github.com/kubevirt/vm-import-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+)
+
+// inspectGuest runs virt-inspector on the imported disks and relabels the target VM after the operating system it
+// finds. A failed inspection doesn't fail the import: the VM keeps the operating system reported by the source.
+func (r *ReconcileVirtualMachineImport) inspectGuest(provider provider.Provider, instance *v2vv1.VirtualMachineImport, mapper provider.Mapper, vmName types.NamespacedName) (bool, error) {
+	log := log.WithValues("Request.Namespace", instance.Namespace, "Request.Name", instance.Name)
+	vmiName := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+
+	pod, err := r.inspectionPodsManager.FindFor(instance)
+	if err != nil {
+		return false, err
+	}
+	if pod == nil {
+		log.Info("Creating guest inspection pod")
+		vmSpec := &kubevirtv1.VirtualMachine{}
+		err = r.client.Get(context.TODO(), vmName, vmSpec)
+		if err != nil {
+			return false, err
+		}
+		dataVolumes, err := mapper.MapDataVolumes(&vmName.Name, r.filesystemOverhead)
+		if err != nil {
+			return false, err
+		}
+
+		pod = guestconversion.MakeGuestInspectionPodSpec(vmSpec, dataVolumes, instance.Spec.GuestConversion)
+		pod.Namespace = utils.TargetNamespace(instance)
+		if err = r.setImportOwnership(instance, pod); err != nil {
+			return false, err
+		}
+		if err = r.inspectionPodsManager.CreateFor(pod, instance); err != nil {
+			return false, err
+		}
+		processingCond := conditions.NewProcessingCondition(string(v2vv1.InspectingGuest), fmt.Sprintf("Running guest inspection pod %s", pod.Name), corev1.ConditionTrue)
+		return false, r.upsertStatusConditions(vmiName, processingCond)
+	}
+
+	var inspection *v2vv1.GuestInspectionStatus
+	switch pod.Status.Phase {
+	case corev1.PodSucceeded:
+		inspection = r.readGuestInspection(pod)
+	case corev1.PodFailed:
+		log.Info("Guest inspection pod failed.", "Pod.Name", pod.Name)
+		inspection = &v2vv1.GuestInspectionStatus{Failure: fmt.Sprintf("guest inspection pod %s failed", pod.Name)}
+	default:
+		return false, nil
+	}
+
+	if inspection.Failure == "" {
+		if err = r.applyGuestInspection(provider, instance, vmName, inspection); err != nil {
+			return false, err
+		}
+	} else {
+		r.recorder.Event(instance, corev1.EventTypeWarning, EventGuestInspectionFailed, inspection.Failure)
+	}
+	if err = r.storeGuestInspection(instance, inspection); err != nil {
+		return false, err
+	}
+	return true, r.inspectionPodsManager.DeleteFor(instance)
+}
+
+// readGuestInspection parses the output of the inspection pod. When it can't be read, the returned inspection
+// reports the failure.
+func (r *ReconcileVirtualMachineImport) readGuestInspection(pod *corev1.Pod) *v2vv1.GuestInspectionStatus {
+	output, err := r.podLogReader.Stream(pod, guestconversion.ContainerName)
+	if err != nil {
+		return &v2vv1.GuestInspectionStatus{Failure: fmt.Sprintf("cannot read the output of guest inspection pod %s: %v", pod.Name, err)}
+	}
+	defer output.Close()
+	inspection, err := guestconversion.ParseInspection(output)
+	if err != nil {
+		return &v2vv1.GuestInspectionStatus{Failure: fmt.Sprintf("guest inspection pod %s: %v", pod.Name, err)}
+	}
+	return inspection
+}
+
+// applyGuestInspection relabels the target VM after the inspected operating system and makes it boot with UEFI when
+// the guest does. When no template matches the inspected operating system, the VM keeps its labels.
+func (r *ReconcileVirtualMachineImport) applyGuestInspection(provider provider.Provider, instance *v2vv1.VirtualMachineImport, vmName types.NamespacedName, inspection *v2vv1.GuestInspectionStatus) error {
+	vm := &kubevirtv1.VirtualMachine{}
+	err := r.client.Get(context.TODO(), vmName, vm)
+	if err != nil {
+		return err
+	}
+
+	if inspection.OperatingSystem != "" {
+		if err = provider.UpdateOperatingSystem(vm, inspection); err != nil {
+			r.recorder.Eventf(instance, corev1.EventTypeWarning, EventGuestInspectionFailed, "Cannot relabel the VM after the inspected operating system %s: %v", inspection.OperatingSystem, err)
+		}
+	}
+
+	if inspection.Firmware == guestconversion.FirmwareUEFI {
+		domain := &vm.Spec.Template.Spec.Domain
+		if domain.Firmware == nil {
+			domain.Firmware = &kubevirtv1.Firmware{}
+		}
+		if domain.Firmware.Bootloader == nil || domain.Firmware.Bootloader.EFI == nil {
+			secureBoot := false
+			domain.Firmware.Bootloader = &kubevirtv1.Bootloader{EFI: &kubevirtv1.EFI{SecureBoot: &secureBoot}}
+		}
+	}
+
+	return r.client.Update(context.TODO(), vm)
+}
+
+// storeGuestInspection stores the result of the inspection in the VM import status. The stored result tells that the
+// inspection is done.
+func (r *ReconcileVirtualMachineImport) storeGuestInspection(instance *v2vv1.VirtualMachineImport, inspection *v2vv1.GuestInspectionStatus) error {
+	var current v2vv1.VirtualMachineImport
+	err := r.apiReader.Get(context.TODO(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, &current)
+	if err != nil {
+		return err
+	}
+	copy := current.DeepCopy()
+	copy.Status.GuestInspection = inspection
+	err = r.client.Status().Update(context.TODO(), copy)
+	if err != nil {
+		return err
+	}
+	instance.Status.GuestInspection = inspection
+	return nil
+}
+
+func shouldInspectGuest(instance *v2vv1.VirtualMachineImport) bool {
+	return instance.Spec.GuestInspection != nil && instance.Spec.GuestInspection.Enabled && instance.Status.GuestInspection == nil
+}
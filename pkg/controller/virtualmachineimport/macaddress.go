@@ -0,0 +1,90 @@
+package virtualmachineimport
+
+import (
+	"fmt"
+	"strings"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/macaddress"
+	provider "github.com/kubevirt/vm-import-operator/pkg/providers"
+	corev1 "k8s.io/api/core/v1"
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+)
+
+// validateMACAddresses returns why the import is blocked when a MAC address the imported VM must keep is already
+// used in the cluster, or an empty string otherwise
+func (r *ReconcileVirtualMachineImport) validateMACAddresses(instance *v2vv1.VirtualMachineImport, provider provider.Provider) (string, error) {
+	mapper, err := provider.CreateValidationMapper()
+	if err != nil {
+		return "", err
+	}
+	conflicts, err := r.findMACConflicts(mapper.MapMACAddresses(instance.Spec.MACPolicy))
+	if err != nil {
+		return "", err
+	}
+	var messages []string
+	for _, conflict := range conflicts {
+		if conflict.NIC.Policy == v2vv1.MACPolicyPreserve {
+			messages = append(messages, conflict.String())
+		}
+	}
+	return strings.Join(messages, "; "), nil
+}
+
+// validateRestoredNetwork returns why the import is blocked when the network configuration restored by the
+// customization can't reach an interface, or an empty string otherwise. The configuration matches the interfaces by
+// the MAC addresses of the source VM, which the interfaces that may get a new MAC address don't keep.
+func validateRestoredNetwork(instance *v2vv1.VirtualMachineImport, provider provider.Provider) (string, error) {
+	if instance.Spec.Customization == nil || !instance.Spec.Customization.RestoreNetwork {
+		return "", nil
+	}
+	mapper, err := provider.CreateValidationMapper()
+	if err != nil {
+		return "", err
+	}
+	var messages []string
+	for _, nic := range mapper.MapMACAddresses(instance.Spec.MACPolicy) {
+		if nic.Policy != v2vv1.MACPolicyPreserve {
+			messages = append(messages, fmt.Sprintf("NIC %s may get a new MAC address with the %s MAC policy, which the restored network configuration can't match", nic.Name, nic.Policy))
+		}
+	}
+	return strings.Join(messages, "; "), nil
+}
+
+// applyMACPolicy removes the MAC addresses the imported VM doesn't keep from its interfaces, letting KubeVirt assign
+// new ones
+func (r *ReconcileVirtualMachineImport) applyMACPolicy(instance *v2vv1.VirtualMachineImport, mapper provider.Mapper, vmSpec *kubevirtv1.VirtualMachine) error {
+	nics := mapper.MapMACAddresses(instance.Spec.MACPolicy)
+	regenerate := make(map[string]bool)
+	for _, nic := range nics {
+		if nic.Policy == v2vv1.MACPolicyRegenerate {
+			regenerate[nic.Name] = true
+		}
+	}
+	conflicts, err := r.findMACConflicts(nics)
+	if err != nil {
+		return err
+	}
+	for _, conflict := range conflicts {
+		if conflict.NIC.Policy == v2vv1.MACPolicyPreserveIfUnique {
+			regenerate[conflict.NIC.Name] = true
+			r.recorder.Eventf(instance, corev1.EventTypeNormal, EventMACAddressRegenerated, "%s, a new one is assigned", conflict)
+		}
+	}
+
+	if vmSpec.Spec.Template == nil {
+		return nil
+	}
+	interfaces := vmSpec.Spec.Template.Spec.Domain.Devices.Interfaces
+	for i := range interfaces {
+		if regenerate[interfaces[i].Name] {
+			interfaces[i].MacAddress = ""
+		}
+	}
+	return nil
+}
+
+func (r *ReconcileVirtualMachineImport) findMACConflicts(nics []macaddress.NIC) ([]macaddress.Conflict, error) {
+	checker := macaddress.NewChecker(r.client, r.ctrlConfig.KubeMacPoolNamespace(), r.ctrlConfig.KubeMacPoolConfigMapName())
+	return checker.FindConflicts(nics)
+}
@@ -0,0 +1,151 @@
+package virtualmachineimport
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/mappings"
+	"github.com/kubevirt/vm-import-operator/pkg/networks"
+	provider "github.com/kubevirt/vm-import-operator/pkg/providers"
+	"github.com/kubevirt/vm-import-operator/pkg/utils"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// validateUnmappedNetworks records what happens to the NICs connected to networks without a mapping, and returns why
+// the import is blocked when the unmapped network policy can't handle them, or an empty string otherwise. A network to
+// generate is blocked when a network attachment definition with its name exists, but doesn't match it.
+func (r *ReconcileVirtualMachineImport) validateUnmappedNetworks(instance *v2vv1.VirtualMachineImport, provider provider.Provider) (string, error) {
+	mapper, err := provider.CreateValidationMapper()
+	if err != nil {
+		return "", err
+	}
+	unmapped := mapper.MapUnmappedNetworks()
+	if !reflect.DeepEqual(unmapped, instance.Status.UnmappedNetworks) {
+		if err := r.storeUnmappedNetworks(instance, unmapped); err != nil {
+			return "", err
+		}
+	}
+
+	var messages []string
+	podNICs := 0
+	generator := networks.NewGenerator(r.client, r.ctrlConfig.NetworkGenerationCNIType(), r.ctrlConfig.NetworkGenerationBridge())
+	verified := make(map[string]bool)
+	for _, nic := range unmapped {
+		switch nic.Action {
+		case v2vv1.UnmappedNetworkFail:
+			messages = append(messages, fmt.Sprintf("NIC %s is connected to network %s, which has no mapping", nic.NIC, nic.Network))
+		case v2vv1.UnmappedNetworkPod:
+			podNICs++
+		case v2vv1.UnmappedNetworkMultus:
+			if nic.Target == nil {
+				messages = append(messages, fmt.Sprintf("NIC %s is connected to network %s, which has no mapping, while the unmapped network policy has no target", nic.NIC, nic.Network))
+			}
+		case v2vv1.UnmappedNetworkGenerate:
+			if nic.VLAN == nil {
+				messages = append(messages, fmt.Sprintf("NIC %s is connected to network %s, which has no mapping, and whose VLAN can't be determined to generate one", nic.NIC, nic.Network))
+				continue
+			}
+			if verified[nic.Network] {
+				continue
+			}
+			verified[nic.Network] = true
+			err := generator.Verify(nic.Network, *nic.VLAN, utils.TargetNamespace(instance))
+			if networks.IsMismatch(err) {
+				messages = append(messages, fmt.Sprintf("NIC %s is connected to network %s, which has no mapping, and can't be generated: %v", nic.NIC, nic.Network, err))
+			} else if err != nil {
+				return "", err
+			}
+		}
+	}
+	if podNICs > 1 {
+		messages = append(messages, fmt.Sprintf("%d NICs connected to networks without a mapping would be connected to the pod network, which takes only one", podNICs))
+	}
+	return strings.Join(messages, "; "), nil
+}
+
+func (r *ReconcileVirtualMachineImport) storeUnmappedNetworks(instance *v2vv1.VirtualMachineImport, unmapped []v2vv1.UnmappedNetworkStatus) error {
+	var current v2vv1.VirtualMachineImport
+	err := r.apiReader.Get(context.TODO(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, &current)
+	if err != nil {
+		return err
+	}
+	copy := current.DeepCopy()
+	copy.Status.UnmappedNetworks = unmapped
+	err = r.client.Status().Update(context.TODO(), copy)
+	if err != nil {
+		return err
+	}
+	instance.Status.UnmappedNetworks = unmapped
+	return nil
+}
+
+// generateNetworks creates the network attachment definitions of the networks without a mapping that the unmapped
+// network policy generates, and records the mappings to them. It returns whether any mapping was generated.
+func (r *ReconcileVirtualMachineImport) generateNetworks(instance *v2vv1.VirtualMachineImport) (bool, error) {
+	if len(instance.Status.GeneratedNetworkMappings) > 0 {
+		return false, nil
+	}
+	generator := networks.NewGenerator(r.client, r.ctrlConfig.NetworkGenerationCNIType(), r.ctrlConfig.NetworkGenerationBridge())
+	var generated []v2vv1.NetworkResourceMappingItem
+	done := make(map[string]bool)
+	for _, nic := range instance.Status.UnmappedNetworks {
+		if nic.Action != v2vv1.UnmappedNetworkGenerate || nic.VLAN == nil || done[nic.Network] {
+			continue
+		}
+		mapping, err := generator.Generate(nic.Network, *nic.VLAN, utils.TargetNamespace(instance))
+		if err != nil {
+			return false, err
+		}
+		generated = append(generated, *mapping)
+		done[nic.Network] = true
+	}
+	if len(generated) == 0 {
+		return false, nil
+	}
+	return true, r.storeGeneratedNetworkMappings(instance, generated)
+}
+
+func (r *ReconcileVirtualMachineImport) storeGeneratedNetworkMappings(instance *v2vv1.VirtualMachineImport, generated []v2vv1.NetworkResourceMappingItem) error {
+	var current v2vv1.VirtualMachineImport
+	err := r.apiReader.Get(context.TODO(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, &current)
+	if err != nil {
+		return err
+	}
+	copy := current.DeepCopy()
+	copy.Status.GeneratedNetworkMappings = generated
+	err = r.client.Status().Update(context.TODO(), copy)
+	if err != nil {
+		return err
+	}
+	instance.Status.GeneratedNetworkMappings = generated
+	return nil
+}
+
+// withGeneratedNetworkMappings adds the generated network mappings to the external resource mapping, so that the
+// mappings of the import spec and of the ResourceMapping take precedence over them
+func withGeneratedNetworkMappings(resourceMapping *v2vv1.ResourceMappingSpec, instance *v2vv1.VirtualMachineImport) *v2vv1.ResourceMappingSpec {
+	generated := instance.Status.GeneratedNetworkMappings
+	if len(generated) == 0 {
+		return resourceMapping
+	}
+	merged := &v2vv1.ResourceMappingSpec{}
+	if resourceMapping != nil {
+		merged = resourceMapping.DeepCopy()
+	}
+	switch {
+	case instance.Spec.Source.Ovirt != nil:
+		if merged.OvirtMappings == nil {
+			merged.OvirtMappings = &v2vv1.OvirtMappings{}
+		}
+		merged.OvirtMappings.NetworkMappings = mappings.MergeNetworkMappings(merged.OvirtMappings.NetworkMappings, &generated)
+	case instance.Spec.Source.Vmware != nil:
+		if merged.VmwareMappings == nil {
+			merged.VmwareMappings = &v2vv1.VmwareMappings{}
+		}
+		merged.VmwareMappings.NetworkMappings = mappings.MergeNetworkMappings(merged.VmwareMappings.NetworkMappings, &generated)
+	}
+	return merged
+}
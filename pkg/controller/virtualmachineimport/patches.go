@@ -0,0 +1,52 @@
+package virtualmachineimport
+
+import (
+	"fmt"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/conditions"
+	"github.com/kubevirt/vm-import-operator/pkg/patches"
+	provider "github.com/kubevirt/vm-import-operator/pkg/providers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+)
+
+// validatePatches checks the patches of the import and that the config maps and keys they reference exist. An invalid
+// patch is returned as a patches.InvalidError, and a failure to read a config map as is.
+func (r *ReconcileVirtualMachineImport) validatePatches(instance *v2vv1.VirtualMachineImport) error {
+	if err := patches.Validate("vmPatches", instance.Spec.VMPatches); err != nil {
+		return &patches.InvalidError{Err: err}
+	}
+	if err := patches.Validate("dataVolumePatches", instance.Spec.DataVolumePatches); err != nil {
+		return &patches.InvalidError{Err: err}
+	}
+	if _, err := patches.Load(r.client, instance.Namespace, instance.Spec.VMPatches); err != nil {
+		return err
+	}
+	_, err := patches.Load(r.client, instance.Namespace, instance.Spec.DataVolumePatches)
+	return err
+}
+
+// patchVM applies the VM patches of the import to the mapped VM. Only a patches.InvalidError is permanent.
+func (r *ReconcileVirtualMachineImport) patchVM(instance *v2vv1.VirtualMachineImport, vmSpec *kubevirtv1.VirtualMachine) error {
+	vmPatches, err := patches.Load(r.client, instance.Namespace, instance.Spec.VMPatches)
+	if err != nil {
+		return err
+	}
+	return patches.ApplyToVM(vmSpec, vmPatches)
+}
+
+// endVMPatchingFailed ends the import in failure like a failure to create the VM
+func (r *ReconcileVirtualMachineImport) endVMPatchingFailed(provider provider.Provider, instance *v2vv1.VirtualMachineImport, vmSpec *kubevirtv1.VirtualMachine, patchErr error) error {
+	message := fmt.Sprintf("Error while patching virtual machine %s/%s: %s", vmSpec.Namespace, vmSpec.Name, patchErr)
+	r.recorder.Event(instance, corev1.EventTypeWarning, EventVMCreationFailed, message)
+
+	instanceNamespacedName := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
+	succeededCond := conditions.NewSucceededCondition(string(v2vv1.VMCreationFailed), message, corev1.ConditionFalse)
+	processingCond := conditions.NewProcessingCondition(string(v2vv1.ProcessingFailed), message, corev1.ConditionFalse)
+	if err := r.upsertStatusConditions(instanceNamespacedName, succeededCond, processingCond); err != nil {
+		return err
+	}
+	return r.afterFailure(provider, instance)
+}
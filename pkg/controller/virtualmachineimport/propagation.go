@@ -0,0 +1,125 @@
+package virtualmachineimport
+
+import (
+	"context"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/propagation"
+	provider "github.com/kubevirt/vm-import-operator/pkg/providers"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+)
+
+// sourceMetadata returns the metadata of the source VM when a propagation rule of the import takes its value from it.
+// The metadata is read from the provider once, and recorded in the status of the import for the next reconciles.
+func (r *ReconcileVirtualMachineImport) sourceMetadata(provider provider.Provider, instance *v2vv1.VirtualMachineImport) (*propagation.SourceMetadata, error) {
+	if !propagation.NeedsSourceMetadata(instance.Spec.Propagation) {
+		return nil, nil
+	}
+	if instance.Status.SourceMetadata == nil {
+		source, err := provider.GetSourceMetadata()
+		if err != nil {
+			return nil, err
+		}
+		status := &v2vv1.SourceMetadataStatus{
+			Tags:             source.Tags,
+			CustomAttributes: source.CustomAttributes,
+			Folder:           source.Folder,
+		}
+		if err = r.storeSourceMetadata(instance, status); err != nil {
+			return nil, err
+		}
+	}
+	status := instance.Status.SourceMetadata
+	return &propagation.SourceMetadata{
+		Tags:             status.Tags,
+		CustomAttributes: status.CustomAttributes,
+		Folder:           status.Folder,
+	}, nil
+}
+
+func (r *ReconcileVirtualMachineImport) storeSourceMetadata(instance *v2vv1.VirtualMachineImport, sourceMetadata *v2vv1.SourceMetadataStatus) error {
+	var current v2vv1.VirtualMachineImport
+	err := r.apiReader.Get(context.TODO(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, &current)
+	if err != nil {
+		return err
+	}
+	copy := current.DeepCopy()
+	copy.Status.SourceMetadata = sourceMetadata
+	err = r.client.Status().Update(context.TODO(), copy)
+	if err != nil {
+		return err
+	}
+	instance.Status.SourceMetadata = sourceMetadata
+	return nil
+}
+
+// propagateToVM sets the propagated labels and annotations on the VM and on the template of its VMIs
+func (r *ReconcileVirtualMachineImport) propagateToVM(provider provider.Provider, instance *v2vv1.VirtualMachineImport, vmSpec *kubevirtv1.VirtualMachine) error {
+	if instance.Spec.Propagation == nil {
+		return nil
+	}
+	source, err := r.sourceMetadata(provider, instance)
+	if err != nil {
+		return err
+	}
+	propagation.Apply(&vmSpec.ObjectMeta, propagation.Resolve(instance, source, v2vv1.PropagationTargetVirtualMachine))
+	if vmSpec.Spec.Template != nil {
+		propagation.Apply(&vmSpec.Spec.Template.ObjectMeta, propagation.Resolve(instance, source, v2vv1.PropagationTargetVirtualMachineInstance))
+	}
+	return nil
+}
+
+// propagateToDataVolume sets the propagated labels and annotations on the data volume
+func (r *ReconcileVirtualMachineImport) propagateToDataVolume(provider provider.Provider, instance *v2vv1.VirtualMachineImport, dv *cdiv1.DataVolume) error {
+	if instance.Spec.Propagation == nil {
+		return nil
+	}
+	source, err := r.sourceMetadata(provider, instance)
+	if err != nil {
+		return err
+	}
+	propagation.Apply(&dv.ObjectMeta, propagation.Resolve(instance, source, v2vv1.PropagationTargetDataVolume))
+	return nil
+}
+
+// propagateToPVC sets the propagated labels and annotations on the PVC of the imported data volume
+func (r *ReconcileVirtualMachineImport) propagateToPVC(provider provider.Provider, instance *v2vv1.VirtualMachineImport, dv *cdiv1.DataVolume) error {
+	if instance.Spec.Propagation == nil {
+		return nil
+	}
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: dv.Namespace, Name: dv.Name}, pvc)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	source, err := r.sourceMetadata(provider, instance)
+	if err != nil {
+		return err
+	}
+	if !propagation.Apply(&pvc.ObjectMeta, propagation.Resolve(instance, source, v2vv1.PropagationTargetPersistentVolumeClaim)) {
+		return nil
+	}
+	return r.client.Update(context.TODO(), pvc)
+}
+
+// propagateToGuestConversionPod sets the propagated labels and annotations on the guest conversion pod
+func (r *ReconcileVirtualMachineImport) propagateToGuestConversionPod(provider provider.Provider, instance *v2vv1.VirtualMachineImport, pod *corev1.Pod) error {
+	if instance.Spec.Propagation == nil {
+		return nil
+	}
+	source, err := r.sourceMetadata(provider, instance)
+	if err != nil {
+		return err
+	}
+	if !propagation.Apply(&pod.ObjectMeta, propagation.Resolve(instance, source, v2vv1.PropagationTargetGuestConversionPod)) {
+		return nil
+	}
+	return r.client.Update(context.TODO(), pod)
+}
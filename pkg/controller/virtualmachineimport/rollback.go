@@ -0,0 +1,125 @@
+package virtualmachineimport
+
+import (
+	"context"
+	"fmt"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/conditions"
+	provider "github.com/kubevirt/vm-import-operator/pkg/providers"
+	"github.com/kubevirt/vm-import-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+)
+
+// shouldRollback returns whether the rollback of a successful import was requested and hasn't completed yet
+func shouldRollback(instance *v2vv1.VirtualMachineImport) bool {
+	if !instance.Spec.Rollback || shouldReconcile(instance) {
+		return false
+	}
+	succeeded := conditions.FindConditionOfType(instance.Status.Conditions, v2vv1.Succeeded)
+	if succeeded == nil || succeeded.Status != corev1.ConditionTrue {
+		return false
+	}
+	rolledBack := conditions.FindConditionOfType(instance.Status.Conditions, v2vv1.RolledBack)
+	return rolledBack == nil || rolledBack.Status != corev1.ConditionTrue
+}
+
+// rollback deletes the imported VM, which stops it, and its data volumes, then starts the source VM again once the
+// imported VM is gone, so that they never run together with the same MAC addresses. The mark of the Mark source VM
+// policy is removed from the source VM. It returns whether the rollback must be requeued while the imported VM stops.
+func (r *ReconcileVirtualMachineImport) rollback(instance *v2vv1.VirtualMachineImport, provider provider.Provider) (bool, error) {
+	if instance.Spec.SourceVMPolicy == v2vv1.SourceVMPolicyDelete {
+		message := "The source VM was deleted by the source VM policy"
+		r.recorder.Event(instance, corev1.EventTypeWarning, EventRollbackFailed, message)
+		return false, r.upsertRolledBackCondition(instance, v2vv1.RollbackFailed, message, corev1.ConditionFalse)
+	}
+
+	err := r.deleteImportedVM(instance)
+	if err == nil {
+		var gone bool
+		gone, err = r.isImportedVMGone(instance)
+		if err == nil && !gone {
+			log.Info("Waiting for the imported VM to be deleted", "Request.Namespace", instance.Namespace, "Request.Name", instance.Name)
+			return true, nil
+		}
+	}
+	if err == nil {
+		err = provider.LoadVM(instance.Spec.Source)
+	}
+	if err == nil && instance.Spec.SourceVMPolicy == v2vv1.SourceVMPolicyMark {
+		vmName := types.NamespacedName{Name: instance.Status.TargetVMName, Namespace: utils.TargetNamespace(instance)}
+		err = provider.UnmarkVMMigrated(r.migrationTarget(vmName))
+	}
+	if err == nil {
+		err = provider.StartVM()
+	}
+	if err != nil {
+		message := fmt.Sprintf("Rollback failed: %v", err)
+		r.recorder.Event(instance, corev1.EventTypeWarning, EventRollbackFailed, message)
+		if cerr := r.upsertRolledBackCondition(instance, v2vv1.RollbackFailed, message, corev1.ConditionFalse); cerr != nil {
+			return false, cerr
+		}
+		return false, err
+	}
+
+	message := "Imported VM deleted and source VM started"
+	if instance.Spec.SourceVMPolicy == v2vv1.SourceVMPolicyMark {
+		message = "Imported VM deleted and source VM unmarked and started"
+	}
+	r.recorder.Eventf(instance, corev1.EventTypeNormal, EventImportRolledBack, "Import of Virtual Machine %s/%s rolled back", instance.Namespace, instance.Status.TargetVMName)
+	return false, r.upsertRolledBackCondition(instance, v2vv1.RollbackCompleted, message, corev1.ConditionTrue)
+}
+
+// isImportedVMGone returns whether the imported VM and its running instance were deleted
+func (r *ReconcileVirtualMachineImport) isImportedVMGone(instance *v2vv1.VirtualMachineImport) (bool, error) {
+	if instance.Status.TargetVMName == "" {
+		return true, nil
+	}
+	vmName := types.NamespacedName{Name: instance.Status.TargetVMName, Namespace: utils.TargetNamespace(instance)}
+	for _, obj := range []runtime.Object{&kubevirtv1.VirtualMachineInstance{}, &kubevirtv1.VirtualMachine{}} {
+		err := r.client.Get(context.TODO(), vmName, obj)
+		if err == nil {
+			return false, nil
+		}
+		if !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// deleteImportedVM deletes the target VM and the data volumes of the import, ignoring the ones already gone
+func (r *ReconcileVirtualMachineImport) deleteImportedVM(instance *v2vv1.VirtualMachineImport) error {
+	if instance.Status.TargetVMName != "" {
+		vm := &kubevirtv1.VirtualMachine{}
+		vm.Name = instance.Status.TargetVMName
+		vm.Namespace = utils.TargetNamespace(instance)
+		if err := r.client.Delete(context.TODO(), vm); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	for _, dataVolume := range instance.Status.DataVolumes {
+		dv := &cdiv1.DataVolume{}
+		dv.Name = dataVolume.Name
+		dv.Namespace = utils.TargetNamespace(instance)
+		if err := r.client.Delete(context.TODO(), dv); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ReconcileVirtualMachineImport) upsertRolledBackCondition(instance *v2vv1.VirtualMachineImport, reason v2vv1.RolledBackConditionReason, message string, status corev1.ConditionStatus) error {
+	condition := conditions.NewCondition(v2vv1.RolledBack, string(reason), message, status)
+	err := r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, condition)
+	if err != nil {
+		return err
+	}
+	conditions.UpsertCondition(instance, condition)
+	return nil
+}
@@ -0,0 +1,82 @@
+package virtualmachineimport
+
+import (
+	"fmt"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/conditions"
+	provider "github.com/kubevirt/vm-import-operator/pkg/providers"
+	"github.com/kubevirt/vm-import-operator/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// validateSourceVMPolicy returns why the source VM policy can't be applied safely, or an empty string otherwise. The
+// source VM and its disks are only deleted once the imported VM is known to boot.
+func validateSourceVMPolicy(instance *v2vv1.VirtualMachineImport) string {
+	if instance.Spec.SourceVMPolicy == v2vv1.SourceVMPolicyDelete && instance.Spec.BootVerification == nil {
+		return "The Delete source VM policy requires bootVerification, so that the source VM is only deleted once the imported VM booted"
+	}
+	return ""
+}
+
+// hasSourceVMPolicyAction returns whether the source VM policy does anything to the source VM, which is left stopped
+// otherwise
+func hasSourceVMPolicyAction(instance *v2vv1.VirtualMachineImport) bool {
+	switch instance.Spec.SourceVMPolicy {
+	case v2vv1.SourceVMPolicyRestore, v2vv1.SourceVMPolicyDelete, v2vv1.SourceVMPolicyMark:
+		return true
+	}
+	return false
+}
+
+// shouldRetrySourceVMPolicy returns whether the source VM policy of a successful import failed and hasn't been applied
+// since. It isn't retried once the import was rolled back.
+func shouldRetrySourceVMPolicy(instance *v2vv1.VirtualMachineImport) bool {
+	if shouldReconcile(instance) || instance.DeletionTimestamp != nil {
+		return false
+	}
+	if conditions.FindConditionOfType(instance.Status.Conditions, v2vv1.RolledBack) != nil {
+		return false
+	}
+	applied := conditions.FindConditionOfType(instance.Status.Conditions, v2vv1.SourceVMPolicyApplied)
+	return applied != nil && applied.Status == corev1.ConditionFalse
+}
+
+// retrySourceVMPolicy applies the source VM policy of a successful import again
+func (r *ReconcileVirtualMachineImport) retrySourceVMPolicy(instance *v2vv1.VirtualMachineImport, provider provider.Provider) error {
+	if err := provider.LoadVM(instance.Spec.Source); err != nil {
+		return err
+	}
+	vmName := types.NamespacedName{Name: instance.Status.TargetVMName, Namespace: utils.TargetNamespace(instance)}
+	return r.enforceSourceVMPolicy(vmName, provider, instance)
+}
+
+// enforceSourceVMPolicy applies the source VM policy and records the outcome in the SourceVMPolicyApplied condition, so
+// that a failure is retried after the import succeeded
+func (r *ReconcileVirtualMachineImport) enforceSourceVMPolicy(vmName types.NamespacedName, p provider.Provider, instance *v2vv1.VirtualMachineImport) error {
+	if !hasSourceVMPolicyAction(instance) {
+		return nil
+	}
+	err := r.applySourceVMPolicy(vmName, p, instance)
+	if err != nil {
+		message := fmt.Sprintf("Applying the %s source VM policy failed, retrying: %v", instance.Spec.SourceVMPolicy, err)
+		r.recorder.Event(instance, corev1.EventTypeWarning, EventSourceVMPolicyFailed, message)
+		if cerr := r.upsertSourceVMPolicyCondition(instance, v2vv1.SourceVMPolicyPending, message, corev1.ConditionFalse); cerr != nil {
+			return cerr
+		}
+		return err
+	}
+	message := fmt.Sprintf("The %s source VM policy was applied", instance.Spec.SourceVMPolicy)
+	return r.upsertSourceVMPolicyCondition(instance, v2vv1.SourceVMPolicyCompleted, message, corev1.ConditionTrue)
+}
+
+func (r *ReconcileVirtualMachineImport) upsertSourceVMPolicyCondition(instance *v2vv1.VirtualMachineImport, reason v2vv1.SourceVMPolicyAppliedConditionReason, message string, status corev1.ConditionStatus) error {
+	condition := conditions.NewCondition(v2vv1.SourceVMPolicyApplied, string(reason), message, status)
+	err := r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, condition)
+	if err != nil {
+		return err
+	}
+	conditions.UpsertCondition(instance, condition)
+	return nil
+}
@@ -0,0 +1,130 @@
+package virtualmachineimport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/requester"
+	"github.com/kubevirt/vm-import-operator/pkg/utils"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// setImportOwnership sets the import as the controller of the object. An object created in another namespace can't be
+// owned by the import, so it's labeled with the import instead.
+func (r *ReconcileVirtualMachineImport) setImportOwnership(instance *v2vv1.VirtualMachineImport, obj metav1.Object) error {
+	if utils.IsCrossNamespace(instance) {
+		utils.SetImportTracking(obj, instance)
+		return nil
+	}
+	return controllerutil.SetControllerReference(instance, obj, r.scheme)
+}
+
+// isImportOwned returns whether the object was created by the import: controlled by it, or tracked by it in another
+// namespace. An object of the same name created by anyone else is never taken over by the import.
+func isImportOwned(instance *v2vv1.VirtualMachineImport, obj metav1.Object) bool {
+	if utils.IsCrossNamespace(instance) {
+		tracked := utils.TrackedImport(obj)
+		return tracked != nil && tracked.Namespace == instance.Namespace && tracked.Name == instance.Name
+	}
+	return metav1.IsControlledBy(obj, instance)
+}
+
+// validateTargetNamespace checks that the target namespace of the import is a valid namespace name
+func validateTargetNamespace(instance *v2vv1.VirtualMachineImport) error {
+	if instance.Spec.TargetNamespace == nil || *instance.Spec.TargetNamespace == "" {
+		return nil
+	}
+	if errs := k8svalidation.IsDNS1123Label(*instance.Spec.TargetNamespace); len(errs) > 0 {
+		return fmt.Errorf("`targetNamespace` is invalid: %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// canCreateVMInTargetNamespace checks that the user who created the import may create VMs in the target namespace,
+// so that the permissions of the controller don't let anyone import into a namespace they have no access to. The
+// requester is recorded by the admission webhook for the imports labeled with requester.Label on creation; an import
+// without it is never allowed into another namespace. The message tells why the import isn't allowed.
+func (r *ReconcileVirtualMachineImport) canCreateVMInTargetNamespace(instance *v2vv1.VirtualMachineImport) (bool, string, error) {
+	if !utils.IsCrossNamespace(instance) {
+		return true, "", nil
+	}
+	targetNamespace := utils.TargetNamespace(instance)
+	userInfo, err := requester.Get(instance)
+	if err != nil {
+		return false, err.Error(), nil
+	}
+	if userInfo == nil {
+		return false, fmt.Sprintf("The requester of the import isn't recorded, so it may not create virtual machines in namespace %s. Label the import with %s when creating it to record its requester", targetNamespace, requester.Label), nil
+	}
+	extra := make(map[string]authorizationv1.ExtraValue, len(userInfo.Extra))
+	for key, value := range userInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   userInfo.Username,
+			UID:    userInfo.UID,
+			Groups: userInfo.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: targetNamespace,
+				Verb:      "create",
+				Group:     kubevirtv1.GroupName,
+				Resource:  "virtualmachines",
+			},
+		},
+	}
+	if err := r.client.Create(context.TODO(), review); err != nil {
+		return false, "", err
+	}
+	if !review.Status.Allowed {
+		return false, fmt.Sprintf("User %s may not create virtual machines in namespace %s", userInfo.Username, targetNamespace), nil
+	}
+	return true, "", nil
+}
+
+// deleteTrackedObjects deletes the objects the import created in another namespace, which aren't garbage collected
+// with the import for lack of owner references
+func (r *ReconcileVirtualMachineImport) deleteTrackedObjects(instance *v2vv1.VirtualMachineImport) error {
+	if !utils.IsCrossNamespace(instance) {
+		return nil
+	}
+	trackedTypes := []runtime.Object{
+		&kubevirtv1.VirtualMachine{},
+		&cdiv1.DataVolume{},
+		&corev1.Pod{},
+		&corev1.ConfigMap{},
+		&corev1.Secret{},
+	}
+	for _, obj := range trackedTypes {
+		err := r.client.DeleteAllOf(context.TODO(), obj,
+			client.InNamespace(utils.TargetNamespace(instance)),
+			client.MatchingLabels(utils.ImportTrackingLabels(instance)),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trackedImportRequests maps an object created by an import in another namespace to the import
+func trackedImportRequests(a handler.MapObject) []reconcile.Request {
+	name := utils.TrackedImport(a.Meta)
+	if name == nil {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: *name}}
+}
@@ -25,25 +25,34 @@ import (
 	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	pclient "github.com/kubevirt/vm-import-operator/pkg/client"
 	"github.com/kubevirt/vm-import-operator/pkg/conditions"
+	"github.com/kubevirt/vm-import-operator/pkg/customization"
+	"github.com/kubevirt/vm-import-operator/pkg/macaddress"
 	"github.com/kubevirt/vm-import-operator/pkg/mappings"
 	"github.com/kubevirt/vm-import-operator/pkg/metrics"
+	"github.com/kubevirt/vm-import-operator/pkg/naming"
 	"github.com/kubevirt/vm-import-operator/pkg/ownerreferences"
+	"github.com/kubevirt/vm-import-operator/pkg/patches"
+	"github.com/kubevirt/vm-import-operator/pkg/pods"
+	"github.com/kubevirt/vm-import-operator/pkg/propagation"
 	provider "github.com/kubevirt/vm-import-operator/pkg/providers"
 	ovirtprovider "github.com/kubevirt/vm-import-operator/pkg/providers/ovirt"
+	"github.com/kubevirt/vm-import-operator/pkg/templates"
+	"github.com/kubevirt/vm-import-operator/pkg/tracing"
 	"github.com/kubevirt/vm-import-operator/pkg/utils"
 	templatev1 "github.com/openshift/client-go/template/clientset/versioned/typed/template/v1"
+	"go.opentelemetry.io/otel/label"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
 	kubevirtv1 "kubevirt.io/client-go/api/v1"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -90,12 +99,28 @@ const (
 	EventDVCreationFailed = "DVCreationFailed"
 	// EventPVCImportFailed is emitted when import of pvc fails
 	EventPVCImportFailed = "EventPVCImportFailed"
+	// EventDiskImportRetry is emitted when a failed disk import is going to be retried
+	EventDiskImportRetry = "DiskImportRetry"
 	// EventGuestConversionFailed is emitted when the virt-v2v conversion job fails.
 	EventGuestConversionFailed = "GuestConversionFailed"
+	// EventGuestInspectionFailed is emitted when the inspection of the guest fails. The import goes on regardless.
+	EventGuestInspectionFailed = "GuestInspectionFailed"
+	// EventMACAddressRegenerated is emitted when a NIC of the imported VM gets a new MAC address because the one of the
+	// source VM is already used in the cluster.
+	EventMACAddressRegenerated = "MACAddressRegenerated"
 	// EventWarmImportFailed is emmitted when a warm import attempt fails.
 	EventWarmImportFailed = "WarmImportFailed"
 	// EventVMNotFound is emitted when the target VM cannot be found, perhaps due to being deleted during an import.
 	EventVMNotFound = "VMNotFound"
+	// EventImportRolledBack is emitted when the imported VM is deleted and the source VM started again on request.
+	EventImportRolledBack = "ImportRolledBack"
+	// EventRollbackFailed is emitted when the rollback of an import fails.
+	EventRollbackFailed = "RollbackFailed"
+	// EventBootVerificationFailed is emitted when the started VM fails the boot verification.
+	EventBootVerificationFailed = "BootVerificationFailed"
+	// EventSourceVMPolicyFailed is emitted when the source VM policy can't be applied after a successful import. It is
+	// retried.
+	EventSourceVMPolicyFailed = "SourceVMPolicyFailed"
 
 	SlowReQ = time.Second * 10
 	FastReQ = time.Second * 2
@@ -127,8 +152,15 @@ func newReconciler(mgr manager.Manager, kvConfigProvider kvConfig.KubeVirtConfig
 		log.Error(err, "Unable to get OC client")
 		panic("Controller cannot operate without OC client")
 	}
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		log.Error(err, "Unable to get kubernetes client")
+		panic("Controller cannot operate without kubernetes client")
+	}
 	reader := mgr.GetAPIReader()
 	client := mgr.GetClient()
+	logReader := pods.NewLogReader(clientset)
+	inspectionPodsManager := pods.NewInspectionManager(client)
 	finder := mappings.NewResourceMappingsFinder(client)
 	ownerreferencesmgr := ownerreferences.NewOwnerReferenceManager(client)
 	factory := pclient.NewSourceClientFactory()
@@ -148,6 +180,8 @@ func newReconciler(mgr manager.Manager, kvConfigProvider kvConfig.KubeVirtConfig
 		ctrlConfigProvider:     ctrlConfigProvider,
 		ctrlConfig:             controllerConfig,
 		recorder:               mgr.GetEventRecorderFor("virtualmachineimport-controller"),
+		podLogReader:           &logReader,
+		inspectionPodsManager:  &inspectionPodsManager,
 	}
 }
 
@@ -203,6 +237,17 @@ func add(mgr manager.Manager, r *ReconcileVirtualMachineImport) error {
 		return err
 	}
 
+	// Watch for the VMs, DVs and pods created in other namespaces, which are tracked by labels instead of owner references:
+	for _, obj := range []runtime.Object{&kubevirtv1.VirtualMachine{}, &cdiv1.DataVolume{}, &corev1.Pod{}} {
+		err = c.Watch(
+			&source.Kind{Type: obj},
+			&handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(trackedImportRequests)},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -226,6 +271,8 @@ type ReconcileVirtualMachineImport struct {
 	controller             controller.Controller
 	apiReader              client.Reader
 	filesystemOverhead     cdiv1.FilesystemOverhead
+	podLogReader           podLogReader
+	inspectionPodsManager  provider.PodsManager
 }
 
 // Reconcile reads that state of the cluster for a VirtualMachineImport object and makes changes based on the state read
@@ -233,7 +280,7 @@ type ReconcileVirtualMachineImport struct {
 // Note:
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
-func (r *ReconcileVirtualMachineImport) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+func (r *ReconcileVirtualMachineImport) Reconcile(request reconcile.Request) (_ reconcile.Result, reconcileErr error) {
 	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling VirtualMachineImport")
 
@@ -251,6 +298,9 @@ func (r *ReconcileVirtualMachineImport) Reconcile(request reconcile.Request) (re
 		return reconcile.Result{}, err
 	}
 
+	span := tracing.StartReconcile(instance.UID, label.String("vmimport.namespace", instance.Namespace), label.String("vmimport.name", instance.Name))
+	defer func() { tracing.EndReconcile(instance.UID, span, reconcileErr) }()
+
 	// Add finalizer to handle cancelled import
 	if !r.vmImportInProgress(instance) {
 		err := utils.AddFinalizer(instance, utils.CancelledImportFinalizer, r.client)
@@ -268,13 +318,18 @@ func (r *ReconcileVirtualMachineImport) Reconcile(request reconcile.Request) (re
 
 		// Cancelled import finalizer
 		if utils.HasFinalizer(instance, utils.CancelledImportFinalizer) {
+			// The objects created in another namespace aren't garbage collected with the import
+			if err := r.deleteTrackedObjects(instance); err != nil {
+				return reconcile.Result{}, err
+			}
 			err := utils.RemoveFinalizer(instance, utils.CancelledImportFinalizer, r.client)
 			if err != nil {
 				return reconcile.Result{}, err
 			}
 
-			metrics.ImportMetrics.IncCancelled()
-			metrics.ImportMetrics.SaveDurationCancelled(calculateImportDuration(instance))
+			metrics.ImportMetrics.IncCancelled(providerName(instance))
+			metrics.ImportMetrics.SaveDurationCancelled(providerName(instance), calculateImportDuration(instance))
+			endImportMetrics(instance)
 		}
 
 		// If no more finalizers then return so resource can be deleted
@@ -317,6 +372,20 @@ func (r *ReconcileVirtualMachineImport) Reconcile(request reconcile.Request) (re
 		return reconcile.Result{}, nil
 	}
 
+	// Roll back a successful import on request
+	if shouldRollback(instance) {
+		requeue, err := r.rollback(instance, provider)
+		if requeue {
+			return reconcile.Result{RequeueAfter: 5 * time.Second}, err
+		}
+		return reconcile.Result{}, err
+	}
+
+	// Retry the source VM policy of a successful import until it's applied
+	if shouldRetrySourceVMPolicy(instance) {
+		return reconcile.Result{}, r.retrySourceVMPolicy(instance, provider)
+	}
+
 	// Exit if we should not run reconcile:
 	if !shouldReconcile(instance) {
 		reqLogger.Info("Not running reconcile")
@@ -330,6 +399,7 @@ func (r *ReconcileVirtualMachineImport) Reconcile(request reconcile.Request) (re
 	}
 
 	// Validate if it's needed at this stage of processing
+	enterImportPhase(instance, metrics.PhaseValidation)
 	valid, err := r.validate(instance, provider)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -338,9 +408,21 @@ func (r *ReconcileVirtualMachineImport) Reconcile(request reconcile.Request) (re
 		return reconcile.Result{RequeueAfter: requeueAfterValidationFailureTime}, nil
 	}
 
+	// Generate the networks without a mapping the unmapped network policy asks for
+	generated, err := r.generateNetworks(instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if generated {
+		if err = r.prepareResourceMapping(instance, provider); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	// don't stop the VM during a warm import unless it's time to finalize
 	if !shouldWarmImport(provider, instance) || shouldFinalizeWarmImport(instance) {
 		// Stop the VM
+		enterImportPhase(instance, metrics.PhaseStopVM)
 		if err = provider.StopVM(instance, r.client); err != nil {
 			return reconcile.Result{}, err
 		}
@@ -352,7 +434,8 @@ func (r *ReconcileVirtualMachineImport) Reconcile(request reconcile.Request) (re
 		return reconcile.Result{}, err
 	}
 
-	vmName := types.NamespacedName{Name: instance.Status.TargetVMName, Namespace: request.Namespace}
+	enterImportPhase(instance, metrics.PhaseCopy)
+	vmName := types.NamespacedName{Name: instance.Status.TargetVMName, Namespace: utils.TargetNamespace(instance)}
 	if instance.Status.TargetVMName == "" {
 		newName, err := r.createVM(provider, instance, mapper)
 		if err != nil {
@@ -394,11 +477,29 @@ func (r *ReconcileVirtualMachineImport) Reconcile(request reconcile.Request) (re
 
 		if !done {
 			reqLogger.Info("Waiting for disks to be imported")
+			return reconcile.Result{RequeueAfter: nextDiskImportRetry(instance)}, nil
+		}
+	}
+
+	if shouldInspectGuest(instance) {
+		enterImportPhase(instance, metrics.PhaseInspection)
+		done, err := r.inspectGuest(provider, instance, mapper, vmName)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+
+		if !done {
+			reqLogger.Info("Waiting for guest to be inspected")
 			return reconcile.Result{}, nil
 		}
 	}
 
-	if shouldConvertGuest(provider, instance) {
+	convertGuest, err := shouldConvertGuest(provider, instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if convertGuest {
+		enterImportPhase(instance, metrics.PhaseConversion)
 		done, err := r.convertGuest(provider, instance, mapper, vmName)
 		if err != nil {
 			return reconcile.Result{}, err
@@ -417,6 +518,7 @@ func (r *ReconcileVirtualMachineImport) Reconcile(request reconcile.Request) (re
 	}
 
 	if shouldStartVM(instance) {
+		enterImportPhase(instance, metrics.PhaseStart)
 		var requeue bool
 		if requeue, err = r.startVM(provider, instance, vmName); err != nil {
 			return reconcile.Result{}, err
@@ -515,10 +617,15 @@ func (r *ReconcileVirtualMachineImport) convertGuest(provider provider.Provider,
 	}
 	if pod == nil {
 		log.Info("Creating conversion pod")
+		_, span := tracing.StartSpan(instance.UID, "LaunchGuestConversionPod")
 		pod, err = provider.LaunchGuestConversionPod(vmSpec, dataVolumes)
+		tracing.EndSpan(span, err)
 		if err != nil {
 			return false, err
 		}
+		if err = r.propagateToGuestConversionPod(provider, instance, pod); err != nil {
+			return false, err
+		}
 		processingCond := conditions.NewProcessingCondition(string(v2vv1.ConvertingGuest), fmt.Sprintf("Running virt-v2v pod %s", pod.Name), corev1.ConditionTrue)
 		err = r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, processingCond)
 		if err != nil {
@@ -532,9 +639,17 @@ func (r *ReconcileVirtualMachineImport) convertGuest(provider provider.Provider,
 	}
 
 	if pod.Status.Phase == corev1.PodSucceeded {
+		if err = r.storeGuestConversionLog(instance, pod); err != nil {
+			return false, err
+		}
+		recordGuestConversionSpan(instance, pod, nil)
 		return true, nil
 	} else if pod.Status.Phase == corev1.PodFailed {
 		log.Info("Conversion pod failed.", "Pod.Name", pod.Name)
+		if err = r.storeGuestConversionLog(instance, pod); err != nil {
+			return false, err
+		}
+		recordGuestConversionSpan(instance, pod, fmt.Errorf("virt-v2v pod %s failed", pod.Name))
 		err := r.endGuestConversionFailed(provider, instance, fmt.Sprintf("virt-v2v pod %s failed", pod.Name))
 		if err != nil {
 			return false, err
@@ -551,25 +666,41 @@ func (r *ReconcileVirtualMachineImport) importDisks(provider provider.Provider,
 		return false, err
 	}
 
+	// the patches are read once for all the data volumes, and the ones that can't be read for now are retried
+	dvPatches, patchErr := patches.Load(r.client, instance.Namespace, instance.Spec.DataVolumePatches)
+	if patchErr != nil && !patches.IsInvalid(patchErr) {
+		return false, patchErr
+	}
+
 	dvsDone := make(map[string]bool)
 	dvsImportProgress := make(map[string]float64)
+	var dvsInProgress []*cdiv1.DataVolume
 	for dvID, dv := range dvs {
 		if err = r.addWatchForImportPod(instance, dvID); err != nil {
 			return false, err
 		}
 
 		foundDv := &cdiv1.DataVolume{}
-		err = r.client.Get(context.TODO(), types.NamespacedName{Namespace: instance.Namespace, Name: dvID}, foundDv)
+		err = r.client.Get(context.TODO(), types.NamespacedName{Namespace: utils.TargetNamespace(instance), Name: dvID}, foundDv)
 		if err != nil && k8serrors.IsNotFound(err) {
+			// Wait for the backoff of a failed import attempt to pass before creating the data volume again:
+			if isDiskImportRetryPending(instance, dvID) {
+				log.Info("Waiting to retry data volume import", "DataVolume.Name", dvID, "VM.Name", vmName)
+				continue
+			}
 			// We have to validate the disk status, so we are sure, the disk wasn't manipulated,
 			// before we execute the import:
-			valid, err := provider.ValidateDiskStatus(dv.Name)
+			valid, err := provider.ValidateDiskStatus(dv.Annotations[utils.SourceDiskIDAnnotation])
 			if err != nil {
 				return false, err
 			}
 			if valid {
 				log.Info("Creating data volume", "DataVolume.Name", dv.Name, "VM.Name", vmName)
-				if _, err = r.createDataVolume(provider, mapper, instance, &dv, vmName); err != nil {
+				err = patchErr
+				if err == nil {
+					_, err = r.createDataVolume(provider, mapper, instance, &dv, vmName, dvPatches)
+				}
+				if err != nil {
 					if err = r.endDiskImportFailed(provider, instance, foundDv, err.Error()); err != nil {
 						return false, err
 					}
@@ -583,14 +714,30 @@ func (r *ReconcileVirtualMachineImport) importDisks(provider provider.Provider,
 				}
 			}
 		} else if err == nil {
+			// A data volume removed due to a failed import attempt is recreated once it's gone:
+			if foundDv.DeletionTimestamp != nil {
+				continue
+			}
+			// A data volume of the same name created by anyone else, e.g. another import, is left alone:
+			if !isImportOwned(instance, foundDv) {
+				message := fmt.Sprintf("data volume %s/%s already exists and wasn't created by the import", foundDv.Namespace, foundDv.Name)
+				if err = r.endDiskImportFailed(provider, instance, foundDv, message); err != nil {
+					return false, err
+				}
+				return false, nil
+			}
 			instanceNamespacedName := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
 			// Set dataVolume as done, if it's in Succeeded state:
 			if foundDv.Status.Phase == cdiv1.Succeeded {
 				log.Info("Data volume import succeeded", "DataVolume.Name", foundDv.Name, "VM.Name", vmName)
+				if err = r.propagateToPVC(provider, instance, foundDv); err != nil {
+					return false, err
+				}
 				dvsDone[dvID] = true
 			} else if foundDv.Status.Phase == cdiv1.Failed {
 				log.Info("Data volume import failed", "DataVolume.Name", foundDv.Name, "VM.Name", vmName)
-				if err = r.endDiskImportFailed(provider, instance, foundDv, "dv is in Failed Phase"); err != nil {
+				failure := classifyDiskImportFailure(foundDv, "", ctrlConfig.DataVolumeFailed)
+				if err = r.retryOrFailDiskImport(provider, instance, foundDv, failure, "dv is in Failed Phase"); err != nil {
 					return false, err
 				}
 			} else if foundDv.Status.Phase == cdiv1.Pending {
@@ -611,9 +758,10 @@ func (r *ReconcileVirtualMachineImport) importDisks(provider provider.Provider,
 				// During ImportInProgress phase importer pod can be in crashloopbackoff, so we need
 				// to check the state of the pod and fail the import:
 				foundPod := &corev1.Pod{}
-				err = r.client.Get(context.TODO(), types.NamespacedName{Namespace: instance.Namespace, Name: importerPodNameFromDv(dvID)}, foundPod)
+				err = r.client.Get(context.TODO(), types.NamespacedName{Namespace: utils.TargetNamespace(instance), Name: importerPodNameFromDv(dvID)}, foundPod)
 				if err == nil {
 					var terminationMessage string
+					crashLooping := false
 					// Emit an event about why pod failed:
 					if foundPod.Status.ContainerStatuses != nil &&
 						foundPod.Status.ContainerStatuses[0].LastTerminationState.Terminated != nil &&
@@ -630,26 +778,35 @@ func (r *ReconcileVirtualMachineImport) importDisks(provider provider.Provider,
 							if terminationMessage != "" {
 								message = fmt.Sprintf("%s (%s)", terminationMessage, message)
 							}
-							if err = r.endDiskImportFailed(provider, instance, foundDv, message); err != nil {
+							failure := classifyDiskImportFailure(foundDv, terminationMessage, ctrlConfig.ImporterPodCrashLoop)
+							if err = r.retryOrFailDiskImport(provider, instance, foundDv, failure, message); err != nil {
 								return false, err
 							}
+							crashLooping = true
+							break
 						}
 					}
+					// The data volume may be already deleted for the retry, its progress is reset with the retry:
+					if crashLooping {
+						continue
+					}
 				}
 			}
 			// Get current progress of the import:
-			progress := string(foundDv.Status.Progress)
-			progressFloat, err := strconv.ParseFloat(strings.TrimRight(progress, "%"), 64)
-			if err != nil {
-				dvsImportProgress[dvID] = 0.0
-			} else {
-				dvsImportProgress[dvID] = progressFloat
+			dvsImportProgress[dvID] = parseDataVolumeProgress(foundDv.Status.Progress)
+			if foundDv.Status.Phase != cdiv1.Failed {
+				dvsInProgress = append(dvsInProgress, foundDv)
 			}
 		} else {
 			return false, err
 		}
 	}
 
+	// Update progress of the individual disks:
+	if err := r.updateDVsProgress(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, dvsInProgress); err != nil {
+		return false, err
+	}
+
 	// Update progress:
 	currentProgress := disksImportProgress(dvsImportProgress, float64(len(dvs)))
 	if err := r.updateProgress(instance, currentProgress); err != nil {
@@ -738,15 +895,22 @@ func (r *ReconcileVirtualMachineImport) createVM(provider provider.Provider, ins
 	if err := r.upsertStatusConditions(instanceNamespacedName, processingCond); err != nil {
 		return "", err
 	}
-	template, err := provider.FindTemplate()
+	template, reason, err := provider.FindTemplate()
 	var spec *kubevirtv1.VirtualMachine
+	var templateStatus *v2vv1.TemplateStatus
 	config, cfgErr := r.kvConfigProvider.GetConfig()
 	if cfgErr != nil {
 		log.Error(cfgErr, "Cannot get KubeVirt cluster config.")
 	}
+	// the pinned template is never replaced by an empty VM; it's checked by the validation, so anything but its absence
+	// is retried
+	pinned := templates.IsPinned(instance.Spec.Template)
+	if err != nil && pinned && !k8serrors.IsNotFound(err) {
+		return "", err
+	}
 	if err != nil {
 		reqLogger.Info("No matching template was found for the virtual machine.")
-		if !config.ImportWithoutTemplateEnabled() {
+		if pinned || !config.ImportWithoutTemplateEnabled() {
 			if err := r.templateMatchingFailed(err.Error(), &processingCond, provider, instance); err != nil {
 				return "", err
 			}
@@ -754,31 +918,59 @@ func (r *ReconcileVirtualMachineImport) createVM(provider provider.Provider, ins
 		}
 		reqLogger.Info("Using empty VM definition.")
 		spec = mapper.CreateEmptyVM(targetVMName)
+		templateStatus = &v2vv1.TemplateStatus{Reason: "No matching template: " + err.Error()}
 	} else {
 		reqLogger.Info("A template was found for creating the virtual machine", "Template.Name", template.ObjectMeta.Name)
-		spec, err = provider.ProcessTemplate(template, targetVMName, instance.Namespace)
+		spec, err = provider.ProcessTemplate(template, targetVMName, utils.TargetNamespace(instance))
 		if err != nil {
 			reqLogger.Info("Failed to process the template. Error: " + err.Error())
-			if !config.ImportWithoutTemplateEnabled() {
+			if pinned || !config.ImportWithoutTemplateEnabled() {
 				return "", err
 			}
 			reqLogger.Info("Using empty VM definition.")
 			spec = mapper.CreateEmptyVM(targetVMName)
+			templateStatus = &v2vv1.TemplateStatus{Reason: fmt.Sprintf("Failed to process template %s/%s: %v", template.Namespace, template.Name, err)}
 		} else {
+			templateStatus = &v2vv1.TemplateStatus{Name: template.Name, Namespace: template.Namespace, Reason: reason}
 			if len(spec.ObjectMeta.Name) > 0 {
 				targetVMName = &spec.ObjectMeta.Name
 			}
 		}
 	}
+	if err = r.storeTemplate(instance, templateStatus); err != nil {
+		return "", err
+	}
 	reqLogger.Info("Mapping virtual machine resources.", "VM.Name", targetVMName)
 	vmSpec, err := mapper.MapVM(targetVMName, spec)
 	if err != nil {
 		return "", err
 	}
 
+	// drop the MAC addresses the VM doesn't keep
+	if err = r.applyMACPolicy(instance, mapper, vmSpec); err != nil {
+		return "", err
+	}
+
 	// propagate annotations
 	setAnnotations(instance, vmSpec)
 
+	// propagate the labels and annotations of the propagation rules
+	if err = r.propagateToVM(provider, instance, vmSpec); err != nil {
+		return "", err
+	}
+
+	// apply the patches of the import
+	if err = r.patchVM(instance, vmSpec); err != nil {
+		// the patches that can't be read for now are retried
+		if !patches.IsInvalid(err) {
+			return "", err
+		}
+		if err := r.endVMPatchingFailed(provider, instance, vmSpec, err); err != nil {
+			return "", err
+		}
+		return "", err
+	}
+
 	// propagate tracking label
 	setTrackerLabel(vmSpec.ObjectMeta, instance)
 
@@ -787,8 +979,8 @@ func (r *ReconcileVirtualMachineImport) createVM(provider provider.Provider, ins
 		return "", err
 	}
 
-	// Set VirtualMachineImport instance as the owner and controller
-	if err := controllerutil.SetControllerReference(instance, vmSpec, r.scheme); err != nil {
+	// Set VirtualMachineImport instance as the owner and controller, or track the VM in another namespace
+	if err := r.setImportOwnership(instance, vmSpec); err != nil {
 		return "", err
 	}
 
@@ -881,6 +1073,10 @@ func setTrackerLabel(meta metav1.ObjectMeta, instance *v2vv1.VirtualMachineImpor
 // startVM start the VM if was requested to be started and VM disks are imported and ready:
 func (r *ReconcileVirtualMachineImport) startVM(provider provider.Provider, instance *v2vv1.VirtualMachineImport, vmName types.NamespacedName) (bool, error) {
 	log := log.WithValues("Request.Namespace", instance.Namespace, "Request.Name", instance.Name)
+	// The stopped VM mustn't be started again while the failed boot verification is rolled back
+	if shouldRollbackFailedBoot(instance) {
+		return r.rollbackFailedBoot(provider, instance, vmName)
+	}
 	vmi := &kubevirtv1.VirtualMachineInstance{}
 	err := r.client.Get(context.TODO(), vmName, vmi)
 	vmIdentifier := utils.ToLoggableResourceName(vmName.Name, &vmName.Namespace)
@@ -892,7 +1088,7 @@ func (r *ReconcileVirtualMachineImport) startVM(provider provider.Provider, inst
 				return false, err
 			}
 			log.Info("Starting a vm", "VM.Name", vmName)
-			if err = r.updateToRunning(vmName); err != nil {
+			if err = r.updateToRunning(vmName, readinessProbe(instance)); err != nil {
 				// Emit event vm failed to start:
 				r.recorder.Eventf(instance, corev1.EventTypeWarning, EventVMStartFailed, "Virtual Machine %s failed to start: %s", vmIdentifier, err)
 				return false, err
@@ -903,8 +1099,20 @@ func (r *ReconcileVirtualMachineImport) startVM(provider provider.Provider, inst
 	}
 
 	log.Info("VMI available", "VM.Name", vmName)
+	if shouldVerifyBoot(instance) {
+		verified, requeue, err := r.verifyBoot(provider, instance, vmName, vmi)
+		if !verified {
+			return requeue, err
+		}
+	}
 	if vmi.Status.Phase == kubevirtv1.Running || vmi.Status.Phase == kubevirtv1.Scheduled {
 		log.Info("The vm started", "VM.Name", vmName)
+		if err = r.removeCustomization(instance, vmName); err != nil {
+			return false, err
+		}
+		if err = r.removeReadinessProbe(instance, vmName); err != nil {
+			return false, err
+		}
 		// Emit event vm is successfully imported and started:
 		r.recorder.Eventf(instance, corev1.EventTypeNormal, EventImportSucceeded, "Virtual Machine %s imported and started", vmIdentifier)
 		if err = r.updateConditionsAfterSuccess(instance, "Virtual machine running", v2vv1.VirtualMachineRunning); err != nil {
@@ -922,6 +1130,23 @@ func (r *ReconcileVirtualMachineImport) startVM(provider provider.Provider, inst
 	return false, nil
 }
 
+// removeCustomization detaches the customization data from the VM once it has started, when asked to. The running VM
+// keeps the data until it is restarted.
+func (r *ReconcileVirtualMachineImport) removeCustomization(instance *v2vv1.VirtualMachineImport, vmName types.NamespacedName) error {
+	if instance.Spec.Customization == nil || !instance.Spec.Customization.RemoveAfterFirstBoot {
+		return nil
+	}
+	vm := &kubevirtv1.VirtualMachine{}
+	err := r.client.Get(context.TODO(), vmName, vm)
+	if err != nil {
+		return err
+	}
+	if !customization.RemoveVolume(vm) {
+		return nil
+	}
+	return r.client.Update(context.TODO(), vm)
+}
+
 func (r *ReconcileVirtualMachineImport) updateConditionsAfterSuccess(instance *v2vv1.VirtualMachineImport, message string, reason v2vv1.SucceededConditionReason) error {
 	succeededCond := conditions.NewSucceededCondition(string(reason), message, corev1.ConditionTrue)
 	conds := []v2vv1.VirtualMachineImportCondition{succeededCond}
@@ -954,8 +1179,11 @@ func shouldStartVM(instance *v2vv1.VirtualMachineImport) bool {
 	return instance.Spec.StartVM != nil && *instance.Spec.StartVM && conditions.HasSucceededConditionOfReason(instance.Status.Conditions, v2vv1.VirtualMachineReady)
 }
 
-func shouldConvertGuest(provider provider.Provider, instance *v2vv1.VirtualMachineImport) bool {
-	return provider.NeedsGuestConversion() && !conditions.HasSucceededConditionOfReason(instance.Status.Conditions, v2vv1.VirtualMachineReady, v2vv1.VirtualMachineRunning)
+func shouldConvertGuest(provider provider.Provider, instance *v2vv1.VirtualMachineImport) (bool, error) {
+	if conditions.HasSucceededConditionOfReason(instance.Status.Conditions, v2vv1.VirtualMachineReady, v2vv1.VirtualMachineRunning) {
+		return false, nil
+	}
+	return provider.NeedsGuestConversion()
 }
 
 func shouldImportDisks(instance *v2vv1.VirtualMachineImport) bool {
@@ -970,11 +1198,14 @@ func (r *ReconcileVirtualMachineImport) isDoneImport(dvsDone map[string]bool, nu
 	return done == numberOfDvs
 }
 
-func (r *ReconcileVirtualMachineImport) createDataVolume(provider provider.Provider, mapper provider.Mapper, instance *v2vv1.VirtualMachineImport, dv *cdiv1.DataVolume, vmName types.NamespacedName) (*cdiv1.DataVolume, error) {
+func (r *ReconcileVirtualMachineImport) createDataVolume(provider provider.Provider, mapper provider.Mapper, instance *v2vv1.VirtualMachineImport, dv *cdiv1.DataVolume, vmName types.NamespacedName, dvPatches []patches.Patch) (_ *cdiv1.DataVolume, err error) {
+	_, span := tracing.StartSpan(instance.UID, "CreateDataVolume", label.String("datavolume.name", dv.Name))
+	defer func() { tracing.EndSpan(span, err) }()
+
 	instanceNamespacedName := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
 	// Update condition to create VM:
 	processingCond := conditions.NewProcessingCondition(string(v2vv1.CopyingDisks), "Copying virtual machine disks", corev1.ConditionTrue)
-	err := r.upsertStatusConditions(instanceNamespacedName, processingCond)
+	err = r.upsertStatusConditions(instanceNamespacedName, processingCond)
 	if err != nil {
 		return nil, err
 	}
@@ -989,8 +1220,18 @@ func (r *ReconcileVirtualMachineImport) createDataVolume(provider provider.Provi
 		return nil, err
 	}
 
-	// Set controller owner reference:
-	if err := controllerutil.SetControllerReference(instance, dv, r.scheme); err != nil {
+	// Propagate the labels and annotations of the propagation rules:
+	if err = r.propagateToDataVolume(provider, instance, dv); err != nil {
+		return nil, err
+	}
+
+	// Apply the patches of the import:
+	if err = patches.ApplyToDataVolume(dv, dvPatches); err != nil {
+		return nil, err
+	}
+
+	// Set controller owner reference, or track the data volume in another namespace:
+	if err := r.setImportOwnership(instance, dv); err != nil {
 		return nil, err
 	}
 
@@ -1086,23 +1327,24 @@ func (r *ReconcileVirtualMachineImport) createProvider(vmi *v2vv1.VirtualMachine
 	return nil, fmt.Errorf("Invalid source type. Only Ovirt and Vmware type is supported")
 }
 
-func (r *ReconcileVirtualMachineImport) updateToRunning(vmName types.NamespacedName) error {
+func (r *ReconcileVirtualMachineImport) updateToRunning(vmName types.NamespacedName, probe *guestReadinessProbe) error {
 	var vm kubevirtv1.VirtualMachine
 	err := r.client.Get(context.TODO(), vmName, &vm)
 	if err != nil {
 		return err
 	}
 
-	copy := vm.DeepCopy()
-	running := true
-	copy.Spec.Running = &running
-
-	patch := client.MergeFrom(&vm)
-	err = r.client.Patch(context.TODO(), copy, patch)
+	spec := map[string]interface{}{"running": true}
+	if probe != nil && vm.Spec.Template != nil {
+		spec["template"] = map[string]interface{}{
+			"spec": map[string]interface{}{"readinessProbe": probe},
+		}
+	}
+	patch, err := json.Marshal(map[string]interface{}{"spec": spec})
 	if err != nil {
 		return err
 	}
-	return nil
+	return r.client.Patch(context.TODO(), &vm, client.RawPatch(types.MergePatchType, patch))
 }
 
 func (r *ReconcileVirtualMachineImport) updateDVs(vmiName types.NamespacedName, dv cdiv1.DataVolume) error {
@@ -1122,7 +1364,7 @@ func (r *ReconcileVirtualMachineImport) updateDVs(vmiName types.NamespacedName,
 	}
 	// Patch the status only in case DV is not already part of the VMImport status:
 	if !dvFound {
-		copy.Status.DataVolumes = append(copy.Status.DataVolumes, v2vv1.DataVolumeItem{Name: dv.Name})
+		copy.Status.DataVolumes = append(copy.Status.DataVolumes, newDataVolumeItem(&dv))
 		err = r.client.Status().Update(context.TODO(), copy)
 		if err != nil {
 			return err
@@ -1155,6 +1397,14 @@ func (r *ReconcileVirtualMachineImport) updateVMSpecDataVolumes(mapper provider.
 	if err != nil {
 		return err
 	}
+	// The volume is already mapped when the data volume is recreated after a failed import attempt:
+	if vm.Spec.Template != nil {
+		for _, volume := range vm.Spec.Template.Spec.Volumes {
+			if volume.DataVolume != nil && volume.DataVolume.Name == dv.Name {
+				return nil
+			}
+		}
+	}
 	copy := vm.DeepCopy()
 	mapper.MapDisk(copy, dv)
 
@@ -1190,6 +1440,40 @@ func (r *ReconcileVirtualMachineImport) storeSourceVMStatus(instance *v2vv1.Virt
 	return r.client.Patch(context.TODO(), vmiCopy, patch)
 }
 
+// storeGuestNetwork stores the IP configuration reported by the source guest tools in the VM import status
+func (r *ReconcileVirtualMachineImport) storeGuestNetwork(instance *v2vv1.VirtualMachineImport, guestNetwork *v2vv1.GuestNetworkStatus) error {
+	var current v2vv1.VirtualMachineImport
+	err := r.apiReader.Get(context.TODO(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, &current)
+	if err != nil {
+		return err
+	}
+	copy := current.DeepCopy()
+	copy.Status.GuestNetwork = guestNetwork
+	err = r.client.Status().Update(context.TODO(), copy)
+	if err != nil {
+		return err
+	}
+	instance.Status.GuestNetwork = guestNetwork
+	return nil
+}
+
+// storeTemplate records the template the imported VM is created from in the VM import status
+func (r *ReconcileVirtualMachineImport) storeTemplate(instance *v2vv1.VirtualMachineImport, template *v2vv1.TemplateStatus) error {
+	var current v2vv1.VirtualMachineImport
+	err := r.apiReader.Get(context.TODO(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, &current)
+	if err != nil {
+		return err
+	}
+	copy := current.DeepCopy()
+	copy.Status.Template = template
+	err = r.client.Status().Update(context.TODO(), copy)
+	if err != nil {
+		return err
+	}
+	instance.Status.Template = template
+	return nil
+}
+
 func (r *ReconcileVirtualMachineImport) updateProgress(instance *v2vv1.VirtualMachineImport, progress string) error {
 	currentProgress, ok := instance.Annotations[AnnCurrentProgress]
 	if !ok {
@@ -1220,8 +1504,9 @@ func (r *ReconcileVirtualMachineImport) afterSuccess(vmName types.NamespacedName
 
 	r.removeFinalizer(utils.CancelledImportFinalizer, instance)
 
-	metrics.ImportMetrics.IncSuccessful()
-	metrics.ImportMetrics.SaveDurationSuccessful(calculateImportDuration(instance))
+	metrics.ImportMetrics.IncSuccessful(providerName(instance))
+	metrics.ImportMetrics.SaveDurationSuccessful(providerName(instance), calculateImportDuration(instance))
+	endImportMetrics(instance)
 
 	vmiName := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
 	var errs []error
@@ -1229,6 +1514,10 @@ func (r *ReconcileVirtualMachineImport) afterSuccess(vmName types.NamespacedName
 	if err != nil {
 		errs = append(errs, err)
 	}
+	err = r.enforceSourceVMPolicy(vmName, p, instance)
+	if err != nil {
+		errs = append(errs, err)
+	}
 
 	e := r.ownerreferencesmgr.PurgeOwnerReferences(vmName)
 	if len(e) > 0 {
@@ -1241,13 +1530,14 @@ func (r *ReconcileVirtualMachineImport) afterSuccess(vmName types.NamespacedName
 	return nil
 }
 
-//TODO: use in proper places
+// TODO: use in proper places
 func (r *ReconcileVirtualMachineImport) afterFailure(p provider.Provider, instance *v2vv1.VirtualMachineImport) error {
 
 	r.removeFinalizer(utils.CancelledImportFinalizer, instance)
 
-	metrics.ImportMetrics.IncFailed()
-	metrics.ImportMetrics.SaveDurationFailed(calculateImportDuration(instance))
+	metrics.ImportMetrics.IncFailed(providerName(instance))
+	metrics.ImportMetrics.SaveDurationFailed(providerName(instance), calculateImportDuration(instance))
+	endImportMetrics(instance)
 
 	vmiName := types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}
 	var errs []error
@@ -1259,6 +1549,10 @@ func (r *ReconcileVirtualMachineImport) afterFailure(p provider.Provider, instan
 	if err != nil {
 		errs = append(errs, err)
 	}
+	err = r.inspectionPodsManager.DeleteFor(instance)
+	if err != nil {
+		errs = append(errs, err)
+	}
 
 	if len(errs) > 0 {
 		return foldErrors(errs, "Import failure", vmiName)
@@ -1285,6 +1579,28 @@ func (r *ReconcileVirtualMachineImport) restoreInitialVMState(vmiName types.Name
 	return nil
 }
 
+// applySourceVMPolicy disposes of the source VM once the import succeeded, according to the source VM policy
+func (r *ReconcileVirtualMachineImport) applySourceVMPolicy(vmName types.NamespacedName, p provider.Provider, instance *v2vv1.VirtualMachineImport) error {
+	switch instance.Spec.SourceVMPolicy {
+	case v2vv1.SourceVMPolicyRestore:
+		return r.restoreInitialVMState(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, p)
+	case v2vv1.SourceVMPolicyDelete:
+		return p.DeleteVM()
+	case v2vv1.SourceVMPolicyMark:
+		return p.MarkVMMigrated(r.migrationTarget(vmName))
+	}
+	return nil
+}
+
+// migrationTarget returns where the Mark source VM policy records the source VM was migrated to
+func (r *ReconcileVirtualMachineImport) migrationTarget(vmName types.NamespacedName) string {
+	target := vmName.Namespace + "/" + vmName.Name
+	if clusterName := r.ctrlConfig.ClusterName(); clusterName != "" {
+		target = clusterName + "/" + target
+	}
+	return target
+}
+
 func foldErrors(errs []error, prefix string, vmiName types.NamespacedName) error {
 	message := ""
 	for _, e := range errs {
@@ -1334,6 +1650,7 @@ func (r *ReconcileVirtualMachineImport) initProvider(instance *v2vv1.VirtualMach
 	if !r.vmImportInProgress(instance) {
 		err = provider.TestConnection()
 		if err != nil {
+			metrics.ImportMetrics.IncProviderConnectionFailure(providerName(instance))
 			message := "Failed to connect to source provider"
 			cerr := r.upsertValidationCondition(instance, v2vv1.UnreachableProvider, message, err)
 			if cerr != nil {
@@ -1382,10 +1699,25 @@ func (r *ReconcileVirtualMachineImport) fetchVM(instance *v2vv1.VirtualMachineIm
 			}
 			return err
 		}
+		if instance.Status.GuestNetwork == nil {
+			// the guest network is captured before the source VM is stopped, while its guest tools still report it
+			guestNetwork, err := provider.GetGuestNetwork()
+			if err != nil {
+				logger.Info("Cannot read the guest network configuration", "Error", err.Error())
+			} else if guestNetwork != nil {
+				if err = r.storeGuestNetwork(instance, guestNetwork); err != nil {
+					return err
+				}
+			}
+		}
 	} else {
 		logger.Info("No need to fetch virtual machine - skipping")
 	}
 
+	return r.prepareResourceMapping(instance, provider)
+}
+
+func (r *ReconcileVirtualMachineImport) prepareResourceMapping(instance *v2vv1.VirtualMachineImport, provider provider.Provider) error {
 	// Load the external resource mapping
 	resourceMapping, err := r.fetchResourceMapping(instance.Spec.ResourceMapping, instance.Namespace)
 	if err != nil {
@@ -1400,7 +1732,7 @@ func (r *ReconcileVirtualMachineImport) fetchVM(instance *v2vv1.VirtualMachineIm
 	}
 
 	// Prepare/merge the resourceMapping
-	provider.PrepareResourceMapping(resourceMapping, instance.Spec.Source)
+	provider.PrepareResourceMapping(withGeneratedNetworkMappings(resourceMapping, instance), instance.Spec.Source)
 
 	return nil
 }
@@ -1458,7 +1790,7 @@ func (r *ReconcileVirtualMachineImport) validateUniqueness(instance *v2vv1.Virtu
 		name = sourceName
 	}
 
-	namespacedName := types.NamespacedName{Namespace: instance.Namespace, Name: name}
+	namespacedName := types.NamespacedName{Namespace: utils.TargetNamespace(instance), Name: name}
 	err := r.client.Get(context.TODO(), namespacedName, &kubevirtv1.VirtualMachine{})
 	if err != nil && k8serrors.IsNotFound(err) {
 		return true, nil
@@ -1483,6 +1815,66 @@ func (r *ReconcileVirtualMachineImport) validate(instance *v2vv1.VirtualMachineI
 			return false, err
 		}
 
+		err = customization.Validate(instance.Spec.Customization)
+		if err != nil {
+			invalidCustomizationCond := conditions.NewCondition(v2vv1.Valid, string(v2vv1.InvalidCustomization), err.Error(), corev1.ConditionFalse)
+			err := r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, invalidCustomizationCond)
+			return false, err
+		}
+
+		err = propagation.Validate(instance.Spec.Propagation)
+		if err != nil {
+			invalidPropagationCond := conditions.NewCondition(v2vv1.Valid, string(v2vv1.InvalidPropagation), err.Error(), corev1.ConditionFalse)
+			err := r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, invalidPropagationCond)
+			return false, err
+		}
+
+		if message := validateSourceVMPolicy(instance); message != "" {
+			invalidSourceVMPolicyCond := conditions.NewCondition(v2vv1.Valid, string(v2vv1.InvalidSourceVMPolicy), message, corev1.ConditionFalse)
+			err := r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, invalidSourceVMPolicyCond)
+			return false, err
+		}
+
+		if message := validateBootVerification(instance); message != "" {
+			invalidBootVerificationCond := conditions.NewCondition(v2vv1.Valid, string(v2vv1.InvalidBootVerification), message, corev1.ConditionFalse)
+			err := r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, invalidBootVerificationCond)
+			return false, err
+		}
+
+		err = r.validatePatches(instance)
+		if err != nil && !patches.IsInvalid(err) {
+			return false, err
+		}
+		if err != nil {
+			invalidPatchCond := conditions.NewCondition(v2vv1.Valid, string(v2vv1.InvalidPatch), err.Error(), corev1.ConditionFalse)
+			err := r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, invalidPatchCond)
+			return false, err
+		}
+
+		err = naming.Validate(instance.Spec.Naming)
+		if err != nil {
+			invalidNamingCond := conditions.NewCondition(v2vv1.Valid, string(v2vv1.InvalidNamingTemplate), err.Error(), corev1.ConditionFalse)
+			err := r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, invalidNamingCond)
+			return false, err
+		}
+
+		err = validateTargetNamespace(instance)
+		if err != nil {
+			invalidTargetNamespaceCond := conditions.NewCondition(v2vv1.Valid, string(v2vv1.InvalidTargetNamespace), err.Error(), corev1.ConditionFalse)
+			err := r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, invalidTargetNamespaceCond)
+			return false, err
+		}
+
+		allowed, message, err := r.canCreateVMInTargetNamespace(instance)
+		if err != nil {
+			return false, err
+		}
+		if !allowed {
+			forbiddenCond := conditions.NewCondition(v2vv1.Valid, string(v2vv1.TargetNamespaceForbidden), message, corev1.ConditionFalse)
+			err := r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, forbiddenCond)
+			return false, err
+		}
+
 		unique, err := r.validateUniqueness(instance, vmName)
 		if err != nil {
 			return false, err
@@ -1493,6 +1885,53 @@ func (r *ReconcileVirtualMachineImport) validate(instance *v2vv1.VirtualMachineI
 			return false, err
 		}
 
+		unrestorable, err := validateRestoredNetwork(instance, provider)
+		if err != nil {
+			return false, err
+		}
+		if unrestorable != "" {
+			invalidCustomizationCond := conditions.NewCondition(v2vv1.Valid, string(v2vv1.InvalidCustomization), unrestorable, corev1.ConditionFalse)
+			err := r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, invalidCustomizationCond)
+			return false, err
+		}
+
+		conflicts, err := r.validateMACAddresses(instance, provider)
+		if macaddress.IsInvalidRange(err) {
+			invalidRangeCond := conditions.NewCondition(v2vv1.Valid, string(v2vv1.InvalidKubeMacPoolRange), err.Error(), corev1.ConditionFalse)
+			err := r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, invalidRangeCond)
+			return false, err
+		}
+		if err != nil {
+			return false, err
+		}
+		if conflicts != "" {
+			macConflictCond := conditions.NewCondition(v2vv1.Valid, string(v2vv1.MACAddressConflict), conflicts, corev1.ConditionFalse)
+			err := r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, macConflictCond)
+			return false, err
+		}
+
+		unmappedNetworks, err := r.validateUnmappedNetworks(instance, provider)
+		if err != nil {
+			return false, err
+		}
+		if unmappedNetworks != "" {
+			unmappedNetworkCond := conditions.NewCondition(v2vv1.Valid, string(v2vv1.UnmappedNetwork), unmappedNetworks, corev1.ConditionFalse)
+			err := r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, unmappedNetworkCond)
+			return false, err
+		}
+
+		// the pinned template must exist, the import never falling back to an empty VM for it
+		if templates.IsPinned(instance.Spec.Template) {
+			if _, _, err := provider.FindTemplate(); err != nil {
+				if !k8serrors.IsNotFound(err) {
+					return false, err
+				}
+				pinnedTemplateCond := conditions.NewCondition(v2vv1.Valid, string(v2vv1.PinnedTemplateNotFound), err.Error(), corev1.ConditionFalse)
+				err := r.upsertStatusConditions(types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, pinnedTemplateCond)
+				return false, err
+			}
+		}
+
 		conditions, err := provider.Validate()
 		if err != nil {
 			return true, err
@@ -1581,6 +2020,25 @@ func (r *ReconcileVirtualMachineImport) removeFinalizer(finalizer string, instan
 	}
 }
 
+// providerName returns the name of the import source provider, which labels the metrics
+func providerName(instance *v2vv1.VirtualMachineImport) string {
+	if instance.Spec.Source.Ovirt != nil {
+		return metrics.ProviderOvirt
+	}
+	if instance.Spec.Source.Vmware != nil {
+		return metrics.ProviderVmware
+	}
+	return metrics.ProviderUnknown
+}
+
+func enterImportPhase(instance *v2vv1.VirtualMachineImport, phase string) {
+	metrics.ImportMetrics.EnterPhase(providerName(instance), instance.Namespace, instance.Name, phase)
+}
+
+func endImportMetrics(instance *v2vv1.VirtualMachineImport) {
+	metrics.ImportMetrics.EndImport(instance.Namespace, instance.Name)
+}
+
 func calculateImportDuration(instance *v2vv1.VirtualMachineImport) float64 {
 	var endTime, startTime time.Time
 	if instance.GetDeletionTimestamp() == nil {
@@ -1593,3 +2051,9 @@ func calculateImportDuration(instance *v2vv1.VirtualMachineImport) float64 {
 
 	return endTime.Sub(startTime).Seconds()
 }
+
+// recordGuestConversionSpan records the span of the guest conversion, from the creation of the conversion pod
+// until now, when the pod is found finished
+func recordGuestConversionSpan(instance *v2vv1.VirtualMachineImport, pod *corev1.Pod, err error) {
+	tracing.RecordSpan(instance.UID, "GuestConversion", pod.CreationTimestamp.Time, time.Now(), err, label.String("pod.name", pod.Name))
+}
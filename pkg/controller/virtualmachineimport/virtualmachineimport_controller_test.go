@@ -3,21 +3,35 @@ package virtualmachineimport
 import (
 	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
 
 	ctrlConfig "github.com/kubevirt/vm-import-operator/pkg/config/controller"
 	"github.com/kubevirt/vm-import-operator/pkg/metrics"
 
 	kvConfig "github.com/kubevirt/vm-import-operator/pkg/config/kubevirt"
 
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	pclient "github.com/kubevirt/vm-import-operator/pkg/client"
+	"github.com/kubevirt/vm-import-operator/pkg/conditions"
+	"github.com/kubevirt/vm-import-operator/pkg/customization"
+	"github.com/kubevirt/vm-import-operator/pkg/macaddress"
 	"github.com/kubevirt/vm-import-operator/pkg/mappings"
 	"github.com/kubevirt/vm-import-operator/pkg/ownerreferences"
+	"github.com/kubevirt/vm-import-operator/pkg/patches"
+	"github.com/kubevirt/vm-import-operator/pkg/propagation"
 	provider "github.com/kubevirt/vm-import-operator/pkg/providers"
+	"github.com/kubevirt/vm-import-operator/pkg/requester"
+	"github.com/kubevirt/vm-import-operator/pkg/utils"
 	oapiv1 "github.com/openshift/api/template/v1"
 	ovirtsdk "github.com/ovirt/go-ovirt"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -47,22 +61,37 @@ var (
 	validate                 func() ([]v2vv1.VirtualMachineImportCondition, error)
 	statusPatch              func(ctx context.Context, obj runtime.Object, patch client.Patch) error
 	getVMStatus              func() (provider.VMStatus, error)
-	findTemplate             func() (*oapiv1.Template, error)
+	findTemplate             func() (*oapiv1.Template, string, error)
 	processTemplate          func(template *oapiv1.Template, name *string, namespace string) (*kubevirtv1.VirtualMachine, error)
 	create                   func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error
 	cleanUp                  func() error
 	update                   func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error
+	deleteObject             func(ctx context.Context, obj runtime.Object) error
+	deleteAllOf              func(ctx context.Context, obj runtime.Object, opts ...client.DeleteAllOfOption) error
 	mapDisks                 func() (map[string]cdiv1.DataVolume, error)
 	getVM                    func(id *string, name *string, cluster *string, clusterID *string) (interface{}, error)
 	stopVM                   func(id string) error
 	list                     func(ctx context.Context, list runtime.Object, opts ...client.ListOption) error
 	getKvConfig              func() kvConfig.KubeVirtConfig
 	getCtrlConfig            func() ctrlConfig.ControllerConfig
-	needsGuestConversion     func() bool
+	needsGuestConversion     func() (bool, error)
 	getGuestConversionPod    func() (*corev1.Pod, error)
 	launchGuestConversionPod func() (*corev1.Pod, error)
 	supportsWarmMigration    func() bool
 	createVMSnapshot         func() (string, error)
+	streamPodLog             func(pod *corev1.Pod, container string) (io.ReadCloser, error)
+	updateOperatingSystem    func(vm *kubevirtv1.VirtualMachine, inspection *v2vv1.GuestInspectionStatus) error
+	findInspectionPod        func() (*corev1.Pod, error)
+	getGuestNetwork          func() (*v2vv1.GuestNetworkStatus, error)
+	mapMACAddresses          func(importPolicy v2vv1.MACPolicy) []macaddress.NIC
+	mapUnmappedNetworks      func() []v2vv1.UnmappedNetworkStatus
+	startSourceVM            func() error
+	deleteSourceVM           func() error
+	markSourceVMMigrated     func(target string) error
+	unmarkSourceVMMigrated   func(target string) error
+	getSourceMetadata        func() (*propagation.SourceMetadata, error)
+	createInspectionPod      func(pod *corev1.Pod) error
+	deleteInspectionPod      func() error
 )
 
 var _ = Describe("Reconcile steps", func() {
@@ -100,6 +129,10 @@ var _ = Describe("Reconcile steps", func() {
 				obj.(*v2vv1.ResourceMapping).Spec = v2vv1.ResourceMappingSpec{}
 			case *corev1.Secret:
 				obj.(*corev1.Secret).Data = map[string][]byte{"ovirt": getSecret()}
+			case *corev1.ConfigMap:
+				return errors.NewNotFound(schema.GroupResource{}, key.Name)
+			case *cdiv1.DataVolume:
+				controlledByImport(obj.(*cdiv1.DataVolume), instance)
 			}
 			return nil
 		}
@@ -127,8 +160,44 @@ var _ = Describe("Reconcile steps", func() {
 		update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
 			return nil
 		}
-		needsGuestConversion = func() bool {
-			return false
+		deleteObject = func(ctx context.Context, obj runtime.Object) error {
+			return nil
+		}
+		deleteAllOf = func(ctx context.Context, obj runtime.Object, opts ...client.DeleteAllOfOption) error {
+			return nil
+		}
+		needsGuestConversion = func() (bool, error) {
+			return false, nil
+		}
+		findInspectionPod = func() (*corev1.Pod, error) {
+			return nil, nil
+		}
+		deleteInspectionPod = func() error {
+			return nil
+		}
+		getGuestNetwork = func() (*v2vv1.GuestNetworkStatus, error) {
+			return nil, nil
+		}
+		mapMACAddresses = func(importPolicy v2vv1.MACPolicy) []macaddress.NIC {
+			return nil
+		}
+		mapUnmappedNetworks = func() []v2vv1.UnmappedNetworkStatus {
+			return nil
+		}
+		startSourceVM = func() error {
+			return nil
+		}
+		deleteSourceVM = func() error {
+			return nil
+		}
+		markSourceVMMigrated = func(target string) error {
+			return nil
+		}
+		unmarkSourceVMMigrated = func(target string) error {
+			return nil
+		}
+		getSourceMetadata = func() (*propagation.SourceMetadata, error) {
+			return &propagation.SourceMetadata{}, nil
 		}
 		vmName = types.NamespacedName{Name: "test", Namespace: "default"}
 		rec := record.NewFakeRecorder(2)
@@ -262,6 +331,38 @@ var _ = Describe("Reconcile steps", func() {
 			Expect(err).To(BeNil())
 		})
 
+		It("should store the guest network reported by the source: ", func() {
+			guestNetwork := &v2vv1.GuestNetworkStatus{
+				Interfaces: []v2vv1.GuestNetworkInterface{{MAC: "56:6f:05:0f:00:05", IPAddresses: []string{"10.0.0.5/24"}}},
+				Routes:     []v2vv1.GuestNetworkRoute{{Destination: "0.0.0.0/0", Gateway: "10.0.0.1"}},
+			}
+			getGuestNetwork = func() (*v2vv1.GuestNetworkStatus, error) {
+				return guestNetwork, nil
+			}
+			var stored *v2vv1.GuestNetworkStatus
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				stored = obj.(*v2vv1.VirtualMachineImport).Status.GuestNetwork
+				return nil
+			}
+
+			err := reconciler.fetchVM(instance, mock)
+
+			Expect(err).To(BeNil())
+			Expect(stored).To(Equal(guestNetwork))
+			Expect(instance.Status.GuestNetwork).To(Equal(guestNetwork))
+		})
+
+		It("should not fail when the guest network can't be read: ", func() {
+			getGuestNetwork = func() (*v2vv1.GuestNetworkStatus, error) {
+				return nil, fmt.Errorf("no guest agent")
+			}
+
+			err := reconciler.fetchVM(instance, mock)
+
+			Expect(err).To(BeNil())
+			Expect(instance.Status.GuestNetwork).To(BeNil())
+		})
+
 	})
 
 	Describe("validate name", func() {
@@ -375,7 +476,8 @@ var _ = Describe("Reconcile steps", func() {
 					obj.(*corev1.Secret).Data = map[string][]byte{"ovirt": getSecret()}
 				case *kubevirtv1.VirtualMachine:
 					return errors.NewNotFound(schema.GroupResource{}, "")
-
+				case *corev1.ConfigMap:
+					return errors.NewNotFound(schema.GroupResource{}, key.Name)
 				}
 				return nil
 			}
@@ -388,59 +490,123 @@ var _ = Describe("Reconcile steps", func() {
 			Expect(validated).To(Equal(true))
 		})
 
-		It("should fail to validate: ", func() {
-			validate = func() ([]v2vv1.VirtualMachineImportCondition, error) {
-				return nil, fmt.Errorf("Failed")
+		It("should block the import when the customization is invalid: ", func() {
+			instance.Spec.Customization = &v2vv1.CustomizationSpec{}
+			var reason string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				reason = *obj.(*v2vv1.VirtualMachineImport).Status.Conditions[0].Reason
+				return nil
 			}
 
 			validated, err := reconciler.validate(instance, mock)
 
-			Expect(err).To(Not(BeNil()))
-			Expect(validated).To(Equal(true))
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.InvalidCustomization)))
 		})
 
-		It("should not validate again: ", func() {
-			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
-				switch obj.(type) {
-				case *v2vv1.VirtualMachineImport:
-					return fmt.Errorf("Let's make it fail if it tries to validate")
-				case *v2vv1.ResourceMapping:
-					obj.(*v2vv1.ResourceMapping).Spec = v2vv1.ResourceMappingSpec{}
-				case *corev1.Secret:
-					obj.(*corev1.Secret).Data = map[string][]byte{"ovirt": getSecret()}
-				case *kubevirtv1.VirtualMachine:
-					return errors.NewNotFound(schema.GroupResource{}, "")
-				}
+		It("should block the import when a naming template is invalid: ", func() {
+			dvTemplate := "{{.VMName"
+			instance.Spec.Naming = &v2vv1.NamingSpec{DataVolume: &dvTemplate}
+			var reason string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				reason = *obj.(*v2vv1.VirtualMachineImport).Status.Conditions[0].Reason
 				return nil
 			}
 
-			conditions := []v2vv1.VirtualMachineImportCondition{}
-			conditions = append(conditions, v2vv1.VirtualMachineImportCondition{
-				Status: corev1.ConditionTrue,
-				Type:   v2vv1.Valid,
-			})
-			conditions = append(conditions, v2vv1.VirtualMachineImportCondition{
-				Status: corev1.ConditionTrue,
-				Type:   v2vv1.MappingRulesVerified,
-			})
-			instance.Status.Conditions = conditions
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.InvalidNamingTemplate)))
+		})
+
+		It("should block the import when the target namespace is invalid: ", func() {
+			targetNamespace := "Apps"
+			instance.Spec.TargetNamespace = &targetNamespace
+			var reason string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				reason = *obj.(*v2vv1.VirtualMachineImport).Status.Conditions[0].Reason
+				return nil
+			}
 
 			validated, err := reconciler.validate(instance, mock)
 
 			Expect(err).To(BeNil())
-			Expect(validated).To(Equal(true))
+			Expect(validated).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.InvalidTargetNamespace)))
 		})
 
-		It("should fail to update conditions: ", func() {
+		It("should block the import when its requester may not create VMs in the target namespace: ", func() {
+			targetNamespace := "apps"
+			instance.Spec.TargetNamespace = &targetNamespace
+			instance.Labels = map[string]string{requester.Label: ""}
+			instance.Annotations = map[string]string{
+				requester.Annotation: `{"username":"alice","uid":"1","groups":["migrators"],"extra":{"scopes":["user:full"]}}`,
+			}
+			var review *authorizationv1.SubjectAccessReview
+			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+				review = obj.(*authorizationv1.SubjectAccessReview)
+				return nil
+			}
+			var reason string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				reason = *obj.(*v2vv1.VirtualMachineImport).Status.Conditions[0].Reason
+				return nil
+			}
+
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.TargetNamespaceForbidden)))
+			Expect(review.Spec.User).To(Equal("alice"))
+			Expect(review.Spec.UID).To(Equal("1"))
+			Expect(review.Spec.Groups).To(ConsistOf("migrators"))
+			Expect(review.Spec.Extra).To(Equal(map[string]authorizationv1.ExtraValue{"scopes": {"user:full"}}))
+			Expect(*review.Spec.ResourceAttributes).To(Equal(authorizationv1.ResourceAttributes{
+				Namespace: "apps",
+				Verb:      "create",
+				Group:     "kubevirt.io",
+				Resource:  "virtualmachines",
+			}))
+		})
+
+		It("should block the import to another namespace when its requester isn't recorded: ", func() {
+			targetNamespace := "apps"
+			instance.Spec.TargetNamespace = &targetNamespace
+			reviewed := false
+			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+				reviewed = true
+				return nil
+			}
+			var reason string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				reason = *obj.(*v2vv1.VirtualMachineImport).Status.Conditions[0].Reason
+				return nil
+			}
+
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.TargetNamespaceForbidden)))
+			Expect(reviewed).To(BeFalse())
+		})
+
+		It("should validate an import to a target namespace its requester may create VMs in: ", func() {
+			targetNamespace := "apps"
+			instance.Spec.TargetNamespace = &targetNamespace
+			instance.Labels = map[string]string{requester.Label: ""}
+			instance.Annotations = map[string]string{requester.Annotation: `{"username":"alice"}`}
+			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+				obj.(*authorizationv1.SubjectAccessReview).Status.Allowed = true
+				return nil
+			}
+			var vmNamespace string
 			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
-				switch obj.(type) {
-				case *v2vv1.VirtualMachineImport:
-					return fmt.Errorf("Not found")
-				case *v2vv1.ResourceMapping:
-					obj.(*v2vv1.ResourceMapping).Spec = v2vv1.ResourceMappingSpec{}
-				case *corev1.Secret:
-					obj.(*corev1.Secret).Data = map[string][]byte{"ovirt": getSecret()}
-				case *kubevirtv1.VirtualMachine:
+				if _, ok := obj.(*kubevirtv1.VirtualMachine); ok {
+					vmNamespace = key.Namespace
 					return errors.NewNotFound(schema.GroupResource{}, "")
 				}
 				return nil
@@ -448,168 +614,632 @@ var _ = Describe("Reconcile steps", func() {
 
 			validated, err := reconciler.validate(instance, mock)
 
-			Expect(err).To(Not(BeNil()))
-			Expect(validated).To(Equal(true))
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeTrue())
+			Expect(vmNamespace).To(Equal("apps"))
 		})
 
-		It("should fail update vmimport with conditions: ", func() {
-			statusPatch = func(ctx context.Context, obj runtime.Object, patch client.Patch) error {
-				return fmt.Errorf("Not modified")
+		It("should block the import when a propagation rule is invalid: ", func() {
+			instance.Spec.Propagation = &v2vv1.PropagationSpec{Labels: []v2vv1.PropagationRule{{Key: "cost-center"}}}
+			var reason string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				reason = *obj.(*v2vv1.VirtualMachineImport).Status.Conditions[0].Reason
+				return nil
 			}
 
 			validated, err := reconciler.validate(instance, mock)
 
-			Expect(err).To(Not(BeNil()))
-			Expect(validated).To(Equal(true))
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.InvalidPropagation)))
 		})
 
-		It("should fail with error conditions: ", func() {
-			message := "message"
-			validate = func() ([]v2vv1.VirtualMachineImportCondition, error) {
-				conditions := []v2vv1.VirtualMachineImportCondition{}
-				conditions = append(conditions, v2vv1.VirtualMachineImportCondition{
-					Status:  corev1.ConditionFalse,
-					Message: &message,
-				})
-				return conditions, nil
+		It("should block the import when a patch is invalid: ", func() {
+			instance.Spec.VMPatches = []v2vv1.ResourcePatch{{Type: "Merge", Patch: &[]string{"{}"}[0]}}
+			var reason string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				reason = *obj.(*v2vv1.VirtualMachineImport).Status.Conditions[0].Reason
+				return nil
 			}
 
 			validated, err := reconciler.validate(instance, mock)
 
 			Expect(err).To(BeNil())
-			Expect(validated).To(Equal(false))
+			Expect(validated).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.InvalidPatch)))
 		})
 
-		It("should fail with vm status: ", func() {
-			getVMStatus = func() (provider.VMStatus, error) {
-				return "", fmt.Errorf("Not found")
+		It("should block the import when the config map of a patch doesn't exist: ", func() {
+			instance.Spec.DataVolumePatches = []v2vv1.ResourcePatch{{ConfigMap: &v2vv1.ConfigMapPatchSource{Name: "site-patches", Key: "dv"}}}
+			var reason, message string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				cond := obj.(*v2vv1.VirtualMachineImport).Status.Conditions[0]
+				reason, message = *cond.Reason, *cond.Message
+				return nil
 			}
 
 			validated, err := reconciler.validate(instance, mock)
 
-			Expect(err).To(Not(BeNil()))
-			Expect(validated).To(Equal(true))
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.InvalidPatch)))
+			Expect(message).To(ContainSubstring("site-patches"))
 		})
 
-		It("should fail to store vm status: ", func() {
-			statusPatch = func(ctx context.Context, obj runtime.Object, patch client.Patch) error {
-				if obj.(*v2vv1.VirtualMachineImport).Annotations[sourceVMInitialState] == string(provider.VMStatusDown) {
-					return fmt.Errorf("Not modified")
+		It("should requeue the validation when the config map of a patch can't be read: ", func() {
+			instance.Spec.VMPatches = []v2vv1.ResourcePatch{{ConfigMap: &v2vv1.ConfigMapPatchSource{Name: "site-patches", Key: "vm"}}}
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				if _, ok := obj.(*corev1.ConfigMap); ok && key.Name == "site-patches" {
+					return fmt.Errorf("connection refused")
+				}
+				if _, ok := obj.(*corev1.ConfigMap); ok {
+					return errors.NewNotFound(schema.GroupResource{}, key.Name)
 				}
 				return nil
 			}
 
 			validated, err := reconciler.validate(instance, mock)
 
-			Expect(err).To(Not(BeNil()))
-			Expect(validated).To(Equal(true))
+			Expect(err).To(HaveOccurred())
+			Expect(validated).To(BeFalse())
 		})
-	})
 
-	Describe("createVM step", func() {
-		var (
-			mapper *mockMapper
-		)
-		BeforeEach(func() {
-			findTemplate = func() (*oapiv1.Template, error) {
-				return &oapiv1.Template{}, nil
-			}
-			processTemplate = func(template *oapiv1.Template, name *string, namespace string) (*kubevirtv1.VirtualMachine, error) {
-				return &kubevirtv1.VirtualMachine{}, nil
+		It("should block the import when the source VM is deleted without boot verification: ", func() {
+			instance.Spec.SourceVMPolicy = v2vv1.SourceVMPolicyDelete
+			var reason string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				reason = *obj.(*v2vv1.VirtualMachineImport).Status.Conditions[0].Reason
+				return nil
 			}
-			mapper = &mockMapper{}
-			targetName := "test"
-			instance.Spec.TargetVMName = &targetName
+
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.InvalidSourceVMPolicy)))
 		})
 
-		It("should fail to find a template: ", func() {
-			templateError := fmt.Errorf("Not found")
-			findTemplate = func() (*oapiv1.Template, error) {
-				return nil, templateError
-			}
-			getKvConfig = func() kvConfig.KubeVirtConfig {
-				// Feature flag is not present
-				return kvConfig.KubeVirtConfig{}
+		It("should block the import when a preserved MAC address is already used: ", func() {
+			mapMACAddresses = func(importPolicy v2vv1.MACPolicy) []macaddress.NIC {
+				return []macaddress.NIC{{Name: "nic1", MAC: "56:6f:05:0f:00:05", Policy: v2vv1.MACPolicyPreserve}}
 			}
-			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
-				switch vmImport := obj.(type) {
-				case *v2vv1.VirtualMachineImport:
-					vmImport.Annotations = map[string]string{"vmimport.v2v.kubevirt.io/source-vm-initial-state": "down"}
-					vmImport.Spec = v2vv1.VirtualMachineImportSpec{}
+			list = func(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+				if vms, ok := list.(*kubevirtv1.VirtualMachineList); ok {
+					vms.Items = []kubevirtv1.VirtualMachine{vmWithMAC("56:6f:05:0f:00:05")}
 				}
 				return nil
 			}
+			var reason, message string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				condition := obj.(*v2vv1.VirtualMachineImport).Status.Conditions[0]
+				reason, message = *condition.Reason, *condition.Message
+				return nil
+			}
 
-			name, err := reconciler.createVM(mock, instance, mapper)
+			validated, err := reconciler.validate(instance, mock)
 
-			Expect(name).To(Equal(""))
-			Expect(err).To(Not(BeNil()))
-			Expect(err).To(BeEquivalentTo(templateError))
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.MACAddressConflict)))
+			Expect(message).To(ContainSubstring("VM prod/running"))
 		})
 
-		It("should fail to process template: ", func() {
-			templateProcessingError := fmt.Errorf("Failed")
-			processTemplate = func(template *oapiv1.Template, name *string, namespace string) (*kubevirtv1.VirtualMachine, error) {
-				return nil, templateProcessingError
+		It("should block the import when the restored network can't match an interface getting a new MAC address: ", func() {
+			instance.Spec.Customization = &v2vv1.CustomizationSpec{RestoreNetwork: true}
+			mapMACAddresses = func(importPolicy v2vv1.MACPolicy) []macaddress.NIC {
+				return []macaddress.NIC{
+					{Name: "nic1", MAC: "56:6f:05:0f:00:05", Policy: v2vv1.MACPolicyPreserve},
+					{Name: "nic2", MAC: "56:6f:05:0f:00:06", Policy: v2vv1.MACPolicyRegenerate},
+				}
 			}
-			getKvConfig = func() kvConfig.KubeVirtConfig {
-				// Feature flag is not present
-				return kvConfig.KubeVirtConfig{}
+			var reason, message string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				condition := obj.(*v2vv1.VirtualMachineImport).Status.Conditions[0]
+				reason, message = *condition.Reason, *condition.Message
+				return nil
 			}
 
-			name, err := reconciler.createVM(mock, instance, mapper)
+			validated, err := reconciler.validate(instance, mock)
 
-			Expect(name).To(Equal(""))
-			Expect(err).To(Not(BeNil()))
-			Expect(err).To(BeEquivalentTo(templateProcessingError))
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.InvalidCustomization)))
+			Expect(message).To(Equal("NIC nic2 may get a new MAC address with the Regenerate MAC policy, which the restored network configuration can't match"))
 		})
 
-		It("should fail to update condition: ", func() {
-			statusPatch = func(ctx context.Context, obj runtime.Object, patch client.Patch) error {
-				return fmt.Errorf("Not modified")
+		It("should restore the network of interfaces keeping their MAC addresses: ", func() {
+			instance.Spec.Customization = &v2vv1.CustomizationSpec{RestoreNetwork: true}
+			mapMACAddresses = func(importPolicy v2vv1.MACPolicy) []macaddress.NIC {
+				return []macaddress.NIC{{Name: "nic1", MAC: "56:6f:05:0f:00:06", Policy: v2vv1.MACPolicyPreserve}}
 			}
 
-			name, err := reconciler.createVM(mock, instance, mapper)
+			validated, err := reconciler.validate(instance, mock)
 
-			Expect(name).To(Equal(""))
-			Expect(err).To(Not(BeNil()))
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeTrue())
 		})
 
-		It("should fail to create vm: ", func() {
-			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
-				return fmt.Errorf("Not created")
+		It("should block the import when the KubeMacPool range is invalid: ", func() {
+			mapMACAddresses = func(importPolicy v2vv1.MACPolicy) []macaddress.NIC {
+				return []macaddress.NIC{{Name: "nic1", MAC: "56:6f:05:0f:00:05", Policy: v2vv1.MACPolicyPreserve}}
+			}
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj := obj.(type) {
+				case *corev1.ConfigMap:
+					obj.Data = map[string]string{"RANGE_START": "invalid", "RANGE_END": "02:ff:ff:ff:ff:ff"}
+				case *kubevirtv1.VirtualMachine:
+					return errors.NewNotFound(schema.GroupResource{}, "")
+				}
+				return nil
+			}
+			var reason string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				reason = *obj.(*v2vv1.VirtualMachineImport).Status.Conditions[0].Reason
+				return nil
 			}
 
-			name, err := reconciler.createVM(mock, instance, mapper)
+			validated, err := reconciler.validate(instance, mock)
 
-			Expect(name).To(Equal(""))
-			Expect(err).To(Not(BeNil()))
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.InvalidKubeMacPoolRange)))
 		})
 
-		It("should fail to create vm and cleanup: ", func() {
-			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
-				return fmt.Errorf("Not created")
+		It("should not block the import when a MAC address used elsewhere can be regenerated: ", func() {
+			mapMACAddresses = func(importPolicy v2vv1.MACPolicy) []macaddress.NIC {
+				return []macaddress.NIC{{Name: "nic1", MAC: "56:6f:05:0f:00:05", Policy: v2vv1.MACPolicyPreserveIfUnique}}
 			}
-			cleanUp = func() error {
-				return fmt.Errorf("Failed")
-			}
-			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
-				switch obj.(type) {
-				case *v2vv1.VirtualMachineImport:
-					obj.(*v2vv1.VirtualMachineImport).Spec = v2vv1.VirtualMachineImportSpec{}
-					obj.(*v2vv1.VirtualMachineImport).Annotations = map[string]string{sourceVMInitialState: string(provider.VMStatusUp)}
+			list = func(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+				if vms, ok := list.(*kubevirtv1.VirtualMachineList); ok {
+					vms.Items = []kubevirtv1.VirtualMachine{vmWithMAC("56:6f:05:0f:00:05")}
 				}
 				return nil
 			}
 
-			name, err := reconciler.createVM(mock, instance, mapper)
+			validated, err := reconciler.validate(instance, mock)
 
-			Expect(name).To(Equal(""))
-			Expect(err).To(Not(BeNil()))
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeTrue())
 		})
 
-		It("should fail to modify vm name: ", func() {
-			counter := 2
+		It("should block the import when the network of a NIC has no mapping: ", func() {
+			mapUnmappedNetworks = func() []v2vv1.UnmappedNetworkStatus {
+				return []v2vv1.UnmappedNetworkStatus{{NIC: "nic1", Network: "network1/profile1", Action: v2vv1.UnmappedNetworkFail}}
+			}
+			var reason, message string
+			var unmapped []v2vv1.UnmappedNetworkStatus
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				status := obj.(*v2vv1.VirtualMachineImport).Status
+				if len(status.Conditions) > 0 {
+					reason, message = *status.Conditions[0].Reason, *status.Conditions[0].Message
+				} else {
+					unmapped = status.UnmappedNetworks
+				}
+				return nil
+			}
+
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.UnmappedNetwork)))
+			Expect(message).To(Equal("NIC nic1 is connected to network network1/profile1, which has no mapping"))
+			Expect(unmapped).To(HaveLen(1))
+		})
+
+		It("should block the import when the pinned template doesn't exist: ", func() {
+			pinned := "rhel8-custom"
+			instance.Spec.Template = &v2vv1.TemplateSpec{Name: &pinned}
+			findTemplate = func() (*oapiv1.Template, string, error) {
+				return nil, "", errors.NewNotFound(oapiv1.Resource("templates"), pinned)
+			}
+			var reason string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if conds := obj.(*v2vv1.VirtualMachineImport).Status.Conditions; len(conds) > 0 {
+					reason = *conds[0].Reason
+				}
+				return nil
+			}
+
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.PinnedTemplateNotFound)))
+		})
+
+		It("should requeue the validation when the pinned template can't be read: ", func() {
+			pinned := "rhel8-custom"
+			instance.Spec.Template = &v2vv1.TemplateSpec{Name: &pinned}
+			findTemplate = func() (*oapiv1.Template, string, error) {
+				return nil, "", fmt.Errorf("connection refused")
+			}
+
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(HaveOccurred())
+			Expect(validated).To(BeFalse())
+		})
+
+		It("should record the dropped NICs and go on: ", func() {
+			dropped := []v2vv1.UnmappedNetworkStatus{{NIC: "nic1", Network: "network1/profile1", Action: v2vv1.UnmappedNetworkDrop}}
+			mapUnmappedNetworks = func() []v2vv1.UnmappedNetworkStatus {
+				return dropped
+			}
+
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeTrue())
+			Expect(instance.Status.UnmappedNetworks).To(Equal(dropped))
+		})
+
+		It("should block the import when several NICs would be connected to the pod network: ", func() {
+			mapUnmappedNetworks = func() []v2vv1.UnmappedNetworkStatus {
+				return []v2vv1.UnmappedNetworkStatus{
+					{NIC: "nic1", Network: "network1/profile1", Action: v2vv1.UnmappedNetworkPod},
+					{NIC: "nic2", Network: "network2/profile2", Action: v2vv1.UnmappedNetworkPod},
+				}
+			}
+			var reason string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if conditions := obj.(*v2vv1.VirtualMachineImport).Status.Conditions; len(conditions) > 0 {
+					reason = *conditions[0].Reason
+				}
+				return nil
+			}
+
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.UnmappedNetwork)))
+		})
+
+		It("should block the import when an existing network attachment definition doesn't match a network to generate: ", func() {
+			vlan := int32(100)
+			mapUnmappedNetworks = func() []v2vv1.UnmappedNetworkStatus {
+				return []v2vv1.UnmappedNetworkStatus{{NIC: "nic1", Network: "VM Network", Action: v2vv1.UnmappedNetworkGenerate, VLAN: &vlan}}
+			}
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj := obj.(type) {
+				case *netv1.NetworkAttachmentDefinition:
+					obj.Annotations = map[string]string{"vmimport.v2v.kubevirt.io/source-network": "VM Network"}
+					obj.Spec.Config = `{"cniVersion":"0.3.1","name":"vmnetwork-vlan100","type":"bridge","bridge":"br1","vlan":200}`
+				case *kubevirtv1.VirtualMachine, *corev1.ConfigMap:
+					return errors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				return nil
+			}
+			var reason, message string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if conditions := obj.(*v2vv1.VirtualMachineImport).Status.Conditions; len(conditions) > 0 {
+					reason, message = *conditions[0].Reason, *conditions[0].Message
+				}
+				return nil
+			}
+
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.UnmappedNetwork)))
+			Expect(message).To(ContainSubstring("connected to VLAN 200"))
+		})
+
+		It("should block the import when the VLAN of a network to generate is unknown: ", func() {
+			mapUnmappedNetworks = func() []v2vv1.UnmappedNetworkStatus {
+				return []v2vv1.UnmappedNetworkStatus{{NIC: "nic1", Network: "dvportgroup-12", Action: v2vv1.UnmappedNetworkGenerate}}
+			}
+			var reason string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if conditions := obj.(*v2vv1.VirtualMachineImport).Status.Conditions; len(conditions) > 0 {
+					reason = *conditions[0].Reason
+				}
+				return nil
+			}
+
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(BeNil())
+			Expect(validated).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.UnmappedNetwork)))
+		})
+
+		It("should fail to validate: ", func() {
+			validate = func() ([]v2vv1.VirtualMachineImportCondition, error) {
+				return nil, fmt.Errorf("Failed")
+			}
+
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(Not(BeNil()))
+			Expect(validated).To(Equal(true))
+		})
+
+		It("should not validate again: ", func() {
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj.(type) {
+				case *v2vv1.VirtualMachineImport:
+					return fmt.Errorf("Let's make it fail if it tries to validate")
+				case *v2vv1.ResourceMapping:
+					obj.(*v2vv1.ResourceMapping).Spec = v2vv1.ResourceMappingSpec{}
+				case *corev1.Secret:
+					obj.(*corev1.Secret).Data = map[string][]byte{"ovirt": getSecret()}
+				case *kubevirtv1.VirtualMachine:
+					return errors.NewNotFound(schema.GroupResource{}, "")
+				}
+				return nil
+			}
+
+			conditions := []v2vv1.VirtualMachineImportCondition{}
+			conditions = append(conditions, v2vv1.VirtualMachineImportCondition{
+				Status: corev1.ConditionTrue,
+				Type:   v2vv1.Valid,
+			})
+			conditions = append(conditions, v2vv1.VirtualMachineImportCondition{
+				Status: corev1.ConditionTrue,
+				Type:   v2vv1.MappingRulesVerified,
+			})
+			instance.Status.Conditions = conditions
+
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(BeNil())
+			Expect(validated).To(Equal(true))
+		})
+
+		It("should fail to update conditions: ", func() {
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj.(type) {
+				case *v2vv1.VirtualMachineImport:
+					return fmt.Errorf("Not found")
+				case *v2vv1.ResourceMapping:
+					obj.(*v2vv1.ResourceMapping).Spec = v2vv1.ResourceMappingSpec{}
+				case *corev1.Secret:
+					obj.(*corev1.Secret).Data = map[string][]byte{"ovirt": getSecret()}
+				case *kubevirtv1.VirtualMachine:
+					return errors.NewNotFound(schema.GroupResource{}, "")
+				}
+				return nil
+			}
+
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(Not(BeNil()))
+			Expect(validated).To(Equal(true))
+		})
+
+		It("should fail update vmimport with conditions: ", func() {
+			statusPatch = func(ctx context.Context, obj runtime.Object, patch client.Patch) error {
+				return fmt.Errorf("Not modified")
+			}
+
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(Not(BeNil()))
+			Expect(validated).To(Equal(true))
+		})
+
+		It("should fail with error conditions: ", func() {
+			message := "message"
+			validate = func() ([]v2vv1.VirtualMachineImportCondition, error) {
+				conditions := []v2vv1.VirtualMachineImportCondition{}
+				conditions = append(conditions, v2vv1.VirtualMachineImportCondition{
+					Status:  corev1.ConditionFalse,
+					Message: &message,
+				})
+				return conditions, nil
+			}
+
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(BeNil())
+			Expect(validated).To(Equal(false))
+		})
+
+		It("should fail with vm status: ", func() {
+			getVMStatus = func() (provider.VMStatus, error) {
+				return "", fmt.Errorf("Not found")
+			}
+
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(Not(BeNil()))
+			Expect(validated).To(Equal(true))
+		})
+
+		It("should fail to store vm status: ", func() {
+			statusPatch = func(ctx context.Context, obj runtime.Object, patch client.Patch) error {
+				if obj.(*v2vv1.VirtualMachineImport).Annotations[sourceVMInitialState] == string(provider.VMStatusDown) {
+					return fmt.Errorf("Not modified")
+				}
+				return nil
+			}
+
+			validated, err := reconciler.validate(instance, mock)
+
+			Expect(err).To(Not(BeNil()))
+			Expect(validated).To(Equal(true))
+		})
+	})
+
+	Describe("generateNetworks step", func() {
+		var vlan100, vlan200 int32 = 100, 200
+
+		BeforeEach(func() {
+			instance.Name = "test"
+			instance.Namespace = "default"
+			instance.Status.UnmappedNetworks = []v2vv1.UnmappedNetworkStatus{
+				{NIC: "nic1", Network: "prod/prod", Action: v2vv1.UnmappedNetworkGenerate, VLAN: &vlan100},
+				{NIC: "nic2", Network: "prod/prod", Action: v2vv1.UnmappedNetworkGenerate, VLAN: &vlan100},
+				{NIC: "nic3", Network: "test/test", Action: v2vv1.UnmappedNetworkGenerate, VLAN: &vlan200},
+			}
+		})
+
+		It("should create a network attachment definition per network and record the mappings to them: ", func() {
+			var created []string
+			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+				nad := obj.(*netv1.NetworkAttachmentDefinition)
+				created = append(created, nad.Namespace+"/"+nad.Name)
+				return nil
+			}
+			var stored []v2vv1.NetworkResourceMappingItem
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				stored = obj.(*v2vv1.VirtualMachineImport).Status.GeneratedNetworkMappings
+				return nil
+			}
+
+			generated, err := reconciler.generateNetworks(instance)
+
+			Expect(err).To(BeNil())
+			Expect(generated).To(BeTrue())
+			Expect(created).To(ConsistOf("default/prod-prod-vlan100", "default/test-test-vlan200"))
+			Expect(stored).To(HaveLen(2))
+			Expect(*stored[0].Source.Name).To(Equal("prod/prod"))
+			Expect(stored[0].Target.Name).To(Equal("prod-prod-vlan100"))
+			Expect(instance.Status.GeneratedNetworkMappings).To(Equal(stored))
+		})
+
+		It("should not generate the networks again: ", func() {
+			instance.Status.GeneratedNetworkMappings = []v2vv1.NetworkResourceMappingItem{{Target: v2vv1.ObjectIdentifier{Name: "prod-prod"}}}
+			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+				Fail("no network attachment definition should be created")
+				return nil
+			}
+
+			generated, err := reconciler.generateNetworks(instance)
+
+			Expect(err).To(BeNil())
+			Expect(generated).To(BeFalse())
+		})
+
+		It("should add the generated mappings after the mappings of the resource mapping: ", func() {
+			instance.Spec.Source.Ovirt = &v2vv1.VirtualMachineImportOvirtSourceSpec{}
+			name := "prod/prod"
+			instance.Status.GeneratedNetworkMappings = []v2vv1.NetworkResourceMappingItem{
+				{Source: v2vv1.Source{Name: &name}, Target: v2vv1.ObjectIdentifier{Name: "prod-prod"}},
+			}
+			resourceMapping := &v2vv1.ResourceMappingSpec{
+				OvirtMappings: &v2vv1.OvirtMappings{
+					NetworkMappings: &[]v2vv1.NetworkResourceMappingItem{
+						{Source: v2vv1.Source{Name: &name}, Target: v2vv1.ObjectIdentifier{Name: "prod-net"}},
+					},
+				},
+			}
+
+			merged := withGeneratedNetworkMappings(resourceMapping, instance)
+
+			Expect(*merged.OvirtMappings.NetworkMappings).To(HaveLen(1))
+			Expect((*merged.OvirtMappings.NetworkMappings)[0].Target.Name).To(Equal("prod-net"))
+			Expect(*withGeneratedNetworkMappings(nil, instance).OvirtMappings.NetworkMappings).To(HaveLen(1))
+		})
+	})
+
+	Describe("createVM step", func() {
+		var (
+			mapper *mockMapper
+		)
+		BeforeEach(func() {
+			findTemplate = func() (*oapiv1.Template, string, error) {
+				return &oapiv1.Template{}, "", nil
+			}
+			processTemplate = func(template *oapiv1.Template, name *string, namespace string) (*kubevirtv1.VirtualMachine, error) {
+				return &kubevirtv1.VirtualMachine{}, nil
+			}
+			mapper = &mockMapper{}
+			targetName := "test"
+			instance.Spec.TargetVMName = &targetName
+		})
+
+		It("should fail to find a template: ", func() {
+			templateError := fmt.Errorf("Not found")
+			findTemplate = func() (*oapiv1.Template, string, error) {
+				return nil, "", templateError
+			}
+			getKvConfig = func() kvConfig.KubeVirtConfig {
+				// Feature flag is not present
+				return kvConfig.KubeVirtConfig{}
+			}
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch vmImport := obj.(type) {
+				case *v2vv1.VirtualMachineImport:
+					vmImport.Annotations = map[string]string{"vmimport.v2v.kubevirt.io/source-vm-initial-state": "down"}
+					vmImport.Spec = v2vv1.VirtualMachineImportSpec{}
+				}
+				return nil
+			}
+
+			name, err := reconciler.createVM(mock, instance, mapper)
+
+			Expect(name).To(Equal(""))
+			Expect(err).To(Not(BeNil()))
+			Expect(err).To(BeEquivalentTo(templateError))
+		})
+
+		It("should fail to process template: ", func() {
+			templateProcessingError := fmt.Errorf("Failed")
+			processTemplate = func(template *oapiv1.Template, name *string, namespace string) (*kubevirtv1.VirtualMachine, error) {
+				return nil, templateProcessingError
+			}
+			getKvConfig = func() kvConfig.KubeVirtConfig {
+				// Feature flag is not present
+				return kvConfig.KubeVirtConfig{}
+			}
+
+			name, err := reconciler.createVM(mock, instance, mapper)
+
+			Expect(name).To(Equal(""))
+			Expect(err).To(Not(BeNil()))
+			Expect(err).To(BeEquivalentTo(templateProcessingError))
+		})
+
+		It("should fail to update condition: ", func() {
+			statusPatch = func(ctx context.Context, obj runtime.Object, patch client.Patch) error {
+				return fmt.Errorf("Not modified")
+			}
+
+			name, err := reconciler.createVM(mock, instance, mapper)
+
+			Expect(name).To(Equal(""))
+			Expect(err).To(Not(BeNil()))
+		})
+
+		It("should fail to create vm: ", func() {
+			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+				return fmt.Errorf("Not created")
+			}
+
+			name, err := reconciler.createVM(mock, instance, mapper)
+
+			Expect(name).To(Equal(""))
+			Expect(err).To(Not(BeNil()))
+		})
+
+		It("should fail to create vm and cleanup: ", func() {
+			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+				return fmt.Errorf("Not created")
+			}
+			cleanUp = func() error {
+				return fmt.Errorf("Failed")
+			}
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj.(type) {
+				case *v2vv1.VirtualMachineImport:
+					obj.(*v2vv1.VirtualMachineImport).Spec = v2vv1.VirtualMachineImportSpec{}
+					obj.(*v2vv1.VirtualMachineImport).Annotations = map[string]string{sourceVMInitialState: string(provider.VMStatusUp)}
+				}
+				return nil
+			}
+
+			name, err := reconciler.createVM(mock, instance, mapper)
+
+			Expect(name).To(Equal(""))
+			Expect(err).To(Not(BeNil()))
+		})
+
+		It("should fail to modify vm name: ", func() {
+			counter := 2
 			statusPatch = func(ctx context.Context, obj runtime.Object, patch client.Patch) error {
 				switch obj.(type) {
 				case *v2vv1.VirtualMachineImport:
@@ -621,36 +1251,305 @@ var _ = Describe("Reconcile steps", func() {
 				return nil
 			}
 
-			name, err := reconciler.createVM(mock, instance, mapper)
+			name, err := reconciler.createVM(mock, instance, mapper)
+
+			Expect(name).To(Equal(""))
+			Expect(err).To(Not(BeNil()))
+		})
+
+		It("should fail to modify progress: ", func() {
+			counter := 2
+			statusPatch = func(ctx context.Context, obj runtime.Object, patch client.Patch) error {
+				switch obj.(type) {
+				case *v2vv1.VirtualMachineImport:
+					counter--
+					if counter == 0 {
+						return fmt.Errorf("Not modified")
+					}
+				}
+				return nil
+			}
+
+			name, err := reconciler.createVM(mock, instance, mapper)
+
+			Expect(name).To(Equal(""))
+			Expect(err).To(Not(BeNil()))
+		})
+
+		It("should succeed to create vm: ", func() {
+			name, err := reconciler.createVM(mock, instance, mapper)
+
+			Expect(name).To(Equal(""))
+			Expect(err).To(BeNil())
+		})
+
+		It("should record the template of the vm: ", func() {
+			findTemplate = func() (*oapiv1.Template, string, error) {
+				template := &oapiv1.Template{}
+				template.Name = "rhel8-server-medium"
+				template.Namespace = "openshift"
+				return template, "Pinned by the import spec", nil
+			}
+
+			_, err := reconciler.createVM(mock, instance, mapper)
+
+			Expect(err).To(BeNil())
+			Expect(instance.Status.Template).To(Equal(&v2vv1.TemplateStatus{
+				Name:      "rhel8-server-medium",
+				Namespace: "openshift",
+				Reason:    "Pinned by the import spec",
+			}))
+		})
+
+		It("should record the vm was created without a template: ", func() {
+			findTemplate = func() (*oapiv1.Template, string, error) {
+				return nil, "", fmt.Errorf("template not found for rhel8 OS")
+			}
+
+			_, err := reconciler.createVM(mock, instance, mapper)
+
+			Expect(err).To(BeNil())
+			Expect(instance.Status.Template.Name).To(BeEmpty())
+			Expect(instance.Status.Template.Reason).To(ContainSubstring("template not found for rhel8 OS"))
+		})
+
+		It("should not create an empty vm in place of the pinned template: ", func() {
+			pinned := "rhel8-custom"
+			instance.Spec.Template = &v2vv1.TemplateSpec{Name: &pinned}
+			findTemplate = func() (*oapiv1.Template, string, error) {
+				return nil, "", errors.NewNotFound(oapiv1.Resource("templates"), pinned)
+			}
+
+			name, err := reconciler.createVM(mock, instance, mapper)
+
+			Expect(name).To(BeEmpty())
+			Expect(err).To(HaveOccurred())
+			Expect(instance.Status.Template).To(BeNil())
+		})
+
+		It("should requeue when the pinned template can't be read: ", func() {
+			pinned := "rhel8-custom"
+			instance.Spec.Template = &v2vv1.TemplateSpec{Name: &pinned}
+			templateError := fmt.Errorf("connection refused")
+			findTemplate = func() (*oapiv1.Template, string, error) {
+				return nil, "", templateError
+			}
+			var failed bool
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if vmImport, ok := obj.(*v2vv1.VirtualMachineImport); ok {
+					for _, cond := range vmImport.Status.Conditions {
+						if cond.Type == v2vv1.Succeeded {
+							failed = true
+						}
+					}
+				}
+				return nil
+			}
+
+			_, err := reconciler.createVM(mock, instance, mapper)
+
+			Expect(err).To(Equal(templateError))
+			Expect(failed).To(BeFalse())
+			Expect(instance.Status.Template).To(BeNil())
+		})
+
+		It("should not create an empty vm when the pinned template can't be processed: ", func() {
+			pinned := "rhel8-custom"
+			instance.Spec.Template = &v2vv1.TemplateSpec{Name: &pinned}
+			processTemplate = func(template *oapiv1.Template, name *string, namespace string) (*kubevirtv1.VirtualMachine, error) {
+				return nil, fmt.Errorf("invalid parameters")
+			}
+
+			_, err := reconciler.createVM(mock, instance, mapper)
+
+			Expect(err).To(HaveOccurred())
+			Expect(instance.Status.Template).To(BeNil())
+		})
+
+		It("should regenerate the MAC addresses the VM doesn't keep: ", func() {
+			processTemplate = func(template *oapiv1.Template, name *string, namespace string) (*kubevirtv1.VirtualMachine, error) {
+				vm := vmWithMAC("56:6f:05:0f:00:05")
+				vm.Spec.Template.Spec.Domain.Devices.Interfaces = append(vm.Spec.Template.Spec.Domain.Devices.Interfaces,
+					kubevirtv1.Interface{Name: "nic2", MacAddress: "56:6f:05:0f:00:06"},
+					kubevirtv1.Interface{Name: "nic3", MacAddress: "56:6f:05:0f:00:07"},
+				)
+				return &vm, nil
+			}
+			mapMACAddresses = func(importPolicy v2vv1.MACPolicy) []macaddress.NIC {
+				return []macaddress.NIC{
+					{Name: "nic1", MAC: "56:6f:05:0f:00:05", Policy: v2vv1.MACPolicyPreserveIfUnique},
+					{Name: "nic2", MAC: "56:6f:05:0f:00:06", Policy: v2vv1.MACPolicyRegenerate},
+					{Name: "nic3", MAC: "56:6f:05:0f:00:07", Policy: v2vv1.MACPolicyPreserveIfUnique},
+				}
+			}
+			list = func(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+				if vms, ok := list.(*kubevirtv1.VirtualMachineList); ok {
+					vms.Items = []kubevirtv1.VirtualMachine{vmWithMAC("56:6f:05:0f:00:05")}
+				}
+				return nil
+			}
+			var interfaces []kubevirtv1.Interface
+			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+				if vm, ok := obj.(*kubevirtv1.VirtualMachine); ok {
+					interfaces = vm.Spec.Template.Spec.Domain.Devices.Interfaces
+				}
+				return nil
+			}
+
+			_, err := reconciler.createVM(mock, instance, mapper)
+
+			Expect(err).To(BeNil())
+			Expect(interfaces).To(ConsistOf(
+				kubevirtv1.Interface{Name: "nic1"},
+				kubevirtv1.Interface{Name: "nic2"},
+				kubevirtv1.Interface{Name: "nic3", MacAddress: "56:6f:05:0f:00:07"},
+			))
+		})
+
+		It("should propagate the labels and annotations to the vm and its template: ", func() {
+			processTemplate = func(template *oapiv1.Template, name *string, namespace string) (*kubevirtv1.VirtualMachine, error) {
+				vm := vmWithMAC("56:6f:05:0f:00:05")
+				return &vm, nil
+			}
+			team, backup := "team", "backup"
+			instance.Labels = map[string]string{team: "db"}
+			instance.Spec.Propagation = &v2vv1.PropagationSpec{
+				Labels: []v2vv1.PropagationRule{
+					{Key: "team", FromImportLabel: &team},
+					{Key: "backup", FromSource: &v2vv1.SourceMetadataSelector{Tag: &backup}, Targets: []v2vv1.PropagationTarget{v2vv1.PropagationTargetVirtualMachineInstance}},
+				},
+			}
+			getSourceMetadata = func() (*propagation.SourceMetadata, error) {
+				return &propagation.SourceMetadata{Tags: []string{"backup"}}, nil
+			}
+			var created *kubevirtv1.VirtualMachine
+			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+				if vm, ok := obj.(*kubevirtv1.VirtualMachine); ok {
+					created = vm
+				}
+				return nil
+			}
+
+			_, err := reconciler.createVM(mock, instance, mapper)
+
+			Expect(err).To(BeNil())
+			Expect(created.Labels).To(HaveKeyWithValue("team", "db"))
+			Expect(created.Labels).ToNot(HaveKey("backup"))
+			Expect(created.Spec.Template.ObjectMeta.Labels).To(HaveKeyWithValue("team", "db"))
+			Expect(created.Spec.Template.ObjectMeta.Labels).To(HaveKeyWithValue("backup", "true"))
+		})
+
+		It("should track the vm created in the target namespace with labels: ", func() {
+			var templateNamespace string
+			processTemplate = func(template *oapiv1.Template, name *string, namespace string) (*kubevirtv1.VirtualMachine, error) {
+				templateNamespace = namespace
+				vm := vmWithMAC("56:6f:05:0f:00:05")
+				vm.Namespace = namespace
+				return &vm, nil
+			}
+			instance.Name = "test"
+			instance.Namespace = "migration"
+			targetNamespace := "apps"
+			instance.Spec.TargetNamespace = &targetNamespace
+			var created *kubevirtv1.VirtualMachine
+			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+				if vm, ok := obj.(*kubevirtv1.VirtualMachine); ok {
+					created = vm
+				}
+				return nil
+			}
+
+			_, err := reconciler.createVM(mock, instance, mapper)
+
+			Expect(err).To(BeNil())
+			Expect(templateNamespace).To(Equal("apps"))
+			Expect(created.OwnerReferences).To(BeEmpty())
+			Expect(created.Labels).To(HaveKeyWithValue(utils.ImportNamespaceLabel, "migration"))
+			Expect(created.Labels).To(HaveKeyWithValue(utils.ImportNameLabel, "test"))
+			Expect(created.Annotations).To(HaveKeyWithValue(utils.ImportAnnotation, "migration/test"))
+		})
+
+		It("should apply the vm patches before creating the vm: ", func() {
+			processTemplate = func(template *oapiv1.Template, name *string, namespace string) (*kubevirtv1.VirtualMachine, error) {
+				vm := vmWithMAC("56:6f:05:0f:00:05")
+				return &vm, nil
+			}
+			strategicMerge := `{"spec": {"template": {"spec": {"nodeSelector": {"site": "east"}}}}}`
+			instance.Spec.VMPatches = []v2vv1.ResourcePatch{
+				{Patch: &strategicMerge},
+				{Type: v2vv1.PatchTypeJSON, ConfigMap: &v2vv1.ConfigMapPatchSource{Name: "site-patches", Key: "eviction"}},
+			}
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				if configMap, ok := obj.(*corev1.ConfigMap); ok {
+					configMap.Data = map[string]string{
+						"eviction": "- op: add\n  path: /spec/template/spec/evictionStrategy\n  value: LiveMigrate\n",
+					}
+				}
+				return nil
+			}
+			var created *kubevirtv1.VirtualMachine
+			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+				if vm, ok := obj.(*kubevirtv1.VirtualMachine); ok {
+					created = vm
+				}
+				return nil
+			}
+
+			_, err := reconciler.createVM(mock, instance, mapper)
 
-			Expect(name).To(Equal(""))
-			Expect(err).To(Not(BeNil()))
+			Expect(err).To(BeNil())
+			Expect(created.Spec.Template.Spec.NodeSelector).To(HaveKeyWithValue("site", "east"))
+			Expect(*created.Spec.Template.Spec.EvictionStrategy).To(Equal(kubevirtv1.EvictionStrategyLiveMigrate))
+			Expect(created.Spec.Template.Spec.Domain.Devices.Interfaces).To(HaveLen(1))
+			Expect(created.OwnerReferences).To(HaveLen(1))
 		})
 
-		It("should fail to modify progress: ", func() {
-			counter := 2
-			statusPatch = func(ctx context.Context, obj runtime.Object, patch client.Patch) error {
-				switch obj.(type) {
-				case *v2vv1.VirtualMachineImport:
-					counter--
-					if counter == 0 {
-						return fmt.Errorf("Not modified")
+		It("should fail the import when a vm patch can't be applied: ", func() {
+			patch := `[{"op": "replace", "path": "/spec/missing/field", "value": 1}]`
+			instance.Spec.VMPatches = []v2vv1.ResourcePatch{{Type: v2vv1.PatchTypeJSON, Patch: &patch}}
+			var reason string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if vmImport, ok := obj.(*v2vv1.VirtualMachineImport); ok {
+					if succeeded := conditions.FindConditionOfType(vmImport.Status.Conditions, v2vv1.Succeeded); succeeded != nil {
+						reason = *succeeded.Reason
 					}
 				}
 				return nil
 			}
+			created := false
+			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+				created = true
+				return nil
+			}
 
-			name, err := reconciler.createVM(mock, instance, mapper)
+			_, err := reconciler.createVM(mock, instance, mapper)
 
-			Expect(name).To(Equal(""))
-			Expect(err).To(Not(BeNil()))
+			Expect(err).ToNot(BeNil())
+			Expect(reason).To(Equal(string(v2vv1.VMCreationFailed)))
+			Expect(created).To(BeFalse())
 		})
 
-		It("should succeed to create vm: ", func() {
-			name, err := reconciler.createVM(mock, instance, mapper)
+		It("should requeue when the config map of a vm patch can't be read: ", func() {
+			instance.Spec.VMPatches = []v2vv1.ResourcePatch{{ConfigMap: &v2vv1.ConfigMapPatchSource{Name: "site-patches", Key: "vm"}}}
+			patchError := fmt.Errorf("connection refused")
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				if _, ok := obj.(*corev1.ConfigMap); ok {
+					return patchError
+				}
+				return nil
+			}
+			failed := false
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if vmImport, ok := obj.(*v2vv1.VirtualMachineImport); ok {
+					failed = failed || conditions.FindConditionOfType(vmImport.Status.Conditions, v2vv1.Succeeded) != nil
+				}
+				return nil
+			}
 
-			Expect(name).To(Equal(""))
-			Expect(err).To(BeNil())
+			_, err := reconciler.createVM(mock, instance, mapper)
+
+			Expect(err).To(Equal(patchError))
+			Expect(failed).To(BeFalse())
 		})
 
 		It("should succeed to create vm with description: ", func() {
@@ -835,6 +1734,281 @@ var _ = Describe("Reconcile steps", func() {
 
 			Expect(err).To(BeNil())
 		})
+
+		It("should detach the customization data after start: ", func() {
+			instance.Spec.Customization = &v2vv1.CustomizationSpec{RemoveAfterFirstBoot: true}
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj.(type) {
+				case *kubevirtv1.VirtualMachineInstance:
+					obj.(*kubevirtv1.VirtualMachineInstance).Status.Phase = kubevirtv1.Running
+				case *kubevirtv1.VirtualMachine:
+					obj.(*kubevirtv1.VirtualMachine).Spec.Template = &kubevirtv1.VirtualMachineInstanceTemplateSpec{
+						Spec: kubevirtv1.VirtualMachineInstanceSpec{
+							Volumes: []kubevirtv1.Volume{{Name: customization.VolumeName}},
+						},
+					}
+				}
+				return nil
+			}
+			var updated *kubevirtv1.VirtualMachine
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if vm, ok := obj.(*kubevirtv1.VirtualMachine); ok {
+					updated = vm
+				}
+				return nil
+			}
+
+			_, err := reconciler.startVM(mock, instance, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(updated).ToNot(BeNil())
+			Expect(updated.Spec.Template.Spec.Volumes).To(BeEmpty())
+		})
+	})
+
+	Describe("boot verification", func() {
+		var (
+			vmi *kubevirtv1.VirtualMachineInstance
+		)
+
+		BeforeEach(func() {
+			shouldStart := true
+			instance.Spec.StartVM = &shouldStart
+			instance.Spec.BootVerification = &v2vv1.BootVerificationSpec{GuestAgent: true}
+			instance.Status.Conditions = []v2vv1.VirtualMachineImportCondition{
+				conditions.NewSucceededCondition(string(v2vv1.VirtualMachineReady), "", corev1.ConditionTrue),
+			}
+			vmi = &kubevirtv1.VirtualMachineInstance{}
+			vmi.Status.Phase = kubevirtv1.Running
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj.(type) {
+				case *kubevirtv1.VirtualMachineInstance:
+					vmi.DeepCopyInto(obj.(*kubevirtv1.VirtualMachineInstance))
+				case *kubevirtv1.VirtualMachine:
+					obj.(*kubevirtv1.VirtualMachine).Spec.Template = &kubevirtv1.VirtualMachineInstanceTemplateSpec{}
+				}
+				return nil
+			}
+		})
+
+		It("should wait for the guest agent to connect: ", func() {
+			var stored *v2vv1.VirtualMachineImport
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				stored = obj.(*v2vv1.VirtualMachineImport)
+				return nil
+			}
+
+			requeue, err := reconciler.startVM(mock, instance, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(requeue).To(BeTrue())
+			Expect(instance.Status.BootVerification.StartTime).ToNot(BeNil())
+			cond := conditions.FindConditionOfType(stored.Status.Conditions, v2vv1.Processing)
+			Expect(*cond.Reason).To(Equal(string(v2vv1.VerifyingBoot)))
+			Expect(*cond.Message).To(ContainSubstring("guest agent"))
+		})
+
+		It("should wait for the VM to become ready: ", func() {
+			instance.Spec.BootVerification = &v2vv1.BootVerificationSpec{
+				ReadinessProbe: &v2vv1.BootReadinessProbe{TCPSocket: &v2vv1.TCPSocketProbe{Port: 22}},
+			}
+
+			requeue, err := reconciler.startVM(mock, instance, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(requeue).To(BeTrue())
+			Expect(pendingBootCheck(instance.Spec.BootVerification, vmi)).To(Equal("the VM to become ready"))
+		})
+
+		It("should succeed once the checks passed: ", func() {
+			vmi.Status.Conditions = []kubevirtv1.VirtualMachineInstanceCondition{
+				{Type: kubevirtv1.VirtualMachineInstanceAgentConnected, Status: corev1.ConditionTrue},
+			}
+			succeeded := false
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if vmImport, ok := obj.(*v2vv1.VirtualMachineImport); ok && conditions.HasSucceededConditionOfReason(vmImport.Status.Conditions, v2vv1.VirtualMachineRunning) {
+					succeeded = true
+				}
+				return nil
+			}
+
+			requeue, err := reconciler.startVM(mock, instance, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(requeue).To(BeFalse())
+			Expect(succeeded).To(BeTrue())
+		})
+
+		It("should fail and keep the VM once the timeout expired: ", func() {
+			startTime := v1.NewTime(time.Now().Add(-time.Hour))
+			instance.Status.BootVerification = &v2vv1.BootVerificationStatus{StartTime: &startTime}
+			var failed *v2vv1.VirtualMachineImport
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if vmImport, ok := obj.(*v2vv1.VirtualMachineImport); ok && conditions.HasSucceededConditionOfReason(vmImport.Status.Conditions, v2vv1.BootVerificationFailed) {
+					failed = vmImport
+				}
+				return nil
+			}
+			deleted := false
+			deleteObject = func(ctx context.Context, obj runtime.Object) error {
+				deleted = true
+				return nil
+			}
+
+			requeue, err := reconciler.startVM(mock, instance, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(requeue).To(BeFalse())
+			Expect(failed).ToNot(BeNil())
+			Expect(instance.Status.BootVerification.Failure).To(ContainSubstring("timed out"))
+			Expect(deleted).To(BeFalse())
+		})
+
+		It("should fail once the VM stopped: ", func() {
+			vmi.Status.Phase = kubevirtv1.Failed
+
+			requeue, err := reconciler.startVM(mock, instance, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(requeue).To(BeFalse())
+			Expect(instance.Status.BootVerification.Failure).To(ContainSubstring("Failed phase"))
+		})
+
+		It("should stop the VM before rolling back a failed boot: ", func() {
+			instance.Spec.BootVerification.RollbackOnFailure = true
+			vmi.Status.Phase = kubevirtv1.Failed
+			var steps []string
+			statusPatch = func(ctx context.Context, obj runtime.Object, patch client.Patch) error {
+				if _, ok := obj.(*kubevirtv1.VirtualMachine); ok {
+					data, _ := patch.Data(obj)
+					steps = append(steps, string(data))
+				}
+				return nil
+			}
+			startSourceVM = func() error {
+				steps = append(steps, "start source VM")
+				return nil
+			}
+			failed := false
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if vmImport, ok := obj.(*v2vv1.VirtualMachineImport); ok && conditions.HasSucceededConditionOfReason(vmImport.Status.Conditions, v2vv1.BootVerificationFailed) {
+					failed = true
+				}
+				return nil
+			}
+
+			requeue, err := reconciler.startVM(mock, instance, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(requeue).To(BeTrue())
+			Expect(failed).To(BeFalse())
+			Expect(steps).To(ConsistOf(MatchJSON(`{"spec":{"running":false}}`)))
+			Expect(instance.Status.BootVerification.Failure).To(ContainSubstring("Failed phase"))
+		})
+
+		It("should restore the source VM once the VM of the failed boot stopped: ", func() {
+			instance.Spec.BootVerification.RollbackOnFailure = true
+			instance.Status.BootVerification = &v2vv1.BootVerificationStatus{Failure: "Boot verification failed: the VM stopped in the Failed phase"}
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj.(type) {
+				case *kubevirtv1.VirtualMachineInstance:
+					return errors.NewNotFound(schema.GroupResource{}, key.Name)
+				case *kubevirtv1.VirtualMachine:
+					running := false
+					obj.(*kubevirtv1.VirtualMachine).Spec.Running = &running
+				case *v2vv1.VirtualMachineImport:
+					obj.(*v2vv1.VirtualMachineImport).Annotations = map[string]string{sourceVMInitialState: string(provider.VMStatusUp)}
+				}
+				return nil
+			}
+			started := false
+			startSourceVM = func() error {
+				started = true
+				return nil
+			}
+			var failed *v2vv1.VirtualMachineImport
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if vmImport, ok := obj.(*v2vv1.VirtualMachineImport); ok && conditions.HasSucceededConditionOfReason(vmImport.Status.Conditions, v2vv1.BootVerificationFailed) {
+					failed = vmImport
+				}
+				return nil
+			}
+
+			requeue, err := reconciler.startVM(mock, instance, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(requeue).To(BeFalse())
+			Expect(failed).ToNot(BeNil())
+			Expect(started).To(BeTrue())
+		})
+
+		It("should set the readiness probe on the VM: ", func() {
+			instance.Spec.BootVerification = &v2vv1.BootVerificationSpec{
+				ReadinessProbe: &v2vv1.BootReadinessProbe{HTTPGet: &v2vv1.HTTPGetProbe{Path: "/healthz", Port: 8080}},
+			}
+
+			probe := readinessProbe(instance)
+
+			Expect(probe.HTTPGet.Path).To(Equal("/healthz"))
+			Expect(probe.HTTPGet.Port.IntValue()).To(Equal(8080))
+			Expect(probe.TCPSocket).To(BeNil())
+		})
+
+		It("should patch the guest agent exec probe on the VM: ", func() {
+			instance.Spec.BootVerification = &v2vv1.BootVerificationSpec{
+				ReadinessProbe: &v2vv1.BootReadinessProbe{Exec: &v2vv1.ExecProbe{Command: []string{"systemctl", "is-system-running"}}, PeriodSeconds: 5},
+			}
+			var patched []byte
+			statusPatch = func(ctx context.Context, obj runtime.Object, patch client.Patch) error {
+				patched, _ = patch.Data(obj)
+				return nil
+			}
+
+			err := reconciler.updateToRunning(vmName, readinessProbe(instance))
+
+			Expect(err).To(BeNil())
+			Expect(patched).To(MatchJSON(`{"spec":{"running":true,"template":{"spec":{"readinessProbe":{"exec":{"command":["systemctl","is-system-running"]},"periodSeconds":5}}}}}`))
+		})
+
+		It("should remove the readiness probe from the VM once verified: ", func() {
+			instance.Spec.BootVerification = &v2vv1.BootVerificationSpec{
+				ReadinessProbe: &v2vv1.BootReadinessProbe{TCPSocket: &v2vv1.TCPSocketProbe{Port: 22}},
+			}
+			vmi.Status.Conditions = []kubevirtv1.VirtualMachineInstanceCondition{
+				{Type: kubevirtv1.VirtualMachineInstanceReady, Status: corev1.ConditionTrue},
+			}
+			var patches []string
+			statusPatch = func(ctx context.Context, obj runtime.Object, patch client.Patch) error {
+				if _, ok := obj.(*kubevirtv1.VirtualMachine); ok {
+					data, _ := patch.Data(obj)
+					patches = append(patches, string(data))
+				}
+				return nil
+			}
+
+			requeue, err := reconciler.startVM(mock, instance, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(requeue).To(BeFalse())
+			Expect(patches).To(ConsistOf(MatchJSON(`{"spec":{"template":{"spec":{"readinessProbe":null}}}}`)))
+		})
+
+		table.DescribeTable("should validate the boot verification: ", func(startVM bool, probe *v2vv1.BootReadinessProbe, expected string) {
+			instance.Spec.StartVM = &startVM
+			instance.Spec.BootVerification = &v2vv1.BootVerificationSpec{ReadinessProbe: probe}
+
+			if expected == "" {
+				Expect(validateBootVerification(instance)).To(BeEmpty())
+			} else {
+				Expect(validateBootVerification(instance)).To(ContainSubstring(expected))
+			}
+		},
+			table.Entry("valid", true, &v2vv1.BootReadinessProbe{TCPSocket: &v2vv1.TCPSocketProbe{Port: 22}}, ""),
+			table.Entry("VM not started", false, nil, "requires startVm"),
+			table.Entry("no check", true, &v2vv1.BootReadinessProbe{}, "exactly one"),
+			table.Entry("two checks", true, &v2vv1.BootReadinessProbe{TCPSocket: &v2vv1.TCPSocketProbe{Port: 22}, Exec: &v2vv1.ExecProbe{Command: []string{"true"}}}, "exactly one"),
+			table.Entry("exec without command", true, &v2vv1.BootReadinessProbe{Exec: &v2vv1.ExecProbe{}}, "no command"),
+		)
 	})
 
 	Describe("createDataVolumes step", func() {
@@ -854,7 +2028,7 @@ var _ = Describe("Reconcile steps", func() {
 			statusPatch = func(ctx context.Context, obj runtime.Object, patch client.Patch) error {
 				return fmt.Errorf("Not modified")
 			}
-			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName)
+			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName, nil)
 
 			Expect(err).To(Not(BeNil()))
 		})
@@ -869,7 +2043,7 @@ var _ = Describe("Reconcile steps", func() {
 				return nil
 			}
 
-			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName)
+			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName, nil)
 
 			Expect(err).To(Not(BeNil()))
 		})
@@ -887,7 +2061,7 @@ var _ = Describe("Reconcile steps", func() {
 				return nil
 			}
 			dv = cdiv1.DataVolume{}
-			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName)
+			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName, nil)
 
 			Expect(err).To(Not(BeNil()))
 		})
@@ -901,7 +2075,7 @@ var _ = Describe("Reconcile steps", func() {
 			}
 			dv = cdiv1.DataVolume{}
 
-			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName)
+			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName, nil)
 
 			Expect(err).To(Not(BeNil()))
 		})
@@ -917,7 +2091,7 @@ var _ = Describe("Reconcile steps", func() {
 			}
 			dv = cdiv1.DataVolume{}
 
-			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName)
+			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName, nil)
 
 			Expect(err).To(Not(BeNil()))
 		})
@@ -933,7 +2107,7 @@ var _ = Describe("Reconcile steps", func() {
 			}
 			dv = cdiv1.DataVolume{}
 
-			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName)
+			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName, nil)
 
 			Expect(err).To(Not(BeNil()))
 		})
@@ -958,11 +2132,163 @@ var _ = Describe("Reconcile steps", func() {
 				return nil
 			}
 
-			dv = cdiv1.DataVolume{}
-
-			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName)
+			dv = cdiv1.DataVolume{}
+
+			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName, nil)
+
+			Expect(err).To(Not(BeNil()))
+		})
+
+		It("should propagate the labels to the data volume: ", func() {
+			costCenter := "cost-center"
+			instance.Annotations = map[string]string{costCenter: "CC 42/7"}
+			instance.Spec.Propagation = &v2vv1.PropagationSpec{
+				Labels: []v2vv1.PropagationRule{{Key: "cost-center", FromImportAnnotation: &costCenter}},
+			}
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj.(type) {
+				case *kubevirtv1.VirtualMachine:
+					obj.(*kubevirtv1.VirtualMachine).Spec.Template = &kubevirtv1.VirtualMachineInstanceTemplateSpec{}
+				}
+				return nil
+			}
+			var labels map[string]string
+			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+				if dv, ok := obj.(*cdiv1.DataVolume); ok {
+					labels = dv.Labels
+				}
+				return nil
+			}
+
+			dv := cdiv1.DataVolume{}
+			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName, nil)
+
+			Expect(err).To(BeNil())
+			Expect(labels).To(HaveKeyWithValue("cost-center", "CC_42_7"))
+		})
+
+		It("should track the data volume created in the target namespace with labels: ", func() {
+			instance.Name = "test"
+			instance.Namespace = "migration"
+			targetNamespace := "apps"
+			instance.Spec.TargetNamespace = &targetNamespace
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj.(type) {
+				case *kubevirtv1.VirtualMachine:
+					obj.(*kubevirtv1.VirtualMachine).Spec.Template = &kubevirtv1.VirtualMachineInstanceTemplateSpec{}
+				}
+				return nil
+			}
+			var created *cdiv1.DataVolume
+			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+				if dv, ok := obj.(*cdiv1.DataVolume); ok {
+					created = dv
+				}
+				return nil
+			}
+
+			dv := cdiv1.DataVolume{}
+			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName, nil)
+
+			Expect(err).To(BeNil())
+			Expect(created.OwnerReferences).To(BeEmpty())
+			Expect(created.Labels).To(HaveKeyWithValue(utils.ImportNamespaceLabel, "migration"))
+			Expect(created.Labels).To(HaveKeyWithValue(utils.ImportNameLabel, "test"))
+		})
+
+		It("should propagate the labels to the pvc of the data volume: ", func() {
+			value := "daily"
+			instance.Spec.Propagation = &v2vv1.PropagationSpec{
+				Labels: []v2vv1.PropagationRule{{Key: "backup", Value: &value, Targets: []v2vv1.PropagationTarget{v2vv1.PropagationTargetPersistentVolumeClaim}}},
+			}
+			var updated *corev1.PersistentVolumeClaim
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if pvc, ok := obj.(*corev1.PersistentVolumeClaim); ok {
+					updated = pvc
+				}
+				return nil
+			}
+			dv.Name = "123"
+
+			err := reconciler.propagateToPVC(mock, instance, &dv)
+
+			Expect(err).To(BeNil())
+			Expect(updated.Labels).To(HaveKeyWithValue("backup", "daily"))
+		})
+
+		It("should not update the pvc of the data volume when it's labeled already: ", func() {
+			value := "daily"
+			instance.Spec.Propagation = &v2vv1.PropagationSpec{
+				Labels: []v2vv1.PropagationRule{{Key: "backup", Value: &value}},
+			}
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				if pvc, ok := obj.(*corev1.PersistentVolumeClaim); ok {
+					pvc.Labels = map[string]string{"backup": "daily"}
+				}
+				return nil
+			}
+			updated := false
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				updated = true
+				return nil
+			}
+
+			err := reconciler.propagateToPVC(mock, instance, &dv)
+
+			Expect(err).To(BeNil())
+			Expect(updated).To(BeFalse())
+		})
+
+		It("should read the source metadata once per import: ", func() {
+			backup := "backup"
+			instance.Spec.Propagation = &v2vv1.PropagationSpec{
+				Labels: []v2vv1.PropagationRule{{Key: "backup", FromSource: &v2vv1.SourceMetadataSelector{Tag: &backup}}},
+			}
+			reads := 0
+			getSourceMetadata = func() (*propagation.SourceMetadata, error) {
+				reads++
+				return &propagation.SourceMetadata{Tags: []string{"backup"}}, nil
+			}
+			var labels map[string]string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if pvc, ok := obj.(*corev1.PersistentVolumeClaim); ok {
+					labels = pvc.Labels
+				}
+				return nil
+			}
+			dv.Name = "123"
+
+			Expect(reconciler.propagateToPVC(mock, instance, &dv)).To(Succeed())
+			Expect(reconciler.propagateToPVC(mock, instance, &dv)).To(Succeed())
+
+			Expect(reads).To(Equal(1))
+			Expect(instance.Status.SourceMetadata.Tags).To(Equal([]string{"backup"}))
+			Expect(labels).To(HaveKeyWithValue("backup", "true"))
+		})
+
+		It("should apply the data volume patches before creating the data volume: ", func() {
+			dvPatches := []patches.Patch{{Type: v2vv1.PatchTypeStrategicMerge, Data: []byte(`{"metadata": {"labels": {"backup": "daily"}}}`)}}
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj.(type) {
+				case *kubevirtv1.VirtualMachine:
+					obj.(*kubevirtv1.VirtualMachine).Spec.Template = &kubevirtv1.VirtualMachineInstanceTemplateSpec{}
+				}
+				return nil
+			}
+			var labels map[string]string
+			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+				if dv, ok := obj.(*cdiv1.DataVolume); ok {
+					labels = dv.Labels
+				}
+				return nil
+			}
+
+			dv := cdiv1.DataVolume{}
+			dv.Name = "123"
+			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName, dvPatches)
 
-			Expect(err).To(Not(BeNil()))
+			Expect(err).To(BeNil())
+			Expect(labels).To(HaveKeyWithValue("backup", "daily"))
 		})
 
 		It("should succeed to create data volumes: ", func() {
@@ -982,7 +2308,7 @@ var _ = Describe("Reconcile steps", func() {
 			}
 
 			dv := cdiv1.DataVolume{}
-			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName)
+			_, err := reconciler.createDataVolume(mock, mapper, instance, &dv, vmName, nil)
 
 			Expect(err).To(BeNil())
 		})
@@ -1025,6 +2351,8 @@ var _ = Describe("Reconcile steps", func() {
 						Controller: &isController,
 					})
 					obj.(*kubevirtv1.VirtualMachine).ObjectMeta.OwnerReferences = refs
+				case *cdiv1.DataVolume:
+					controlledByImport(obj.(*cdiv1.DataVolume), instance)
 				}
 				return nil
 			}
@@ -1080,6 +2408,38 @@ var _ = Describe("Reconcile steps", func() {
 			Expect(err).To(BeNil())
 		})
 
+		It("should not take over a dv created by another import: ", func() {
+			instance.UID = "import-uid"
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj := obj.(type) {
+				case *v2vv1.VirtualMachineImport:
+					obj.Annotations = map[string]string{"vmimport.v2v.kubevirt.io/source-vm-initial-state": "down"}
+				case *cdiv1.DataVolume:
+					obj.Name = "123"
+					obj.Status.Phase = cdiv1.Succeeded
+					controlledByImport(obj, &v2vv1.VirtualMachineImport{ObjectMeta: v1.ObjectMeta{UID: "other-import-uid"}})
+				}
+				return nil
+			}
+			var reason, message string
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if vmImport, ok := obj.(*v2vv1.VirtualMachineImport); ok {
+					if succeeded := conditions.FindConditionOfType(vmImport.Status.Conditions, v2vv1.Succeeded); succeeded != nil {
+						reason, message = *succeeded.Reason, *succeeded.Message
+					}
+				}
+				return nil
+			}
+
+			done, err := reconciler.importDisks(mock, instance, mockMap, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(done).To(BeFalse())
+			Expect(reason).To(Equal(string(v2vv1.DataVolumeCreationFailed)))
+			Expect(message).To(ContainSubstring("wasn't created by the import"))
+			Expect(instance.Status.DataVolumes).To(BeEmpty())
+		})
+
 		It("should not find a dv: ", func() {
 			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
 				switch obj.(type) {
@@ -1098,6 +2458,7 @@ var _ = Describe("Reconcile steps", func() {
 			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
 				switch obj.(type) {
 				case *cdiv1.DataVolume:
+					controlledByImport(obj.(*cdiv1.DataVolume), instance)
 					obj.(*cdiv1.DataVolume).Status = cdiv1.DataVolumeStatus{
 						Phase: cdiv1.Succeeded,
 					}
@@ -1117,6 +2478,7 @@ var _ = Describe("Reconcile steps", func() {
 			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
 				switch obj.(type) {
 				case *cdiv1.DataVolume:
+					controlledByImport(obj.(*cdiv1.DataVolume), instance)
 					obj.(*cdiv1.DataVolume).Status = cdiv1.DataVolumeStatus{
 						Phase: cdiv1.Pending,
 					}
@@ -1133,6 +2495,7 @@ var _ = Describe("Reconcile steps", func() {
 			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
 				switch obj.(type) {
 				case *cdiv1.DataVolume:
+					controlledByImport(obj.(*cdiv1.DataVolume), instance)
 					obj.(*cdiv1.DataVolume).Status = cdiv1.DataVolumeStatus{
 						Phase: cdiv1.Succeeded,
 					}
@@ -1157,6 +2520,7 @@ var _ = Describe("Reconcile steps", func() {
 			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
 				switch obj.(type) {
 				case *cdiv1.DataVolume:
+					controlledByImport(obj.(*cdiv1.DataVolume), instance)
 					obj.(*cdiv1.DataVolume).Status = cdiv1.DataVolumeStatus{
 						Phase: cdiv1.Failed,
 					}
@@ -1171,6 +2535,337 @@ var _ = Describe("Reconcile steps", func() {
 
 			Expect(err).To(BeNil())
 		})
+
+		It("should retry failed dv: ", func() {
+			var updated *v2vv1.VirtualMachineImport
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj.(type) {
+				case *cdiv1.DataVolume:
+					controlledByImport(obj.(*cdiv1.DataVolume), instance)
+					obj.(*cdiv1.DataVolume).Name = "123"
+					obj.(*cdiv1.DataVolume).Status = cdiv1.DataVolumeStatus{
+						Phase: cdiv1.Failed,
+						Conditions: []cdiv1.DataVolumeCondition{
+							{Type: cdiv1.DataVolumeRunning, Reason: "Error", Message: "Unable to connect to imageio data source: connection refused"},
+						},
+					}
+				}
+				return nil
+			}
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if vmImport, ok := obj.(*v2vv1.VirtualMachineImport); ok {
+					updated = vmImport
+				}
+				return nil
+			}
+			cleanedUp := false
+			cleanUp = func() error {
+				cleanedUp = true
+				return nil
+			}
+
+			done, err := reconciler.importDisks(mock, instance, mockMap, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(done).To(BeFalse())
+			Expect(cleanedUp).To(BeFalse())
+			Expect(updated).ToNot(BeNil())
+			Expect(updated.Status.DataVolumes).To(HaveLen(1))
+			Expect(updated.Status.DataVolumes[0].Name).To(Equal("123"))
+			Expect(updated.Status.DataVolumes[0].RetryCount).To(Equal(1))
+			Expect(*updated.Status.DataVolumes[0].LastFailure).To(Equal("dv is in Failed Phase"))
+			Expect(updated.Status.DataVolumes[0].NextRetryTime).ToNot(BeNil())
+		})
+
+		It("should fail the import when dv retries are exhausted: ", func() {
+			instance.Status.DataVolumes = []v2vv1.DataVolumeItem{{Name: "123", RetryCount: 3}}
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj.(type) {
+				case *cdiv1.DataVolume:
+					controlledByImport(obj.(*cdiv1.DataVolume), instance)
+					obj.(*cdiv1.DataVolume).Name = "123"
+					obj.(*cdiv1.DataVolume).Status = cdiv1.DataVolumeStatus{
+						Phase: cdiv1.Failed,
+					}
+				case *v2vv1.VirtualMachineImport:
+					obj.(*v2vv1.VirtualMachineImport).Annotations = map[string]string{sourceVMInitialState: string(provider.VMStatusDown)}
+				}
+				return nil
+			}
+			cleanedUp := false
+			cleanUp = func() error {
+				cleanedUp = true
+				return nil
+			}
+
+			done, err := reconciler.importDisks(mock, instance, mockMap, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(done).To(BeFalse())
+			Expect(cleanedUp).To(BeTrue())
+		})
+
+		It("should not retry failed dv with a permanent failure: ", func() {
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj.(type) {
+				case *cdiv1.DataVolume:
+					controlledByImport(obj.(*cdiv1.DataVolume), instance)
+					obj.(*cdiv1.DataVolume).Name = "123"
+					obj.(*cdiv1.DataVolume).Status = cdiv1.DataVolumeStatus{
+						Phase: cdiv1.Failed,
+						Conditions: []cdiv1.DataVolumeCondition{
+							{Type: cdiv1.DataVolumeRunning, Reason: "Error", Message: "Unable to connect to imageio data source: 401 Unauthorized"},
+						},
+					}
+				case *v2vv1.VirtualMachineImport:
+					obj.(*v2vv1.VirtualMachineImport).Annotations = map[string]string{sourceVMInitialState: string(provider.VMStatusDown)}
+				}
+				return nil
+			}
+			cleanedUp := false
+			cleanUp = func() error {
+				cleanedUp = true
+				return nil
+			}
+
+			done, err := reconciler.importDisks(mock, instance, mockMap, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(done).To(BeFalse())
+			Expect(cleanedUp).To(BeTrue())
+		})
+
+		It("should not report progress of dv deleted for a retry: ", func() {
+			var updated *v2vv1.VirtualMachineImport
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj.(type) {
+				case *cdiv1.DataVolume:
+					controlledByImport(obj.(*cdiv1.DataVolume), instance)
+					obj.(*cdiv1.DataVolume).Name = "123"
+					obj.(*cdiv1.DataVolume).Status = cdiv1.DataVolumeStatus{
+						Phase:    cdiv1.ImportInProgress,
+						Progress: "45.50%",
+					}
+				case *corev1.Pod:
+					obj.(*corev1.Pod).Status.ContainerStatuses = []corev1.ContainerStatus{
+						{
+							RestartCount: int32(importPodRestartTolerance + 1),
+							State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: podCrashLoopBackOff}},
+							LastTerminationState: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: "Unable to process data: unexpected EOF"},
+							},
+						},
+					}
+				}
+				return nil
+			}
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if vmImport, ok := obj.(*v2vv1.VirtualMachineImport); ok {
+					updated = vmImport
+				}
+				return nil
+			}
+
+			done, err := reconciler.importDisks(mock, instance, mockMap, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(done).To(BeFalse())
+			Expect(updated).ToNot(BeNil())
+			Expect(updated.Status.DataVolumes).To(HaveLen(1))
+			Expect(updated.Status.DataVolumes[0].RetryCount).To(Equal(1))
+			Expect(updated.Status.DataVolumes[0].Progress).To(BeEmpty())
+		})
+
+		It("should update dv progress: ", func() {
+			var updated *v2vv1.VirtualMachineImport
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj.(type) {
+				case *cdiv1.DataVolume:
+					controlledByImport(obj.(*cdiv1.DataVolume), instance)
+					obj.(*cdiv1.DataVolume).Name = "123"
+					obj.(*cdiv1.DataVolume).Status = cdiv1.DataVolumeStatus{
+						Phase:    cdiv1.ImportInProgress,
+						Progress: "45.50%",
+					}
+				}
+				return nil
+			}
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if vmImport, ok := obj.(*v2vv1.VirtualMachineImport); ok {
+					updated = vmImport
+				}
+				return nil
+			}
+
+			done, err := reconciler.importDisks(mock, instance, mockMap, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(done).To(BeFalse())
+			Expect(updated).ToNot(BeNil())
+			Expect(updated.Status.DataVolumes).To(HaveLen(1))
+			Expect(updated.Status.DataVolumes[0].Name).To(Equal("123"))
+			Expect(updated.Status.DataVolumes[0].Phase).To(Equal(string(cdiv1.ImportInProgress)))
+			Expect(updated.Status.DataVolumes[0].Progress).To(Equal("45.50%"))
+			Expect(updated.Status.DataVolumes[0].StartTime).ToNot(BeNil())
+		})
+
+		It("should wait for pending dv retry: ", func() {
+			nextRetryTime := v1.NewTime(time.Now().Add(time.Minute))
+			instance.Status.DataVolumes = []v2vv1.DataVolumeItem{{Name: "123", RetryCount: 1, NextRetryTime: &nextRetryTime}}
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj.(type) {
+				case *cdiv1.DataVolume:
+					return errors.NewNotFound(schema.GroupResource{}, "")
+				}
+				return nil
+			}
+			created := false
+			create = func(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
+				created = true
+				return nil
+			}
+
+			done, err := reconciler.importDisks(mock, instance, mockMap, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(done).To(BeFalse())
+			Expect(created).To(BeFalse())
+			Expect(nextDiskImportRetry(instance)).To(BeNumerically(">", 0))
+		})
+	})
+
+	Describe("inspectGuest step", func() {
+		var (
+			prov    *mockProvider
+			mapper  *mockMapper
+			pod     *corev1.Pod
+			vm      *kubevirtv1.VirtualMachine
+			deleted bool
+		)
+
+		BeforeEach(func() {
+			prov = &mockProvider{}
+			mapper = &mockMapper{}
+			instance.Name = "test"
+			instance.Namespace = "default"
+			instance.Spec.GuestInspection = &v2vv1.GuestInspectionSpec{Enabled: true}
+			deleted = false
+			vm = nil
+
+			pod = &corev1.Pod{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "test-pod",
+				},
+			}
+
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj.(type) {
+				case *kubevirtv1.VirtualMachine:
+					obj.(*kubevirtv1.VirtualMachine).Spec.Template = &kubevirtv1.VirtualMachineInstanceTemplateSpec{
+						Spec: kubevirtv1.VirtualMachineInstanceSpec{
+							Volumes: []kubevirtv1.Volume{{
+								Name: "dv",
+								VolumeSource: kubevirtv1.VolumeSource{
+									DataVolume: &kubevirtv1.DataVolumeSource{Name: "123"},
+								},
+							}},
+						},
+					}
+				case *v2vv1.VirtualMachineImport:
+					obj.(*v2vv1.VirtualMachineImport).Spec = v2vv1.VirtualMachineImportSpec{}
+				}
+				return nil
+			}
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				if updated, ok := obj.(*kubevirtv1.VirtualMachine); ok {
+					vm = updated
+				}
+				return nil
+			}
+			findInspectionPod = func() (*corev1.Pod, error) {
+				return pod, nil
+			}
+			deleteInspectionPod = func() error {
+				deleted = true
+				return nil
+			}
+			updateOperatingSystem = func(vm *kubevirtv1.VirtualMachine, inspection *v2vv1.GuestInspectionStatus) error {
+				vm.Labels = map[string]string{"os.template.kubevirt.io/" + inspection.OperatingSystem: "true"}
+				return nil
+			}
+			streamPodLog = func(_ *corev1.Pod, _ string) (io.ReadCloser, error) {
+				return ioutil.NopCloser(strings.NewReader("<operatingsystems><operatingsystem><distro>rhel</distro><osinfo>rhel8.2</osinfo></operatingsystem></operatingsystems>\nfirmware: uefi\n")), nil
+			}
+		})
+
+		It("should create the inspection pod", func() {
+			findInspectionPod = func() (*corev1.Pod, error) {
+				return nil, nil
+			}
+			var created *corev1.Pod
+			createInspectionPod = func(pod *corev1.Pod) error {
+				created = pod
+				return nil
+			}
+
+			done, err := reconciler.inspectGuest(prov, instance, mapper, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(done).To(BeFalse())
+			Expect(created).ToNot(BeNil())
+			Expect(created.OwnerReferences).To(HaveLen(1))
+		})
+
+		It("should wait for the inspection pod to complete", func() {
+			pod.Status.Phase = corev1.PodRunning
+
+			done, err := reconciler.inspectGuest(prov, instance, mapper, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(done).To(BeFalse())
+			Expect(deleted).To(BeFalse())
+		})
+
+		It("should relabel the VM after the inspected operating system", func() {
+			pod.Status.Phase = corev1.PodSucceeded
+
+			done, err := reconciler.inspectGuest(prov, instance, mapper, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(done).To(BeTrue())
+			Expect(deleted).To(BeTrue())
+			Expect(instance.Status.GuestInspection.OperatingSystem).To(Equal("rhel8.2"))
+			Expect(vm.Labels).To(HaveKey("os.template.kubevirt.io/rhel8.2"))
+			Expect(vm.Spec.Template.Spec.Domain.Firmware.Bootloader.EFI).ToNot(BeNil())
+			Expect(shouldInspectGuest(instance)).To(BeFalse())
+		})
+
+		It("should go on without relabeling the VM when the inspection fails", func() {
+			pod.Status.Phase = corev1.PodFailed
+
+			done, err := reconciler.inspectGuest(prov, instance, mapper, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(done).To(BeTrue())
+			Expect(deleted).To(BeTrue())
+			Expect(instance.Status.GuestInspection.Failure).ToNot(BeEmpty())
+			Expect(vm).To(BeNil())
+			Expect(reconciler.recorder.(*record.FakeRecorder).Events).To(Receive(ContainSubstring(EventGuestInspectionFailed)))
+		})
+
+		It("should go on when the output of the inspection is malformed", func() {
+			pod.Status.Phase = corev1.PodSucceeded
+			streamPodLog = func(_ *corev1.Pod, _ string) (io.ReadCloser, error) {
+				return ioutil.NopCloser(strings.NewReader("Failed to inspect the guest!\n")), nil
+			}
+
+			done, err := reconciler.inspectGuest(prov, instance, mapper, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(done).To(BeTrue())
+			Expect(instance.Status.GuestInspection.Failure).ToNot(BeEmpty())
+			Expect(vm).To(BeNil())
+		})
 	})
 
 	Describe("convertGuest step", func() {
@@ -1228,8 +2923,8 @@ var _ = Describe("Reconcile steps", func() {
 				}
 				return nil
 			}
-			needsGuestConversion = func() bool {
-				return true
+			needsGuestConversion = func() (bool, error) {
+				return true, nil
 			}
 			getGuestConversionPod = func() (*corev1.Pod, error) {
 				return nil, nil
@@ -1237,6 +2932,9 @@ var _ = Describe("Reconcile steps", func() {
 			launchGuestConversionPod = func() (*corev1.Pod, error) {
 				return pod, nil
 			}
+			streamPodLog = func(_ *corev1.Pod, _ string) (io.ReadCloser, error) {
+				return ioutil.NopCloser(strings.NewReader("i_product_name = Windows Server 2012 R2 Standard\ngcaps_block_bus = virtio-blk")), nil
+			}
 		})
 
 		It("should return false with no error when the pod is pending", func() {
@@ -1255,20 +2953,75 @@ var _ = Describe("Reconcile steps", func() {
 			Expect(done).To(BeFalse())
 		})
 
-		It("should return false with no error when the pod is failed", func() {
-			pod.Status.Phase = corev1.PodFailed
+		It("should return false with no error when the pod is failed", func() {
+			pod.Status.Phase = corev1.PodFailed
+
+			done, err := reconciler.convertGuest(prov, instance, mapper, vmName)
+			Expect(err).To(BeNil())
+			Expect(done).To(BeFalse())
+		})
+
+		It("should return true with no error when the pod is successful", func() {
+			pod.Status.Phase = corev1.PodSucceeded
+
+			done, err := reconciler.convertGuest(prov, instance, mapper, vmName)
+			Expect(err).To(BeNil())
+			Expect(done).To(BeTrue())
+		})
+
+		It("should store the summary of the conversion in the status", func() {
+			pod.Status.Phase = corev1.PodSucceeded
+			var stored *v2vv1.GuestConversionStatus
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				stored = obj.(*v2vv1.VirtualMachineImport).Status.GuestConversion
+				return nil
+			}
+
+			_, err := reconciler.convertGuest(prov, instance, mapper, vmName)
+
+			Expect(err).To(BeNil())
+			Expect(stored).ToNot(BeNil())
+			Expect(stored.OperatingSystem).To(Equal("Windows Server 2012 R2 Standard"))
+			Expect(stored.InstalledDrivers).To(ConsistOf("virtio-blk"))
+			Expect(instance.Status.GuestConversion).To(Equal(stored))
+		})
+
+		It("should go on without the summary when the log can't be read", func() {
+			pod.Status.Phase = corev1.PodSucceeded
+			streamPodLog = func(_ *corev1.Pod, _ string) (io.ReadCloser, error) {
+				return nil, fmt.Errorf("not found")
+			}
 
 			done, err := reconciler.convertGuest(prov, instance, mapper, vmName)
+
 			Expect(err).To(BeNil())
-			Expect(done).To(BeFalse())
+			Expect(done).To(BeTrue())
+			Expect(instance.Status.GuestConversion).To(BeNil())
 		})
 
-		It("should return true with no error when the pod is successful", func() {
+		It("should store the partial summary when the log can't be read to the end", func() {
 			pod.Status.Phase = corev1.PodSucceeded
+			streamPodLog = func(_ *corev1.Pod, _ string) (io.ReadCloser, error) {
+				reader, writer := io.Pipe()
+				go func() {
+					_, _ = writer.Write([]byte("i_product_name = Windows Server 2012 R2 Standard\n"))
+					_ = writer.CloseWithError(fmt.Errorf("connection reset"))
+				}()
+				return reader, nil
+			}
+			var stored *v2vv1.GuestConversionStatus
+			update = func(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+				stored = obj.(*v2vv1.VirtualMachineImport).Status.GuestConversion
+				return nil
+			}
 
 			done, err := reconciler.convertGuest(prov, instance, mapper, vmName)
+
 			Expect(err).To(BeNil())
 			Expect(done).To(BeTrue())
+			Expect(stored).ToNot(BeNil())
+			Expect(stored.OperatingSystem).To(Equal("Windows Server 2012 R2 Standard"))
+			Expect(stored.LogError).To(Equal("connection reset"))
 		})
 	})
 
@@ -1349,6 +3102,70 @@ var _ = Describe("Reconcile steps", func() {
 			durationSamplesAfter := getCountDurationSuccessful()
 			Expect(durationSamplesAfter).To(Equal(durationSamplesBefore + 1))
 		})
+		It("should leave the source VM stopped by default", func() {
+			startSourceVM = func() error {
+				Fail("the source VM should stay stopped")
+				return nil
+			}
+
+			err := reconciler.afterSuccess(vmName, &mockProvider{}, config)
+
+			Expect(err).To(BeNil())
+		})
+		It("should restore the initial state of the source VM", func() {
+			config.Spec.SourceVMPolicy = v2vv1.SourceVMPolicyRestore
+			started := false
+			startSourceVM = func() error {
+				started = true
+				return nil
+			}
+
+			err := reconciler.afterSuccess(vmName, &mockProvider{}, config)
+
+			Expect(err).To(BeNil())
+			Expect(started).To(BeTrue())
+		})
+		It("should delete the source VM", func() {
+			config.Spec.SourceVMPolicy = v2vv1.SourceVMPolicyDelete
+			deleted := false
+			deleteSourceVM = func() error {
+				deleted = true
+				return nil
+			}
+
+			err := reconciler.afterSuccess(vmName, &mockProvider{}, config)
+
+			Expect(err).To(BeNil())
+			Expect(deleted).To(BeTrue())
+		})
+		It("should mark the source VM as migrated to the imported VM", func() {
+			config.Spec.SourceVMPolicy = v2vv1.SourceVMPolicyMark
+			var marked string
+			markSourceVMMigrated = func(target string) error {
+				marked = target
+				return nil
+			}
+
+			err := reconciler.afterSuccess(vmName, &mockProvider{}, config)
+
+			Expect(err).To(BeNil())
+			Expect(marked).To(Equal("default/test"))
+		})
+		It("should report the failure to mark the source VM", func() {
+			config.Spec.SourceVMPolicy = v2vv1.SourceVMPolicyMark
+			markSourceVMMigrated = func(target string) error {
+				return fmt.Errorf("no permission")
+			}
+
+			err := reconciler.afterSuccess(vmName, &mockProvider{}, config)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("no permission"))
+			cond := conditions.FindConditionOfType(config.Status.Conditions, v2vv1.SourceVMPolicyApplied)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+			Expect(*cond.Reason).To(Equal(string(v2vv1.SourceVMPolicyPending)))
+		})
 		It("should increment failed counter", func() {
 			counterValueBefore := getCounterFailed()
 			durationSamplesBefore := getCountDurationFailed()
@@ -1364,6 +3181,226 @@ var _ = Describe("Reconcile steps", func() {
 		})
 	})
 
+	Describe("rollback step", func() {
+		var (
+			config *v2vv1.VirtualMachineImport
+		)
+		BeforeEach(func() {
+			config = &v2vv1.VirtualMachineImport{}
+			config.Name = "test"
+			config.Namespace = "default"
+			config.Spec = v2vv1.VirtualMachineImportSpec{
+				Source: v2vv1.VirtualMachineImportSourceSpec{
+					Ovirt: &v2vv1.VirtualMachineImportOvirtSourceSpec{},
+				},
+				Rollback: true,
+			}
+			config.Status.TargetVMName = "test"
+			config.Status.DataVolumes = []v2vv1.DataVolumeItem{{Name: "test-dv"}}
+			config.Status.Conditions = []v2vv1.VirtualMachineImportCondition{
+				conditions.NewSucceededCondition(string(v2vv1.VirtualMachineReady), "", corev1.ConditionTrue),
+			}
+			loadVM = func(spec v2vv1.VirtualMachineImportSourceSpec) error {
+				return nil
+			}
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				switch obj.(type) {
+				case *kubevirtv1.VirtualMachine, *kubevirtv1.VirtualMachineInstance:
+					return errors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				return nil
+			}
+		})
+
+		It("should roll back a successful import", func() {
+			Expect(shouldRollback(config)).To(BeTrue())
+		})
+		It("should not roll back an import in progress", func() {
+			config.Status.Conditions = nil
+
+			Expect(shouldRollback(config)).To(BeFalse())
+		})
+		It("should not roll back a failed import", func() {
+			config.Status.Conditions = []v2vv1.VirtualMachineImportCondition{
+				conditions.NewSucceededCondition(string(v2vv1.VMCreationFailed), "", corev1.ConditionFalse),
+			}
+
+			Expect(shouldRollback(config)).To(BeFalse())
+		})
+		It("should not roll back an import twice", func() {
+			config.Status.Conditions = append(config.Status.Conditions,
+				conditions.NewCondition(v2vv1.RolledBack, string(v2vv1.RollbackCompleted), "", corev1.ConditionTrue))
+
+			Expect(shouldRollback(config)).To(BeFalse())
+		})
+		It("should delete the imported VM and data volumes and start the source VM", func() {
+			var deleted []string
+			deleteObject = func(ctx context.Context, obj runtime.Object) error {
+				deleted = append(deleted, obj.(v1.Object).GetName())
+				return nil
+			}
+			started := false
+			startSourceVM = func() error {
+				started = true
+				return nil
+			}
+
+			requeue, err := reconciler.rollback(config, &mockProvider{})
+
+			Expect(err).To(BeNil())
+			Expect(requeue).To(BeFalse())
+			Expect(deleted).To(ConsistOf("test", "test-dv"))
+			Expect(started).To(BeTrue())
+			cond := conditions.FindConditionOfType(config.Status.Conditions, v2vv1.RolledBack)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+			Expect(*cond.Reason).To(Equal(string(v2vv1.RollbackCompleted)))
+		})
+		It("should wait for the imported VM to stop before starting the source VM", func() {
+			get = func(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+				if _, ok := obj.(*kubevirtv1.VirtualMachine); ok {
+					return errors.NewNotFound(schema.GroupResource{}, key.Name)
+				}
+				return nil
+			}
+			started := false
+			startSourceVM = func() error {
+				started = true
+				return nil
+			}
+
+			requeue, err := reconciler.rollback(config, &mockProvider{})
+
+			Expect(err).To(BeNil())
+			Expect(requeue).To(BeTrue())
+			Expect(started).To(BeFalse())
+			Expect(shouldRollback(config)).To(BeTrue())
+		})
+		It("should unmark the source VM marked as migrated", func() {
+			config.Spec.SourceVMPolicy = v2vv1.SourceVMPolicyMark
+			var unmarked string
+			unmarkSourceVMMigrated = func(target string) error {
+				unmarked = target
+				return nil
+			}
+
+			_, err := reconciler.rollback(config, &mockProvider{})
+
+			Expect(err).To(BeNil())
+			Expect(unmarked).To(Equal("default/test"))
+			cond := conditions.FindConditionOfType(config.Status.Conditions, v2vv1.RolledBack)
+			Expect(*cond.Message).To(ContainSubstring("unmarked"))
+		})
+		It("should record the failure to unmark the source VM", func() {
+			config.Spec.SourceVMPolicy = v2vv1.SourceVMPolicyMark
+			unmarkSourceVMMigrated = func(target string) error {
+				return fmt.Errorf("no permission")
+			}
+			started := false
+			startSourceVM = func() error {
+				started = true
+				return nil
+			}
+
+			_, err := reconciler.rollback(config, &mockProvider{})
+
+			Expect(err).To(HaveOccurred())
+			Expect(started).To(BeFalse())
+			Expect(shouldRollback(config)).To(BeTrue())
+		})
+		It("should ignore the imported objects already deleted", func() {
+			deleteObject = func(ctx context.Context, obj runtime.Object) error {
+				return errors.NewNotFound(schema.GroupResource{}, "")
+			}
+
+			_, err := reconciler.rollback(config, &mockProvider{})
+
+			Expect(err).To(BeNil())
+			Expect(shouldRollback(config)).To(BeFalse())
+		})
+		It("should record the failure to start the source VM", func() {
+			startSourceVM = func() error {
+				return fmt.Errorf("no permission")
+			}
+
+			_, err := reconciler.rollback(config, &mockProvider{})
+
+			Expect(err).To(HaveOccurred())
+			cond := conditions.FindConditionOfType(config.Status.Conditions, v2vv1.RolledBack)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+			Expect(*cond.Message).To(ContainSubstring("no permission"))
+			Expect(shouldRollback(config)).To(BeTrue())
+		})
+		It("should not roll back when the source VM was deleted", func() {
+			config.Spec.SourceVMPolicy = v2vv1.SourceVMPolicyDelete
+			deleted := false
+			deleteObject = func(ctx context.Context, obj runtime.Object) error {
+				deleted = true
+				return nil
+			}
+
+			_, err := reconciler.rollback(config, &mockProvider{})
+
+			Expect(err).To(BeNil())
+			Expect(deleted).To(BeFalse())
+			cond := conditions.FindConditionOfType(config.Status.Conditions, v2vv1.RolledBack)
+			Expect(cond).ToNot(BeNil())
+			Expect(*cond.Reason).To(Equal(string(v2vv1.RollbackFailed)))
+		})
+	})
+
+	Describe("source VM policy retry", func() {
+		var (
+			config *v2vv1.VirtualMachineImport
+		)
+		BeforeEach(func() {
+			config = &v2vv1.VirtualMachineImport{}
+			config.Name = "test"
+			config.Namespace = "default"
+			config.Spec.SourceVMPolicy = v2vv1.SourceVMPolicyMark
+			config.Status.TargetVMName = "test"
+			config.Status.Conditions = []v2vv1.VirtualMachineImportCondition{
+				conditions.NewSucceededCondition(string(v2vv1.VirtualMachineReady), "", corev1.ConditionTrue),
+				conditions.NewCondition(v2vv1.SourceVMPolicyApplied, string(v2vv1.SourceVMPolicyPending), "", corev1.ConditionFalse),
+			}
+			loadVM = func(spec v2vv1.VirtualMachineImportSourceSpec) error {
+				return nil
+			}
+		})
+
+		It("should retry the pending source VM policy of a successful import", func() {
+			Expect(shouldRetrySourceVMPolicy(config)).To(BeTrue())
+		})
+		It("should not retry the source VM policy once applied", func() {
+			config.Status.Conditions[1] = conditions.NewCondition(v2vv1.SourceVMPolicyApplied, string(v2vv1.SourceVMPolicyCompleted), "", corev1.ConditionTrue)
+
+			Expect(shouldRetrySourceVMPolicy(config)).To(BeFalse())
+		})
+		It("should not retry the source VM policy of a rolled back import", func() {
+			config.Status.Conditions = append(config.Status.Conditions,
+				conditions.NewCondition(v2vv1.RolledBack, string(v2vv1.RollbackCompleted), "", corev1.ConditionTrue))
+
+			Expect(shouldRetrySourceVMPolicy(config)).To(BeFalse())
+		})
+		It("should record the source VM policy once applied", func() {
+			var marked string
+			markSourceVMMigrated = func(target string) error {
+				marked = target
+				return nil
+			}
+
+			err := reconciler.retrySourceVMPolicy(config, &mockProvider{})
+
+			Expect(err).To(BeNil())
+			Expect(marked).To(Equal("default/test"))
+			cond := conditions.FindConditionOfType(config.Status.Conditions, v2vv1.SourceVMPolicyApplied)
+			Expect(cond.Status).To(Equal(corev1.ConditionTrue))
+			Expect(*cond.Reason).To(Equal(string(v2vv1.SourceVMPolicyCompleted)))
+			Expect(shouldRetrySourceVMPolicy(config)).To(BeFalse())
+		})
+	})
+
 	Describe("Reconcile step", func() {
 
 		var (
@@ -1394,6 +3431,8 @@ var _ = Describe("Reconcile steps", func() {
 					obj.(*v2vv1.VirtualMachineImport).Spec.TargetVMName = &name
 				case *corev1.Secret:
 					obj.(*corev1.Secret).Data = map[string][]byte{"ovirt": getSecret()}
+				case *cdiv1.DataVolume:
+					controlledByImport(obj.(*cdiv1.DataVolume), instance)
 				}
 				return nil
 			}
@@ -1793,6 +3832,8 @@ var _ = Describe("Reconcile steps", func() {
 						config.DeepCopyInto(obj.(*v2vv1.VirtualMachineImport))
 					case *corev1.Secret:
 						obj.(*corev1.Secret).Data = map[string][]byte{"ovirt": getSecret()}
+					case *cdiv1.DataVolume:
+						controlledByImport(obj.(*cdiv1.DataVolume), config)
 					}
 					return nil
 				}
@@ -1847,6 +3888,35 @@ var _ = Describe("Reconcile steps", func() {
 				Expect(durationSamplesAfter).To(Equal(durationSamplesBefore))
 			})
 
+			It("should delete the objects created in the target namespace: ", func() {
+				config.Name = "test"
+				config.Namespace = "migration"
+				targetNamespace := "apps"
+				config.Spec.TargetNamespace = &targetNamespace
+				config.SetDeletionTimestamp(&v1.Time{})
+				var deleted []runtime.Object
+				deleteAllOf = func(ctx context.Context, obj runtime.Object, opts ...client.DeleteAllOfOption) error {
+					options := &client.DeleteAllOfOptions{}
+					options.ApplyOptions(opts)
+					Expect(options.Namespace).To(Equal("apps"))
+					Expect(options.LabelSelector.String()).To(Equal(utils.ImportNameLabel + "=test," + utils.ImportNamespaceLabel + "=migration"))
+					deleted = append(deleted, obj)
+					return nil
+				}
+
+				result, err := reconciler.Reconcile(request)
+
+				Expect(err).To(BeNil())
+				Expect(result).To(Equal(reconcile.Result{}))
+				Expect(deleted).To(ConsistOf(
+					BeAssignableToTypeOf(&kubevirtv1.VirtualMachine{}),
+					BeAssignableToTypeOf(&cdiv1.DataVolume{}),
+					BeAssignableToTypeOf(&corev1.Pod{}),
+					BeAssignableToTypeOf(&corev1.ConfigMap{}),
+					BeAssignableToTypeOf(&corev1.Secret{}),
+				))
+			})
+
 			It("should increment counter for in progress import: ", func() {
 				counterValueBefore := getCounterCancelled()
 				durationSamplesBefore := getCountDurationCancelled()
@@ -1890,6 +3960,144 @@ var _ = Describe("Disks import progress", func() {
 	)
 })
 
+var _ = Describe("Disk import retry backoff", func() {
+	table.DescribeTable("Backoff",
+		func(initialSeconds int, retryCount int, expected time.Duration) {
+			Expect(diskImportRetryBackoff(initialSeconds, retryCount)).To(Equal(expected))
+		},
+		table.Entry("First attempt", 30, 0, 30*time.Second),
+		table.Entry("Second attempt", 30, 1, 60*time.Second),
+		table.Entry("Fourth attempt", 30, 3, 240*time.Second),
+		table.Entry("Capped", 30, 20, maxDiskImportRetryBackoff),
+		table.Entry("No backoff", 0, 2, time.Duration(0)),
+	)
+})
+
+var _ = Describe("Disk import failure classification", func() {
+	table.DescribeTable("Failure class",
+		func(terminationMessage string, conditionMessage string, expected string) {
+			dv := &cdiv1.DataVolume{
+				Status: cdiv1.DataVolumeStatus{
+					Conditions: []cdiv1.DataVolumeCondition{{Type: cdiv1.DataVolumeRunning, Reason: "Error", Message: conditionMessage}},
+				},
+			}
+			Expect(classifyDiskImportFailure(dv, terminationMessage, ctrlConfig.DataVolumeFailed)).To(Equal(expected))
+		},
+		table.Entry("Connection refused", "", "dial tcp 10.0.0.1:54322: connect: connection refused", ctrlConfig.TransientFailure),
+		table.Entry("Timeout", "Unable to process data: context deadline exceeded (Client.Timeout exceeded)", "", ctrlConfig.TransientFailure),
+		table.Entry("Service unavailable", "", "unexpected status code 503", ctrlConfig.TransientFailure),
+		table.Entry("Bad credentials", "", "Unable to connect to imageio data source: 401 Unauthorized", ctrlConfig.AuthenticationFailure),
+		table.Entry("Bad credentials with a timeout", "Unauthorized", "timeout", ctrlConfig.AuthenticationFailure),
+		table.Entry("Missing disk", "disk 123 not found", "", ctrlConfig.DiskNotFound),
+		table.Entry("Unknown", "Unable to process data: invalid image format", "", ctrlConfig.DataVolumeFailed),
+		table.Entry("Status line", "", "HTTP/1.1 503", ctrlConfig.TransientFailure),
+		table.Entry("End of file", "Unable to transfer source data to target file: unexpected EOF", "", ctrlConfig.TransientFailure),
+		table.Entry("Transient failure of a disk whose ID contains 404", "", "dial tcp 10.0.0.1:54322: connect: connection refused while importing disk 8f3a404b-6c1e-4d2a-9b7e-1c2d3e4f5a6b", ctrlConfig.TransientFailure),
+		table.Entry("Transient failure of a disk whose ID contains 401", "Unable to process data: i/o timeout reading disk 0e401c7d-2b3a-4c5d-8e9f-a0b1c2d3e4f5", "", ctrlConfig.TransientFailure),
+		table.Entry("Failure of a disk whose ID contains 503", "Unable to process data: invalid image format of disk 5d503e2f-7a8b-4c9d-8e1f-2a3b4c5d6e7f", "", ctrlConfig.DataVolumeFailed),
+		table.Entry("Size containing 404", "Unable to process data: expected 4040404 bytes", "", ctrlConfig.DataVolumeFailed),
+		table.Entry("Word containing eof", "Unable to process data: geoff's disk has an invalid image format", "", ctrlConfig.DataVolumeFailed),
+		table.Entry("Missing disk reported by its status code", "", "unexpected status code 404", ctrlConfig.DiskNotFound),
+	)
+})
+
+var _ = Describe("Disk import progress", func() {
+	var (
+		dv   *cdiv1.DataVolume
+		size resource.Quantity
+		now  v1.Time
+	)
+
+	BeforeEach(func() {
+		size = resource.MustParse("1000")
+		now = v1.NewTime(time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC))
+		dv = &cdiv1.DataVolume{
+			ObjectMeta: v1.ObjectMeta{
+				Name: "dv",
+				Annotations: map[string]string{
+					utils.SourceDiskIDAnnotation:   "disk-id",
+					utils.SourceDiskNameAnnotation: "disk-name",
+				},
+			},
+			Spec: cdiv1.DataVolumeSpec{
+				PVC: &corev1.PersistentVolumeClaimSpec{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceStorage: size,
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("should describe the source and the target of the disk import", func() {
+		item := newDataVolumeItem(dv)
+
+		Expect(item.Name).To(Equal("dv"))
+		Expect(item.PVCName).To(Equal("dv"))
+		Expect(item.SourceDiskID).To(Equal("disk-id"))
+		Expect(item.SourceDiskName).To(Equal("disk-name"))
+		Expect(item.Size.Value()).To(Equal(int64(1000)))
+	})
+
+	It("should start tracking the import once it's in progress", func() {
+		item := newDataVolumeItem(dv)
+		dv.Status = cdiv1.DataVolumeStatus{Phase: cdiv1.ImportInProgress, Progress: "0.00%"}
+
+		changed := updateDataVolumeItemProgress(&item, dv, now)
+
+		Expect(changed).To(BeTrue())
+		Expect(item.Phase).To(Equal(string(cdiv1.ImportInProgress)))
+		Expect(item.Progress).To(Equal("0.00%"))
+		Expect(*item.StartTime).To(Equal(now))
+		Expect(item.EndTime).To(BeNil())
+		Expect(item.EstimatedCompletionTime).To(BeNil())
+	})
+
+	It("should compute the throughput and the estimated completion time", func() {
+		item := newDataVolumeItem(dv)
+		startTime := v1.NewTime(now.Add(-10 * time.Second))
+		item.StartTime = &startTime
+		dv.Status = cdiv1.DataVolumeStatus{Phase: cdiv1.ImportInProgress, Progress: "25.00%"}
+
+		changed := updateDataVolumeItemProgress(&item, dv, now)
+
+		Expect(changed).To(BeTrue())
+		Expect(item.Progress).To(Equal("25.00%"))
+		Expect(item.BytesTransferred).To(Equal(int64(250)))
+		Expect(item.Throughput).To(Equal(int64(25)))
+		Expect(item.EstimatedCompletionTime.Time).To(Equal(now.Add(30 * time.Second)))
+	})
+
+	It("should not change when the progress hasn't moved", func() {
+		item := newDataVolumeItem(dv)
+		dv.Status = cdiv1.DataVolumeStatus{Phase: cdiv1.ImportInProgress, Progress: "25.00%"}
+		updateDataVolumeItemProgress(&item, dv, now)
+
+		changed := updateDataVolumeItemProgress(&item, dv, v1.NewTime(now.Add(time.Minute)))
+
+		Expect(changed).To(BeFalse())
+	})
+
+	It("should complete the import", func() {
+		item := newDataVolumeItem(dv)
+		startTime := v1.NewTime(now.Add(-100 * time.Second))
+		item.StartTime = &startTime
+		dv.Status = cdiv1.DataVolumeStatus{Phase: cdiv1.Succeeded}
+
+		changed := updateDataVolumeItemProgress(&item, dv, now)
+
+		Expect(changed).To(BeTrue())
+		Expect(item.Phase).To(Equal(string(cdiv1.Succeeded)))
+		Expect(item.Progress).To(Equal("100.00%"))
+		Expect(item.BytesTransferred).To(Equal(int64(1000)))
+		Expect(item.Throughput).To(Equal(int64(10)))
+		Expect(*item.EndTime).To(Equal(now))
+		Expect(item.EstimatedCompletionTime).To(BeNil())
+	})
+})
+
 func NewReconciler(client client.Client, finder mappings.ResourceFinder, scheme *runtime.Scheme, ownerreferencesmgr ownerreferences.OwnerReferenceManager, factory pclient.Factory, kvConfigProvider kvConfig.KubeVirtConfigProvider, recorder record.EventRecorder, controller controller.Controller, ctrlConfigProvider ctrlConfig.ControllerConfigProvider) *ReconcileVirtualMachineImport {
 	return &ReconcileVirtualMachineImport{
 		client:                 client,
@@ -1902,6 +4110,8 @@ func NewReconciler(client client.Client, finder mappings.ResourceFinder, scheme
 		recorder:               recorder,
 		controller:             controller,
 		ctrlConfigProvider:     ctrlConfigProvider,
+		podLogReader:           &mockPodLogReader{},
+		inspectionPodsManager:  &mockPodsManager{},
 	}
 }
 
@@ -1925,6 +4135,10 @@ type mockOvirtClient struct{}
 
 type mockVmwareClient struct{}
 
+type mockPodLogReader struct{}
+
+type mockPodsManager struct{}
+
 // Create implements client.Client
 func (c *mockClient) Create(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
 	return create(ctx, obj)
@@ -1937,12 +4151,12 @@ func (c *mockClient) Update(ctx context.Context, obj runtime.Object, opts ...cli
 
 // Delete implements client.Client
 func (c *mockClient) Delete(ctx context.Context, obj runtime.Object, opts ...client.DeleteOption) error {
-	return nil
+	return deleteObject(ctx, obj)
 }
 
 // DeleteAllOf implements client.Client
 func (c *mockClient) DeleteAllOf(ctx context.Context, obj runtime.Object, opts ...client.DeleteAllOfOption) error {
-	return nil
+	return deleteAllOf(ctx, obj, opts...)
 }
 
 // Patch implements client.Client
@@ -2014,7 +4228,12 @@ func (p *mockProvider) UpdateVM(vmSpec *kubevirtv1.VirtualMachine, dvs map[strin
 
 // CreateMapper implements Provider.CreateMapper
 func (p *mockProvider) CreateMapper() (provider.Mapper, error) {
-	return nil, nil
+	return &mockMapper{}, nil
+}
+
+// CreateValidationMapper implements Provider.CreateValidationMapper
+func (p *mockProvider) CreateValidationMapper() (provider.Mapper, error) {
+	return &mockMapper{}, nil
 }
 
 // GetVMStatus implements Provider.GetVMStatus
@@ -2027,9 +4246,40 @@ func (p *mockProvider) GetVMName() (string, error) {
 	return "", nil
 }
 
+// GetGuestNetwork implements Provider.GetGuestNetwork
+func (p *mockProvider) GetGuestNetwork() (*v2vv1.GuestNetworkStatus, error) {
+	return getGuestNetwork()
+}
+
 // StartVM implements Provider.StartVM
 func (p *mockProvider) StartVM() error {
-	return nil
+	return startSourceVM()
+}
+
+// DeleteVM implements Provider.DeleteVM
+func (p *mockProvider) DeleteVM() error {
+	return deleteSourceVM()
+}
+
+// MarkVMMigrated implements Provider.MarkVMMigrated
+func (p *mockProvider) MarkVMMigrated(target string) error {
+	return markSourceVMMigrated(target)
+}
+
+// UnmarkVMMigrated implements Provider.UnmarkVMMigrated
+func (p *mockProvider) UnmarkVMMigrated(target string) error {
+	return unmarkSourceVMMigrated(target)
+}
+
+// controlledByImport makes the import the controller of the data volume, as when the import created it
+func controlledByImport(dv *cdiv1.DataVolume, instance *v2vv1.VirtualMachineImport) {
+	isController := true
+	dv.OwnerReferences = append(dv.OwnerReferences, v1.OwnerReference{Controller: &isController, UID: instance.UID})
+}
+
+// GetSourceMetadata implements Provider.GetSourceMetadata
+func (p *mockProvider) GetSourceMetadata() (*propagation.SourceMetadata, error) {
+	return getSourceMetadata()
 }
 
 // CleanUp implements Provider.CleanUp
@@ -2038,7 +4288,7 @@ func (p *mockProvider) CleanUp(failure bool, cr *v2vv1.VirtualMachineImport, cli
 }
 
 // FindTemplate implements Provider.FindTemplate
-func (p *mockProvider) FindTemplate() (*oapiv1.Template, error) {
+func (p *mockProvider) FindTemplate() (*oapiv1.Template, string, error) {
 	return findTemplate()
 }
 
@@ -2047,7 +4297,11 @@ func (p *mockProvider) ProcessTemplate(template *oapiv1.Template, name *string,
 	return processTemplate(template, name, namespace)
 }
 
-func (p *mockProvider) NeedsGuestConversion() bool {
+func (p *mockProvider) UpdateOperatingSystem(vm *kubevirtv1.VirtualMachine, inspection *v2vv1.GuestInspectionStatus) error {
+	return updateOperatingSystem(vm, inspection)
+}
+
+func (p *mockProvider) NeedsGuestConversion() (bool, error) {
 	return needsGuestConversion()
 }
 
@@ -2091,6 +4345,32 @@ func (m *mockMapper) MapDataVolumes(targetVMName *string, overhead cdiv1.Filesys
 func (m *mockMapper) MapDisk(vmSpec *kubevirtv1.VirtualMachine, dv cdiv1.DataVolume) {
 }
 
+// MapMACAddresses implements Mapper.MapMACAddresses
+func (m *mockMapper) MapMACAddresses(importPolicy v2vv1.MACPolicy) []macaddress.NIC {
+	return mapMACAddresses(importPolicy)
+}
+
+func (m *mockMapper) MapUnmappedNetworks() []v2vv1.UnmappedNetworkStatus {
+	return mapUnmappedNetworks()
+}
+
+func vmWithMAC(mac string) kubevirtv1.VirtualMachine {
+	return kubevirtv1.VirtualMachine{
+		ObjectMeta: v1.ObjectMeta{Name: "running", Namespace: "prod"},
+		Spec: kubevirtv1.VirtualMachineSpec{
+			Template: &kubevirtv1.VirtualMachineInstanceTemplateSpec{
+				Spec: kubevirtv1.VirtualMachineInstanceSpec{
+					Domain: kubevirtv1.DomainSpec{
+						Devices: kubevirtv1.Devices{
+							Interfaces: []kubevirtv1.Interface{{Name: "nic1", MacAddress: mac}},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 // NewOvirtClient implements Factory.NewOvirtClient
 func (f *mockFactory) NewOvirtClient(dataMap map[string]string) (pclient.VMClient, error) {
 	return &mockOvirtClient{}, nil
@@ -2113,19 +4393,31 @@ func (f *mockController) Reconcile(reconcile.Request) (reconcile.Result, error)
 	return reconcile.Result{}, nil
 }
 
-func (c *mockOvirtClient) GetVM(id *string, name *string, cluster *string, clusterID *string) (interface{}, error) {
+func (c *mockOvirtClient) GetVM(_ context.Context, id *string, name *string, cluster *string, clusterID *string) (interface{}, error) {
 	return getVM(id, name, cluster, clusterID)
 }
 
-func (c *mockOvirtClient) StopVM(id string) error {
+func (c *mockOvirtClient) StopVM(_ context.Context, id string) error {
 	return stopVM(id)
 }
 
-func (c *mockOvirtClient) StartVM(id string) error {
+func (c *mockOvirtClient) StartVM(_ context.Context, id string) error {
+	return nil
+}
+
+func (c *mockOvirtClient) DeleteVM(_ context.Context, id string) error {
+	return nil
+}
+
+func (c *mockOvirtClient) MarkVMMigrated(_ context.Context, id string, target string) error {
 	return nil
 }
 
-func (c *mockOvirtClient) TestConnection() error {
+func (c *mockOvirtClient) UnmarkVMMigrated(_ context.Context, id string, target string) error {
+	return nil
+}
+
+func (c *mockOvirtClient) TestConnection(_ context.Context) error {
 	return nil
 }
 
@@ -2133,15 +4425,27 @@ func (c *mockOvirtClient) Close() error {
 	return nil
 }
 
-func (c *mockVmwareClient) GetVM(id *string, name *string, cluster *string, clusterID *string) (interface{}, error) {
+func (c *mockVmwareClient) GetVM(_ context.Context, id *string, name *string, cluster *string, clusterID *string) (interface{}, error) {
 	return getVM(id, name, cluster, clusterID)
 }
 
-func (c *mockVmwareClient) StopVM(id string) error {
+func (c *mockVmwareClient) StopVM(_ context.Context, id string) error {
 	return stopVM(id)
 }
 
-func (c *mockVmwareClient) StartVM(id string) error {
+func (c *mockVmwareClient) StartVM(_ context.Context, id string) error {
+	return nil
+}
+
+func (c *mockVmwareClient) DeleteVM(_ context.Context, id string) error {
+	return nil
+}
+
+func (c *mockVmwareClient) MarkVMMigrated(_ context.Context, id string, target string) error {
+	return nil
+}
+
+func (c *mockVmwareClient) UnmarkVMMigrated(_ context.Context, id string, target string) error {
 	return nil
 }
 
@@ -2149,7 +4453,7 @@ func (c *mockVmwareClient) Close() error {
 	return nil
 }
 
-func (c *mockVmwareClient) TestConnection() error {
+func (c *mockVmwareClient) TestConnection(_ context.Context) error {
 	return nil
 }
 
@@ -2161,6 +4465,22 @@ func (c *mockControllerConfigProvider) GetConfig() (ctrlConfig.ControllerConfig,
 	return getCtrlConfig(), nil
 }
 
+func (r *mockPodLogReader) Stream(pod *corev1.Pod, container string) (io.ReadCloser, error) {
+	return streamPodLog(pod, container)
+}
+
+func (m *mockPodsManager) FindFor(_ *v2vv1.VirtualMachineImport) (*corev1.Pod, error) {
+	return findInspectionPod()
+}
+
+func (m *mockPodsManager) CreateFor(pod *corev1.Pod, _ *v2vv1.VirtualMachineImport) error {
+	return createInspectionPod(pod)
+}
+
+func (m *mockPodsManager) DeleteFor(_ *v2vv1.VirtualMachineImport) error {
+	return deleteInspectionPod()
+}
+
 func getSecret() []byte {
 	contents := []byte(`{"apiUrl": "https://test", "username": "admin@internal", "password": "password", "caCert": "ABC"}`)
 	secret, _ := yaml.JSONToYAML(contents)
@@ -9,7 +9,10 @@ import (
 	"github.com/go-logr/logr"
 	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	"github.com/kubevirt/vm-import-operator/pkg/conditions"
+	"github.com/kubevirt/vm-import-operator/pkg/metrics"
+	"github.com/kubevirt/vm-import-operator/pkg/patches"
 	provider "github.com/kubevirt/vm-import-operator/pkg/providers"
+	"github.com/kubevirt/vm-import-operator/pkg/utils"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -115,20 +118,33 @@ func (r *ReconcileVirtualMachineImport) ensureDisksExist(provider provider.Provi
 		return false, err
 	}
 
+	// the patches that can't be read for now are retried
+	dvPatches, err := patches.Load(r.client, instance.Namespace, instance.Spec.DataVolumePatches)
+	if patches.IsInvalid(err) {
+		return false, r.endWarmImportFailed(provider, instance, err.Error())
+	}
+	if err != nil {
+		return false, err
+	}
+
 	for dvID, dvDef := range dvs {
-		dvName := types.NamespacedName{Namespace: instance.Namespace, Name: dvID}
+		dvName := types.NamespacedName{Namespace: utils.TargetNamespace(instance), Name: dvID}
 
 		dv, err := r.getDataVolume(dvName)
 		if err != nil {
 			return false, err
 		}
+		// a data volume of the same name created by anyone else, e.g. another import, is left alone
+		if dv != nil && !isImportOwned(instance, dv) {
+			return false, r.endWarmImportFailed(provider, instance, fmt.Sprintf("data volume %s/%s already exists and wasn't created by the import", dv.Namespace, dv.Name))
+		}
 		if dv != nil {
 			continue
 		}
 
 		// We have to validate the disk status, so we are sure, the disk wasn't manipulated,
 		// before we execute the import:
-		valid, err := provider.ValidateDiskStatus(dvName.Name)
+		valid, err := provider.ValidateDiskStatus(dvDef.Annotations[utils.SourceDiskIDAnnotation])
 		if err != nil {
 			return false, err
 		}
@@ -143,7 +159,7 @@ func (r *ReconcileVirtualMachineImport) ensureDisksExist(provider provider.Provi
 		dvDef.Spec.Checkpoints = []cdiv1.DataVolumeCheckpoint{
 			{Previous: "", Current: snapshotRef},
 		}
-		dv, err = r.createDataVolume(provider, mapper, instance, &dvDef, vmName)
+		dv, err = r.createDataVolume(provider, mapper, instance, &dvDef, vmName, dvPatches)
 		if err != nil {
 			return false, err
 		}
@@ -159,7 +175,7 @@ func (r *ReconcileVirtualMachineImport) isStageComplete(instance *v2vv1.VirtualM
 		return false, err
 	}
 	for dvID, _ := range dvs {
-		dvName := types.NamespacedName{Namespace: instance.Namespace, Name: dvID}
+		dvName := types.NamespacedName{Namespace: utils.TargetNamespace(instance), Name: dvID}
 		dv, err := r.getDataVolume(dvName)
 		if err != nil {
 			return false, err
@@ -190,7 +206,7 @@ func (r *ReconcileVirtualMachineImport) setupNextStage(provider provider.Provide
 	}
 
 	for dvID, _ := range dvs {
-		dvName := types.NamespacedName{Namespace: instance.Namespace, Name: dvID}
+		dvName := types.NamespacedName{Namespace: utils.TargetNamespace(instance), Name: dvID}
 		dv := &cdiv1.DataVolume{}
 		err := r.client.Get(context.TODO(), dvName, dv)
 		if err != nil {
@@ -251,6 +267,7 @@ func (r *ReconcileVirtualMachineImport) setNextStageTime(vmiName types.Namespace
 	instance.Status.WarmImport.ConsecutiveFailures = 0
 	instanceCopy.Status.WarmImport.NextStageTime = &nextStageTime
 
+	metrics.ImportMetrics.IncWarmImportStageSuccessful(providerName(&instance))
 	return r.client.Status().Update(context.TODO(), instanceCopy)
 }
 
@@ -274,5 +291,6 @@ func (r *ReconcileVirtualMachineImport) setWarmImportCondition(instance *v2vv1.V
 func (r *ReconcileVirtualMachineImport) incrementWarmImportFailures(instance *v2vv1.VirtualMachineImport) error {
 	instance.Status.WarmImport.Failures += 1
 	instance.Status.WarmImport.ConsecutiveFailures += 1
+	metrics.ImportMetrics.IncWarmImportStageFailed(providerName(instance))
 	return r.client.Status().Update(context.TODO(), instance)
 }
@@ -0,0 +1,175 @@
+package customization
+
+import (
+	"fmt"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+)
+
+const (
+	// VolumeName is the name of the volume and of the disk holding the customization data
+	VolumeName = "customization"
+	// sysprepVolumeLabel is the label of the disk holding the unattend file
+	sysprepVolumeLabel = "UNATTEND"
+	// busSata lets the guest read the customization disk whether it has the virtio drivers or not
+	busSata = "sata"
+)
+
+// Validate checks that the customization names exactly one source of data, or only restores the guest network
+func Validate(spec *v2vv1.CustomizationSpec) error {
+	if spec == nil {
+		return nil
+	}
+	switch {
+	case spec.CloudInit != nil && spec.Sysprep != nil:
+		return fmt.Errorf("only one of `cloudInit` and `sysprep` may be set")
+	case spec.CloudInit != nil:
+		if spec.CloudInit.UserDataSecretRef == nil && spec.CloudInit.NetworkDataSecretRef == nil {
+			return fmt.Errorf("`cloudInit` must reference the user data or the network data")
+		}
+		switch spec.CloudInit.Type {
+		case "", v2vv1.CloudInitNoCloud, v2vv1.CloudInitConfigDrive:
+		default:
+			return fmt.Errorf("unknown cloud-init type %s", spec.CloudInit.Type)
+		}
+	case spec.Sysprep != nil:
+		if (spec.Sysprep.ConfigMap == nil) == (spec.Sysprep.Secret == nil) {
+			return fmt.Errorf("exactly one of `sysprep.configMap` and `sysprep.secret` must be set")
+		}
+	case !spec.RestoreNetwork:
+		return fmt.Errorf("one of `cloudInit`, `sysprep` and `restoreNetwork` must be set")
+	}
+	return nil
+}
+
+// AddVolume attaches the customization data to the VM as a CD-ROM, replacing the one attached before. When the
+// customization restores the guest network, the network data is generated from the guest network of the source VM.
+func AddVolume(vmSpec *kubevirtv1.VirtualMachine, spec *v2vv1.CustomizationSpec, guestNetwork *v2vv1.GuestNetworkStatus) error {
+	if spec == nil {
+		return nil
+	}
+	source, err := makeVolumeSource(spec, guestNetwork)
+	if err != nil {
+		return err
+	}
+	if source == nil {
+		return nil
+	}
+	RemoveVolume(vmSpec)
+
+	readOnly := true
+	vmiSpec := &vmSpec.Spec.Template.Spec
+	vmiSpec.Volumes = append(vmiSpec.Volumes, kubevirtv1.Volume{
+		Name:         VolumeName,
+		VolumeSource: *source,
+	})
+	vmiSpec.Domain.Devices.Disks = append(vmiSpec.Domain.Devices.Disks, kubevirtv1.Disk{
+		Name: VolumeName,
+		DiskDevice: kubevirtv1.DiskDevice{
+			CDRom: &kubevirtv1.CDRomTarget{
+				Bus:      busSata,
+				ReadOnly: &readOnly,
+			},
+		},
+	})
+	return nil
+}
+
+// RemoveVolume detaches the customization data from the VM. It returns whether the VM had the data attached.
+func RemoveVolume(vmSpec *kubevirtv1.VirtualMachine) bool {
+	if vmSpec.Spec.Template == nil {
+		return false
+	}
+	vmiSpec := &vmSpec.Spec.Template.Spec
+	removed := false
+
+	volumes := make([]kubevirtv1.Volume, 0, len(vmiSpec.Volumes))
+	for _, volume := range vmiSpec.Volumes {
+		if volume.Name == VolumeName {
+			removed = true
+			continue
+		}
+		volumes = append(volumes, volume)
+	}
+	disks := make([]kubevirtv1.Disk, 0, len(vmiSpec.Domain.Devices.Disks))
+	for _, disk := range vmiSpec.Domain.Devices.Disks {
+		if disk.Name == VolumeName {
+			continue
+		}
+		disks = append(disks, disk)
+	}
+
+	if removed {
+		vmiSpec.Volumes = volumes
+		vmiSpec.Domain.Devices.Disks = disks
+	}
+	return removed
+}
+
+func makeVolumeSource(spec *v2vv1.CustomizationSpec, guestNetwork *v2vv1.GuestNetworkStatus) (*kubevirtv1.VolumeSource, error) {
+	if spec.Sysprep != nil {
+		return makeSysprepVolumeSource(spec.Sysprep), nil
+	}
+
+	cloudInit := spec.CloudInit
+	if cloudInit == nil {
+		cloudInit = &v2vv1.CloudInitCustomization{}
+	}
+	networkData := ""
+	if spec.RestoreNetwork && cloudInit.NetworkDataSecretRef == nil && guestNetwork != nil {
+		data, err := NetworkData(guestNetwork)
+		if err != nil {
+			return nil, err
+		}
+		networkData = data
+	}
+	if cloudInit.UserDataSecretRef == nil && cloudInit.NetworkDataSecretRef == nil && networkData == "" {
+		return nil, nil
+	}
+	userData := ""
+	if cloudInit.UserDataSecretRef == nil {
+		userData = emptyUserData
+	}
+
+	if cloudInit.Type == v2vv1.CloudInitConfigDrive {
+		return &kubevirtv1.VolumeSource{
+			CloudInitConfigDrive: &kubevirtv1.CloudInitConfigDriveSource{
+				UserDataSecretRef:    cloudInit.UserDataSecretRef,
+				UserData:             userData,
+				NetworkDataSecretRef: cloudInit.NetworkDataSecretRef,
+				NetworkData:          networkData,
+			},
+		}, nil
+	}
+	return &kubevirtv1.VolumeSource{
+		CloudInitNoCloud: &kubevirtv1.CloudInitNoCloudSource{
+			UserDataSecretRef:    cloudInit.UserDataSecretRef,
+			UserData:             userData,
+			NetworkDataSecretRef: cloudInit.NetworkDataSecretRef,
+			NetworkData:          networkData,
+		},
+	}, nil
+}
+
+func makeSysprepVolumeSource(sysprep *v2vv1.SysprepCustomization) *kubevirtv1.VolumeSource {
+	// the keys of the config map or secret become the files of the disk, where Windows looks for unattend.xml
+	// and autounattend.xml
+	if sysprep.ConfigMap != nil {
+		return &kubevirtv1.VolumeSource{
+			ConfigMap: &kubevirtv1.ConfigMapVolumeSource{
+				LocalObjectReference: *sysprep.ConfigMap,
+				VolumeLabel:          sysprepVolumeLabel,
+			},
+		}
+	}
+	if sysprep.Secret != nil {
+		return &kubevirtv1.VolumeSource{
+			Secret: &kubevirtv1.SecretVolumeSource{
+				SecretName:  sysprep.Secret.Name,
+				VolumeLabel: sysprepVolumeLabel,
+			},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package customization_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestCustomization(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Customization Suite")
+}
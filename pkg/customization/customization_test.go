@@ -0,0 +1,138 @@
+package customization_test
+
+import (
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/customization"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+)
+
+var _ = Describe("Customization", func() {
+	var vm *kubevirtv1.VirtualMachine
+	userData := &corev1.LocalObjectReference{Name: "user-data"}
+
+	BeforeEach(func() {
+		vm = &kubevirtv1.VirtualMachine{
+			Spec: kubevirtv1.VirtualMachineSpec{
+				Template: &kubevirtv1.VirtualMachineInstanceTemplateSpec{
+					Spec: kubevirtv1.VirtualMachineInstanceSpec{
+						Volumes: []kubevirtv1.Volume{{Name: "dv-disk"}},
+						Domain: kubevirtv1.DomainSpec{
+							Devices: kubevirtv1.Devices{
+								Disks: []kubevirtv1.Disk{{Name: "dv-disk"}},
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	table.DescribeTable("should validate", func(spec *v2vv1.CustomizationSpec, valid bool) {
+		err := customization.Validate(spec)
+
+		if valid {
+			Expect(err).ToNot(HaveOccurred())
+		} else {
+			Expect(err).To(HaveOccurred())
+		}
+	},
+		table.Entry("no customization", nil, true),
+		table.Entry("cloud-init user data", &v2vv1.CustomizationSpec{CloudInit: &v2vv1.CloudInitCustomization{UserDataSecretRef: userData}}, true),
+		table.Entry("config drive", &v2vv1.CustomizationSpec{CloudInit: &v2vv1.CloudInitCustomization{Type: v2vv1.CloudInitConfigDrive, UserDataSecretRef: userData}}, true),
+		table.Entry("sysprep config map", &v2vv1.CustomizationSpec{Sysprep: &v2vv1.SysprepCustomization{ConfigMap: userData}}, true),
+		table.Entry("network restoration only", &v2vv1.CustomizationSpec{RestoreNetwork: true}, true),
+		table.Entry("empty customization", &v2vv1.CustomizationSpec{}, false),
+		table.Entry("cloud-init without data", &v2vv1.CustomizationSpec{CloudInit: &v2vv1.CloudInitCustomization{}}, false),
+		table.Entry("unknown cloud-init type", &v2vv1.CustomizationSpec{CloudInit: &v2vv1.CloudInitCustomization{Type: "Ignition", UserDataSecretRef: userData}}, false),
+		table.Entry("both cloud-init and sysprep", &v2vv1.CustomizationSpec{CloudInit: &v2vv1.CloudInitCustomization{UserDataSecretRef: userData}, Sysprep: &v2vv1.SysprepCustomization{ConfigMap: userData}}, false),
+		table.Entry("sysprep from both config map and secret", &v2vv1.CustomizationSpec{Sysprep: &v2vv1.SysprepCustomization{ConfigMap: userData, Secret: userData}}, false),
+	)
+
+	It("should attach the cloud-init data as a CD-ROM", func() {
+		networkData := &corev1.LocalObjectReference{Name: "network-data"}
+
+		Expect(customization.AddVolume(vm, &v2vv1.CustomizationSpec{CloudInit: &v2vv1.CloudInitCustomization{UserDataSecretRef: userData, NetworkDataSecretRef: networkData}}, nil)).To(Succeed())
+
+		volumes := vm.Spec.Template.Spec.Volumes
+		Expect(volumes).To(HaveLen(2))
+		Expect(volumes[1].Name).To(Equal(customization.VolumeName))
+		Expect(volumes[1].CloudInitNoCloud.UserDataSecretRef).To(Equal(userData))
+		Expect(volumes[1].CloudInitNoCloud.NetworkDataSecretRef).To(Equal(networkData))
+		disks := vm.Spec.Template.Spec.Domain.Devices.Disks
+		Expect(disks).To(HaveLen(2))
+		Expect(disks[1].Name).To(Equal(customization.VolumeName))
+		Expect(disks[1].CDRom.Bus).To(Equal("sata"))
+	})
+
+	It("should attach the cloud-init data as a config drive", func() {
+		Expect(customization.AddVolume(vm, &v2vv1.CustomizationSpec{CloudInit: &v2vv1.CloudInitCustomization{Type: v2vv1.CloudInitConfigDrive, UserDataSecretRef: userData}}, nil)).To(Succeed())
+
+		Expect(vm.Spec.Template.Spec.Volumes[1].CloudInitConfigDrive.UserDataSecretRef).To(Equal(userData))
+	})
+
+	It("should attach the unattend file from a secret", func() {
+		Expect(customization.AddVolume(vm, &v2vv1.CustomizationSpec{Sysprep: &v2vv1.SysprepCustomization{Secret: &corev1.LocalObjectReference{Name: "unattend"}}}, nil)).To(Succeed())
+
+		Expect(vm.Spec.Template.Spec.Volumes[1].Secret.SecretName).To(Equal("unattend"))
+	})
+
+	It("should attach the data only once", func() {
+		spec := &v2vv1.CustomizationSpec{Sysprep: &v2vv1.SysprepCustomization{ConfigMap: &corev1.LocalObjectReference{Name: "unattend"}}}
+
+		Expect(customization.AddVolume(vm, spec, nil)).To(Succeed())
+		Expect(customization.AddVolume(vm, spec, nil)).To(Succeed())
+
+		Expect(vm.Spec.Template.Spec.Volumes).To(HaveLen(2))
+		Expect(vm.Spec.Template.Spec.Volumes[1].ConfigMap.Name).To(Equal("unattend"))
+		Expect(vm.Spec.Template.Spec.Domain.Devices.Disks).To(HaveLen(2))
+	})
+
+	It("should generate the network data restoring the guest network", func() {
+		guestNetwork := &v2vv1.GuestNetworkStatus{
+			Interfaces: []v2vv1.GuestNetworkInterface{{MAC: "56:6f:05:0f:00:05", IPAddresses: []string{"10.0.0.5/24"}}},
+		}
+
+		Expect(customization.AddVolume(vm, &v2vv1.CustomizationSpec{RestoreNetwork: true}, guestNetwork)).To(Succeed())
+
+		noCloud := vm.Spec.Template.Spec.Volumes[1].CloudInitNoCloud
+		Expect(noCloud.UserData).To(Equal("#cloud-config\n"))
+		Expect(noCloud.NetworkData).To(ContainSubstring("10.0.0.5/24"))
+	})
+
+	It("should keep the referenced network data when restoring the guest network", func() {
+		networkData := &corev1.LocalObjectReference{Name: "network-data"}
+		guestNetwork := &v2vv1.GuestNetworkStatus{
+			Interfaces: []v2vv1.GuestNetworkInterface{{MAC: "56:6f:05:0f:00:05", IPAddresses: []string{"10.0.0.5/24"}}},
+		}
+		spec := &v2vv1.CustomizationSpec{
+			CloudInit:      &v2vv1.CloudInitCustomization{UserDataSecretRef: userData, NetworkDataSecretRef: networkData},
+			RestoreNetwork: true,
+		}
+
+		Expect(customization.AddVolume(vm, spec, guestNetwork)).To(Succeed())
+
+		noCloud := vm.Spec.Template.Spec.Volumes[1].CloudInitNoCloud
+		Expect(noCloud.NetworkDataSecretRef).To(Equal(networkData))
+		Expect(noCloud.NetworkData).To(BeEmpty())
+		Expect(noCloud.UserData).To(BeEmpty())
+	})
+
+	It("should attach nothing when there is no guest network to restore", func() {
+		Expect(customization.AddVolume(vm, &v2vv1.CustomizationSpec{RestoreNetwork: true}, nil)).To(Succeed())
+
+		Expect(vm.Spec.Template.Spec.Volumes).To(HaveLen(1))
+	})
+
+	It("should detach the data", func() {
+		Expect(customization.AddVolume(vm, &v2vv1.CustomizationSpec{CloudInit: &v2vv1.CloudInitCustomization{UserDataSecretRef: userData}}, nil)).To(Succeed())
+
+		Expect(customization.RemoveVolume(vm)).To(BeTrue())
+		Expect(vm.Spec.Template.Spec.Volumes).To(ConsistOf(kubevirtv1.Volume{Name: "dv-disk"}))
+		Expect(vm.Spec.Template.Spec.Domain.Devices.Disks).To(ConsistOf(kubevirtv1.Disk{Name: "dv-disk"}))
+		Expect(customization.RemoveVolume(vm)).To(BeFalse())
+	})
+})
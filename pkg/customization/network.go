@@ -0,0 +1,159 @@
+package customization
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"gopkg.in/yaml.v2"
+)
+
+// emptyUserData lets cloud-init apply the network data when the customization references no user data
+const emptyUserData = "#cloud-config\n"
+
+// networkConfig is version 2 of the cloud-init network configuration
+type networkConfig struct {
+	Version   int                 `yaml:"version"`
+	Ethernets map[string]ethernet `yaml:"ethernets"`
+}
+
+type ethernet struct {
+	Match       match        `yaml:"match"`
+	DHCP4       bool         `yaml:"dhcp4"`
+	Addresses   []string     `yaml:"addresses,omitempty"`
+	Routes      []route      `yaml:"routes,omitempty"`
+	Nameservers *nameservers `yaml:"nameservers,omitempty"`
+}
+
+type match struct {
+	MACAddress string `yaml:"macaddress"`
+}
+
+type route struct {
+	To  string `yaml:"to"`
+	Via string `yaml:"via"`
+}
+
+type nameservers struct {
+	Addresses []string `yaml:"addresses,omitempty"`
+	Search    []string `yaml:"search,omitempty"`
+}
+
+// NetworkData returns the cloud-init network configuration re-applying the static addresses, routes and name servers
+// of the guest network on the interfaces with the same MAC addresses. The other interfaces use DHCP.
+func NetworkData(network *v2vv1.GuestNetworkStatus) (string, error) {
+	config := networkConfig{Version: 2, Ethernets: make(map[string]ethernet)}
+	for i, nic := range network.Interfaces {
+		eth := ethernet{Match: match{MACAddress: nic.MAC}, DHCP4: true}
+		if isStatic(nic) {
+			eth.DHCP4 = false
+			eth.Addresses = nic.IPAddresses
+			for _, r := range network.Routes {
+				if routeInterface(network, r) == i {
+					eth.Routes = append(eth.Routes, route{To: r.Destination, Via: r.Gateway})
+				}
+			}
+			if len(network.DNSServers) > 0 || len(network.DNSSearchDomains) > 0 {
+				eth.Nameservers = &nameservers{Addresses: network.DNSServers, Search: network.DNSSearchDomains}
+			}
+		}
+		config.Ethernets[fmt.Sprintf("nic%d", i)] = eth
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// NetshScript returns a Windows batch script re-applying the static addresses, routes and name servers of the guest
+// network on the adapters with the same MAC addresses. It is run once, on the first boot of the imported VM.
+func NetshScript(network *v2vv1.GuestNetworkStatus) string {
+	var script strings.Builder
+	script.WriteString("@echo off\r\n")
+	script.WriteString("rem Re-applies the static IP configuration of the source VM\r\n")
+	for i, nic := range network.Interfaces {
+		if !isStatic(nic) {
+			continue
+		}
+		// Windows writes the MAC addresses of its adapters in upper case, separated by dashes
+		mac := strings.ToUpper(strings.ReplaceAll(nic.MAC, ":", "-"))
+		fmt.Fprintf(&script, "set NIC=\r\n")
+		fmt.Fprintf(&script, "for /f \"usebackq delims=\" %%%%n in (`powershell -NoProfile -Command \"(Get-NetAdapter | Where-Object MacAddress -eq '%s').Name\"`) do set NIC=%%%%n\r\n", mac)
+		fmt.Fprintf(&script, "if not defined NIC goto nic%d\r\n", i)
+
+		ipv4Set := false
+		for _, address := range nic.IPAddresses {
+			ip, ipNet, err := net.ParseCIDR(address)
+			if err != nil {
+				continue
+			}
+			switch {
+			case ip.To4() == nil:
+				fmt.Fprintf(&script, "netsh interface ipv6 add address \"%%NIC%%\" %s\r\n", address)
+			case !ipv4Set:
+				fmt.Fprintf(&script, "netsh interface ipv4 set address \"%%NIC%%\" static %s %s\r\n", ip, net.IP(ipNet.Mask))
+				ipv4Set = true
+			default:
+				fmt.Fprintf(&script, "netsh interface ipv4 add address \"%%NIC%%\" %s %s\r\n", ip, net.IP(ipNet.Mask))
+			}
+		}
+		for _, r := range network.Routes {
+			if routeInterface(network, r) != i {
+				continue
+			}
+			family := "ipv4"
+			if ip := net.ParseIP(r.Gateway); ip != nil && ip.To4() == nil {
+				family = "ipv6"
+			}
+			fmt.Fprintf(&script, "netsh interface %s add route %s \"%%NIC%%\" %s\r\n", family, r.Destination, r.Gateway)
+		}
+		for j, server := range network.DNSServers {
+			family := "ipv4"
+			if ip := net.ParseIP(server); ip != nil && ip.To4() == nil {
+				family = "ipv6"
+			}
+			fmt.Fprintf(&script, "netsh interface %s add dnsservers \"%%NIC%%\" %s index=%d validate=no\r\n", family, server, j+1)
+		}
+		fmt.Fprintf(&script, ":nic%d\r\n", i)
+	}
+	return script.String()
+}
+
+// RestoreNetworkScript returns the netsh script restoring the guest network when the customization asks for it, or
+// nil otherwise
+func RestoreNetworkScript(spec *v2vv1.CustomizationSpec, network *v2vv1.GuestNetworkStatus) []byte {
+	if spec == nil || !spec.RestoreNetwork || network == nil {
+		return nil
+	}
+	return []byte(NetshScript(network))
+}
+
+// isStatic tells whether the interface has addresses that aren't assigned by a DHCP server
+func isStatic(nic v2vv1.GuestNetworkInterface) bool {
+	return !nic.DHCP && len(nic.IPAddresses) > 0
+}
+
+// routeInterface returns the index of the interface a route goes through: the one with the MAC address of the route,
+// or else the first static one on the network of the gateway. It returns -1 when no interface matches.
+func routeInterface(network *v2vv1.GuestNetworkStatus, r v2vv1.GuestNetworkRoute) int {
+	gateway := net.ParseIP(r.Gateway)
+	for i, nic := range network.Interfaces {
+		if !isStatic(nic) {
+			continue
+		}
+		if r.MAC != "" {
+			if strings.EqualFold(r.MAC, nic.MAC) {
+				return i
+			}
+			continue
+		}
+		for _, address := range nic.IPAddresses {
+			if _, ipNet, err := net.ParseCIDR(address); err == nil && gateway != nil && ipNet.Contains(gateway) {
+				return i
+			}
+		}
+	}
+	return -1
+}
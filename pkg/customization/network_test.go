@@ -0,0 +1,64 @@
+package customization_test
+
+import (
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/customization"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Guest network restoration", func() {
+	guestNetwork := &v2vv1.GuestNetworkStatus{
+		Interfaces: []v2vv1.GuestNetworkInterface{
+			{MAC: "56:6f:05:0f:00:05", IPAddresses: []string{"10.0.0.5/24", "10.0.0.6/24"}},
+			{MAC: "56:6f:05:0f:00:06", DHCP: true},
+		},
+		Routes: []v2vv1.GuestNetworkRoute{
+			{Destination: "0.0.0.0/0", Gateway: "10.0.0.1"},
+			{Destination: "192.168.0.0/16", Gateway: "10.0.1.1", MAC: "56:6f:05:0f:00:07"},
+		},
+		DNSServers:       []string{"10.0.0.2", "10.0.0.3"},
+		DNSSearchDomains: []string{"example.com"},
+	}
+
+	It("should generate the cloud-init network data", func() {
+		data, err := customization.NetworkData(guestNetwork)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(Equal(`version: 2
+ethernets:
+  nic0:
+    match:
+      macaddress: 56:6f:05:0f:00:05
+    dhcp4: false
+    addresses:
+    - 10.0.0.5/24
+    - 10.0.0.6/24
+    routes:
+    - to: 0.0.0.0/0
+      via: 10.0.0.1
+    nameservers:
+      addresses:
+      - 10.0.0.2
+      - 10.0.0.3
+      search:
+      - example.com
+  nic1:
+    match:
+      macaddress: 56:6f:05:0f:00:06
+    dhcp4: true
+`))
+	})
+
+	It("should generate the netsh script", func() {
+		script := customization.NetshScript(guestNetwork)
+
+		Expect(script).To(ContainSubstring("Where-Object MacAddress -eq '56-6F-05-0F-00-05'"))
+		Expect(script).To(ContainSubstring("netsh interface ipv4 set address \"%NIC%\" static 10.0.0.5 255.255.255.0\r\n"))
+		Expect(script).To(ContainSubstring("netsh interface ipv4 add address \"%NIC%\" 10.0.0.6 255.255.255.0\r\n"))
+		Expect(script).To(ContainSubstring("netsh interface ipv4 add route 0.0.0.0/0 \"%NIC%\" 10.0.0.1\r\n"))
+		Expect(script).To(ContainSubstring("netsh interface ipv4 add dnsservers \"%NIC%\" 10.0.0.3 index=2 validate=no\r\n"))
+		Expect(script).ToNot(ContainSubstring("56-6F-05-0F-00-06"))
+		Expect(script).ToNot(ContainSubstring("192.168.0.0/16"))
+	})
+})
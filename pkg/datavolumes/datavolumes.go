@@ -4,6 +4,7 @@ import (
 	"context"
 
 	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/utils"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -32,7 +33,7 @@ func (m *Manager) FindFor(vmiCrName types.NamespacedName) ([]*cdiv1.DataVolume,
 	var dvs []*cdiv1.DataVolume
 	for _, dvID := range instance.Status.DataVolumes {
 		dv := &cdiv1.DataVolume{}
-		if err := m.client.Get(context.TODO(), types.NamespacedName{Name: dvID.Name, Namespace: instance.Namespace}, dv); err != nil {
+		if err := m.client.Get(context.TODO(), types.NamespacedName{Name: dvID.Name, Namespace: utils.TargetNamespace(instance)}, dv); err != nil {
 			errs = append(errs, err)
 		}
 		dvs = append(dvs, dv)
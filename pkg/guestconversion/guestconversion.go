@@ -1,8 +1,12 @@
 package guestconversion
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 
@@ -12,9 +16,44 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "kubevirt.io/client-go/api/v1"
 	libvirtxml "libvirt.org/libvirt-go-xml"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("guestconversion")
+
+const (
+	configMapVolumeName        = "libvirt-domain-xml"
+	virtioWinVolumeName        = "virtio-win"
+	virtioWinMountPath         = "/mnt/virtio-win"
+	defaultVirtioWinImagePath  = "/virtio-win.iso"
+	defaultVirtioWinVolumePath = "virtio-win.iso"
+	firstBootVolumeName        = "firstboot-scripts"
+	// firstBootMountPath is where the virt-v2v pod expects to see the first boot scripts
+	firstBootMountPath = "/mnt/firstboot"
+	kvmDevice          = "devices.kubevirt.io/kvm"
+
+	// RestoreNetworkScriptKey is the config map key of the script restoring the network of Windows guests. The
+	// virt-v2v pod runs it on the first boot of the guest when it finds Windows in the converted disks.
+	RestoreNetworkScriptKey = "restore-network.bat"
+
+	// ContainerName is the name of the container running virt-v2v in the guest conversion pod
+	ContainerName = "virt-v2v"
+
+	// PodConfigEnvVar is the environment variable holding the resources and placement of the virt-v2v pods
+	// configured in VMImportConfig, serialized to JSON
+	PodConfigEnvVar = "VIRTV2V_POD_CONFIG"
+	// inspectionCommand is the script of the virt-v2v image inspecting the guest
+	inspectionCommand = "/usr/local/bin/inspect"
+
+	busVirtio = "virtio"
 )
 
-const configMapVolumeName = "libvirt-domain-xml"
+// diskPrefixes are the prefixes of the names of the disks on the buses KubeVirt supports
+var diskPrefixes = map[string]string{
+	busVirtio: "vd",
+	"sata":    "sd",
+	"scsi":    "sd",
+}
 
 var (
 	virtV2vImage    = os.Getenv("VIRTV2V_IMAGE")
@@ -24,13 +63,14 @@ var (
 // MakeGuestConversionPodSpec creates a pod spec for a virt-v2v pod,
 // containing a volume and a mount for each volume on the VM, as well
 // as a volume and mount for the config map containing the libvirt domain XML.
-func MakeGuestConversionPodSpec(vmSpec *v1.VirtualMachine, dataVolumes map[string]cdiv1.DataVolume, libvirtConfigMap *corev1.ConfigMap) *corev1.Pod {
+// The options of the import, if any, are applied on top.
+func MakeGuestConversionPodSpec(vmSpec *v1.VirtualMachine, dataVolumes map[string]cdiv1.DataVolume, libvirtConfigMap *corev1.ConfigMap, options *v2vv1.GuestConversionSpec) *corev1.Pod {
 	// this is the fsGroup that the CDI importer pod uses
 	fsGroup := common.QemuSubGid
 
 	volumes, volumeMounts, volumeDevices := makePodVolumeMounts(vmSpec, dataVolumes, libvirtConfigMap)
 
-	return &corev1.Pod{
+	pod := &corev1.Pod{
 		Spec: corev1.PodSpec{
 			SecurityContext: &corev1.PodSecurityContext{
 				FSGroup: &fsGroup,
@@ -38,7 +78,7 @@ func MakeGuestConversionPodSpec(vmSpec *v1.VirtualMachine, dataVolumes map[strin
 			RestartPolicy: corev1.RestartPolicyNever,
 			Containers: []corev1.Container{
 				{
-					Name:            "virt-v2v",
+					Name:            ContainerName,
 					Image:           virtV2vImage,
 					VolumeMounts:    volumeMounts,
 					VolumeDevices:   volumeDevices,
@@ -46,7 +86,7 @@ func MakeGuestConversionPodSpec(vmSpec *v1.VirtualMachine, dataVolumes map[strin
 					// Request access to /dev/kvm via Kubevirt's Device Manager
 					Resources: corev1.ResourceRequirements{
 						Limits: corev1.ResourceList{
-							"devices.kubevirt.io/kvm": resource.MustParse("1"),
+							kvmDevice: resource.MustParse("1"),
 						},
 					},
 				},
@@ -58,6 +98,203 @@ func MakeGuestConversionPodSpec(vmSpec *v1.VirtualMachine, dataVolumes map[strin
 			},
 		},
 	}
+
+	podConfig := defaultPodConfig()
+	if options != nil {
+		if options.Pod != nil {
+			podConfig = overridePodConfig(podConfig, options.Pod)
+		}
+		applyOptions(&pod.Spec, options)
+	}
+	applyPodConfig(&pod.Spec, podConfig)
+	return pod
+}
+
+// MakeGuestInspectionPodSpec creates a pod spec for a pod running virt-inspector against the disks of the VM,
+// mounted the same way as in the virt-v2v pod. The pod runs with the resources and placement of the virt-v2v pod.
+func MakeGuestInspectionPodSpec(vmSpec *v1.VirtualMachine, dataVolumes map[string]cdiv1.DataVolume, options *v2vv1.GuestConversionSpec) *corev1.Pod {
+	fsGroup := common.QemuSubGid
+
+	volumes, volumeMounts, volumeDevices := makePodVolumeMounts(vmSpec, dataVolumes, nil)
+
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{
+				FSGroup: &fsGroup,
+			},
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{
+					Name:            ContainerName,
+					Image:           virtV2vImage,
+					Command:         []string{inspectionCommand},
+					VolumeMounts:    volumeMounts,
+					VolumeDevices:   volumeDevices,
+					ImagePullPolicy: imagePullPolicy,
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							kvmDevice: resource.MustParse("1"),
+						},
+					},
+				},
+			},
+			Volumes: volumes,
+			NodeSelector: map[string]string{
+				"kubevirt.io/schedulable": "true",
+			},
+		},
+	}
+
+	podConfig := defaultPodConfig()
+	if options != nil && options.Pod != nil {
+		podConfig = overridePodConfig(podConfig, options.Pod)
+	}
+	applyPodConfig(&pod.Spec, podConfig)
+	return pod
+}
+
+// defaultPodConfig returns the resources and placement of the virt-v2v pods configured in VMImportConfig
+func defaultPodConfig() *v2vv1.GuestConversionPodConfig {
+	config := &v2vv1.GuestConversionPodConfig{}
+	if raw := os.Getenv(PodConfigEnvVar); raw != "" {
+		// the variable is set by the operator, so it can't be malformed unless tampered with
+		if err := json.Unmarshal([]byte(raw), config); err != nil {
+			log.Error(err, "Ignoring malformed virt-v2v pod configuration", "EnvVar", PodConfigEnvVar)
+			return &v2vv1.GuestConversionPodConfig{}
+		}
+	}
+	return config
+}
+
+// overridePodConfig returns the configuration with the settings of the import replacing the default ones
+func overridePodConfig(config *v2vv1.GuestConversionPodConfig, override *v2vv1.GuestConversionPodConfig) *v2vv1.GuestConversionPodConfig {
+	merged := config.DeepCopy()
+	if override.Resources != nil {
+		merged.Resources = override.Resources.DeepCopy()
+	}
+	if override.Placement != nil {
+		merged.Placement = override.Placement.DeepCopy()
+	}
+	if override.PriorityClassName != "" {
+		merged.PriorityClassName = override.PriorityClassName
+	}
+	return merged
+}
+
+func applyPodConfig(podSpec *corev1.PodSpec, config *v2vv1.GuestConversionPodConfig) {
+	container := &podSpec.Containers[0]
+	if config.Resources != nil {
+		resources := config.Resources.DeepCopy()
+		if resources.Limits == nil {
+			resources.Limits = corev1.ResourceList{}
+		}
+		resources.Limits[kvmDevice] = container.Resources.Limits[kvmDevice]
+		container.Resources = *resources
+	}
+
+	if config.Placement != nil {
+		// the pod still needs a node where /dev/kvm is present
+		for key, value := range config.Placement.NodeSelector {
+			podSpec.NodeSelector[key] = value
+		}
+		podSpec.Affinity = config.Placement.Affinity.DeepCopy()
+		podSpec.Tolerations = config.Placement.Tolerations
+	}
+
+	podSpec.PriorityClassName = config.PriorityClassName
+}
+
+func applyOptions(podSpec *corev1.PodSpec, options *v2vv1.GuestConversionSpec) {
+	container := &podSpec.Containers[0]
+
+	// the entrypoint of the virt-v2v pod passes its arguments to virt-v2v
+	container.Args = options.ExtraArgs
+
+	if options.TimeoutMinutes != nil {
+		deadline := int64(*options.TimeoutMinutes) * 60
+		podSpec.ActiveDeadlineSeconds = &deadline
+	}
+
+	if options.VMwareTools != "" {
+		container.Env = append(container.Env, corev1.EnvVar{Name: "VMWARE_TOOLS", Value: string(options.VMwareTools)})
+	}
+
+	if options.FirstBootScripts != nil {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: firstBootVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: *options.FirstBootScripts,
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      firstBootVolumeName,
+			MountPath: firstBootMountPath,
+		})
+	}
+
+	if options.VirtioWin != nil {
+		applyVirtioWin(podSpec, options.VirtioWin)
+	}
+}
+
+// applyVirtioWin makes the virtio-win ISO available to the virt-v2v pod, either by mounting the PVC holding it
+// or by copying it out of the image in an init container, and points virt-v2v to it.
+func applyVirtioWin(podSpec *corev1.PodSpec, source *v2vv1.VirtioWinSource) {
+	var isoPath string
+	switch {
+	case source.PersistentVolumeClaim != nil:
+		path := defaultVirtioWinVolumePath
+		if source.Path != nil {
+			path = *source.Path
+		}
+		isoPath = filepath.Join(virtioWinMountPath, path)
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: virtioWinVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: *source.PersistentVolumeClaim,
+					ReadOnly:  true,
+				},
+			},
+		})
+	case source.Image != nil:
+		path := defaultVirtioWinImagePath
+		if source.Path != nil {
+			path = *source.Path
+		}
+		isoPath = filepath.Join(virtioWinMountPath, defaultVirtioWinVolumePath)
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: virtioWinVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+		podSpec.InitContainers = append(podSpec.InitContainers, corev1.Container{
+			Name:            virtioWinVolumeName,
+			Image:           *source.Image,
+			ImagePullPolicy: imagePullPolicy,
+			Command:         []string{"cp", path, isoPath},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      virtioWinVolumeName,
+					MountPath: virtioWinMountPath,
+				},
+			},
+		})
+	default:
+		return
+	}
+
+	container := &podSpec.Containers[0]
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      virtioWinVolumeName,
+		MountPath: virtioWinMountPath,
+		ReadOnly:  true,
+	})
+	// virt-v2v installs the Windows drivers from the ISO the VIRTIO_WIN variable points to
+	container.Env = append(container.Env, corev1.EnvVar{Name: "VIRTIO_WIN", Value: isoPath})
 }
 
 func makePodVolumeMounts(vmSpec *v1.VirtualMachine, dataVolumes map[string]cdiv1.DataVolume, libvirtConfigMap *corev1.ConfigMap) ([]corev1.Volume, []corev1.VolumeMount, []corev1.VolumeDevice) {
@@ -68,6 +305,10 @@ func makePodVolumeMounts(vmSpec *v1.VirtualMachine, dataVolumes map[string]cdiv1
 	// add volumes and mounts for each of the VM's disks.
 	// the virt-v2v pod expects to see the disks mounted at /mnt/disks/diskX
 	for i, v := range vmSpec.Spec.Template.Spec.Volumes {
+		// only the imported disks are converted, not e.g. the customization data
+		if v.DataVolume == nil {
+			continue
+		}
 		var volumeMode corev1.PersistentVolumeMode
 		dv, ok := dataVolumes[v.DataVolume.Name]
 		if ok && dv.Spec.PVC != nil && dv.Spec.PVC.VolumeMode != nil {
@@ -103,6 +344,11 @@ func makePodVolumeMounts(vmSpec *v1.VirtualMachine, dataVolumes map[string]cdiv1
 		}
 	}
 
+	// the inspection pod doesn't need the libvirt domain
+	if libvirtConfigMap == nil {
+		return volumes, volumeMounts, volumeDevices
+	}
+
 	// add volume and mount for the libvirt domain xml config map.
 	// the virt-v2v pod expects to see the libvirt xml at /mnt/v2v/input.xml
 	volumes = append(volumes, corev1.Volume{
@@ -126,8 +372,18 @@ func makePodVolumeMounts(vmSpec *v1.VirtualMachine, dataVolumes map[string]cdiv1
 func MakeLibvirtDomain(vmSpec *v1.VirtualMachine, dataVolumes map[string]cdiv1.DataVolume) *libvirtxml.Domain {
 	// virt-v2v needs a very minimal libvirt domain XML file to be provided
 	// with the locations of each of the disks on the VM that is to be converted.
+	domain := vmSpec.Spec.Template.Spec.Domain
+	disks := make(map[string]v1.Disk)
+	for _, disk := range domain.Devices.Disks {
+		disks[disk.Name] = disk
+	}
+
 	libvirtDisks := make([]libvirtxml.DomainDisk, 0)
+	devicesOnBus := make(map[string]int)
 	for i, vol := range vmSpec.Spec.Template.Spec.Volumes {
+		if vol.DataVolume == nil {
+			continue
+		}
 		diskSource := libvirtxml.DomainDiskSource{}
 
 		dv := dataVolumes[vol.DataVolume.Name]
@@ -143,23 +399,26 @@ func MakeLibvirtDomain(vmSpec *v1.VirtualMachine, dataVolumes map[string]cdiv1.D
 			}
 		}
 
+		bus := diskBus(disks[vol.Name])
+		prefix := diskPrefixes[bus]
 		libvirtDisk := libvirtxml.DomainDisk{
 			Device: "disk",
 			Driver: &libvirtxml.DomainDiskDriver{
 				Name: "qemu",
+				// CDI converts every image it imports to raw, on file system and block volumes alike
 				Type: "raw",
 			},
 			Source: &diskSource,
 			Target: &libvirtxml.DomainDiskTarget{
-				Dev: "hd" + string(rune('a'+i)),
-				Bus: "virtio",
+				Dev: deviceName(prefix, devicesOnBus[prefix]),
+				Bus: bus,
 			},
 		}
+		devicesOnBus[prefix]++
 		libvirtDisks = append(libvirtDisks, libvirtDisk)
 	}
 
 	// generate libvirt domain xml
-	domain := vmSpec.Spec.Template.Spec.Domain
 	return &libvirtxml.Domain{
 		Type: "kvm",
 		Name: vmSpec.Name,
@@ -172,18 +431,91 @@ func MakeLibvirtDomain(vmSpec *v1.VirtualMachine, dataVolumes map[string]cdiv1.D
 				Cores:   int(domain.CPU.Cores),
 			},
 		},
-		OS: &libvirtxml.DomainOS{
-			Type: &libvirtxml.DomainOSType{
-				Type: "hvm",
+		OS: makeLibvirtOS(domain.Firmware),
+		Devices: &libvirtxml.DomainDeviceList{
+			Disks:      libvirtDisks,
+			Interfaces: makeLibvirtInterfaces(domain.Devices.Interfaces),
+		},
+	}
+}
+
+// makeLibvirtOS sets the firmware of the domain, so that virt-v2v handles the bootloader of UEFI guests
+func makeLibvirtOS(firmware *v1.Firmware) *libvirtxml.DomainOS {
+	domainOS := &libvirtxml.DomainOS{
+		Type: &libvirtxml.DomainOSType{
+			Type: "hvm",
+		},
+		BootDevices: []libvirtxml.DomainBootDevice{
+			{
+				Dev: "hd",
 			},
-			BootDevices: []libvirtxml.DomainBootDevice{
-				{
-					Dev: "hd",
+		},
+	}
+	if firmware == nil || firmware.Bootloader == nil || firmware.Bootloader.EFI == nil {
+		return domainOS
+	}
+
+	efi := firmware.Bootloader.EFI
+	domainOS.Firmware = "efi"
+	domainOS.Loader = &libvirtxml.DomainLoader{
+		Type:     "pflash",
+		Readonly: "yes",
+		Secure:   "no",
+	}
+	// KubeVirt enables the secure boot unless it's explicitly disabled
+	if efi.SecureBoot == nil || *efi.SecureBoot {
+		domainOS.Loader.Secure = "yes"
+	}
+	return domainOS
+}
+
+// makeLibvirtInterfaces defines a NIC for each interface of the VM, besides the SR-IOV ones which are passed
+// through to the guest rather than emulated
+func makeLibvirtInterfaces(interfaces []v1.Interface) []libvirtxml.DomainInterface {
+	libvirtInterfaces := make([]libvirtxml.DomainInterface, 0)
+	for _, iface := range interfaces {
+		if iface.SRIOV != nil {
+			continue
+		}
+		model := iface.Model
+		if model == "" {
+			model = busVirtio
+		}
+		libvirtInterface := libvirtxml.DomainInterface{
+			Source: &libvirtxml.DomainInterfaceSource{
+				Network: &libvirtxml.DomainInterfaceSourceNetwork{
+					Network: iface.Name,
 				},
 			},
-		},
-		Devices: &libvirtxml.DomainDeviceList{
-			Disks: libvirtDisks,
-		},
+			Model: &libvirtxml.DomainInterfaceModel{
+				Type: model,
+			},
+		}
+		if iface.MacAddress != "" {
+			libvirtInterface.MAC = &libvirtxml.DomainInterfaceMAC{
+				Address: iface.MacAddress,
+			}
+		}
+		libvirtInterfaces = append(libvirtInterfaces, libvirtInterface)
+	}
+	return libvirtInterfaces
+}
+
+func diskBus(disk v1.Disk) string {
+	if disk.Disk != nil {
+		if _, known := diskPrefixes[disk.Disk.Bus]; known {
+			return disk.Disk.Bus
+		}
+	}
+	return busVirtio
+}
+
+// deviceName names the disk at the index on its bus the way libvirt does: vda to vdz, then vdaa to vdzz and so on
+func deviceName(prefix string, index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('a'+index%26)) + name
+		index = index/26 - 1
 	}
+	return prefix + name
 }
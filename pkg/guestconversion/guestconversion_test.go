@@ -1,13 +1,20 @@
 package guestconversion
 
 import (
+	"encoding/json"
+	"os"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kubevirtv1 "kubevirt.io/client-go/api/v1"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	sdkapi "kubevirt.io/controller-lifecycle-operator-sdk/pkg/sdk/api"
+	libvirtxml "libvirt.org/libvirt-go-xml"
 )
 
 var _ = Describe("GuestConversion", func() {
@@ -61,7 +68,7 @@ var _ = Describe("GuestConversion", func() {
 		})
 
 		It("should create a volume and mount for the libvirt domain config map", func() {
-			pod := MakeGuestConversionPodSpec(vmSpec, dataVolumes, configMap)
+			pod := MakeGuestConversionPodSpec(vmSpec, dataVolumes, configMap, nil)
 			Expect(len(pod.Spec.Volumes)).To(Equal(1))
 			Expect(pod.Spec.Volumes[0].Name).To(Equal(configMapVolumeName))
 			Expect(pod.Spec.Volumes[0].ConfigMap).ToNot(BeNil())
@@ -91,7 +98,7 @@ var _ = Describe("GuestConversion", func() {
 					},
 				},
 			}
-			pod := MakeGuestConversionPodSpec(vmSpec, dataVolumes, configMap)
+			pod := MakeGuestConversionPodSpec(vmSpec, dataVolumes, configMap, nil)
 			Expect(len(pod.Spec.Volumes)).To(Equal(4))
 			Expect(pod.Spec.Volumes[0].Name).To(Equal("dv-1"))
 			Expect(pod.Spec.Volumes[0].VolumeSource.PersistentVolumeClaim.ClaimName).To(Equal("dv-1"))
@@ -115,6 +122,140 @@ var _ = Describe("GuestConversion", func() {
 			Expect(pod.Spec.Containers[0].VolumeDevices[0].Name).To(Equal("dv-block"))
 			Expect(pod.Spec.Containers[0].VolumeDevices[0].DevicePath).To(Equal("/dev/block2"))
 		})
+
+		It("should make an inspection pod mounting the disks only", func() {
+			priorityClass := "inspection"
+			options := &v2vv1.GuestConversionSpec{
+				ExtraArgs: []string{"--root=/dev/sda2"},
+				Pod:       &v2vv1.GuestConversionPodConfig{PriorityClassName: priorityClass},
+			}
+			vmSpec.Spec.Template.Spec.Volumes = []kubevirtv1.Volume{
+				{
+					Name: "dv-1",
+					VolumeSource: kubevirtv1.VolumeSource{
+						DataVolume: &kubevirtv1.DataVolumeSource{Name: "dv-1"},
+					},
+				},
+				{
+					Name: "dv-block",
+					VolumeSource: kubevirtv1.VolumeSource{
+						DataVolume: &kubevirtv1.DataVolumeSource{Name: "dv-block"},
+					},
+				},
+			}
+
+			pod := MakeGuestInspectionPodSpec(vmSpec, dataVolumes, options)
+
+			Expect(pod.Spec.Containers[0].Command).To(Equal([]string{"/usr/local/bin/inspect"}))
+			Expect(pod.Spec.Containers[0].Args).To(BeEmpty())
+			Expect(pod.Spec.Volumes).To(HaveLen(2))
+			for _, volume := range pod.Spec.Volumes {
+				Expect(volume.ConfigMap).To(BeNil())
+			}
+			Expect(pod.Spec.Containers[0].VolumeMounts).To(HaveLen(1))
+			Expect(pod.Spec.Containers[0].VolumeMounts[0].MountPath).To(Equal("/mnt/disks/disk0"))
+			Expect(pod.Spec.Containers[0].VolumeDevices[0].DevicePath).To(Equal("/dev/block1"))
+			Expect(pod.Spec.PriorityClassName).To(Equal(priorityClass))
+		})
+
+		It("should apply the extra arguments, timeout and VMware Tools policy", func() {
+			timeout := int32(90)
+			options := &v2vv1.GuestConversionSpec{
+				ExtraArgs:      []string{"--mac", "00:11:22:33:44:55:network:default"},
+				TimeoutMinutes: &timeout,
+				VMwareTools:    v2vv1.VMwareToolsRemove,
+			}
+
+			pod := MakeGuestConversionPodSpec(vmSpec, dataVolumes, configMap, options)
+
+			Expect(pod.Spec.Containers[0].Args).To(Equal(options.ExtraArgs))
+			Expect(*pod.Spec.ActiveDeadlineSeconds).To(BeEquivalentTo(5400))
+			Expect(pod.Spec.Containers[0].Env).To(ContainElement(v1.EnvVar{Name: "VMWARE_TOOLS", Value: "Remove"}))
+		})
+
+		It("should mount the first boot scripts", func() {
+			options := &v2vv1.GuestConversionSpec{
+				FirstBootScripts: &v1.LocalObjectReference{Name: "scripts"},
+			}
+
+			pod := MakeGuestConversionPodSpec(vmSpec, dataVolumes, configMap, options)
+
+			Expect(pod.Spec.Volumes).To(HaveLen(2))
+			Expect(pod.Spec.Volumes[1].ConfigMap.Name).To(Equal("scripts"))
+			Expect(pod.Spec.Containers[0].VolumeMounts[1].Name).To(Equal(pod.Spec.Volumes[1].Name))
+			Expect(pod.Spec.Containers[0].VolumeMounts[1].MountPath).To(Equal("/mnt/firstboot"))
+		})
+
+		It("should mount virtio-win ISO from PVC", func() {
+			claim := "virtio-win-pvc"
+			path := "drivers/virtio-win-0.1.185.iso"
+			options := &v2vv1.GuestConversionSpec{
+				VirtioWin: &v2vv1.VirtioWinSource{PersistentVolumeClaim: &claim, Path: &path},
+			}
+
+			pod := MakeGuestConversionPodSpec(vmSpec, dataVolumes, configMap, options)
+
+			Expect(pod.Spec.InitContainers).To(BeEmpty())
+			Expect(pod.Spec.Volumes[1].PersistentVolumeClaim.ClaimName).To(Equal(claim))
+			Expect(pod.Spec.Containers[0].VolumeMounts[1].MountPath).To(Equal("/mnt/virtio-win"))
+			Expect(pod.Spec.Containers[0].Env).To(ContainElement(v1.EnvVar{Name: "VIRTIO_WIN", Value: "/mnt/virtio-win/drivers/virtio-win-0.1.185.iso"}))
+		})
+
+		It("should apply the pod configuration", func() {
+			defaults := v2vv1.GuestConversionPodConfig{
+				Resources: &v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("2Gi")},
+				},
+				Placement: &sdkapi.NodePlacement{
+					NodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""},
+					Tolerations:  []v1.Toleration{{Key: "conversion", Operator: v1.TolerationOpExists}},
+				},
+				PriorityClassName: "low",
+			}
+			raw, _ := json.Marshal(defaults)
+			os.Setenv(PodConfigEnvVar, string(raw))
+			defer os.Unsetenv(PodConfigEnvVar)
+			options := &v2vv1.GuestConversionSpec{
+				Pod: &v2vv1.GuestConversionPodConfig{PriorityClassName: "high"},
+			}
+
+			pod := MakeGuestConversionPodSpec(vmSpec, dataVolumes, configMap, options)
+
+			resources := pod.Spec.Containers[0].Resources
+			Expect(resources.Requests).To(HaveKeyWithValue(v1.ResourceMemory, resource.MustParse("2Gi")))
+			Expect(resources.Limits).To(HaveKeyWithValue(v1.ResourceName("devices.kubevirt.io/kvm"), resource.MustParse("1")))
+			Expect(pod.Spec.NodeSelector).To(Equal(map[string]string{
+				"kubevirt.io/schedulable":        "true",
+				"node-role.kubernetes.io/worker": "",
+			}))
+			Expect(pod.Spec.Tolerations).To(Equal(defaults.Placement.Tolerations))
+			Expect(pod.Spec.PriorityClassName).To(Equal("high"))
+		})
+
+		It("should ignore a malformed pod configuration", func() {
+			os.Setenv(PodConfigEnvVar, `{"priorityClassName": "low", "resources": [`)
+			defer os.Unsetenv(PodConfigEnvVar)
+
+			pod := MakeGuestConversionPodSpec(vmSpec, dataVolumes, configMap, nil)
+
+			Expect(pod.Spec.PriorityClassName).To(BeEmpty())
+			Expect(pod.Spec.NodeSelector).To(Equal(map[string]string{"kubevirt.io/schedulable": "true"}))
+		})
+
+		It("should copy virtio-win ISO from image", func() {
+			image := "quay.io/example/virtio-win:latest"
+			options := &v2vv1.GuestConversionSpec{
+				VirtioWin: &v2vv1.VirtioWinSource{Image: &image},
+			}
+
+			pod := MakeGuestConversionPodSpec(vmSpec, dataVolumes, configMap, options)
+
+			Expect(pod.Spec.Volumes[1].EmptyDir).ToNot(BeNil())
+			Expect(pod.Spec.InitContainers).To(HaveLen(1))
+			Expect(pod.Spec.InitContainers[0].Image).To(Equal(image))
+			Expect(pod.Spec.InitContainers[0].Command).To(Equal([]string{"cp", "/virtio-win.iso", "/mnt/virtio-win/virtio-win.iso"}))
+			Expect(pod.Spec.Containers[0].Env).To(ContainElement(v1.EnvVar{Name: "VIRTIO_WIN", Value: "/mnt/virtio-win/virtio-win.iso"}))
+		})
 	})
 
 	Describe("MakeLibvirtDomain", func() {
@@ -194,11 +335,122 @@ var _ = Describe("GuestConversion", func() {
 			domain := MakeLibvirtDomain(vmSpec, dataVolumes)
 			Expect(len(domain.Devices.Disks)).To(Equal(3))
 			Expect(domain.Devices.Disks[0].Source.File.File).To(Equal("/mnt/disks/disk0/disk.img"))
-			Expect(domain.Devices.Disks[0].Target.Dev).To(Equal("hda"))
+			Expect(domain.Devices.Disks[0].Target.Dev).To(Equal("vda"))
 			Expect(domain.Devices.Disks[1].Source.File.File).To(Equal("/mnt/disks/disk1/disk.img"))
-			Expect(domain.Devices.Disks[1].Target.Dev).To(Equal("hdb"))
+			Expect(domain.Devices.Disks[1].Target.Dev).To(Equal("vdb"))
 			Expect(domain.Devices.Disks[2].Source.Block.Dev).To(Equal("/dev/block2"))
-			Expect(domain.Devices.Disks[2].Target.Dev).To(Equal("hdc"))
+			Expect(domain.Devices.Disks[2].Target.Dev).To(Equal("vdc"))
+			for _, disk := range domain.Devices.Disks {
+				Expect(disk.Driver.Type).To(Equal("raw"))
+				Expect(disk.Target.Bus).To(Equal("virtio"))
+			}
+		})
+
+		It("should only define the disks of the data volumes", func() {
+			vmSpec.Spec.Template.Spec.Volumes = append(vmSpec.Spec.Template.Spec.Volumes, kubevirtv1.Volume{
+				Name: "customization",
+				VolumeSource: kubevirtv1.VolumeSource{
+					CloudInitNoCloud: &kubevirtv1.CloudInitNoCloudSource{UserData: "#cloud-config"},
+				},
+			})
+
+			domain := MakeLibvirtDomain(vmSpec, dataVolumes)
+			pod := MakeGuestInspectionPodSpec(vmSpec, dataVolumes, nil)
+
+			Expect(domain.Devices.Disks).To(HaveLen(3))
+			Expect(pod.Spec.Volumes).To(HaveLen(3))
+		})
+
+		It("should name the disks after their bus", func() {
+			vmSpec.Spec.Template.Spec.Domain.Devices.Disks = []kubevirtv1.Disk{
+				{Name: "dv-1", DiskDevice: kubevirtv1.DiskDevice{Disk: &kubevirtv1.DiskTarget{Bus: "sata"}}},
+				{Name: "dv-2", DiskDevice: kubevirtv1.DiskDevice{Disk: &kubevirtv1.DiskTarget{Bus: "virtio"}}},
+				{Name: "dv-block", DiskDevice: kubevirtv1.DiskDevice{Disk: &kubevirtv1.DiskTarget{Bus: "scsi"}}},
+			}
+
+			domain := MakeLibvirtDomain(vmSpec, dataVolumes)
+
+			Expect(domain.Devices.Disks[0].Target).To(Equal(&libvirtxml.DomainDiskTarget{Dev: "sda", Bus: "sata"}))
+			Expect(domain.Devices.Disks[1].Target).To(Equal(&libvirtxml.DomainDiskTarget{Dev: "vda", Bus: "virtio"}))
+			Expect(domain.Devices.Disks[2].Target).To(Equal(&libvirtxml.DomainDiskTarget{Dev: "sdb", Bus: "scsi"}))
+		})
+
+		It("should name more than 26 disks", func() {
+			manyVolumes := make([]kubevirtv1.Volume, 0)
+			for i := 0; i < 30; i++ {
+				manyVolumes = append(manyVolumes, kubevirtv1.Volume{
+					Name: "dv-1",
+					VolumeSource: kubevirtv1.VolumeSource{
+						DataVolume: &kubevirtv1.DataVolumeSource{Name: "dv-1"},
+					},
+				})
+			}
+			vmSpec.Spec.Template.Spec.Volumes = manyVolumes
+
+			domain := MakeLibvirtDomain(vmSpec, dataVolumes)
+
+			Expect(domain.Devices.Disks).To(HaveLen(30))
+			Expect(domain.Devices.Disks[25].Target.Dev).To(Equal("vdz"))
+			Expect(domain.Devices.Disks[26].Target.Dev).To(Equal("vdaa"))
+			Expect(domain.Devices.Disks[29].Target.Dev).To(Equal("vdad"))
+		})
+
+		table.DescribeTable("should name the disk at the index", func(index int, name string) {
+			Expect(deviceName("vd", index)).To(Equal(name))
+		},
+			table.Entry("first", 0, "vda"),
+			table.Entry("last single letter", 25, "vdz"),
+			table.Entry("first double letter", 26, "vdaa"),
+			table.Entry("last double letter", 701, "vdzz"),
+			table.Entry("first triple letter", 702, "vdaaa"),
+		)
+
+		It("should boot with BIOS by default", func() {
+			domain := MakeLibvirtDomain(vmSpec, dataVolumes)
+
+			Expect(domain.OS.Firmware).To(BeEmpty())
+			Expect(domain.OS.Loader).To(BeNil())
+		})
+
+		It("should boot with UEFI and secure boot", func() {
+			vmSpec.Spec.Template.Spec.Domain.Firmware = &kubevirtv1.Firmware{
+				Bootloader: &kubevirtv1.Bootloader{EFI: &kubevirtv1.EFI{}},
+			}
+
+			domain := MakeLibvirtDomain(vmSpec, dataVolumes)
+
+			Expect(domain.OS.Firmware).To(Equal("efi"))
+			Expect(domain.OS.Loader).To(Equal(&libvirtxml.DomainLoader{Type: "pflash", Readonly: "yes", Secure: "yes"}))
+		})
+
+		It("should boot with UEFI without secure boot", func() {
+			secureBoot := false
+			vmSpec.Spec.Template.Spec.Domain.Firmware = &kubevirtv1.Firmware{
+				Bootloader: &kubevirtv1.Bootloader{EFI: &kubevirtv1.EFI{SecureBoot: &secureBoot}},
+			}
+
+			domain := MakeLibvirtDomain(vmSpec, dataVolumes)
+
+			Expect(domain.OS.Firmware).To(Equal("efi"))
+			Expect(domain.OS.Loader.Secure).To(Equal("no"))
+		})
+
+		It("should define the NICs of the VM", func() {
+			vmSpec.Spec.Template.Spec.Domain.Devices.Interfaces = []kubevirtv1.Interface{
+				{Name: "net-1", Model: "e1000", MacAddress: "56:6f:05:0f:00:05"},
+				{Name: "net-2"},
+				{Name: "net-sriov", InterfaceBindingMethod: kubevirtv1.InterfaceBindingMethod{SRIOV: &kubevirtv1.InterfaceSRIOV{}}},
+			}
+
+			domain := MakeLibvirtDomain(vmSpec, dataVolumes)
+
+			Expect(domain.Devices.Interfaces).To(HaveLen(2))
+			Expect(domain.Devices.Interfaces[0].Source.Network.Network).To(Equal("net-1"))
+			Expect(domain.Devices.Interfaces[0].Model.Type).To(Equal("e1000"))
+			Expect(domain.Devices.Interfaces[0].MAC.Address).To(Equal("56:6f:05:0f:00:05"))
+			Expect(domain.Devices.Interfaces[1].Source.Network.Network).To(Equal("net-2"))
+			Expect(domain.Devices.Interfaces[1].Model.Type).To(Equal("virtio"))
+			Expect(domain.Devices.Interfaces[1].MAC).To(BeNil())
 		})
 	})
 })
@@ -0,0 +1,79 @@
+package guestconversion
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+)
+
+const (
+	// FirmwareBIOS is the firmware of the guests booting with BIOS
+	FirmwareBIOS = "bios"
+	// FirmwareUEFI is the firmware of the guests booting with UEFI
+	FirmwareUEFI = "uefi"
+
+	firmwarePrefix = "firmware: "
+	driverPrefix   = "driver: "
+)
+
+// inspectorReport is the part of the virt-inspector XML report the import is interested in
+type inspectorReport struct {
+	OperatingSystems []struct {
+		Name         string `xml:"name"`
+		Distro       string `xml:"distro"`
+		ProductName  string `xml:"product_name"`
+		MajorVersion string `xml:"major_version"`
+		MinorVersion string `xml:"minor_version"`
+		OSInfo       string `xml:"osinfo"`
+	} `xml:"operatingsystem"`
+}
+
+// ParseInspection reads the output of the inspection pod: the virt-inspector XML report, followed by the firmware
+// of the guest and its virtio drivers. Only the first operating system of the report is taken into account, the
+// same one virt-v2v converts.
+func ParseInspection(output io.Reader) (*v2vv1.GuestInspectionStatus, error) {
+	status := &v2vv1.GuestInspectionStatus{}
+	var report strings.Builder
+
+	scanner := bufio.NewScanner(output)
+	scanner.Buffer(make([]byte, 64*1024), maxLineBytes)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, firmwarePrefix):
+			status.Firmware = strings.TrimSpace(strings.TrimPrefix(line, firmwarePrefix))
+		case strings.HasPrefix(line, driverPrefix):
+			status.InstalledDrivers = appendUnique(status.InstalledDrivers, strings.TrimSpace(strings.TrimPrefix(line, driverPrefix)))
+		default:
+			report.WriteString(line)
+			report.WriteString("\n")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	var inspected inspectorReport
+	if err := xml.Unmarshal([]byte(report.String()), &inspected); err != nil {
+		return nil, fmt.Errorf("malformed virt-inspector report: %v", err)
+	}
+	if len(inspected.OperatingSystems) == 0 {
+		return nil, fmt.Errorf("virt-inspector found no operating system")
+	}
+
+	guest := inspected.OperatingSystems[0]
+	status.OperatingSystem = guest.OSInfo
+	status.ProductName = guest.ProductName
+	status.Distro = guest.Distro
+	if status.Distro == "" || status.Distro == "unknown" {
+		status.Distro = guest.Name
+	}
+	if guest.MajorVersion != "" {
+		status.Version = guest.MajorVersion + "." + guest.MinorVersion
+	}
+	return status, nil
+}
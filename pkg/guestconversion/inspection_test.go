@@ -0,0 +1,88 @@
+package guestconversion
+
+import (
+	"strings"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const windowsInspection = `<?xml version="1.0"?>
+<operatingsystems>
+  <operatingsystem>
+    <root>/dev/sda2</root>
+    <name>windows</name>
+    <arch>x86_64</arch>
+    <distro>windows</distro>
+    <product_name>Windows Server 2019 Standard</product_name>
+    <product_variant>Server</product_variant>
+    <major_version>10</major_version>
+    <minor_version>0</minor_version>
+    <windows_systemroot>/Windows</windows_systemroot>
+    <hostname>WIN-2019</hostname>
+    <osinfo>win2k19</osinfo>
+    <mountpoints>
+      <mountpoint dev="/dev/sda2">/</mountpoint>
+    </mountpoints>
+  </operatingsystem>
+</operatingsystems>
+firmware: uefi
+driver: netkvm
+driver: viostor
+`
+
+const linuxInspection = `<?xml version="1.0"?>
+<operatingsystems>
+  <operatingsystem>
+    <root>/dev/rhel/root</root>
+    <name>linux</name>
+    <arch>x86_64</arch>
+    <distro>rhel</distro>
+    <product_name>Red Hat Enterprise Linux 8.2 (Ootpa)</product_name>
+    <major_version>8</major_version>
+    <minor_version>2</minor_version>
+    <osinfo>rhel8.2</osinfo>
+  </operatingsystem>
+</operatingsystems>
+firmware: bios
+`
+
+var _ = Describe("ParseInspection", func() {
+	It("should summarize the inspection of a Windows guest", func() {
+		status, err := ParseInspection(strings.NewReader(windowsInspection))
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(&v2vv1.GuestInspectionStatus{
+			OperatingSystem:  "win2k19",
+			ProductName:      "Windows Server 2019 Standard",
+			Distro:           "windows",
+			Version:          "10.0",
+			Firmware:         FirmwareUEFI,
+			InstalledDrivers: []string{"netkvm", "viostor"},
+		}))
+	})
+
+	It("should summarize the inspection of a Linux guest", func() {
+		status, err := ParseInspection(strings.NewReader(linuxInspection))
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.OperatingSystem).To(Equal("rhel8.2"))
+		Expect(status.Distro).To(Equal("rhel"))
+		Expect(status.Version).To(Equal("8.2"))
+		Expect(status.Firmware).To(Equal(FirmwareBIOS))
+		Expect(status.InstalledDrivers).To(BeEmpty())
+	})
+
+	It("should fail when no operating system is found", func() {
+		_, err := ParseInspection(strings.NewReader("<?xml version=\"1.0\"?>\n<operatingsystems/>\nfirmware: bios\n"))
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail when the report is malformed", func() {
+		_, err := ParseInspection(strings.NewReader("Failed to inspect the guest!\n"))
+
+		Expect(err).To(HaveOccurred())
+	})
+})
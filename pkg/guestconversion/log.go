@@ -0,0 +1,135 @@
+package guestconversion
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+)
+
+const (
+	// LogTailLines is how many lines at the end of the virt-v2v log are kept
+	LogTailLines = 50
+	// maxLogTailBytes caps the size of the kept log tail, so that it fits in the status of the import
+	maxLogTailBytes = 8192
+	maxWarnings     = 20
+	maxLineBytes    = 1024 * 1024
+
+	warningPrefix = "virt-v2v: warning: "
+)
+
+// guestCapabilities maps the guest capabilities virt-v2v reports after the conversion to the drivers they stand for.
+// The capabilities with a boolean value map to a driver only when true.
+var guestCapabilities = map[string]string{
+	"gcaps_block_bus":      "",
+	"gcaps_net_bus":        "",
+	"gcaps_video":          "",
+	"gcaps_virtio_rng":     "virtio-rng",
+	"gcaps_virtio_balloon": "virtio-balloon",
+	"gcaps_isa_pvpanic":    "pvpanic",
+}
+
+// ParseLog reads the virt-v2v log and summarizes the conversion: the inspected operating system, the drivers the
+// converted guest uses and the warnings. The end of the log is kept as well. When the log can't be read to the end,
+// the summary of the part read so far is returned along with the error.
+func ParseLog(log io.Reader) (*v2vv1.GuestConversionStatus, error) {
+	status := &v2vv1.GuestConversionStatus{}
+	inspection := make(map[string]string)
+	tail := make([]string, 0, LogTailLines)
+	warnings := make(map[string]bool)
+
+	scanner := bufio.NewScanner(log)
+	scanner.Buffer(make([]byte, 64*1024), maxLineBytes)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if len(tail) == LogTailLines {
+			tail = tail[1:]
+		}
+		tail = append(tail, line)
+
+		if i := strings.Index(line, warningPrefix); i >= 0 {
+			warning := strings.TrimSpace(line[i+len(warningPrefix):])
+			if !warnings[warning] && len(status.Warnings) < maxWarnings {
+				warnings[warning] = true
+				status.Warnings = append(status.Warnings, warning)
+			}
+			continue
+		}
+
+		key, value, found := parseAssignment(line)
+		if !found {
+			continue
+		}
+		if strings.HasPrefix(key, "i_") {
+			// the inspection is reported once per root, only the first one is converted
+			if _, seen := inspection[key]; !seen {
+				inspection[key] = value
+			}
+		} else if driver, isCapability := guestCapabilities[key]; isCapability {
+			switch {
+			case driver == "":
+				status.InstalledDrivers = appendUnique(status.InstalledDrivers, value)
+			case value == "true":
+				status.InstalledDrivers = appendUnique(status.InstalledDrivers, driver)
+			}
+		}
+	}
+
+	status.OperatingSystem = operatingSystem(inspection)
+	status.LogTail = trimLogTail(strings.Join(tail, "\n"))
+	return status, scanner.Err()
+}
+
+// IsLogStored returns whether the virt-v2v log is summarized in the status completely, so that the virt-v2v pod
+// isn't needed for debugging anymore
+func IsLogStored(status *v2vv1.GuestConversionStatus) bool {
+	return status != nil && status.LogError == ""
+}
+
+func parseAssignment(line string) (string, string, bool) {
+	parts := strings.SplitN(strings.TrimSpace(line), " = ", 2)
+	if len(parts) != 2 || strings.ContainsAny(parts[0], " \t") {
+		return "", "", false
+	}
+	return parts[0], strings.TrimSpace(parts[1]), true
+}
+
+func operatingSystem(inspection map[string]string) string {
+	if name := inspection["i_product_name"]; name != "" && name != "unknown" {
+		return name
+	}
+	distro := inspection["i_distro"]
+	if distro == "" || distro == "unknown" {
+		return inspection["i_type"]
+	}
+	if major, found := inspection["i_major_version"]; found {
+		return distro + " " + major + "." + inspection["i_minor_version"]
+	}
+	return distro
+}
+
+func trimLogTail(tail string) string {
+	if len(tail) <= maxLogTailBytes {
+		return tail
+	}
+	tail = tail[len(tail)-maxLogTailBytes:]
+	// don't start in the middle of a line
+	if i := strings.Index(tail, "\n"); i >= 0 {
+		tail = tail[i+1:]
+	}
+	return tail
+}
+
+func appendUnique(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
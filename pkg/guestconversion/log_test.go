@@ -0,0 +1,76 @@
+package guestconversion
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const windowsLog = `[   0.0] Opening the source -i libvirtxml /mnt/v2v/input.xml
+[   1.2] Inspecting the overlay
+i_root = /dev/sda2
+i_type = windows
+i_distro = windows
+i_arch = x86_64
+i_major_version = 6
+i_minor_version = 3
+i_product_name = Windows Server 2012 R2 Standard
+virt-v2v: warning: /usr/share/virt-tools/pnp_wait.exe is missing.  Firstboot scripts may conflict with PnP.
+[  12.8] Converting Windows Server 2012 R2 Standard to run on KVM
+virt-v2v: warning: /usr/share/virt-tools/pnp_wait.exe is missing.  Firstboot scripts may conflict with PnP.
+gcaps_block_bus = virtio-blk
+gcaps_net_bus = virtio-net
+gcaps_video = qxl
+gcaps_virtio_rng = true
+gcaps_virtio_balloon = true
+gcaps_isa_pvpanic = false
+[  20.1] Mapping filesystem data to avoid copying unused and blank areas
+[  21.4] Closing the overlay`
+
+var _ = Describe("ParseLog", func() {
+	It("should summarize the conversion", func() {
+		status, err := ParseLog(strings.NewReader(windowsLog))
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.OperatingSystem).To(Equal("Windows Server 2012 R2 Standard"))
+		Expect(status.InstalledDrivers).To(Equal([]string{"virtio-blk", "virtio-net", "qxl", "virtio-rng", "virtio-balloon"}))
+		Expect(status.Warnings).To(Equal([]string{"/usr/share/virt-tools/pnp_wait.exe is missing.  Firstboot scripts may conflict with PnP."}))
+		Expect(status.LogTail).To(Equal(windowsLog))
+	})
+
+	It("should describe the operating system by distribution when the product is unknown", func() {
+		status, err := ParseLog(strings.NewReader("i_type = linux\ni_distro = rhel\ni_major_version = 8\ni_minor_version = 2\ni_product_name = unknown"))
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.OperatingSystem).To(Equal("rhel 8.2"))
+	})
+
+	It("should keep the tail of the log", func() {
+		var log strings.Builder
+		for i := 0; i < 2*LogTailLines; i++ {
+			fmt.Fprintf(&log, "line %d\n", i)
+		}
+
+		status, err := ParseLog(strings.NewReader(log.String()))
+
+		Expect(err).ToNot(HaveOccurred())
+		lines := strings.Split(status.LogTail, "\n")
+		Expect(lines).To(HaveLen(LogTailLines))
+		Expect(lines[0]).To(Equal(fmt.Sprintf("line %d", LogTailLines)))
+	})
+
+	It("should cap the size of the log tail", func() {
+		line := strings.Repeat("x", 1000)
+		log := strings.Repeat(line+"\n", LogTailLines)
+
+		status, err := ParseLog(strings.NewReader(log))
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(len(status.LogTail)).To(BeNumerically("<=", maxLogTailBytes))
+		for _, l := range strings.Split(status.LogTail, "\n") {
+			Expect(l).To(Equal(line))
+		}
+	})
+})
@@ -0,0 +1,193 @@
+package macaddress
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// rangeStartKey is the key of the first MAC address of the KubeMacPool range in its config map
+	rangeStartKey = "RANGE_START"
+	// rangeEndKey is the key of the last MAC address of the KubeMacPool range in its config map
+	rangeEndKey = "RANGE_END"
+)
+
+// NIC is a network interface of the source VM, as mapped to the target VM
+type NIC struct {
+	// Name is the name of the interface of the target VM
+	Name string
+	// MAC is the MAC address of the NIC of the source VM
+	MAC string
+	// Policy tells whether the interface of the target VM keeps the MAC address
+	Policy v2vv1.MACPolicy
+}
+
+// Conflict is a MAC address of the source VM already used in the cluster
+type Conflict struct {
+	NIC NIC
+	// UsedBy describes what uses the MAC address
+	UsedBy string
+}
+
+// String describes the conflict
+func (c Conflict) String() string {
+	return fmt.Sprintf("MAC address %s of interface %s is used by %s", c.NIC.MAC, c.NIC.Name, c.UsedBy)
+}
+
+// InvalidRangeError is returned when the KubeMacPool config map holds a range that isn't made of valid MAC addresses
+type InvalidRangeError struct {
+	ConfigMap types.NamespacedName
+	Err       error
+}
+
+func (e *InvalidRangeError) Error() string {
+	return fmt.Sprintf("invalid KubeMacPool range in config map %s: %v", e.ConfigMap, e.Err)
+}
+
+// IsInvalidRange returns whether the error is caused by an invalid KubeMacPool range
+func IsInvalidRange(err error) bool {
+	_, ok := err.(*InvalidRangeError)
+	return ok
+}
+
+// ResolvePolicy returns the MAC policy of a NIC: the one of its network mapping, or else the one of the import
+func ResolvePolicy(importPolicy v2vv1.MACPolicy, mappingPolicy *v2vv1.MACPolicy) v2vv1.MACPolicy {
+	if mappingPolicy != nil && *mappingPolicy != "" {
+		return *mappingPolicy
+	}
+	if importPolicy == "" {
+		return v2vv1.MACPolicyPreserve
+	}
+	return importPolicy
+}
+
+// Checker finds the MAC addresses already used in the cluster
+type Checker struct {
+	client               client.Client
+	kubeMacPoolNamespace string
+	kubeMacPoolConfigMap string
+}
+
+// NewChecker creates a new Checker. The MAC addresses are also checked against the range of KubeMacPool, read from
+// the given config map when it exists.
+func NewChecker(client client.Client, kubeMacPoolNamespace string, kubeMacPoolConfigMap string) *Checker {
+	return &Checker{
+		client:               client,
+		kubeMacPoolNamespace: kubeMacPoolNamespace,
+		kubeMacPoolConfigMap: kubeMacPoolConfigMap,
+	}
+}
+
+// FindConflicts returns the NICs keeping their MAC address while it is used by another VM of the cluster, or while
+// it belongs to the range KubeMacPool assigns addresses from
+func (c *Checker) FindConflicts(nics []NIC) ([]Conflict, error) {
+	var preserved []NIC
+	for _, nic := range nics {
+		if nic.MAC != "" && nic.Policy != v2vv1.MACPolicyRegenerate {
+			preserved = append(preserved, nic)
+		}
+	}
+	if len(preserved) == 0 {
+		return nil, nil
+	}
+
+	used, err := c.usedMACAddresses()
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := c.kubeMacPoolRange()
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []Conflict
+	for _, nic := range preserved {
+		mac, err := net.ParseMAC(nic.MAC)
+		if err != nil {
+			continue
+		}
+		if vm, found := used[mac.String()]; found {
+			conflicts = append(conflicts, Conflict{NIC: nic, UsedBy: "VM " + vm})
+		} else if start != nil && inRange(mac, start, end) {
+			conflicts = append(conflicts, Conflict{NIC: nic, UsedBy: fmt.Sprintf("the KubeMacPool range %s-%s", start, end)})
+		}
+	}
+	return conflicts, nil
+}
+
+// usedMACAddresses returns the MAC addresses of the interfaces of the VMs of the cluster, with the VM using each. The
+// addresses KubeVirt assigned to the running VMs are read from the status of their instances.
+func (c *Checker) usedMACAddresses() (map[string]string, error) {
+	vms := &kubevirtv1.VirtualMachineList{}
+	if err := c.client.List(context.TODO(), vms); err != nil {
+		return nil, err
+	}
+	used := make(map[string]string)
+	for _, vm := range vms.Items {
+		if vm.Spec.Template == nil {
+			continue
+		}
+		for _, iface := range vm.Spec.Template.Spec.Domain.Devices.Interfaces {
+			if mac, err := net.ParseMAC(iface.MacAddress); err == nil {
+				used[mac.String()] = vm.Namespace + "/" + vm.Name
+			}
+		}
+	}
+
+	vmis := &kubevirtv1.VirtualMachineInstanceList{}
+	if err := c.client.List(context.TODO(), vmis); err != nil {
+		return nil, err
+	}
+	for _, vmi := range vmis.Items {
+		for _, iface := range vmi.Status.Interfaces {
+			mac, err := net.ParseMAC(iface.MAC)
+			if err != nil {
+				continue
+			}
+			if _, found := used[mac.String()]; !found {
+				used[mac.String()] = vmi.Namespace + "/" + vmi.Name
+			}
+		}
+	}
+	return used, nil
+}
+
+// kubeMacPoolRange returns the first and last addresses KubeMacPool assigns, or nil when KubeMacPool isn't deployed.
+// A range that can't be parsed is an InvalidRangeError, since the addresses KubeMacPool assigns couldn't be checked.
+func (c *Checker) kubeMacPoolRange() (net.HardwareAddr, net.HardwareAddr, error) {
+	if c.kubeMacPoolConfigMap == "" {
+		return nil, nil, nil
+	}
+	name := types.NamespacedName{Namespace: c.kubeMacPoolNamespace, Name: c.kubeMacPoolConfigMap}
+	configMap := &corev1.ConfigMap{}
+	err := c.client.Get(context.TODO(), name, configMap)
+	if k8serrors.IsNotFound(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	start, err := net.ParseMAC(strings.TrimSpace(configMap.Data[rangeStartKey]))
+	if err != nil {
+		return nil, nil, &InvalidRangeError{ConfigMap: name, Err: fmt.Errorf("%s: %v", rangeStartKey, err)}
+	}
+	end, err := net.ParseMAC(strings.TrimSpace(configMap.Data[rangeEndKey]))
+	if err != nil {
+		return nil, nil, &InvalidRangeError{ConfigMap: name, Err: fmt.Errorf("%s: %v", rangeEndKey, err)}
+	}
+	return start, end, nil
+}
+
+func inRange(mac net.HardwareAddr, start net.HardwareAddr, end net.HardwareAddr) bool {
+	return bytes.Compare(mac, start) >= 0 && bytes.Compare(mac, end) <= 0
+}
@@ -0,0 +1,13 @@
+package macaddress_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestMACAddress(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "MAC Address Suite")
+}
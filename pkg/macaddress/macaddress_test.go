@@ -0,0 +1,119 @@
+package macaddress_test
+
+import (
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/macaddress"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("MAC addresses", func() {
+	regenerate := v2vv1.MACPolicyRegenerate
+
+	table.DescribeTable("should resolve the policy", func(importPolicy v2vv1.MACPolicy, mappingPolicy *v2vv1.MACPolicy, expected v2vv1.MACPolicy) {
+		Expect(macaddress.ResolvePolicy(importPolicy, mappingPolicy)).To(Equal(expected))
+	},
+		table.Entry("preserve by default", v2vv1.MACPolicy(""), nil, v2vv1.MACPolicyPreserve),
+		table.Entry("from the import", v2vv1.MACPolicyPreserveIfUnique, nil, v2vv1.MACPolicyPreserveIfUnique),
+		table.Entry("from the mapping", v2vv1.MACPolicyPreserveIfUnique, &regenerate, v2vv1.MACPolicyRegenerate),
+	)
+
+	Describe("conflicts", func() {
+		var objects []runtime.Object
+
+		BeforeEach(func() {
+			objects = []runtime.Object{
+				&kubevirtv1.VirtualMachine{
+					ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "prod"},
+					Spec: kubevirtv1.VirtualMachineSpec{
+						Template: &kubevirtv1.VirtualMachineInstanceTemplateSpec{
+							Spec: kubevirtv1.VirtualMachineInstanceSpec{
+								Domain: kubevirtv1.DomainSpec{
+									Devices: kubevirtv1.Devices{
+										Interfaces: []kubevirtv1.Interface{{Name: "nic1", MacAddress: "56:6F:05:0F:00:05"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		newChecker := func() *macaddress.Checker {
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			Expect(kubevirtv1.AddToScheme(scheme)).To(Succeed())
+			return macaddress.NewChecker(fake.NewFakeClientWithScheme(scheme, objects...), "kubemacpool-system", "kubemacpool-mac-range-config")
+		}
+
+		findConflicts := func(nics ...macaddress.NIC) []macaddress.Conflict {
+			conflicts, err := newChecker().FindConflicts(nics)
+
+			Expect(err).ToNot(HaveOccurred())
+			return conflicts
+		}
+
+		It("should find a MAC address used by another VM", func() {
+			conflicts := findConflicts(macaddress.NIC{Name: "nic1", MAC: "56:6f:05:0f:00:05", Policy: v2vv1.MACPolicyPreserve})
+
+			Expect(conflicts).To(HaveLen(1))
+			Expect(conflicts[0].String()).To(Equal("MAC address 56:6f:05:0f:00:05 of interface nic1 is used by VM prod/running"))
+		})
+
+		It("should ignore the MAC addresses that are regenerated", func() {
+			Expect(findConflicts(macaddress.NIC{Name: "nic1", MAC: "56:6f:05:0f:00:05", Policy: v2vv1.MACPolicyRegenerate})).To(BeEmpty())
+		})
+
+		It("should find a MAC address in the KubeMacPool range", func() {
+			objects = append(objects, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "kubemacpool-mac-range-config", Namespace: "kubemacpool-system"},
+				Data:       map[string]string{"RANGE_START": "02:00:00:00:00:00", "RANGE_END": "02:FF:FF:FF:FF:FF"},
+			})
+
+			conflicts := findConflicts(
+				macaddress.NIC{Name: "nic1", MAC: "02:00:00:00:00:07", Policy: v2vv1.MACPolicyPreserveIfUnique},
+				macaddress.NIC{Name: "nic2", MAC: "00:50:56:aa:00:01", Policy: v2vv1.MACPolicyPreserve},
+			)
+
+			Expect(conflicts).To(HaveLen(1))
+			Expect(conflicts[0].NIC.Name).To(Equal("nic1"))
+			Expect(conflicts[0].UsedBy).To(ContainSubstring("KubeMacPool"))
+		})
+
+		It("should find a MAC address KubeVirt assigned to a running VM", func() {
+			objects = append(objects, &kubevirtv1.VirtualMachineInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "assigned", Namespace: "prod"},
+				Status: kubevirtv1.VirtualMachineInstanceStatus{
+					Interfaces: []kubevirtv1.VirtualMachineInstanceNetworkInterface{{Name: "nic1", MAC: "02:00:00:00:00:09"}},
+				},
+			})
+
+			conflicts := findConflicts(macaddress.NIC{Name: "nic1", MAC: "02:00:00:00:00:09", Policy: v2vv1.MACPolicyPreserve})
+
+			Expect(conflicts).To(HaveLen(1))
+			Expect(conflicts[0].UsedBy).To(Equal("VM prod/assigned"))
+		})
+
+		It("should fail on an invalid KubeMacPool range", func() {
+			objects = append(objects, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "kubemacpool-mac-range-config", Namespace: "kubemacpool-system"},
+				Data:       map[string]string{"RANGE_START": "02:00:00:00:00:00", "RANGE_END": "02:FF:FF"},
+			})
+
+			_, err := newChecker().FindConflicts([]macaddress.NIC{{Name: "nic1", MAC: "02:00:00:00:00:07", Policy: v2vv1.MACPolicyPreserve}})
+
+			Expect(macaddress.IsInvalidRange(err)).To(BeTrue())
+		})
+
+		It("should find no conflict without KubeMacPool", func() {
+			Expect(findConflicts(macaddress.NIC{Name: "nic1", MAC: "02:00:00:00:00:07", Policy: v2vv1.MACPolicyPreserve})).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,56 @@
+package mappings
+
+import (
+	"github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+)
+
+const (
+	networkTypeMultus = "multus"
+	networkTypePod    = "pod"
+)
+
+// MergeUnmappedNetworkPolicy returns the primary unmapped network policy, or the secondary one when the primary isn't set
+func MergeUnmappedNetworkPolicy(primaryPolicy *v1beta1.UnmappedNetworkPolicy, secondaryPolicy *v1beta1.UnmappedNetworkPolicy) *v1beta1.UnmappedNetworkPolicy {
+	if primaryPolicy != nil {
+		return primaryPolicy
+	}
+	return secondaryPolicy
+}
+
+// UnmappedNetworkAction returns the action of the policy, Fail when it isn't set
+func UnmappedNetworkAction(policy *v1beta1.UnmappedNetworkPolicy) v1beta1.UnmappedNetworkAction {
+	if policy == nil || policy.Action == "" {
+		return v1beta1.UnmappedNetworkFail
+	}
+	return policy.Action
+}
+
+// UnmappedNetworkMapping returns the network mapping applied to the NICs connected to networks without a mapping, or nil
+// when the policy doesn't connect them to any network
+func UnmappedNetworkMapping(policy *v1beta1.UnmappedNetworkPolicy) *v1beta1.NetworkResourceMappingItem {
+	switch UnmappedNetworkAction(policy) {
+	case v1beta1.UnmappedNetworkPod:
+		networkType := networkTypePod
+		return &v1beta1.NetworkResourceMappingItem{Type: &networkType}
+	case v1beta1.UnmappedNetworkMultus:
+		if policy.Target == nil {
+			return nil
+		}
+		networkType := networkTypeMultus
+		return &v1beta1.NetworkResourceMappingItem{Target: *policy.Target, Type: &networkType}
+	}
+	return nil
+}
+
+// NewUnmappedNetworkStatus records what the policy does with a NIC connected to a network without a mapping
+func NewUnmappedNetworkStatus(nic string, network string, policy *v1beta1.UnmappedNetworkPolicy) v1beta1.UnmappedNetworkStatus {
+	status := v1beta1.UnmappedNetworkStatus{
+		NIC:     nic,
+		Network: network,
+		Action:  UnmappedNetworkAction(policy),
+	}
+	if status.Action == v1beta1.UnmappedNetworkMultus {
+		status.Target = policy.Target
+	}
+	return status
+}
@@ -1,6 +1,9 @@
 package metrics
 
 import (
+	"sync"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -13,8 +16,44 @@ const (
 	MetricsPort int32 = 8383
 	// OperatorMetricsPort port where operator metrics are served
 	OperatorMetricsPort int32 = 8686
+
+	// ProviderOvirt is the provider label of imports from oVirt
+	ProviderOvirt = "ovirt"
+	// ProviderVmware is the provider label of imports from VMware
+	ProviderVmware = "vmware"
+	// ProviderUnknown is the provider label of imports with invalid source
+	ProviderUnknown = "unknown"
+
+	// PhaseValidation is the phase of validating the source VM and the mappings
+	PhaseValidation = "validation"
+	// PhaseStopVM is the phase of shutting down the source VM
+	PhaseStopVM = "stop_vm"
+	// PhaseCopy is the phase of creating the target VM and copying the disks
+	PhaseCopy = "copy"
+	// PhaseInspection is the phase of inspecting the guest
+	PhaseInspection = "inspection"
+	// PhaseConversion is the phase of converting the guest
+	PhaseConversion = "conversion"
+	// PhaseStart is the phase of starting the target VM
+	PhaseStart = "start"
 )
 
+// phaseOrder defines the order of the import phases, an import never goes back to an earlier phase
+var phaseOrder = map[string]int{
+	PhaseValidation: 0,
+	PhaseStopVM:     1,
+	PhaseCopy:       2,
+	PhaseInspection: 3,
+	PhaseConversion: 4,
+	PhaseStart:      5,
+}
+
+// importDurationBuckets are the buckets of kubevirt_vmimport_duration: 1 minute, 15 minutes, 1 hour and above 1 hour duration
+var importDurationBuckets = []float64{60, 15 * 60, 60 * 60}
+
+// durationBuckets are buckets for import durations: 1 minute, 5 minutes, 15 minutes, 30 minutes, 1 hour, 2 hours, 4 hours, 8 hours, 1 day and above
+var durationBuckets = []float64{60, 5 * 60, 15 * 60, 30 * 60, 60 * 60, 2 * 60 * 60, 4 * 60 * 60, 8 * 60 * 60, 24 * 60 * 60}
+
 var (
 	// counter vector which count the number of vm imports done
 	importCounterVec = prometheus.NewCounterVec(
@@ -29,22 +68,165 @@ var (
 		prometheus.HistogramOpts{
 			Name:    "kubevirt_vmimport_duration",
 			Help:    "Statistics of virtual machine import duration",
-			Buckets: []float64{60, 15 * 60, 60 * 60}, // 1 minute, 15 minutes 1 hour and above 1 hour duration
+			Buckets: importDurationBuckets,
 		},
 		[]string{"result"},
 	)
+	// counter vector which count the number of vm imports done per provider
+	providerImportCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kubevirt_vmimport_provider_imports_total",
+			Help: "Count of virtual machine import done per provider",
+		},
+		[]string{"provider", "result"},
+	)
+	// Histogram for duration of imports per provider
+	providerImportDurationVec = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kubevirt_vmimport_provider_import_duration_seconds",
+			Help:    "Statistics of virtual machine import duration per provider",
+			Buckets: durationBuckets,
+		},
+		[]string{"provider", "result"},
+	)
+	// Histogram for duration of the individual import phases
+	phaseDurationVec = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kubevirt_vmimport_phase_duration_seconds",
+			Help:    "Statistics of virtual machine import phase duration",
+			Buckets: durationBuckets,
+		},
+		[]string{"provider", "phase"},
+	)
+	// gauge vector of the imports currently in each phase
+	inProgressGaugeVec = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kubevirt_vmimport_in_progress",
+			Help: "Number of virtual machine imports in progress",
+		},
+		[]string{"provider", "phase"},
+	)
+	// gauge vector of the imports that haven't finished yet
+	queueDepthGaugeVec = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kubevirt_vmimport_queue_depth",
+			Help: "Number of virtual machine imports that haven't finished yet",
+		},
+		[]string{"provider"},
+	)
+	// gauge vector of the bytes transferred by each import
+	bytesTransferredGaugeVec = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kubevirt_vmimport_bytes_transferred",
+			Help: "Number of bytes transferred by the virtual machine import",
+		},
+		[]string{"provider", "namespace", "name"},
+	)
+	// gauge vector of the transfer rate of each import
+	throughputGaugeVec = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kubevirt_vmimport_throughput_bytes_per_second",
+			Help: "Transfer rate of the virtual machine import in bytes per second",
+		},
+		[]string{"provider", "namespace", "name"},
+	)
+	// gauge vector of the time when each import entered its current phase
+	phaseStartTimeGaugeVec = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kubevirt_vmimport_phase_start_time_seconds",
+			Help: "Unix time when the virtual machine import entered its current phase",
+		},
+		[]string{"provider", "namespace", "name", "phase"},
+	)
+	// counter vector which count the failed connections to the source provider
+	providerConnectionFailureCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kubevirt_vmimport_provider_connection_failures_total",
+			Help: "Count of failed connections to the source provider",
+		},
+		[]string{"provider"},
+	)
+	// counter vector which count the warm import stages
+	warmImportStageCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kubevirt_vmimport_warm_import_stages_total",
+			Help: "Count of warm import stages done",
+		},
+		[]string{"provider", "result"},
+	)
+	// counter vector which count the validation failures
+	validationFailureCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kubevirt_vmimport_validation_failures_total",
+			Help: "Count of virtual machine import validation failures",
+		},
+		[]string{"provider", "check_id"},
+	)
 	// ImportMetrics wrapper for all import metrics
-	ImportMetrics = importMetrics{importCounterVec, importDurationVec}
+	ImportMetrics = importMetrics{
+		importCounterVec:                    importCounterVec,
+		importDurationVec:                   importDurationVec,
+		providerImportCounterVec:            providerImportCounterVec,
+		providerImportDurationVec:           providerImportDurationVec,
+		phaseDurationVec:                    phaseDurationVec,
+		inProgressGaugeVec:                  inProgressGaugeVec,
+		queueDepthGaugeVec:                  queueDepthGaugeVec,
+		bytesTransferredGaugeVec:            bytesTransferredGaugeVec,
+		throughputGaugeVec:                  throughputGaugeVec,
+		phaseStartTimeGaugeVec:              phaseStartTimeGaugeVec,
+		providerConnectionFailureCounterVec: providerConnectionFailureCounterVec,
+		warmImportStageCounterVec:           warmImportStageCounterVec,
+		validationFailureCounterVec:         validationFailureCounterVec,
+		phases:                              make(map[string]*importPhase),
+	}
 )
 
 func init() {
-	metrics.Registry.MustRegister(importCounterVec, importDurationVec)
+	metrics.Registry.MustRegister(
+		importCounterVec,
+		importDurationVec,
+		providerImportCounterVec,
+		providerImportDurationVec,
+		phaseDurationVec,
+		inProgressGaugeVec,
+		queueDepthGaugeVec,
+		bytesTransferredGaugeVec,
+		throughputGaugeVec,
+		phaseStartTimeGaugeVec,
+		providerConnectionFailureCounterVec,
+		warmImportStageCounterVec,
+		validationFailureCounterVec,
+	)
 }
 
 // importMetrics holds all metrics
 type importMetrics struct {
-	importCounterVec  *prometheus.CounterVec
-	importDurationVec *prometheus.HistogramVec
+	importCounterVec                    *prometheus.CounterVec
+	importDurationVec                   *prometheus.HistogramVec
+	providerImportCounterVec            *prometheus.CounterVec
+	providerImportDurationVec           *prometheus.HistogramVec
+	phaseDurationVec                    *prometheus.HistogramVec
+	inProgressGaugeVec                  *prometheus.GaugeVec
+	queueDepthGaugeVec                  *prometheus.GaugeVec
+	bytesTransferredGaugeVec            *prometheus.GaugeVec
+	throughputGaugeVec                  *prometheus.GaugeVec
+	phaseStartTimeGaugeVec              *prometheus.GaugeVec
+	providerConnectionFailureCounterVec *prometheus.CounterVec
+	warmImportStageCounterVec           *prometheus.CounterVec
+	validationFailureCounterVec         *prometheus.CounterVec
+
+	// phases holds the current phase of the imports in progress, keyed by namespace/name of the import
+	phases     map[string]*importPhase
+	phasesLock sync.Mutex
+}
+
+// importPhase is the phase an import is currently in
+type importPhase struct {
+	provider  string
+	namespace string
+	name      string
+	phase     string
+	start     time.Time
 }
 
 // Return current value of counter. If error is not nil then value is undefined
@@ -54,46 +236,80 @@ func (ic *importMetrics) getValue(labels prometheus.Labels) (float64, error) {
 	return m.Counter.GetValue(), err
 }
 
+// Return current value of the provider counter. If error is not nil then value is undefined
+func (ic *importMetrics) getProviderValue(labels prometheus.Labels) (float64, error) {
+	var m = &dto.Metric{}
+	err := ic.providerImportCounterVec.With(labels).Write(m)
+	return m.Counter.GetValue(), err
+}
+
+// incResult increments the import counters with the result label
+func (ic *importMetrics) incResult(provider string, result string) {
+	ic.importCounterVec.With(prometheus.Labels{"result": result}).Inc()
+	ic.providerImportCounterVec.With(prometheus.Labels{"provider": provider, "result": result}).Inc()
+}
+
+// saveDuration observes the import duration with the result label
+func (ic *importMetrics) saveDuration(provider string, result string, d float64) {
+	ic.importDurationVec.With(prometheus.Labels{"result": result}).Observe(d)
+	ic.providerImportDurationVec.With(prometheus.Labels{"provider": provider, "result": result}).Observe(d)
+}
+
 // IncFailed increment failed label
-func (ic *importMetrics) IncFailed() {
-	ic.importCounterVec.With(prometheus.Labels{"result": "failed"}).Inc()
+func (ic *importMetrics) IncFailed(provider string) {
+	ic.incResult(provider, "failed")
 }
 
 func (ic *importMetrics) GetFailed() (float64, error) {
 	return ic.getValue(prometheus.Labels{"result": "failed"})
 }
 
+// GetProviderFailed returns number of failed imports from the provider
+func (ic *importMetrics) GetProviderFailed(provider string) (float64, error) {
+	return ic.getProviderValue(prometheus.Labels{"provider": provider, "result": "failed"})
+}
+
 // IncSuccessful increment successfull label
-func (ic *importMetrics) IncSuccessful() {
-	ic.importCounterVec.With(prometheus.Labels{"result": "successful"}).Inc()
+func (ic *importMetrics) IncSuccessful(provider string) {
+	ic.incResult(provider, "successful")
 }
 
 func (ic *importMetrics) GetSuccessful() (float64, error) {
 	return ic.getValue(prometheus.Labels{"result": "successful"})
 }
 
+// GetProviderSuccessful returns number of successful imports from the provider
+func (ic *importMetrics) GetProviderSuccessful(provider string) (float64, error) {
+	return ic.getProviderValue(prometheus.Labels{"provider": provider, "result": "successful"})
+}
+
 // IncCancelled increment successfull label
-func (ic *importMetrics) IncCancelled() {
-	ic.importCounterVec.With(prometheus.Labels{"result": "cancelled"}).Inc()
+func (ic *importMetrics) IncCancelled(provider string) {
+	ic.incResult(provider, "cancelled")
 }
 
 func (ic *importMetrics) GetCancelled() (float64, error) {
 	return ic.getValue(prometheus.Labels{"result": "cancelled"})
 }
 
+// GetProviderCancelled returns number of cancelled imports from the provider
+func (ic *importMetrics) GetProviderCancelled(provider string) (float64, error) {
+	return ic.getProviderValue(prometheus.Labels{"provider": provider, "result": "cancelled"})
+}
+
 // SaveDurationFailed
-func (ic *importMetrics) SaveDurationFailed(d float64) {
-	ic.importDurationVec.With(prometheus.Labels{"result": "failed"}).Observe(d)
+func (ic *importMetrics) SaveDurationFailed(provider string, d float64) {
+	ic.saveDuration(provider, "failed", d)
 }
 
 // SaveDurationSuccessful
-func (ic *importMetrics) SaveDurationSuccessful(d float64) {
-	ic.importDurationVec.With(prometheus.Labels{"result": "successful"}).Observe(d)
+func (ic *importMetrics) SaveDurationSuccessful(provider string, d float64) {
+	ic.saveDuration(provider, "successful", d)
 }
 
 // SaveDurationCancelled
-func (ic *importMetrics) SaveDurationCancelled(d float64) {
-	ic.importDurationVec.With(prometheus.Labels{"result": "cancelled"}).Observe(d)
+func (ic *importMetrics) SaveDurationCancelled(provider string, d float64) {
+	ic.saveDuration(provider, "cancelled", d)
 }
 
 // getCountDurationSamples returns number of duration samples for given label
@@ -119,3 +335,141 @@ func (ic *importMetrics) GetCountDurationFailed() (uint64, error) {
 func (ic *importMetrics) GetCountDurationCancelled() (uint64, error) {
 	return ic.getCountDurationSamples(prometheus.Labels{"result": "cancelled"})
 }
+
+// GetCountProviderDuration returns number of duration samples for imports from the provider with the result
+func (ic *importMetrics) GetCountProviderDuration(provider string, result string) (uint64, error) {
+	var m = &dto.Metric{}
+	err := ic.providerImportDurationVec.With(prometheus.Labels{"provider": provider, "result": result}).(prometheus.Histogram).Write(m)
+	return m.Histogram.GetSampleCount(), err
+}
+
+// EnterPhase records that the import moved to the given phase. The duration of the previous phase is observed
+// and the in-progress gauges are updated. Entering the current or an earlier phase is a no-op.
+func (ic *importMetrics) EnterPhase(provider string, namespace string, name string, phase string) {
+	ic.phasesLock.Lock()
+	defer ic.phasesLock.Unlock()
+
+	key := importKey(namespace, name)
+	current, found := ic.phases[key]
+	if found && phaseOrder[phase] <= phaseOrder[current.phase] {
+		return
+	}
+	if found {
+		ic.leavePhase(current)
+	} else {
+		ic.queueDepthGaugeVec.With(prometheus.Labels{"provider": provider}).Inc()
+	}
+	current = &importPhase{provider: provider, namespace: namespace, name: name, phase: phase, start: time.Now()}
+	ic.phases[key] = current
+	ic.inProgressGaugeVec.With(prometheus.Labels{"provider": provider, "phase": phase}).Inc()
+	ic.phaseStartTimeGaugeVec.With(current.phaseLabels()).Set(float64(current.start.Unix()))
+}
+
+// EndImport records that the import finished and drops its per-import metrics
+func (ic *importMetrics) EndImport(namespace string, name string) {
+	ic.phasesLock.Lock()
+	defer ic.phasesLock.Unlock()
+
+	key := importKey(namespace, name)
+	current, found := ic.phases[key]
+	if !found {
+		return
+	}
+	ic.leavePhase(current)
+	ic.queueDepthGaugeVec.With(prometheus.Labels{"provider": current.provider}).Dec()
+	delete(ic.phases, key)
+
+	labels := prometheus.Labels{"provider": current.provider, "namespace": namespace, "name": name}
+	ic.bytesTransferredGaugeVec.Delete(labels)
+	ic.throughputGaugeVec.Delete(labels)
+}
+
+func (ic *importMetrics) leavePhase(current *importPhase) {
+	ic.phaseDurationVec.With(prometheus.Labels{"provider": current.provider, "phase": current.phase}).Observe(time.Since(current.start).Seconds())
+	ic.inProgressGaugeVec.With(prometheus.Labels{"provider": current.provider, "phase": current.phase}).Dec()
+	ic.phaseStartTimeGaugeVec.Delete(current.phaseLabels())
+}
+
+func (p *importPhase) phaseLabels() prometheus.Labels {
+	return prometheus.Labels{"provider": p.provider, "namespace": p.namespace, "name": p.name, "phase": p.phase}
+}
+
+func importKey(namespace string, name string) string {
+	return namespace + "/" + name
+}
+
+// GetInProgress returns number of imports currently in the phase
+func (ic *importMetrics) GetInProgress(provider string, phase string) (float64, error) {
+	var m = &dto.Metric{}
+	err := ic.inProgressGaugeVec.With(prometheus.Labels{"provider": provider, "phase": phase}).Write(m)
+	return m.Gauge.GetValue(), err
+}
+
+// GetQueueDepth returns number of imports that haven't finished yet
+func (ic *importMetrics) GetQueueDepth(provider string) (float64, error) {
+	var m = &dto.Metric{}
+	err := ic.queueDepthGaugeVec.With(prometheus.Labels{"provider": provider}).Write(m)
+	return m.Gauge.GetValue(), err
+}
+
+// GetCountPhaseDuration returns number of duration samples for the phase
+func (ic *importMetrics) GetCountPhaseDuration(provider string, phase string) (uint64, error) {
+	var m = &dto.Metric{}
+	err := ic.phaseDurationVec.With(prometheus.Labels{"provider": provider, "phase": phase}).(prometheus.Histogram).Write(m)
+	return m.Histogram.GetSampleCount(), err
+}
+
+// SetTransfer sets the number of bytes transferred and the transfer rate of the import
+func (ic *importMetrics) SetTransfer(provider string, namespace string, name string, bytesTransferred int64, throughput int64) {
+	labels := prometheus.Labels{"provider": provider, "namespace": namespace, "name": name}
+	ic.bytesTransferredGaugeVec.With(labels).Set(float64(bytesTransferred))
+	ic.throughputGaugeVec.With(labels).Set(float64(throughput))
+}
+
+// GetBytesTransferred returns number of bytes transferred by the import
+func (ic *importMetrics) GetBytesTransferred(provider string, namespace string, name string) (float64, error) {
+	var m = &dto.Metric{}
+	err := ic.bytesTransferredGaugeVec.With(prometheus.Labels{"provider": provider, "namespace": namespace, "name": name}).Write(m)
+	return m.Gauge.GetValue(), err
+}
+
+// IncProviderConnectionFailure increment failed connections to the source provider
+func (ic *importMetrics) IncProviderConnectionFailure(provider string) {
+	ic.providerConnectionFailureCounterVec.With(prometheus.Labels{"provider": provider}).Inc()
+}
+
+// GetProviderConnectionFailures returns number of failed connections to the source provider
+func (ic *importMetrics) GetProviderConnectionFailures(provider string) (float64, error) {
+	var m = &dto.Metric{}
+	err := ic.providerConnectionFailureCounterVec.With(prometheus.Labels{"provider": provider}).Write(m)
+	return m.Counter.GetValue(), err
+}
+
+// IncWarmImportStageSuccessful increment successful warm import stages
+func (ic *importMetrics) IncWarmImportStageSuccessful(provider string) {
+	ic.warmImportStageCounterVec.With(prometheus.Labels{"provider": provider, "result": "successful"}).Inc()
+}
+
+// IncWarmImportStageFailed increment failed warm import stages
+func (ic *importMetrics) IncWarmImportStageFailed(provider string) {
+	ic.warmImportStageCounterVec.With(prometheus.Labels{"provider": provider, "result": "failed"}).Inc()
+}
+
+// GetWarmImportStages returns number of warm import stages with the result
+func (ic *importMetrics) GetWarmImportStages(provider string, result string) (float64, error) {
+	var m = &dto.Metric{}
+	err := ic.warmImportStageCounterVec.With(prometheus.Labels{"provider": provider, "result": result}).Write(m)
+	return m.Counter.GetValue(), err
+}
+
+// IncValidationFailure increment validation failures of the check
+func (ic *importMetrics) IncValidationFailure(provider string, checkID string) {
+	ic.validationFailureCounterVec.With(prometheus.Labels{"provider": provider, "check_id": checkID}).Inc()
+}
+
+// GetValidationFailures returns number of validation failures of the check
+func (ic *importMetrics) GetValidationFailures(provider string, checkID string) (float64, error) {
+	var m = &dto.Metric{}
+	err := ic.validationFailureCounterVec.With(prometheus.Labels{"provider": provider, "check_id": checkID}).Write(m)
+	return m.Counter.GetValue(), err
+}
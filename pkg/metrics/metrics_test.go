@@ -0,0 +1,116 @@
+package metrics_test
+
+import (
+	"github.com/kubevirt/vm-import-operator/pkg/metrics"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Import phase metrics", func() {
+	const (
+		namespace = "ns"
+		name      = "import"
+	)
+
+	AfterEach(func() {
+		metrics.ImportMetrics.EndImport(namespace, name)
+	})
+
+	It("should track the import in the current phase", func() {
+		queueDepthBefore := getQueueDepth()
+
+		metrics.ImportMetrics.EnterPhase(metrics.ProviderVmware, namespace, name, metrics.PhaseValidation)
+
+		Expect(getInProgress(metrics.PhaseValidation)).To(Equal(1.0))
+		Expect(getQueueDepth()).To(Equal(queueDepthBefore + 1))
+	})
+
+	It("should observe the duration of the previous phase", func() {
+		samplesBefore := getCountPhaseDuration(metrics.PhaseValidation)
+		metrics.ImportMetrics.EnterPhase(metrics.ProviderVmware, namespace, name, metrics.PhaseValidation)
+
+		metrics.ImportMetrics.EnterPhase(metrics.ProviderVmware, namespace, name, metrics.PhaseCopy)
+
+		Expect(getCountPhaseDuration(metrics.PhaseValidation)).To(Equal(samplesBefore + 1))
+		Expect(getInProgress(metrics.PhaseValidation)).To(Equal(0.0))
+		Expect(getInProgress(metrics.PhaseCopy)).To(Equal(1.0))
+	})
+
+	It("should not go back to an earlier phase", func() {
+		metrics.ImportMetrics.EnterPhase(metrics.ProviderVmware, namespace, name, metrics.PhaseCopy)
+
+		metrics.ImportMetrics.EnterPhase(metrics.ProviderVmware, namespace, name, metrics.PhaseValidation)
+
+		Expect(getInProgress(metrics.PhaseValidation)).To(Equal(0.0))
+		Expect(getInProgress(metrics.PhaseCopy)).To(Equal(1.0))
+	})
+
+	It("should drop the import when it ends", func() {
+		queueDepthBefore := getQueueDepth()
+		samplesBefore := getCountPhaseDuration(metrics.PhaseStart)
+		metrics.ImportMetrics.EnterPhase(metrics.ProviderVmware, namespace, name, metrics.PhaseStart)
+		metrics.ImportMetrics.SetTransfer(metrics.ProviderVmware, namespace, name, 100, 10)
+
+		metrics.ImportMetrics.EndImport(namespace, name)
+
+		Expect(getInProgress(metrics.PhaseStart)).To(Equal(0.0))
+		Expect(getQueueDepth()).To(Equal(queueDepthBefore))
+		Expect(getCountPhaseDuration(metrics.PhaseStart)).To(Equal(samplesBefore + 1))
+		bytesTransferred, err := metrics.ImportMetrics.GetBytesTransferred(metrics.ProviderVmware, namespace, name)
+		Expect(err).To(BeNil())
+		Expect(bytesTransferred).To(Equal(0.0))
+	})
+})
+
+var _ = Describe("Import result metrics", func() {
+	It("should count the import with and without the provider", func() {
+		failedBefore, err := metrics.ImportMetrics.GetFailed()
+		Expect(err).To(BeNil())
+		providerFailedBefore, err := metrics.ImportMetrics.GetProviderFailed(metrics.ProviderVmware)
+		Expect(err).To(BeNil())
+
+		metrics.ImportMetrics.IncFailed(metrics.ProviderVmware)
+
+		failedAfter, err := metrics.ImportMetrics.GetFailed()
+		Expect(err).To(BeNil())
+		Expect(failedAfter).To(Equal(failedBefore + 1))
+		providerFailedAfter, err := metrics.ImportMetrics.GetProviderFailed(metrics.ProviderVmware)
+		Expect(err).To(BeNil())
+		Expect(providerFailedAfter).To(Equal(providerFailedBefore + 1))
+	})
+
+	It("should observe the import duration with and without the provider", func() {
+		samplesBefore, err := metrics.ImportMetrics.GetCountDurationSuccessful()
+		Expect(err).To(BeNil())
+		providerSamplesBefore, err := metrics.ImportMetrics.GetCountProviderDuration(metrics.ProviderVmware, "successful")
+		Expect(err).To(BeNil())
+
+		metrics.ImportMetrics.SaveDurationSuccessful(metrics.ProviderVmware, 120)
+
+		samplesAfter, err := metrics.ImportMetrics.GetCountDurationSuccessful()
+		Expect(err).To(BeNil())
+		Expect(samplesAfter).To(Equal(samplesBefore + 1))
+		providerSamplesAfter, err := metrics.ImportMetrics.GetCountProviderDuration(metrics.ProviderVmware, "successful")
+		Expect(err).To(BeNil())
+		Expect(providerSamplesAfter).To(Equal(providerSamplesBefore + 1))
+	})
+})
+
+func getInProgress(phase string) float64 {
+	value, err := metrics.ImportMetrics.GetInProgress(metrics.ProviderVmware, phase)
+	Expect(err).To(BeNil())
+	return value
+}
+
+func getQueueDepth() float64 {
+	value, err := metrics.ImportMetrics.GetQueueDepth(metrics.ProviderVmware)
+	Expect(err).To(BeNil())
+	return value
+}
+
+func getCountPhaseDuration(phase string) uint64 {
+	value, err := metrics.ImportMetrics.GetCountPhaseDuration(metrics.ProviderVmware, phase)
+	Expect(err).To(BeNil())
+	return value
+}
@@ -0,0 +1,116 @@
+package naming
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"text/template"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/utils"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+// DataVolume holds the values the data volume naming template is executed with
+type DataVolume struct {
+	// VMName is the name of the target VM
+	VMName string
+	// DiskName is the name of the disk on the source VM
+	DiskName string
+	// DiskID is the ID of the disk on the source VM
+	DiskID string
+	// Index is the position of the disk on the source VM, starting at 0
+	Index int
+}
+
+// Interface holds the values the interface naming template is executed with
+type Interface struct {
+	// NICName is the name of the NIC on the source VM
+	NICName string
+	// Index is the position of the NIC on the source VM, starting at 0
+	Index int
+}
+
+// Validate checks that the templates of the naming spec can be parsed and executed, and that the data volume names
+// depend on the VM name: the data volumes of the imports into a namespace would share their names otherwise.
+func Validate(spec *v2vv1.NamingSpec) error {
+	if spec == nil {
+		return nil
+	}
+	disk := DataVolume{VMName: "vm-a", DiskName: "disk", DiskID: "id"}
+	name, err := NewDataVolumeNamer(spec).Name(disk, "default")
+	if err != nil {
+		return fmt.Errorf("`naming.dataVolume` is invalid: %v", err)
+	}
+	if spec.DataVolume != nil && *spec.DataVolume != "" {
+		disk.VMName = "vm-b"
+		if otherName, _ := NewDataVolumeNamer(spec).Name(disk, "default"); otherName == name {
+			return fmt.Errorf("`naming.dataVolume` must include the {{.VMName}}, the data volumes of the imports into the same namespace sharing their names otherwise")
+		}
+	}
+	if _, err := NewInterfaceNamer(spec).Name(Interface{NICName: "nic"}, "default"); err != nil {
+		return fmt.Errorf("`naming.interface` is invalid: %v", err)
+	}
+	return nil
+}
+
+// Namer names a set of objects with a template
+type Namer struct {
+	text *string
+	used map[string]bool
+}
+
+// NewNamer creates a namer executing the template, or keeping the default names when it's nil
+func NewNamer(text *string) *Namer {
+	return &Namer{
+		text: text,
+		used: map[string]bool{},
+	}
+}
+
+// NewDataVolumeNamer creates a namer executing the data volume template of the naming spec
+func NewDataVolumeNamer(spec *v2vv1.NamingSpec) *Namer {
+	if spec == nil {
+		return NewNamer(nil)
+	}
+	return NewNamer(spec.DataVolume)
+}
+
+// NewInterfaceNamer creates a namer executing the interface template of the naming spec
+func NewInterfaceNamer(spec *v2vv1.NamingSpec) *Namer {
+	if spec == nil {
+		return NewNamer(nil)
+	}
+	return NewNamer(spec.Interface)
+}
+
+// Name returns the name of the object the template is executed with, normalized to DNS-1123. A name already returned
+// by the namer is suffixed with the lowest free index. The default name is returned as is when there's no template.
+func (n *Namer) Name(data interface{}, defaultName string) (string, error) {
+	if n.text == nil || *n.text == "" {
+		return defaultName, nil
+	}
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(*n.text)
+	if err != nil {
+		return "", err
+	}
+	var buffer bytes.Buffer
+	if err = tmpl.Execute(&buffer, data); err != nil {
+		return "", err
+	}
+	name, err := utils.NormalizeName(buffer.String())
+	if err != nil {
+		return "", err
+	}
+	unique := name
+	for i := 1; n.used[unique]; i++ {
+		suffix := "-" + strconv.Itoa(i)
+		base := name
+		if len(base)+len(suffix) > k8svalidation.DNS1123SubdomainMaxLength {
+			base = base[:k8svalidation.DNS1123SubdomainMaxLength-len(suffix)]
+		}
+		unique = base + suffix
+	}
+	n.used[unique] = true
+	return unique, nil
+}
@@ -0,0 +1,67 @@
+package naming_test
+
+import (
+	"strings"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/naming"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Naming", func() {
+	str := func(value string) *string {
+		return &value
+	}
+
+	table.DescribeTable("should validate", func(spec *v2vv1.NamingSpec, valid bool) {
+		err := naming.Validate(spec)
+
+		if valid {
+			Expect(err).ToNot(HaveOccurred())
+		} else {
+			Expect(err).To(HaveOccurred())
+		}
+	},
+		table.Entry("no naming", nil, true),
+		table.Entry("a data volume template", &v2vv1.NamingSpec{DataVolume: str("{{.VMName}}-disk{{.Index}}")}, true),
+		table.Entry("an interface template", &v2vv1.NamingSpec{Interface: str("{{.NICName}}")}, true),
+		table.Entry("an unparsable template", &v2vv1.NamingSpec{DataVolume: str("{{.VMName")}, false),
+		table.Entry("an unknown field", &v2vv1.NamingSpec{DataVolume: str("{{.NICName}}")}, false),
+		table.Entry("a template without a name", &v2vv1.NamingSpec{Interface: str("---")}, false),
+		table.Entry("a data volume template without the VM name", &v2vv1.NamingSpec{DataVolume: str("disk{{.Index}}")}, false),
+		table.Entry("a data volume template truncating the VM name", &v2vv1.NamingSpec{DataVolume: str("{{slice .VMName 0 2}}-{{.DiskName}}")}, false),
+	)
+
+	It("should keep the default names without a template", func() {
+		namer := naming.NewDataVolumeNamer(nil)
+
+		Expect(namer.Name(naming.DataVolume{VMName: "vm"}, "Default")).To(Equal("Default"))
+	})
+
+	It("should normalize the names", func() {
+		namer := naming.NewDataVolumeNamer(&v2vv1.NamingSpec{DataVolume: str("{{.VMName}}-{{.DiskName}}")})
+
+		Expect(namer.Name(naming.DataVolume{VMName: "db", DiskName: "Hard disk 1"}, "default")).To(Equal("db-harddisk1"))
+	})
+
+	It("should suffix the names already taken", func() {
+		namer := naming.NewInterfaceNamer(&v2vv1.NamingSpec{Interface: str("{{.NICName}}")})
+
+		Expect(namer.Name(naming.Interface{NICName: "nic", Index: 0}, "default")).To(Equal("nic"))
+		Expect(namer.Name(naming.Interface{NICName: "nic", Index: 1}, "default")).To(Equal("nic-1"))
+		Expect(namer.Name(naming.Interface{NICName: "nic", Index: 2}, "default")).To(Equal("nic-2"))
+	})
+
+	It("should keep the suffixed names within the length limit", func() {
+		namer := naming.NewDataVolumeNamer(&v2vv1.NamingSpec{DataVolume: str("{{.VMName}}")})
+		long := strings.Repeat("a", 253)
+
+		Expect(namer.Name(naming.DataVolume{VMName: long}, "default")).To(Equal(long))
+		name, err := namer.Name(naming.DataVolume{VMName: long}, "default")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(name).To(Equal(strings.Repeat("a", 251) + "-1"))
+	})
+})
@@ -0,0 +1,173 @@
+package networks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/utils"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	cniVersion              = "0.3.1"
+	networkTypeMultus       = "multus"
+	sourceNetworkAnnotation = "vmimport.v2v.kubevirt.io/source-network"
+)
+
+// MismatchError is returned when a network attachment definition with the name of a generated one exists, but isn't
+// connected to the same source network and VLAN
+type MismatchError struct {
+	NetworkAttachmentDefinition types.NamespacedName
+	Reason                      string
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("network attachment definition %s already exists, but %s", e.NetworkAttachmentDefinition, e.Reason)
+}
+
+// IsMismatch returns whether the error is caused by an existing network attachment definition that doesn't match the
+// generated one
+func IsMismatch(err error) bool {
+	_, ok := err.(*MismatchError)
+	return ok
+}
+
+// cniConfig holds the fields of the CNI configuration of a generated network attachment definition that select the L2
+// segment it's connected to
+type cniConfig struct {
+	Type   string `json:"type"`
+	Bridge string `json:"bridge"`
+	VLAN   int32  `json:"vlan"`
+}
+
+// Generator creates the network attachment definitions connecting the imported VMs to the VLANs of their source networks
+type Generator struct {
+	client  client.Client
+	cniType string
+	bridge  string
+}
+
+// NewGenerator creates a generator of network attachment definitions attached to the given bridge with the given CNI plugin
+func NewGenerator(client client.Client, cniType string, bridge string) *Generator {
+	return &Generator{
+		client:  client,
+		cniType: cniType,
+		bridge:  bridge,
+	}
+}
+
+// Generate creates the network attachment definition connected to the VLAN of a source network, unless an identical one
+// already exists, and returns the mapping of the source network to it. An existing one connected to another source
+// network, VLAN or bridge is a MismatchError.
+func (g *Generator) Generate(network string, vlan int32, namespace string) (*v2vv1.NetworkResourceMappingItem, error) {
+	nad, err := g.NewNetworkAttachmentDefinition(network, vlan, namespace)
+	if err != nil {
+		return nil, err
+	}
+	err = g.client.Create(context.TODO(), nad)
+	if k8serrors.IsAlreadyExists(err) {
+		err = g.verify(nad)
+	}
+	if err != nil {
+		return nil, err
+	}
+	networkType := networkTypeMultus
+	return &v2vv1.NetworkResourceMappingItem{
+		Source: v2vv1.Source{Name: &network},
+		Target: v2vv1.ObjectIdentifier{Name: nad.Name, Namespace: &nad.Namespace},
+		Type:   &networkType,
+	}, nil
+}
+
+// Verify returns a MismatchError when the network attachment definition generated for a source network already exists,
+// but is connected to another source network, VLAN or bridge
+func (g *Generator) Verify(network string, vlan int32, namespace string) error {
+	nad, err := g.NewNetworkAttachmentDefinition(network, vlan, namespace)
+	if err != nil {
+		return err
+	}
+	err = g.verify(nad)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func (g *Generator) verify(nad *netv1.NetworkAttachmentDefinition) error {
+	name := types.NamespacedName{Name: nad.Name, Namespace: nad.Namespace}
+	existing := &netv1.NetworkAttachmentDefinition{}
+	if err := g.client.Get(context.TODO(), name, existing); err != nil {
+		return err
+	}
+	network := nad.Annotations[sourceNetworkAnnotation]
+	if existing.Annotations[sourceNetworkAnnotation] != network {
+		return &MismatchError{NetworkAttachmentDefinition: name, Reason: fmt.Sprintf("it wasn't generated for source network %s", network)}
+	}
+	var expectedConfig, existingConfig cniConfig
+	if err := json.Unmarshal([]byte(nad.Spec.Config), &expectedConfig); err != nil {
+		return err
+	}
+	if err := json.Unmarshal([]byte(existing.Spec.Config), &existingConfig); err != nil {
+		return &MismatchError{NetworkAttachmentDefinition: name, Reason: fmt.Sprintf("its CNI configuration can't be parsed: %v", err)}
+	}
+	if existingConfig != expectedConfig {
+		return &MismatchError{NetworkAttachmentDefinition: name, Reason: fmt.Sprintf("it's connected to VLAN %d of bridge %s with CNI plugin %s instead of VLAN %d of bridge %s with CNI plugin %s",
+			existingConfig.VLAN, existingConfig.Bridge, existingConfig.Type, expectedConfig.VLAN, expectedConfig.Bridge, expectedConfig.Type)}
+	}
+	return nil
+}
+
+// NewNetworkAttachmentDefinition builds the network attachment definition connected to the VLAN of a source network
+func (g *Generator) NewNetworkAttachmentDefinition(network string, vlan int32, namespace string) (*netv1.NetworkAttachmentDefinition, error) {
+	name, err := Name(network, vlan)
+	if err != nil {
+		return nil, err
+	}
+	config := map[string]interface{}{
+		"cniVersion": cniVersion,
+		"name":       name,
+		"type":       g.cniType,
+		"bridge":     g.bridge,
+	}
+	if vlan != 0 {
+		config["vlan"] = vlan
+	}
+	rawConfig, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	return &netv1.NetworkAttachmentDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				sourceNetworkAnnotation: network,
+			},
+		},
+		Spec: netv1.NetworkAttachmentDefinitionSpec{
+			Config: string(rawConfig),
+		},
+	}, nil
+}
+
+// Name returns the name of the network attachment definition generated for a source network and its VLAN. The VLAN is
+// kept when the name is shortened, so that same-named networks on different VLANs don't share one.
+func Name(network string, vlan int32) (string, error) {
+	name, err := utils.NormalizeLabel(strings.Replace(network, "/", "-", -1))
+	if err != nil {
+		return "", fmt.Errorf("cannot name a network attachment definition after network %s: %v", network, err)
+	}
+	suffix := fmt.Sprintf("-vlan%d", vlan)
+	if maxLength := k8svalidation.DNS1123LabelMaxLength - len(suffix); len(name) > maxLength {
+		name = strings.TrimRight(name[:maxLength], "-")
+	}
+	return name + suffix, nil
+}
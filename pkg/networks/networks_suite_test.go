@@ -0,0 +1,13 @@
+package networks_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestNetworks(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Networks Suite")
+}
@@ -0,0 +1,111 @@
+package networks_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	netv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	"github.com/kubevirt/vm-import-operator/pkg/networks"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Generated networks", func() {
+	table.DescribeTable("should be named after the source network and its VLAN", func(network string, vlan int32, expected string) {
+		name, err := networks.Name(network, vlan)
+
+		Expect(err).To(BeNil())
+		Expect(name).To(Equal(expected))
+	},
+		table.Entry("oVirt network and vNIC profile", "ovirtmgmt/ovirtmgmt", int32(0), "ovirtmgmt-ovirtmgmt-vlan0"),
+		table.Entry("vSphere port group", "VM Network", int32(100), "vmnetwork-vlan100"),
+		table.Entry("vSphere distributed port group", "dvportgroup-12", int32(4094), "dvportgroup-12-vlan4094"),
+		table.Entry("long network", strings.Repeat("a", 70), int32(100), strings.Repeat("a", 55)+"-vlan100"),
+	)
+
+	table.DescribeTable("should configure the CNI plugin", func(cniType string, vlan int32, expected string) {
+		generator := networks.NewGenerator(nil, cniType, "br1")
+
+		nad, err := generator.NewNetworkAttachmentDefinition("prod/prod", vlan, "default")
+
+		Expect(err).To(BeNil())
+		Expect(nad.Name).To(Equal(fmt.Sprintf("prod-prod-vlan%d", vlan)))
+		Expect(nad.Namespace).To(Equal("default"))
+		Expect(nad.Annotations).To(HaveKeyWithValue("vmimport.v2v.kubevirt.io/source-network", "prod/prod"))
+		Expect(nad.Spec.Config).To(MatchJSON(expected))
+	},
+		table.Entry("bridge", "bridge", int32(100), `{"cniVersion":"0.3.1","name":"prod-prod-vlan100","type":"bridge","bridge":"br1","vlan":100}`),
+		table.Entry("OVS", "ovs", int32(100), `{"cniVersion":"0.3.1","name":"prod-prod-vlan100","type":"ovs","bridge":"br1","vlan":100}`),
+		table.Entry("untagged", "bridge", int32(0), `{"cniVersion":"0.3.1","name":"prod-prod-vlan0","type":"bridge","bridge":"br1"}`),
+	)
+
+	Describe("generation", func() {
+		var cl client.Client
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			_ = netv1.AddToScheme(scheme)
+			cl = fake.NewFakeClientWithScheme(scheme,
+				&netv1.NetworkAttachmentDefinition{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "existing-vlan100",
+						Namespace:   "default",
+						Annotations: map[string]string{"vmimport.v2v.kubevirt.io/source-network": "existing"},
+					},
+					Spec: netv1.NetworkAttachmentDefinitionSpec{Config: `{"cniVersion":"0.3.1","name":"existing-vlan100","type":"bridge","bridge":"br1","vlan":100}`},
+				},
+				&netv1.NetworkAttachmentDefinition{
+					ObjectMeta: metav1.ObjectMeta{Name: "user-vlan100", Namespace: "default"},
+					Spec:       netv1.NetworkAttachmentDefinitionSpec{Config: `{"cniVersion":"0.3.1","name":"user-vlan100","type":"bridge","bridge":"br1","vlan":100}`},
+				},
+			)
+		})
+
+		It("should create the network attachment definition and map the source network to it", func() {
+			generator := networks.NewGenerator(cl, "bridge", "br1")
+
+			mapping, err := generator.Generate("prod/prod", 100, "default")
+
+			Expect(err).To(BeNil())
+			Expect(*mapping.Source.Name).To(Equal("prod/prod"))
+			Expect(mapping.Target.Name).To(Equal("prod-prod-vlan100"))
+			Expect(*mapping.Target.Namespace).To(Equal("default"))
+			Expect(*mapping.Type).To(Equal("multus"))
+			nad := netv1.NetworkAttachmentDefinition{}
+			Expect(cl.Get(context.TODO(), types.NamespacedName{Name: "prod-prod-vlan100", Namespace: "default"}, &nad)).To(Succeed())
+		})
+
+		It("should keep an existing network attachment definition generated for the same network", func() {
+			generator := networks.NewGenerator(cl, "bridge", "br1")
+
+			mapping, err := generator.Generate("existing", 100, "default")
+
+			Expect(err).To(BeNil())
+			Expect(mapping.Target.Name).To(Equal("existing-vlan100"))
+		})
+
+		table.DescribeTable("should reject an existing network attachment definition that doesn't match", func(network string, bridge string, reason string) {
+			generator := networks.NewGenerator(cl, "bridge", bridge)
+
+			Expect(generator.Verify(network, 100, "default")).To(MatchError(ContainSubstring(reason)))
+			_, err := generator.Generate(network, 100, "default")
+			Expect(networks.IsMismatch(err)).To(BeTrue())
+		},
+			table.Entry("created by the user", "user", "br1", "it wasn't generated for source network user"),
+			table.Entry("on another bridge", "existing", "br2", "it's connected to VLAN 100 of bridge br1"),
+		)
+
+		It("should verify a network attachment definition that doesn't exist yet", func() {
+			generator := networks.NewGenerator(cl, "bridge", "br1")
+
+			Expect(generator.Verify("prod/prod", 100, "default")).To(Succeed())
+		})
+	})
+})
@@ -60,6 +60,9 @@ type OperatorArgs struct {
 	Namespace              string
 	MonitoringNamespace    string
 	InfraNodePlacement     *sdkapi.NodePlacement
+	MonitoringConfig       *v2vv1.MonitoringConfig
+	TracingConfig          *v2vv1.TracingConfig
+	GuestConversionPod     *v2vv1.GuestConversionPodConfig
 }
 
 // newReconciler returns a new reconcile.Reconciler
@@ -168,6 +171,9 @@ func (r *ReconcileVMImportConfig) getOperatorArgs(cr *v2vv1.VMImportConfig) *Ope
 			result.PullPolicy = string(cr.Spec.ImagePullPolicy)
 		}
 		result.InfraNodePlacement = &cr.Spec.Infra
+		result.MonitoringConfig = cr.Spec.Monitoring
+		result.TracingConfig = cr.Spec.Tracing
+		result.GuestConversionPod = cr.Spec.GuestConversionPod
 	}
 
 	operatorDeployment := &appsv1.Deployment{}
@@ -648,7 +648,7 @@ var _ = Describe("Controller", func() {
 	},
 		Entry("verify - unused deployment deleted",
 			func() (runtime.Object, error) {
-				deployment := resources.CreateControllerDeployment("fake-deployment", Namespace, "fake-vmimport", "fake-virtv2v", "Always", int32(1), &sdkapi.NodePlacement{})
+				deployment := resources.CreateControllerDeployment("fake-deployment", Namespace, "fake-vmimport", "fake-virtv2v", "Always", int32(1), &sdkapi.NodePlacement{}, nil, nil)
 				return deployment, nil
 			}),
 
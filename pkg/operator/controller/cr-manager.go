@@ -10,6 +10,7 @@ import (
 
 	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
@@ -46,6 +47,8 @@ func (r *ReconcileVMImportConfig) GetDependantResourcesListObjects() []runtime.O
 		&rbacv1.ClusterRoleList{},
 		&appsv1.DeploymentList{},
 		&corev1.ServiceAccountList{},
+		&corev1.ServiceList{},
+		&admissionregistrationv1.MutatingWebhookConfigurationList{},
 	}
 }
 
@@ -74,15 +77,22 @@ func createControllerResources(args *OperatorArgs) []runtime.Object {
 		resources.CreateServiceAccount(args.Namespace),
 		resources.CreateControllerRole(),
 		resources.CreateControllerRoleBinding(args.Namespace),
-		resources.CreateControllerDeployment(resources.ControllerName, args.Namespace, args.ControllerImage, args.Virtv2vImage, args.PullPolicy, int32(1), args.InfraNodePlacement),
+		resources.CreateControllerDeployment(resources.ControllerName, args.Namespace, args.ControllerImage, args.Virtv2vImage, args.PullPolicy, int32(1), args.InfraNodePlacement, args.TracingConfig, args.GuestConversionPod),
+		resources.CreateWebhookService(args.Namespace),
+		resources.CreateMutatingWebhookConfiguration(args.Namespace),
 	}
 	// Add metrics objects if servicemonitor is available:
 	if ok, err := hasServiceMonitor(); ok && err == nil {
 		objs = append(objs,
 			resources.CreateMetricsService(args.Namespace),
 			resources.CreateServiceMonitor(args.MonitoringNamespace, args.Namespace),
+			resources.CreateMonitoringDashboard(args.Namespace),
 		)
 	}
+	// Add alerting rules if prometheusrule is available:
+	if ok, err := hasPrometheusRule(); ok && err == nil {
+		objs = append(objs, resources.CreatePrometheusRule(args.MonitoringNamespace, args.Namespace, args.MonitoringConfig))
+	}
 
 	return objs
 }
@@ -102,6 +112,21 @@ func hasServiceMonitor() (bool, error) {
 	return k8sutil.ResourceExists(dc, apiVersion, kind)
 }
 
+// hasPrometheusRule checks if PrometheusRule is registered in the cluster.
+func hasPrometheusRule() (bool, error) {
+	// Get a config to talk to the apiserver
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return false, fmt.Errorf("Can't load restconfig")
+	}
+
+	dc := discovery.NewDiscoveryClientForConfigOrDie(cfg)
+	apiVersion := "monitoring.coreos.com/v1"
+	kind := "PrometheusRule"
+
+	return k8sutil.ResourceExists(dc, apiVersion, kind)
+}
+
 func createCRDResources() []runtime.Object {
 	return []runtime.Object{
 		resources.CreateResourceMapping(),
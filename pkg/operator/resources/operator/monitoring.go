@@ -0,0 +1,197 @@
+package operator
+
+import (
+	"fmt"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// DefaultStuckPhaseMinutes is the default time an import can stay in one phase before it's reported as stuck
+	DefaultStuckPhaseMinutes = 240
+	// DefaultWarmImportStageFailures is the default number of warm import stages that can fail within an hour
+	DefaultWarmImportStageFailures = 3
+	// DefaultFailureRatePercent is the default percentage of failed imports reported as a high failure rate
+	DefaultFailureRatePercent = 25
+
+	// MonitoringDashboardName is the name of the config map holding the VM import dashboard
+	MonitoringDashboardName = "vm-import-dashboard"
+)
+
+// CreatePrometheusRule creates the alerting rules for VM import metrics
+func CreatePrometheusRule(monitoringNamespace string, svcNamespace string, config *v2vv1.MonitoringConfig) *monitoringv1.PrometheusRule {
+	stuckPhaseMinutes, warmImportStageFailures, failureRatePercent := monitoringThresholds(config)
+	metricsJob := fmt.Sprintf("%s-metrics", operatorName)
+
+	return &monitoringv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-rules", operatorName),
+			Namespace: monitoringNamespace,
+			Labels: map[string]string{
+				"name":       operatorName,
+				"prometheus": "k8s",
+				"role":       "alert-rules",
+			},
+		},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{
+				{
+					Name: "vm-import.rules",
+					Rules: []monitoringv1.Rule{
+						{
+							Alert: "VMImportStuckInPhase",
+							Expr:  intstr.FromString(fmt.Sprintf("time() - kubevirt_vmimport_phase_start_time_seconds > %d", stuckPhaseMinutes*60)),
+							Labels: map[string]string{
+								"severity": "warning",
+							},
+							Annotations: map[string]string{
+								"summary":     "Virtual machine import is stuck",
+								"description": fmt.Sprintf("Virtual machine import {{ $labels.namespace }}/{{ $labels.name }} from {{ $labels.provider }} has been in the {{ $labels.phase }} phase for more than %d minutes.", stuckPhaseMinutes),
+							},
+						},
+						{
+							Alert: "VMImportWarmStageFailures",
+							Expr:  intstr.FromString(fmt.Sprintf(`sum by (provider) (increase(kubevirt_vmimport_warm_import_stages_total{result="failed"}[1h])) >= %d`, warmImportStageFailures)),
+							Labels: map[string]string{
+								"severity": "warning",
+							},
+							Annotations: map[string]string{
+								"summary":     "Warm import stages keep failing",
+								"description": fmt.Sprintf("At least %d warm import stages from {{ $labels.provider }} failed within the last hour.", warmImportStageFailures),
+							},
+						},
+						{
+							Alert: "VMImportHighFailureRate",
+							Expr: intstr.FromString(fmt.Sprintf(
+								`100 * sum by (provider) (increase(kubevirt_vmimport_provider_imports_total{result="failed"}[1h])) / sum by (provider) (increase(kubevirt_vmimport_provider_imports_total[1h])) > %d`,
+								failureRatePercent,
+							)),
+							Labels: map[string]string{
+								"severity": "warning",
+							},
+							Annotations: map[string]string{
+								"summary":     "High virtual machine import failure rate",
+								"description": fmt.Sprintf("More than %d%% of the virtual machine imports from {{ $labels.provider }} finished within the last hour failed.", failureRatePercent),
+							},
+						},
+						{
+							Alert: "VMImportProviderUnreachable",
+							Expr:  intstr.FromString("sum by (provider) (increase(kubevirt_vmimport_provider_connection_failures_total[10m])) > 0"),
+							For:   "10m",
+							Labels: map[string]string{
+								"severity": "warning",
+							},
+							Annotations: map[string]string{
+								"summary":     "Source provider is unreachable",
+								"description": "Virtual machine imports can't connect to {{ $labels.provider }}.",
+							},
+						},
+						{
+							Alert: "VMImportControllerDown",
+							Expr:  intstr.FromString(fmt.Sprintf(`absent(up{job="%s", namespace="%s"} == 1)`, metricsJob, svcNamespace)),
+							For:   "5m",
+							Labels: map[string]string{
+								"severity": "critical",
+							},
+							Annotations: map[string]string{
+								"summary":     "VM import controller is down",
+								"description": "The VM import controller hasn't been reachable for 5 minutes, no virtual machine imports are processed.",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// CreateMonitoringDashboard creates the config map holding the Grafana dashboard of VM import metrics
+func CreateMonitoringDashboard(namespace string) *corev1.ConfigMap {
+	configMap := resourceBuilder.CreateConfigMap(MonitoringDashboardName)
+	configMap.Namespace = namespace
+	configMap.Labels["grafana_dashboard"] = "1"
+	configMap.Data = map[string]string{
+		"vm-import-dashboard.json": monitoringDashboard,
+	}
+	return configMap
+}
+
+func monitoringThresholds(config *v2vv1.MonitoringConfig) (int32, int32, int32) {
+	stuckPhaseMinutes := int32(DefaultStuckPhaseMinutes)
+	warmImportStageFailures := int32(DefaultWarmImportStageFailures)
+	failureRatePercent := int32(DefaultFailureRatePercent)
+	if config != nil {
+		if config.StuckPhaseMinutes != nil {
+			stuckPhaseMinutes = *config.StuckPhaseMinutes
+		}
+		if config.WarmImportStageFailures != nil {
+			warmImportStageFailures = *config.WarmImportStageFailures
+		}
+		if config.FailureRatePercent != nil {
+			failureRatePercent = *config.FailureRatePercent
+		}
+	}
+	return stuckPhaseMinutes, warmImportStageFailures, failureRatePercent
+}
+
+const monitoringDashboard = `{
+  "title": "VM Import",
+  "uid": "vm-import",
+  "schemaVersion": 16,
+  "time": {"from": "now-24h", "to": "now"},
+  "panels": [
+    {
+      "title": "Imports in progress by phase",
+      "type": "graph",
+      "gridPos": {"x": 0, "y": 0, "w": 12, "h": 8},
+      "targets": [{"expr": "sum by (provider, phase) (kubevirt_vmimport_in_progress)", "legendFormat": "{{provider}} {{phase}}"}]
+    },
+    {
+      "title": "Unfinished imports",
+      "type": "graph",
+      "gridPos": {"x": 12, "y": 0, "w": 12, "h": 8},
+      "targets": [{"expr": "sum by (provider) (kubevirt_vmimport_queue_depth)", "legendFormat": "{{provider}}"}]
+    },
+    {
+      "title": "Finished imports per hour",
+      "type": "graph",
+      "gridPos": {"x": 0, "y": 8, "w": 12, "h": 8},
+      "targets": [{"expr": "sum by (provider, result) (increase(kubevirt_vmimport_provider_imports_total[1h]))", "legendFormat": "{{provider}} {{result}}"}]
+    },
+    {
+      "title": "Phase duration (90th percentile)",
+      "type": "graph",
+      "gridPos": {"x": 12, "y": 8, "w": 12, "h": 8},
+      "targets": [{"expr": "histogram_quantile(0.9, sum by (le, provider, phase) (rate(kubevirt_vmimport_phase_duration_seconds_bucket[6h])))", "legendFormat": "{{provider}} {{phase}}"}]
+    },
+    {
+      "title": "Throughput",
+      "type": "graph",
+      "gridPos": {"x": 0, "y": 16, "w": 12, "h": 8},
+      "targets": [{"expr": "sum by (provider, namespace, name) (kubevirt_vmimport_throughput_bytes_per_second)", "legendFormat": "{{namespace}}/{{name}}"}]
+    },
+    {
+      "title": "Bytes transferred",
+      "type": "graph",
+      "gridPos": {"x": 12, "y": 16, "w": 12, "h": 8},
+      "targets": [{"expr": "sum by (provider, namespace, name) (kubevirt_vmimport_bytes_transferred)", "legendFormat": "{{namespace}}/{{name}}"}]
+    },
+    {
+      "title": "Warm import stages per hour",
+      "type": "graph",
+      "gridPos": {"x": 0, "y": 24, "w": 12, "h": 8},
+      "targets": [{"expr": "sum by (provider, result) (increase(kubevirt_vmimport_warm_import_stages_total[1h]))", "legendFormat": "{{provider}} {{result}}"}]
+    },
+    {
+      "title": "Validation failures per hour",
+      "type": "graph",
+      "gridPos": {"x": 12, "y": 24, "w": 12, "h": 8},
+      "targets": [{"expr": "sum by (provider, check_id) (increase(kubevirt_vmimport_validation_failures_total[1h]))", "legendFormat": "{{provider}} {{check_id}}"}]
+    }
+  ]
+}
+`
@@ -0,0 +1,69 @@
+package operator_test
+
+import (
+	"encoding/json"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	vmioperator "github.com/kubevirt/vm-import-operator/pkg/operator/resources/operator"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Monitoring resources", func() {
+	It("should create alerts with default thresholds", func() {
+		rule := vmioperator.CreatePrometheusRule("openshift-monitoring", "kubevirt-hyperconverged", nil)
+
+		Expect(rule.Namespace).To(Equal("openshift-monitoring"))
+		Expect(rule.Spec.Groups).To(HaveLen(1))
+		Expect(findAlert(rule, "VMImportStuckInPhase")).To(ContainSubstring("> 14400"))
+		Expect(findAlert(rule, "VMImportWarmStageFailures")).To(ContainSubstring(">= 3"))
+		Expect(findAlert(rule, "VMImportHighFailureRate")).To(ContainSubstring("> 25"))
+		Expect(findAlert(rule, "VMImportProviderUnreachable")).To(ContainSubstring("kubevirt_vmimport_provider_connection_failures_total"))
+		Expect(findAlert(rule, "VMImportControllerDown")).To(ContainSubstring(`namespace="kubevirt-hyperconverged"`))
+	})
+
+	It("should create alerts with configured thresholds", func() {
+		stuckPhaseMinutes := int32(60)
+		warmImportStageFailures := int32(5)
+		failureRatePercent := int32(50)
+		config := &v2vv1.MonitoringConfig{
+			StuckPhaseMinutes:       &stuckPhaseMinutes,
+			WarmImportStageFailures: &warmImportStageFailures,
+			FailureRatePercent:      &failureRatePercent,
+		}
+
+		rule := vmioperator.CreatePrometheusRule("openshift-monitoring", "kubevirt-hyperconverged", config)
+
+		Expect(findAlert(rule, "VMImportStuckInPhase")).To(ContainSubstring("> 3600"))
+		Expect(findAlert(rule, "VMImportWarmStageFailures")).To(ContainSubstring(">= 5"))
+		Expect(findAlert(rule, "VMImportHighFailureRate")).To(ContainSubstring("> 50"))
+	})
+
+	It("should create dashboard config map", func() {
+		configMap := vmioperator.CreateMonitoringDashboard("kubevirt-hyperconverged")
+
+		Expect(configMap.Name).To(Equal(vmioperator.MonitoringDashboardName))
+		Expect(configMap.Namespace).To(Equal("kubevirt-hyperconverged"))
+		Expect(configMap.Labels).To(HaveKeyWithValue("grafana_dashboard", "1"))
+		Expect(configMap.Data).To(HaveKey("vm-import-dashboard.json"))
+
+		var dashboard map[string]interface{}
+		err := json.Unmarshal([]byte(configMap.Data["vm-import-dashboard.json"]), &dashboard)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(dashboard["panels"]).ToNot(BeEmpty())
+	})
+})
+
+func findAlert(rule *monitoringv1.PrometheusRule, name string) string {
+	for _, group := range rule.Spec.Groups {
+		for _, r := range group.Rules {
+			if r.Alert == name {
+				return r.Expr.String()
+			}
+		}
+	}
+	Fail("alert " + name + " not found")
+	return ""
+}
@@ -4,6 +4,7 @@ package operator
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	sdkapi "kubevirt.io/controller-lifecycle-operator-sdk/pkg/sdk/api"
 
@@ -14,7 +15,10 @@ import (
 
 	"github.com/coreos/go-semver/semver"
 	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/guestconversion"
 	vmimportmetrics "github.com/kubevirt/vm-import-operator/pkg/metrics"
+	vmitracing "github.com/kubevirt/vm-import-operator/pkg/tracing"
 	csvv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/apis/operators/v1alpha1"
 	"github.com/operator-framework/operator-sdk/pkg/metrics"
 	appsv1 "k8s.io/api/apps/v1"
@@ -87,6 +91,7 @@ func getControllerPolicyRules() []rbacv1.PolicyRule {
 			},
 			Resources: []string{
 				"pods",
+				"pods/log",
 				"services",
 				"services/finalizers",
 				"endpoints",
@@ -233,6 +238,7 @@ func getControllerPolicyRules() []rbacv1.PolicyRule {
 				"get",
 				"list",
 				"watch",
+				"create",
 			},
 		},
 		{
@@ -261,6 +267,32 @@ func getControllerPolicyRules() []rbacv1.PolicyRule {
 				"use",
 			},
 		},
+		{
+			APIGroups: []string{
+				"authorization.k8s.io",
+			},
+			Resources: []string{
+				"subjectaccessreviews",
+			},
+			Verbs: []string{
+				"create",
+			},
+		},
+		{
+			APIGroups: []string{
+				"admissionregistration.k8s.io",
+			},
+			Resources: []string{
+				"mutatingwebhookconfigurations",
+			},
+			ResourceNames: []string{
+				WebhookName,
+			},
+			Verbs: []string{
+				"get",
+				"update",
+			},
+		},
 	}
 	return rules
 }
@@ -273,6 +305,7 @@ func getOperatorPolicyRules() []rbacv1.PolicyRule {
 			},
 			Resources: []string{
 				"pods",
+				"pods/log",
 				"events",
 				"configmaps",
 				"secrets",
@@ -338,6 +371,18 @@ func getOperatorPolicyRules() []rbacv1.PolicyRule {
 			},
 			Resources: []string{
 				"servicemonitors",
+				"prometheusrules",
+			},
+			Verbs: []string{
+				"*",
+			},
+		},
+		{
+			APIGroups: []string{
+				"admissionregistration.k8s.io",
+			},
+			Resources: []string{
+				"mutatingwebhookconfigurations",
 			},
 			Verbs: []string{
 				"*",
@@ -348,18 +393,39 @@ func getOperatorPolicyRules() []rbacv1.PolicyRule {
 }
 
 // CreateControllerDeployment returns vmimport controller deployment
-func CreateControllerDeployment(name, namespace, image, virtV2vImage, pullPolicy string, numReplicas int32, policy *sdkapi.NodePlacement) *appsv1.Deployment {
+func CreateControllerDeployment(name, namespace, image, virtV2vImage, pullPolicy string, numReplicas int32, policy *sdkapi.NodePlacement, tracing *v2vv1.TracingConfig, guestConversionPod *v2vv1.GuestConversionPodConfig) *appsv1.Deployment {
 	podSpec := corev1.PodSpec{
 		ServiceAccountName: ControllerName,
-		Containers:         createControllerContainers(image, virtV2vImage, pullPolicy),
+		Containers:         createControllerContainers(image, virtV2vImage, pullPolicy, tracing, guestConversionPod),
 	}
+	addWebhookCert(&podSpec)
 	selectorMatchMap := resourceBuilder.WithOperatorLabels(map[string]string{"v2v.kubevirt.io": ControllerName})
 	return resources.CreateDeployment(name, namespace, selectorMatchMap, selectorMatchMap, numReplicas, podSpec, ControllerName, policy)
 }
 
-func createControllerContainers(image, virtV2vImage, pullPolicy string) []v1.Container {
+func createControllerContainers(image, virtV2vImage, pullPolicy string, tracing *v2vv1.TracingConfig, guestConversionPod *v2vv1.GuestConversionPodConfig) []v1.Container {
 	container := resourceBuilder.CreateContainer(ControllerName, image, pullPolicy)
 	container.Env = createControllerEnv(virtV2vImage, pullPolicy)
+	if tracing != nil && tracing.Endpoint != "" {
+		container.Env = append(container.Env,
+			corev1.EnvVar{
+				Name:  vmitracing.EndpointEnvVar,
+				Value: tracing.Endpoint,
+			},
+			corev1.EnvVar{
+				Name:  vmitracing.InsecureEnvVar,
+				Value: strconv.FormatBool(tracing.Insecure),
+			},
+		)
+	}
+	if guestConversionPod != nil {
+		// the configuration consists of plain API types, so it always serializes
+		podConfig, _ := json.Marshal(guestConversionPod)
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  guestconversion.PodConfigEnvVar,
+			Value: string(podConfig),
+		})
+	}
 	container.Command = []string{ControllerName}
 	return []corev1.Container{*container}
 }
@@ -1146,867 +1212,2259 @@ func CreateVMImportConfig() *extv1.CustomResourceDefinition {
 												},
 											},
 										},
-										"infra": {
-											Description: "Rules on which nodes vm import infrastructure pods will be scheduled",
+										"monitoring": {
+											Description: "Thresholds of the alerts deployed by the operator",
 											Type:        "object",
 											Properties: map[string]extv1.JSONSchemaProps{
-												"affinity": {
-													Description: "affinity enables pod affinity/anti-affinity placement expanding the types of constraints that can be expressed with nodeSelector. affinity is going to be applied to the relevant kind of pods in parallel with nodeSelector See https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node/#affinity-and-anti-affinity",
-													Type:        "object",
-													Properties: map[string]extv1.JSONSchemaProps{
-														"nodeAffinity": {
-															Description: "Describes node affinity scheduling rules for the pod.",
-															Type:        "object",
-															Properties: map[string]extv1.JSONSchemaProps{
-																"preferredDuringSchedulingIgnoredDuringExecution": {
-																	Description: "The scheduler will prefer to schedule pods to nodes that satisfy the affinity expressions specified by this field, but it may choose a node that violates one or more of the expressions. The node that is most preferred is the one with the greatest sum of weights, i.e. for each node that meets all of the scheduling requirements (resource request, requiredDuringScheduling affinity expressions, etc.), compute a sum by iterating through the elements of this field and adding \"weight\" to the sum if the node matches the corresponding matchExpressions; the node(s) with the highest sum are the most preferred.",
-																	Type:        "array",
-																	Items: &extv1.JSONSchemaPropsOrArray{
-																		Schema: &extv1.JSONSchemaProps{
-																			Description: "An empty preferred scheduling term matches all objects with implicit weight 0 (i.e. it's a no-op). A null preferred scheduling term matches no objects (i.e. is also a no-op).",
-																			Type:        "object",
-																			Properties: map[string]extv1.JSONSchemaProps{
-																				"preference": {
-																					Description: "A node selector term, associated with the corresponding weight.",
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"matchExpressions": {
-																							Description: "A list of node selector requirements by node's labels.",
-																							Type:        "array",
-																							Items: &extv1.JSONSchemaPropsOrArray{
-																								Schema: &extv1.JSONSchemaProps{
-																									Description: "A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
-																									Type:        "object",
-																									Properties: map[string]extv1.JSONSchemaProps{
-																										"key": {
-																											Description: "The label key that the selector applies to.",
-																											Type:        "string",
-																										},
-																										"operator": {
-																											Description: "Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.",
-																											Type:        "string",
-																										},
-																										"values": {
-																											Description: "An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.",
-																											Type:        "array",
-																											Items: &extv1.JSONSchemaPropsOrArray{
-																												Schema: &extv1.JSONSchemaProps{
-																													Type: "string",
-																												},
-																											},
-																										},
-																									},
-																									Required: []string{
-																										"key",
-																										"operator",
-																									},
-																								},
-																							},
-																						},
-																						"matchFields": {
-																							Description: "A list of node selector requirements by node's fields.",
-																							Type:        "array",
-																							Items: &extv1.JSONSchemaPropsOrArray{
-																								Schema: &extv1.JSONSchemaProps{
-																									Description: "A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
-																									Type:        "object",
-																									Properties: map[string]extv1.JSONSchemaProps{
-																										"key": {
-																											Description: "The label key that the selector applies to.",
-																											Type:        "string",
-																										},
-																										"operator": {
-																											Description: "Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.",
-																											Type:        "string",
-																										},
-																										"values": {
-																											Description: "An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.",
-																											Type:        "array",
-																											Items: &extv1.JSONSchemaPropsOrArray{
-																												Schema: &extv1.JSONSchemaProps{
-																													Type: "string",
-																												},
-																											},
-																										},
-																									},
-																									Required: []string{
-																										"key",
-																										"operator",
-																									},
-																								},
-																							},
-																						},
+												"stuckPhaseMinutes": {
+													Description: "How long, in minutes, an import can stay in one phase before it's reported as stuck. Defaults to 240.",
+													Type:        "integer",
+													Format:      "int32",
+												},
+												"warmImportStageFailures": {
+													Description: "How many warm import stages can fail within an hour before it's reported. Defaults to 3.",
+													Type:        "integer",
+													Format:      "int32",
+												},
+												"failureRatePercent": {
+													Description: "Percentage of failed imports out of the imports finished within an hour that is reported as a high failure rate. Defaults to 25.",
+													Type:        "integer",
+													Format:      "int32",
+												},
+											},
+										},
+										"tracing": {
+											Description: "Export of the controller traces",
+											Type:        "object",
+											Properties: map[string]extv1.JSONSchemaProps{
+												"endpoint": {
+													Description: "Address, host:port, of the OTLP gRPC receiver the traces are exported to",
+													Type:        "string",
+												},
+												"insecure": {
+													Description: "Disables TLS of the connection to the receiver",
+													Type:        "boolean",
+												},
+											},
+											Required: []string{"endpoint"},
+										},
+										"guestConversionPod": guestConversionPodSchema("Resources and placement of the virt-v2v pods converting the guests"),
+										"infra":              nodePlacementSchema("Rules on which nodes vm import infrastructure pods will be scheduled"),
+									},
+								},
+								"status": sdkopenapi.OperatorConfigStatus(""),
+							},
+						},
+					},
+				},
+			},
+			Names: extv1.CustomResourceDefinitionNames{
+				Kind:     "VMImportConfig",
+				ListKind: "VMImportConfigList",
+				Plural:   "vmimportconfigs",
+				Singular: "vmimportconfig",
+				Categories: []string{
+					"all",
+				},
+			},
+		},
+	}
+}
+
+func localObjectReferenceSchema(description string) extv1.JSONSchemaProps {
+	return extv1.JSONSchemaProps{
+		Type:        "object",
+		Description: description,
+		Properties: map[string]extv1.JSONSchemaProps{
+			"name": {
+				Type: "string",
+			},
+		},
+	}
+}
+
+func nodePlacementSchema(description string) extv1.JSONSchemaProps {
+	return extv1.JSONSchemaProps{
+		Description: description,
+		Type:        "object",
+		Properties: map[string]extv1.JSONSchemaProps{
+			"affinity": {
+				Description: "affinity enables pod affinity/anti-affinity placement expanding the types of constraints that can be expressed with nodeSelector. affinity is going to be applied to the relevant kind of pods in parallel with nodeSelector See https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node/#affinity-and-anti-affinity",
+				Type:        "object",
+				Properties: map[string]extv1.JSONSchemaProps{
+					"nodeAffinity": {
+						Description: "Describes node affinity scheduling rules for the pod.",
+						Type:        "object",
+						Properties: map[string]extv1.JSONSchemaProps{
+							"preferredDuringSchedulingIgnoredDuringExecution": {
+								Description: "The scheduler will prefer to schedule pods to nodes that satisfy the affinity expressions specified by this field, but it may choose a node that violates one or more of the expressions. The node that is most preferred is the one with the greatest sum of weights, i.e. for each node that meets all of the scheduling requirements (resource request, requiredDuringScheduling affinity expressions, etc.), compute a sum by iterating through the elements of this field and adding \"weight\" to the sum if the node matches the corresponding matchExpressions; the node(s) with the highest sum are the most preferred.",
+								Type:        "array",
+								Items: &extv1.JSONSchemaPropsOrArray{
+									Schema: &extv1.JSONSchemaProps{
+										Description: "An empty preferred scheduling term matches all objects with implicit weight 0 (i.e. it's a no-op). A null preferred scheduling term matches no objects (i.e. is also a no-op).",
+										Type:        "object",
+										Properties: map[string]extv1.JSONSchemaProps{
+											"preference": {
+												Description: "A node selector term, associated with the corresponding weight.",
+												Type:        "object",
+												Properties: map[string]extv1.JSONSchemaProps{
+													"matchExpressions": {
+														Description: "A list of node selector requirements by node's labels.",
+														Type:        "array",
+														Items: &extv1.JSONSchemaPropsOrArray{
+															Schema: &extv1.JSONSchemaProps{
+																Description: "A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+																Type:        "object",
+																Properties: map[string]extv1.JSONSchemaProps{
+																	"key": {
+																		Description: "The label key that the selector applies to.",
+																		Type:        "string",
+																	},
+																	"operator": {
+																		Description: "Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.",
+																		Type:        "string",
+																	},
+																	"values": {
+																		Description: "An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.",
+																		Type:        "array",
+																		Items: &extv1.JSONSchemaPropsOrArray{
+																			Schema: &extv1.JSONSchemaProps{
+																				Type: "string",
+																			},
+																		},
+																	},
+																},
+																Required: []string{
+																	"key",
+																	"operator",
+																},
+															},
+														},
+													},
+													"matchFields": {
+														Description: "A list of node selector requirements by node's fields.",
+														Type:        "array",
+														Items: &extv1.JSONSchemaPropsOrArray{
+															Schema: &extv1.JSONSchemaProps{
+																Description: "A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+																Type:        "object",
+																Properties: map[string]extv1.JSONSchemaProps{
+																	"key": {
+																		Description: "The label key that the selector applies to.",
+																		Type:        "string",
+																	},
+																	"operator": {
+																		Description: "Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.",
+																		Type:        "string",
+																	},
+																	"values": {
+																		Description: "An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.",
+																		Type:        "array",
+																		Items: &extv1.JSONSchemaPropsOrArray{
+																			Schema: &extv1.JSONSchemaProps{
+																				Type: "string",
+																			},
+																		},
+																	},
+																},
+																Required: []string{
+																	"key",
+																	"operator",
+																},
+															},
+														},
+													},
+												},
+											},
+											"weight": {
+												Description: "Weight associated with matching the corresponding nodeSelectorTerm, in the range 1-100.",
+												Format:      "int32",
+												Type:        "integer",
+											},
+										},
+										Required: []string{
+											"preference",
+											"weight",
+										},
+									},
+								},
+							},
+							"requiredDuringSchedulingIgnoredDuringExecution": {
+								Description: "If the affinity requirements specified by this field are not met at scheduling time, the pod will not be scheduled onto the node. If the affinity requirements specified by this field cease to be met at some point during pod execution (e.g. due to an update), the system may or may not try to eventually evict the pod from its node.",
+								Type:        "object",
+								Properties: map[string]extv1.JSONSchemaProps{
+									"nodeSelectorTerms": {
+										Description: "Required. A list of node selector terms. The terms are ORed.",
+										Type:        "array",
+										Items: &extv1.JSONSchemaPropsOrArray{
+											Schema: &extv1.JSONSchemaProps{
+												Description: "A null or empty node selector term matches no objects. The requirements of them are ANDed. The TopologySelectorTerm type implements a subset of the NodeSelectorTerm.",
+												Type:        "object",
+												Properties: map[string]extv1.JSONSchemaProps{
+													"matchExpressions": {
+														Description: "A list of node selector requirements by node's labels.",
+														Type:        "array",
+														Items: &extv1.JSONSchemaPropsOrArray{
+															Schema: &extv1.JSONSchemaProps{
+																Description: "A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+																Type:        "object",
+																Properties: map[string]extv1.JSONSchemaProps{
+																	"key": {
+																		Description: "The label key that the selector applies to.",
+																		Type:        "string",
+																	},
+																	"operator": {
+																		Description: "Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.",
+																		Type:        "string",
+																	},
+																	"values": {
+																		Description: "An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.",
+																		Type:        "array",
+																		Items: &extv1.JSONSchemaPropsOrArray{
+																			Schema: &extv1.JSONSchemaProps{
+																				Type: "string",
+																			},
+																		},
+																	},
+																},
+																Required: []string{
+																	"key",
+																	"operator",
+																},
+															},
+														},
+													},
+													"matchFields": {
+														Description: "A list of node selector requirements by node's fields.",
+														Type:        "array",
+														Items: &extv1.JSONSchemaPropsOrArray{
+															Schema: &extv1.JSONSchemaProps{
+																Description: "A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+																Type:        "object",
+																Properties: map[string]extv1.JSONSchemaProps{
+																	"key": {
+																		Description: "The label key that the selector applies to.",
+																		Type:        "string",
+																	},
+																	"operator": {
+																		Description: "Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.",
+																		Type:        "string",
+																	},
+																	"values": {
+																		Description: "An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.",
+																		Type:        "array",
+																		Items: &extv1.JSONSchemaPropsOrArray{
+																			Schema: &extv1.JSONSchemaProps{
+																				Type: "string",
+																			},
+																		},
+																	},
+																},
+																Required: []string{
+																	"key",
+																	"operator",
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+								Required: []string{
+									"nodeSelectorTerms",
+								},
+							},
+						},
+					},
+					"podAffinity": {
+						Description: "Describes pod affinity scheduling rules (e.g. co-locate this pod in the same node, zone, etc. as some other pod(s)).",
+						Type:        "object",
+						Properties: map[string]extv1.JSONSchemaProps{
+							"preferredDuringSchedulingIgnoredDuringExecution": {
+								Description: "The scheduler will prefer to schedule pods to nodes that satisfy the affinity expressions specified by this field, but it may choose a node that violates one or more of the expressions. The node that is most preferred is the one with the greatest sum of weights, i.e. for each node that meets all of the scheduling requirements (resource request, requiredDuringScheduling affinity expressions, etc.), compute a sum by iterating through the elements of this field and adding \"weight\" to the sum if the node has pods which matches the corresponding podAffinityTerm; the node(s) with the highest sum are the most preferred.",
+								Type:        "array",
+								Items: &extv1.JSONSchemaPropsOrArray{
+									Schema: &extv1.JSONSchemaProps{
+										Description: "The weights of all of the matched WeightedPodAffinityTerm fields are added per-node to find the most preferred node(s)",
+										Type:        "object",
+										Properties: map[string]extv1.JSONSchemaProps{
+											"podAffinityTerm": {
+												Description: "Required. A pod affinity term, associated with the corresponding weight.",
+												Type:        "object",
+												Properties: map[string]extv1.JSONSchemaProps{
+													"labelSelector": {
+														Description: "A label query over a set of resources, in this case pods.",
+														Type:        "object",
+														Properties: map[string]extv1.JSONSchemaProps{
+															"matchExpressions": {
+																Description: "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
+																Type:        "array",
+																Items: &extv1.JSONSchemaPropsOrArray{
+																	Schema: &extv1.JSONSchemaProps{
+																		Description: "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+																		Type:        "object",
+																		Properties: map[string]extv1.JSONSchemaProps{
+																			"key": {
+																				Description: "key is the label key that the selector applies to.",
+																				Type:        "string",
+																			},
+																			"operator": {
+																				Description: "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
+																				Type:        "string",
+																			},
+																			"values": {
+																				Description: "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
+																				Type:        "array",
+																				Items: &extv1.JSONSchemaPropsOrArray{
+																					Schema: &extv1.JSONSchemaProps{
+																						Type: "string",
 																					},
 																				},
-																				"weight": {
-																					Description: "Weight associated with matching the corresponding nodeSelectorTerm, in the range 1-100.",
-																					Format:      "int32",
-																					Type:        "integer",
-																				},
 																			},
-																			Required: []string{
-																				"preference",
-																				"weight",
+																		},
+																		Required: []string{
+																			"key",
+																			"operator",
+																		},
+																	},
+																},
+															},
+															"matchLabels": {
+																Description: "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
+																Type:        "object",
+																AdditionalProperties: &extv1.JSONSchemaPropsOrBool{
+																	Schema: &extv1.JSONSchemaProps{
+																		Type: "string",
+																	},
+																},
+															},
+														},
+													},
+													"namespaces": {
+														Description: "namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means \"this pod's namespace\"",
+														Type:        "array",
+														Items: &extv1.JSONSchemaPropsOrArray{
+															Schema: &extv1.JSONSchemaProps{
+																Type: "string",
+															},
+														},
+													},
+													"topologyKey": {
+														Description: "This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.",
+														Type:        "string",
+													},
+												},
+												Required: []string{
+													"topologyKey",
+												},
+											},
+											"weight": {
+												Description: "weight associated with matching the corresponding podAffinityTerm, in the range 1-100.",
+												Type:        "integer",
+												Format:      "int32",
+											},
+										},
+										Required: []string{
+											"podAffinityTerm",
+											"weight",
+										},
+									},
+								},
+							},
+							"requiredDuringSchedulingIgnoredDuringExecution": {
+								Description: "If the affinity requirements specified by this field are not met at scheduling time, the pod will not be scheduled onto the node. If the affinity requirements specified by this field cease to be met at some point during pod execution (e.g. due to a pod label update), the system may or may not try to eventually evict the pod from its node. When there are multiple elements, the lists of nodes corresponding to each podAffinityTerm are intersected, i.e. all terms must be satisfied.",
+								Type:        "array",
+								Items: &extv1.JSONSchemaPropsOrArray{
+									Schema: &extv1.JSONSchemaProps{
+										Description: "Defines a set of pods (namely those matching the labelSelector relative to the given namespace(s)) that this pod should be co-located (affinity) or not co-located (anti-affinity) with, where co-located is defined as running on a node whose value of the label with key <topologyKey> matches that of any node on which a pod of the set of pods is running",
+										Type:        "object",
+										Properties: map[string]extv1.JSONSchemaProps{
+											"labelSelector": {
+												Description: "A label query over a set of resources, in this case pods.",
+												Type:        "object",
+												Properties: map[string]extv1.JSONSchemaProps{
+													"matchExpressions": {
+														Description: "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
+														Type:        "array",
+														Items: &extv1.JSONSchemaPropsOrArray{
+															Schema: &extv1.JSONSchemaProps{
+																Description: "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+																Type:        "object",
+																Properties: map[string]extv1.JSONSchemaProps{
+																	"key": {
+																		Description: "key is the label key that the selector applies to.",
+																		Type:        "string",
+																	},
+																	"operator": {
+																		Description: "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
+																		Type:        "string",
+																	},
+																	"values": {
+																		Description: "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
+																		Type:        "array",
+																		Items: &extv1.JSONSchemaPropsOrArray{
+																			Schema: &extv1.JSONSchemaProps{
+																				Type: "string",
 																			},
 																		},
 																	},
 																},
-																"requiredDuringSchedulingIgnoredDuringExecution": {
-																	Description: "If the affinity requirements specified by this field are not met at scheduling time, the pod will not be scheduled onto the node. If the affinity requirements specified by this field cease to be met at some point during pod execution (e.g. due to an update), the system may or may not try to eventually evict the pod from its node.",
+																Required: []string{
+																	"key",
+																	"operator",
+																},
+															},
+														},
+													},
+													"matchLabels": {
+														Description: "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
+														Type:        "object",
+														AdditionalProperties: &extv1.JSONSchemaPropsOrBool{
+															Schema: &extv1.JSONSchemaProps{
+																Type: "string",
+															},
+														},
+													},
+												},
+											},
+											"namespaces": {
+												Description: "namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means \"this pod's namespace\"",
+												Type:        "array",
+												Items: &extv1.JSONSchemaPropsOrArray{
+													Schema: &extv1.JSONSchemaProps{
+														Type: "string",
+													},
+												},
+											},
+											"topologyKey": {
+												Description: "This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.",
+												Type:        "string",
+											},
+										},
+										Required: []string{
+											"topologyKey",
+										},
+									},
+								},
+							},
+						},
+					},
+					"podAntiAffinity": {
+						Description: "Describes pod anti-affinity scheduling rules (e.g. avoid putting this pod in the same node, zone, etc. as some other pod(s)).",
+						Type:        "object",
+						Properties: map[string]extv1.JSONSchemaProps{
+							"preferredDuringSchedulingIgnoredDuringExecution": {
+								Description: "The scheduler will prefer to schedule pods to nodes that satisfy the anti-affinity expressions specified by this field, but it may choose a node that violates one or more of the expressions. The node that is most preferred is the one with the greatest sum of weights, i.e. for each node that meets all of the scheduling requirements (resource request, requiredDuringScheduling anti-affinity expressions, etc.), compute a sum by iterating through the elements of this field and adding \"weight\" to the sum if the node has pods which matches the corresponding podAffinityTerm; the node(s) with the highest sum are the most preferred.",
+								Type:        "array",
+								Items: &extv1.JSONSchemaPropsOrArray{
+									Schema: &extv1.JSONSchemaProps{
+										Description: "The weights of all of the matched WeightedPodAffinityTerm fields are added per-node to find the most preferred node(s)",
+										Type:        "object",
+										Properties: map[string]extv1.JSONSchemaProps{
+											"podAffinityTerm": {
+												Description: "Required. A pod affinity term, associated with the corresponding weight.",
+												Type:        "object",
+												Properties: map[string]extv1.JSONSchemaProps{
+													"labelSelector": {
+														Description: "A label query over a set of resources, in this case pods.",
+														Type:        "object",
+														Properties: map[string]extv1.JSONSchemaProps{
+															"matchExpressions": {
+																Description: "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
+																Type:        "array",
+																Items: &extv1.JSONSchemaPropsOrArray{
+																	Schema: &extv1.JSONSchemaProps{
+																		Description: "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+																		Type:        "object",
+																		Properties: map[string]extv1.JSONSchemaProps{
+																			"key": {
+																				Description: "key is the label key that the selector applies to.",
+																				Type:        "string",
+																			},
+																			"operator": {
+																				Description: "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
+																				Type:        "string",
+																			},
+																			"values": {
+																				Description: "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
+																				Type:        "array",
+																				Items: &extv1.JSONSchemaPropsOrArray{
+																					Schema: &extv1.JSONSchemaProps{
+																						Type: "string",
+																					},
+																				},
+																			},
+																		},
+																		Required: []string{
+																			"key",
+																			"operator",
+																		},
+																	},
+																},
+															},
+															"matchLabels": {
+																Description: "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
+																Type:        "object",
+																AdditionalProperties: &extv1.JSONSchemaPropsOrBool{
+																	Schema: &extv1.JSONSchemaProps{
+																		Type: "string",
+																	},
+																},
+															},
+														},
+													},
+													"namespaces": {
+														Description: "namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means \"this pod's namespace\"",
+														Type:        "array",
+														Items: &extv1.JSONSchemaPropsOrArray{
+															Schema: &extv1.JSONSchemaProps{
+																Type: "string",
+															},
+														},
+													},
+													"topologyKey": {
+														Description: "This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.",
+														Type:        "string",
+													},
+												},
+												Required: []string{
+													"topologyKey",
+												},
+											},
+											"weight": {
+												Description: "weight associated with matching the corresponding podAffinityTerm, in the range 1-100.",
+												Type:        "integer",
+												Format:      "int32",
+											},
+										},
+										Required: []string{
+											"podAffinityTerm",
+											"weight",
+										},
+									},
+								},
+							},
+							"requiredDuringSchedulingIgnoredDuringExecution": {
+								Description: "If the anti-affinity requirements specified by this field are not met at scheduling time, the pod will not be scheduled onto the node. If the anti-affinity requirements specified by this field cease to be met at some point during pod execution (e.g. due to a pod label update), the system may or may not try to eventually evict the pod from its node. When there are multiple elements, the lists of nodes corresponding to each podAffinityTerm are intersected, i.e. all terms must be satisfied.",
+								Type:        "array",
+								Items: &extv1.JSONSchemaPropsOrArray{
+									Schema: &extv1.JSONSchemaProps{
+										Description: "Defines a set of pods (namely those matching the labelSelector relative to the given namespace(s)) that this pod should be co-located (affinity) or not co-located (anti-affinity) with, where co-located is defined as running on a node whose value of the label with key <topologyKey> matches that of any node on which a pod of the set of pods is running",
+										Type:        "object",
+										Properties: map[string]extv1.JSONSchemaProps{
+											"labelSelector": {
+												Description: "A label query over a set of resources, in this case pods.",
+												Type:        "object",
+												Properties: map[string]extv1.JSONSchemaProps{
+													"matchExpressions": {
+														Description: "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
+														Type:        "array",
+														Items: &extv1.JSONSchemaPropsOrArray{
+															Schema: &extv1.JSONSchemaProps{
+																Description: "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
+																Type:        "object",
+																Properties: map[string]extv1.JSONSchemaProps{
+																	"key": {
+																		Description: "key is the label key that the selector applies to.",
+																		Type:        "string",
+																	},
+																	"operator": {
+																		Description: "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
+																		Type:        "string",
+																	},
+																	"values": {
+																		Description: "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
+																		Type:        "array",
+																		Items: &extv1.JSONSchemaPropsOrArray{
+																			Schema: &extv1.JSONSchemaProps{
+																				Type: "string",
+																			},
+																		},
+																	},
+																},
+																Required: []string{
+																	"key",
+																	"operator",
+																},
+															},
+														},
+													},
+													"matchLabels": {
+														Description: "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
+														Type:        "object",
+														AdditionalProperties: &extv1.JSONSchemaPropsOrBool{
+															Schema: &extv1.JSONSchemaProps{
+																Type: "string",
+															},
+														},
+													},
+												},
+											},
+											"namespaces": {
+												Description: "namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means \"this pod's namespace\"",
+												Type:        "array",
+												Items: &extv1.JSONSchemaPropsOrArray{
+													Schema: &extv1.JSONSchemaProps{
+														Type: "string",
+													},
+												},
+											},
+											"topologyKey": {
+												Description: "This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.",
+												Type:        "string",
+											},
+										},
+										Required: []string{
+											"topologyKey",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"nodeSelector": {
+				Description: "nodeSelector is the node selector applied to the relevant kind of pods It specifies a map of key-value pairs: for the pod to be eligible to run on a node, the node must have each of the indicated key-value pairs as labels (it can have additional labels as well). See https://kubernetes.io/docs/concepts/configuration/assign-pod-node/#nodeselector",
+				Type:        "object",
+				AdditionalProperties: &extv1.JSONSchemaPropsOrBool{
+					Schema: &extv1.JSONSchemaProps{
+						Type: "string",
+					},
+				},
+			},
+			"tolerations": {
+				Description: "tolerations is a list of tolerations applied to the relevant kind of pods See https://kubernetes.io/docs/concepts/configuration/taint-and-toleration/ for more info. These are additional tolerations other than default ones.",
+				Type:        "array",
+				Items: &extv1.JSONSchemaPropsOrArray{
+					Schema: &extv1.JSONSchemaProps{
+						Description: "The pod this Toleration is attached to tolerates any taint that matches the triple <key,value,effect> using the matching operator <operator>.",
+						Type:        "object",
+						Properties: map[string]extv1.JSONSchemaProps{
+							"effect": {
+								Description: "Effect indicates the taint effect to match. Empty means match all taint effects. When specified, allowed values are NoSchedule, PreferNoSchedule and NoExecute.",
+								Type:        "string",
+							},
+							"key": {
+								Description: "Key is the taint key that the toleration applies to. Empty means match all taint keys. If the key is empty, operator must be Exists; this combination means to match all values and all keys.",
+								Type:        "string",
+							},
+							"operator": {
+								Description: "Operator represents a key's relationship to the value. Valid operators are Exists and Equal. Defaults to Equal. Exists is equivalent to wildcard for value, so that a pod can tolerate all taints of a particular category.",
+								Type:        "string",
+							},
+							"tolerationSeconds": {
+								Description: "TolerationSeconds represents the period of time the toleration (which must be of effect NoExecute, otherwise this field is ignored) tolerates the taint. By default, it is not set, which means tolerate the taint forever (do not evict). Zero and negative values will be treated as 0 (evict immediately) by the system.",
+								Type:        "integer",
+								Format:      "int64",
+							},
+							"value": {
+								Description: "Value is the taint value the toleration matches to. If the operator is Exists, the value should be empty, otherwise just a regular string.",
+								Type:        "string",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func guestConversionPodSchema(description string) extv1.JSONSchemaProps {
+	quantities := extv1.JSONSchemaProps{
+		Type: "object",
+		AdditionalProperties: &extv1.JSONSchemaPropsOrBool{
+			Schema: &extv1.JSONSchemaProps{
+				XIntOrString: true,
+				AnyOf: []extv1.JSONSchemaProps{
+					{Type: "integer"},
+					{Type: "string"},
+				},
+			},
+		},
+	}
+	return extv1.JSONSchemaProps{
+		Description: description,
+		Type:        "object",
+		Properties: map[string]extv1.JSONSchemaProps{
+			"resources": {
+				Description: "Resources of the virt-v2v container. The limit of the KVM device is always added.",
+				Type:        "object",
+				Properties: map[string]extv1.JSONSchemaProps{
+					"limits":   quantities,
+					"requests": quantities,
+				},
+			},
+			"placement": nodePlacementSchema("Restricts the nodes the pod is scheduled on, on top of the nodes labeled kubevirt.io/schedulable"),
+			"priorityClassName": {
+				Description: "The priority class of the pod",
+				Type:        "string",
+			},
+		},
+	}
+}
+
+// CreateVMImport creates the VM Import CRD
+func CreateVMImport() *extv1.CustomResourceDefinition {
+	maxTargetVMName := int64(validation.LabelValueMaxLength)
+	maxTargetNamespace := int64(validation.DNS1123LabelMaxLength)
+	return &extv1.CustomResourceDefinition{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apiextensions.k8s.io/v1",
+			Kind:       "CustomResourceDefinition",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "virtualmachineimports.v2v.kubevirt.io",
+			Labels: map[string]string{
+				"operator.v2v.kubevirt.io": "",
+			},
+		},
+		Spec: extv1.CustomResourceDefinitionSpec{
+			Group: "v2v.kubevirt.io",
+			Scope: "Namespaced",
+			Conversion: &extv1.CustomResourceConversion{
+				Strategy: extv1.NoneConverter,
+			},
+			Versions: []extv1.CustomResourceDefinitionVersion{
+				{
+					Name:    "v1alpha1",
+					Served:  true,
+					Storage: false,
+					Subresources: &extv1.CustomResourceSubresources{
+						Status: &extv1.CustomResourceSubresourceStatus{},
+					},
+					Schema: &extv1.CustomResourceValidation{
+						OpenAPIV3Schema: &extv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]extv1.JSONSchemaProps{
+								"apiVersion": {
+									Type: "string",
+									Description: `APIVersion defines the versioned schema of this representation
+					of an object. Servers should convert recognized schemas to the latest
+					internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources`,
+								},
+								"kind": {
+									Type: "string",
+									Description: `Kind is a string value representing the REST resource this
+					object represents. Servers may infer this from the endpoint the client
+					submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds`,
+								},
+								"metadata": {
+									Type: "object",
+								},
+								"spec": {
+									Type:        "object",
+									Description: "VirtualMachineImportSpec defines the desired state of VirtualMachineImport",
+									Properties: map[string]extv1.JSONSchemaProps{
+										"providerCredentialsSecret": {
+											Type:        "object",
+											Description: "ProviderCredentialsSecret defines how a secret resource should be identified on kubevirt",
+											Properties: map[string]extv1.JSONSchemaProps{
+												"name": {
+													Type: "string",
+												},
+												"namespace": {
+													Type: "string",
+												},
+											},
+											Required: []string{"name"},
+										},
+										"resourceMapping": {
+											Type:        "object",
+											Description: "ObjectIdentifier defines how a resource should be identified",
+											Properties: map[string]extv1.JSONSchemaProps{
+												"name": {
+													Type: "string",
+												},
+												"namespace": {
+													Type: "string",
+												},
+											},
+											Required: []string{"name"},
+										},
+										"source": {
+											Type:        "object",
+											Description: "VirtualMachineImportSourceSpec defines the source provider and the internal mapping resources",
+											Properties: map[string]extv1.JSONSchemaProps{
+												"ovirt": {
+													Type:        "object",
+													Description: `VirtualMachineImportOvirtSourceSpec defines the mapping resources and the VM identity for oVirt source provider`,
+													Properties: map[string]extv1.JSONSchemaProps{
+														"mappings": {
+															Type:        "object",
+															Description: "OvirtMappings defines the mappings of ovirt resources to kubevirt",
+															Properties: map[string]extv1.JSONSchemaProps{
+																"networkMappings": {
+																	Type: "array",
+																	Description: `NetworkMappings defines the mapping of vnic profile
+					to network attachment definition When providing source network by name, the format is 'network name/vnic profile name'. When
+					providing source network by ID, the ID represents the vnic profile ID. A logical network from ovirt can be mapped to multiple network
+					attachment definitions on kubevirt by using vnic profile to network attachment definition mapping.`,
+																	Items: &extv1.JSONSchemaPropsOrArray{
+																		Schema: &extv1.JSONSchemaProps{
+																			Type:        "object",
+																			Description: `ResourceMappingItem defines the mapping of a single resource from the provider to kubevirt`,
+																			Properties: map[string]extv1.JSONSchemaProps{
+																				"source": {
+																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
+																					Type:        "object",
+																					Properties: map[string]extv1.JSONSchemaProps{
+																						"id": {
+																							Type: "string",
+																						},
+																						"name": {
+																							Type: "string",
+																						},
+																					},
+																				},
+																				"target": {
+																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
+																					Type:        "object",
+																					Properties: map[string]extv1.JSONSchemaProps{
+																						"name": {
+																							Type: "string",
+																						},
+																						"namespace": {
+																							Type: "string",
+																						},
+																					},
+																					Required: []string{"name"},
+																				},
+																				"type": {
+																					Type: "string",
+																				},
+																			},
+																			Required: []string{"source"},
+																		},
+																	},
+																},
+																"storageMappings": {
+																	Type:        "array",
+																	Description: `StorageMappings defines the mapping of storage domains to storage classes.`,
+																	Items: &extv1.JSONSchemaPropsOrArray{
+																		Schema: &extv1.JSONSchemaProps{
+																			Type:        "object",
+																			Description: `ResourceMappingItem defines the mapping of a single resource from the provider to kubevirt`,
+																			Properties: map[string]extv1.JSONSchemaProps{
+																				"source": {
+																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
+																					Type:        "object",
+																					Properties: map[string]extv1.JSONSchemaProps{
+																						"id": {
+																							Type: "string",
+																						},
+																						"name": {
+																							Type: "string",
+																						},
+																					},
+																				},
+																				"target": {
+																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
+																					Type:        "object",
+																					Properties: map[string]extv1.JSONSchemaProps{
+																						"name": {
+																							Type: "string",
+																						},
+																						"namespace": {
+																							Type: "string",
+																						},
+																					},
+																					Required: []string{"name"},
+																				},
+																				"type": {
+																					Type: "string",
+																				},
+																			},
+																			Required: []string{"source"},
+																		},
+																	},
+																},
+																"diskMappings": {
+																	Type: "array",
+																	Description: `DiskMappings defines the mapping of disks to storage
+					classes DiskMappings.Source.ID represents the disk ID on ovirt (as opposed to disk-attachment ID) DiskMappings.Source.Name represents
+					the disk alias on ovirt DiskMappings is respected only when provided in context of a single VM import within VirtualMachineImport.`,
+																	Items: &extv1.JSONSchemaPropsOrArray{
+																		Schema: &extv1.JSONSchemaProps{
+																			Type:        "object",
+																			Description: `ResourceMappingItem defines the mapping of a single resource from the provider to kubevirt`,
+																			Properties: map[string]extv1.JSONSchemaProps{
+																				"source": {
+																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
+																					Type:        "object",
+																					Properties: map[string]extv1.JSONSchemaProps{
+																						"id": {
+																							Type: "string",
+																						},
+																						"name": {
+																							Type: "string",
+																						},
+																					},
+																				},
+																				"target": {
+																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
+																					Type:        "object",
+																					Properties: map[string]extv1.JSONSchemaProps{
+																						"name": {
+																							Type: "string",
+																						},
+																						"namespace": {
+																							Type: "string",
+																						},
+																					},
+																					Required: []string{"name"},
+																				},
+																				"type": {
+																					Type: "string",
+																				},
+																			},
+																			Required: []string{"source"},
+																		},
+																	},
+																},
+															},
+														},
+														"vm": {
+															Type:        "object",
+															Description: `VirtualMachineImportOvirtSourceVMSpec defines the definition of the VM info in oVirt`,
+															Properties: map[string]extv1.JSONSchemaProps{
+																"cluster": {
+																	Description: `VirtualMachineImportOvirtSourceVMClusterSpec defines the source cluster's identity of the VM in oVirt`,
+																	Type:        "object",
+																	Properties: map[string]extv1.JSONSchemaProps{
+																		"id": {
+																			Type: "string",
+																		},
+																		"name": {
+																			Type: "string",
+																		},
+																	},
+																},
+																"id": {
+																	Type: "string",
+																},
+																"name": {
+																	Type: "string",
+																},
+															},
+														},
+													},
+													Required: []string{"vm"},
+												},
+											},
+										},
+										"startVm": {
+											Type: "boolean",
+										},
+										"targetVmName": {
+											Type:      "string",
+											MaxLength: &maxTargetVMName,
+										},
+									},
+									Required: []string{"providerCredentialsSecret", "source"},
+								},
+								"status": {
+									Type:        "object",
+									Description: `VirtualMachineImportStatus defines the observed state of VirtualMachineImport`,
+									Properties: map[string]extv1.JSONSchemaProps{
+										"conditions": {
+											Description: "A list of current conditions of the VirtualMachineImport resource",
+											Type:        "array",
+											Items: &extv1.JSONSchemaPropsOrArray{
+												Schema: &extv1.JSONSchemaProps{
+													Type: "object",
+													Properties: map[string]extv1.JSONSchemaProps{
+														"lastHeartbeatTime": {
+															Description: "The last time we got an update on a given condition",
+															Type:        "string",
+															Format:      "date-time",
+														},
+														"lastTransitionTime": {
+															Description: `The last time the condition transit from one status to another`,
+															Type:        "string",
+															Format:      "date-time",
+														},
+														"message": {
+															Description: `A human-readable message indicating details about last transition`,
+															Type:        "string",
+														},
+														"reason": {
+															Description: `A brief CamelCase string that describes why the VM import process is in current condition status`,
+															Type:        "string",
+														},
+														"status": {
+															Description: "Status of the condition, one of True, False, Unknown",
+															Type:        "string",
+														},
+														"type": {
+															Description: "Type of virtual machine import condition",
+															Type:        "string",
+														},
+													},
+													Required: []string{"status", "type"},
+												},
+											},
+										},
+										"dataVolumes": {
+											Description: `DataVolumeItem defines the details of a data volume created by the VM import process`,
+											Type:        "array",
+											Items: &extv1.JSONSchemaPropsOrArray{
+												Schema: &extv1.JSONSchemaProps{
+													Type: "object",
+													Properties: map[string]extv1.JSONSchemaProps{
+														"name": {
+															Type: "string",
+														},
+													},
+													Required: []string{"name"},
+												},
+											},
+										},
+										"targetVmName": {
+											Description: "The name of the virtual machine created by the import process",
+											Type:        "string",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Name:    "v1beta1",
+					Served:  true,
+					Storage: true,
+					Subresources: &extv1.CustomResourceSubresources{
+						Status: &extv1.CustomResourceSubresourceStatus{},
+					},
+					Schema: &extv1.CustomResourceValidation{
+						OpenAPIV3Schema: &extv1.JSONSchemaProps{
+							Type: "object",
+							Properties: map[string]extv1.JSONSchemaProps{
+								"apiVersion": {
+									Type: "string",
+									Description: `APIVersion defines the versioned schema of this representation
+		of an object. Servers should convert recognized schemas to the latest
+		internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources`,
+								},
+								"kind": {
+									Type: "string",
+									Description: `Kind is a string value representing the REST resource this
+		object represents. Servers may infer this from the endpoint the client
+		submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds`,
+								},
+								"metadata": {
+									Type: "object",
+								},
+								"spec": {
+									Type:        "object",
+									Description: "VirtualMachineImportSpec defines the desired state of VirtualMachineImport",
+									Properties: map[string]extv1.JSONSchemaProps{
+										"providerCredentialsSecret": {
+											Type:        "object",
+											Description: "ProviderCredentialsSecret defines how a secret resource should be identified on kubevirt",
+											Properties: map[string]extv1.JSONSchemaProps{
+												"name": {
+													Description: "Name of the secret to be used for the virtual machine import",
+													Type:        "string",
+												},
+												"namespace": {
+													Description: "Namespace of the secret to be used for the virtual machine import",
+													Type:        "string",
+												},
+											},
+											Required: []string{"name"},
+										},
+										"resourceMapping": {
+											Type:        "object",
+											Description: "ObjectIdentifier defines how a resource should be identified",
+											Properties: map[string]extv1.JSONSchemaProps{
+												"name": {
+													Description: "Name of the ResourceMapping to be used for the virtual machine import",
+													Type:        "string",
+												},
+												"namespace": {
+													Description: "Namespace of the ResourceMapping to be used for the virtual machine import",
+													Type:        "string",
+												},
+											},
+											Required: []string{"name"},
+										},
+										"warm": {
+											Type:        "boolean",
+											Description: "Indicates whether this is a warm import.",
+										},
+										"finalizeDate": {
+											Type:        "string",
+											Format:      "date-time",
+											Description: "Indicates when to stop incrementally copying and finalize a warm import.",
+										},
+										"source": {
+											Type:        "object",
+											Description: "VirtualMachineImportSourceSpec defines the source provider and the internal mapping resources",
+											Properties: map[string]extv1.JSONSchemaProps{
+												"ovirt": {
+													Type:        "object",
+													Description: `VirtualMachineImportOvirtSourceSpec defines the mapping resources and the VM identity for oVirt source provider`,
+													Properties: map[string]extv1.JSONSchemaProps{
+														"mappings": {
+															Type:        "object",
+															Description: "OvirtMappings defines the mappings of ovirt resources to kubevirt",
+															Properties: map[string]extv1.JSONSchemaProps{
+																"unmappedNetworks": {
 																	Type:        "object",
+																	Description: `Defines what happens to the NICs connected to networks without a mapping`,
 																	Properties: map[string]extv1.JSONSchemaProps{
-																		"nodeSelectorTerms": {
-																			Description: "Required. A list of node selector terms. The terms are ORed.",
-																			Type:        "array",
-																			Items: &extv1.JSONSchemaPropsOrArray{
-																				Schema: &extv1.JSONSchemaProps{
-																					Description: "A null or empty node selector term matches no objects. The requirements of them are ANDed. The TopologySelectorTerm type implements a subset of the NodeSelectorTerm.",
+																		"action": {
+																			Type:        "string",
+																			Description: `Fail blocks the import, Drop skips the NICs, Pod connects them to the pod network, Multus to the target network and Generate to network attachment definitions generated from the VLANs of their source networks. Fail by default`,
+																			Enum: []extv1.JSON{
+																				{
+																					Raw: []byte(`"Fail"`),
+																				},
+																				{
+																					Raw: []byte(`"Drop"`),
+																				},
+																				{
+																					Raw: []byte(`"Pod"`),
+																				},
+																				{
+																					Raw: []byte(`"Multus"`),
+																				},
+																				{
+																					Raw: []byte(`"Generate"`),
+																				},
+																			},
+																		},
+																		"target": {
+																			Type:        "object",
+																			Description: `Network attachment definition the NICs are connected to with the Multus action`,
+																			Properties: map[string]extv1.JSONSchemaProps{
+																				"name": {
+																					Type: "string",
+																				},
+																				"namespace": {
+																					Type: "string",
+																				},
+																			},
+																			Required: []string{"name"},
+																		},
+																	},
+																},
+																"networkMappings": {
+																	Type: "array",
+																	Description: `NetworkMappings defines the mapping of vnic profile
+		to network attachment definition When providing source network by name, the format is 'network name/vnic profile name'. When
+		providing source network by ID, the ID represents the vnic profile ID. A logical network from ovirt can be mapped to multiple network
+		attachment definitions on kubevirt by using vnic profile to network attachment definition mapping.`,
+																	Items: &extv1.JSONSchemaPropsOrArray{
+																		Schema: &extv1.JSONSchemaProps{
+																			Type:        "object",
+																			Description: `ResourceMappingItem defines the mapping of a single resource from the provider to kubevirt`,
+																			Properties: map[string]extv1.JSONSchemaProps{
+																				"source": {
+																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
 																					Type:        "object",
 																					Properties: map[string]extv1.JSONSchemaProps{
-																						"matchExpressions": {
-																							Description: "A list of node selector requirements by node's labels.",
-																							Type:        "array",
-																							Items: &extv1.JSONSchemaPropsOrArray{
-																								Schema: &extv1.JSONSchemaProps{
-																									Description: "A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
-																									Type:        "object",
-																									Properties: map[string]extv1.JSONSchemaProps{
-																										"key": {
-																											Description: "The label key that the selector applies to.",
-																											Type:        "string",
-																										},
-																										"operator": {
-																											Description: "Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.",
-																											Type:        "string",
-																										},
-																										"values": {
-																											Description: "An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.",
-																											Type:        "array",
-																											Items: &extv1.JSONSchemaPropsOrArray{
-																												Schema: &extv1.JSONSchemaProps{
-																													Type: "string",
-																												},
-																											},
-																										},
-																									},
-																									Required: []string{
-																										"key",
-																										"operator",
-																									},
-																								},
-																							},
+																						"id": {
+																							Type: "string",
 																						},
-																						"matchFields": {
-																							Description: "A list of node selector requirements by node's fields.",
-																							Type:        "array",
-																							Items: &extv1.JSONSchemaPropsOrArray{
-																								Schema: &extv1.JSONSchemaProps{
-																									Description: "A node selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
-																									Type:        "object",
-																									Properties: map[string]extv1.JSONSchemaProps{
-																										"key": {
-																											Description: "The label key that the selector applies to.",
-																											Type:        "string",
-																										},
-																										"operator": {
-																											Description: "Represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists, DoesNotExist. Gt, and Lt.",
-																											Type:        "string",
-																										},
-																										"values": {
-																											Description: "An array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. If the operator is Gt or Lt, the values array must have a single element, which will be interpreted as an integer. This array is replaced during a strategic merge patch.",
-																											Type:        "array",
-																											Items: &extv1.JSONSchemaPropsOrArray{
-																												Schema: &extv1.JSONSchemaProps{
-																													Type: "string",
-																												},
-																											},
-																										},
-																									},
-																									Required: []string{
-																										"key",
-																										"operator",
-																									},
-																								},
-																							},
+																						"name": {
+																							Type: "string",
+																						},
+																					},
+																				},
+																				"target": {
+																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
+																					Type:        "object",
+																					Properties: map[string]extv1.JSONSchemaProps{
+																						"name": {
+																							Type: "string",
+																						},
+																						"namespace": {
+																							Type: "string",
+																						},
+																					},
+																					Required: []string{"name"},
+																				},
+																				"type": {
+																					Type: "string",
+																				},
+																				"macPolicy": {
+																					Type:        "string",
+																					Description: `Overrides the MAC policy of the import for the interfaces connected to the mapped network`,
+																					Enum: []extv1.JSON{
+																						{
+																							Raw: []byte(`"Preserve"`),
+																						},
+																						{
+																							Raw: []byte(`"Regenerate"`),
+																						},
+																						{
+																							Raw: []byte(`"PreserveIfUnique"`),
+																						},
+																					},
+																				},
+																				"interfaceModel": {
+																					Type:        "string",
+																					Description: `Model of the interfaces connected to the mapped network, only used for VMware`,
+																					Enum: []extv1.JSON{
+																						{
+																							Raw: []byte(`"e1000"`),
+																						},
+																						{
+																							Raw: []byte(`"e1000e"`),
+																						},
+																						{
+																							Raw: []byte(`"ne2k_pci"`),
+																						},
+																						{
+																							Raw: []byte(`"pcnet"`),
+																						},
+																						{
+																							Raw: []byte(`"rtl8139"`),
+																						},
+																						{
+																							Raw: []byte(`"virtio"`),
+																						},
+																					},
+																				},
+																				"interfaceBinding": {
+																					Type:        "string",
+																					Description: `Defines how the interfaces are connected to the mapped network, only used for VMware`,
+																					Enum: []extv1.JSON{
+																						{
+																							Raw: []byte(`"bridge"`),
+																						},
+																						{
+																							Raw: []byte(`"masquerade"`),
+																						},
+																						{
+																							Raw: []byte(`"sriov"`),
+																						},
+																					},
+																				},
+																			},
+																			Required: []string{"source"},
+																		},
+																	},
+																},
+																"storageMappings": {
+																	Type:        "array",
+																	Description: `StorageMappings defines the mapping of storage domains to storage classes.`,
+																	Items: &extv1.JSONSchemaPropsOrArray{
+																		Schema: &extv1.JSONSchemaProps{
+																			Type:        "object",
+																			Description: `StorageResourceMappingItem defines the mapping of a single storage resource from the provider to kubevirt`,
+																			Properties: map[string]extv1.JSONSchemaProps{
+																				"source": {
+																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
+																					Type:        "object",
+																					Properties: map[string]extv1.JSONSchemaProps{
+																						"id": {
+																							Type: "string",
+																						},
+																						"name": {
+																							Type: "string",
+																						},
+																					},
+																				},
+																				"target": {
+																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
+																					Type:        "object",
+																					Properties: map[string]extv1.JSONSchemaProps{
+																						"name": {
+																							Type: "string",
+																						},
+																						"namespace": {
+																							Type: "string",
+																						},
+																					},
+																					Required: []string{"name"},
+																				},
+																				"type": {
+																					Type: "string",
+																				},
+																				"volumeMode": {
+																					Type: "string",
+																				},
+																				"accessMode": {
+																					Type: "string",
+																				},
+																				"diskBus": {
+																					Type:        "string",
+																					Description: `Bus of the mapped disks, only used for VMware`,
+																					Enum: []extv1.JSON{
+																						{
+																							Raw: []byte(`"sata"`),
+																						},
+																						{
+																							Raw: []byte(`"scsi"`),
+																						},
+																						{
+																							Raw: []byte(`"virtio"`),
+																						},
+																					},
+																				},
+																			},
+																			Required: []string{"source"},
+																		},
+																	},
+																},
+																"diskMappings": {
+																	Type: "array",
+																	Description: `DiskMappings defines the mapping of disks to storage
+		classes DiskMappings.Source.ID represents the disk ID on ovirt (as opposed to disk-attachment ID) DiskMappings.Source.Name represents
+		the disk alias on ovirt DiskMappings is respected only when provided in context of a single VM import within VirtualMachineImport.`,
+																	Items: &extv1.JSONSchemaPropsOrArray{
+																		Schema: &extv1.JSONSchemaProps{
+																			Type:        "object",
+																			Description: `NetworkResourceMappingItem defines the mapping of a single disk resource from the provider to kubevirt`,
+																			Properties: map[string]extv1.JSONSchemaProps{
+																				"source": {
+																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
+																					Type:        "object",
+																					Properties: map[string]extv1.JSONSchemaProps{
+																						"id": {
+																							Type: "string",
+																						},
+																						"name": {
+																							Type: "string",
+																						},
+																					},
+																				},
+																				"target": {
+																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
+																					Type:        "object",
+																					Properties: map[string]extv1.JSONSchemaProps{
+																						"name": {
+																							Type: "string",
+																						},
+																						"namespace": {
+																							Type: "string",
+																						},
+																					},
+																					Required: []string{"name"},
+																				},
+																				"type": {
+																					Type: "string",
+																				},
+																				"volumeMode": {
+																					Type: "string",
+																				},
+																				"accessMode": {
+																					Type: "string",
+																				},
+																				"diskBus": {
+																					Type:        "string",
+																					Description: `Bus of the mapped disks, only used for VMware`,
+																					Enum: []extv1.JSON{
+																						{
+																							Raw: []byte(`"sata"`),
+																						},
+																						{
+																							Raw: []byte(`"scsi"`),
+																						},
+																						{
+																							Raw: []byte(`"virtio"`),
 																						},
 																					},
 																				},
 																			},
+																			Required: []string{"source"},
 																		},
 																	},
-																	Required: []string{
-																		"nodeSelectorTerms",
+																},
+															},
+														},
+														"vm": {
+															Type:        "object",
+															Description: `VirtualMachineImportOvirtSourceVMSpec defines the definition of the VM info in oVirt`,
+															Properties: map[string]extv1.JSONSchemaProps{
+																"cluster": {
+																	Description: `VirtualMachineImportOvirtSourceVMClusterSpec defines the source cluster's identity of the VM in oVirt`,
+																	Type:        "object",
+																	Properties: map[string]extv1.JSONSchemaProps{
+																		"id": {
+																			Description: `id defines the id of the source cluster where VM resides`,
+																			Type:        "string",
+																		},
+																		"name": {
+																			Description: `name defines the name of the source cluster where VM resides`,
+																			Type:        "string",
+																		},
 																	},
 																},
+																"id": {
+																	Description: `id defines the id of the source VM`,
+																	Type:        "string",
+																},
+																"name": {
+																	Description: `name defines the name of the source VM`,
+																	Type:        "string",
+																},
 															},
 														},
-														"podAffinity": {
-															Description: "Describes pod affinity scheduling rules (e.g. co-locate this pod in the same node, zone, etc. as some other pod(s)).",
+													},
+													Required: []string{"vm"},
+												},
+												"vmware": {
+													Type:        "object",
+													Description: `VirtualMachineImportVmwareSourceSpec defines the mapping resources and the VM identity for vmware source provider`,
+													Properties: map[string]extv1.JSONSchemaProps{
+														"mappings": {
 															Type:        "object",
+															Description: "VmwareMappings defines the mappings of vmware resources to kubevirt",
 															Properties: map[string]extv1.JSONSchemaProps{
-																"preferredDuringSchedulingIgnoredDuringExecution": {
-																	Description: "The scheduler will prefer to schedule pods to nodes that satisfy the affinity expressions specified by this field, but it may choose a node that violates one or more of the expressions. The node that is most preferred is the one with the greatest sum of weights, i.e. for each node that meets all of the scheduling requirements (resource request, requiredDuringScheduling affinity expressions, etc.), compute a sum by iterating through the elements of this field and adding \"weight\" to the sum if the node has pods which matches the corresponding podAffinityTerm; the node(s) with the highest sum are the most preferred.",
-																	Type:        "array",
-																	Items: &extv1.JSONSchemaPropsOrArray{
-																		Schema: &extv1.JSONSchemaProps{
-																			Description: "The weights of all of the matched WeightedPodAffinityTerm fields are added per-node to find the most preferred node(s)",
+																"unmappedNetworks": {
+																	Type:        "object",
+																	Description: `Defines what happens to the NICs connected to networks without a mapping`,
+																	Properties: map[string]extv1.JSONSchemaProps{
+																		"action": {
+																			Type:        "string",
+																			Description: `Fail blocks the import, Drop skips the NICs, Pod connects them to the pod network, Multus to the target network and Generate to network attachment definitions generated from the VLANs of their source networks. Fail by default`,
+																			Enum: []extv1.JSON{
+																				{
+																					Raw: []byte(`"Fail"`),
+																				},
+																				{
+																					Raw: []byte(`"Drop"`),
+																				},
+																				{
+																					Raw: []byte(`"Pod"`),
+																				},
+																				{
+																					Raw: []byte(`"Multus"`),
+																				},
+																				{
+																					Raw: []byte(`"Generate"`),
+																				},
+																			},
+																		},
+																		"target": {
 																			Type:        "object",
+																			Description: `Network attachment definition the NICs are connected to with the Multus action`,
 																			Properties: map[string]extv1.JSONSchemaProps{
-																				"podAffinityTerm": {
-																					Description: "Required. A pod affinity term, associated with the corresponding weight.",
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"labelSelector": {
-																							Description: "A label query over a set of resources, in this case pods.",
-																							Type:        "object",
-																							Properties: map[string]extv1.JSONSchemaProps{
-																								"matchExpressions": {
-																									Description: "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
-																									Type:        "array",
-																									Items: &extv1.JSONSchemaPropsOrArray{
-																										Schema: &extv1.JSONSchemaProps{
-																											Description: "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
-																											Type:        "object",
-																											Properties: map[string]extv1.JSONSchemaProps{
-																												"key": {
-																													Description: "key is the label key that the selector applies to.",
-																													Type:        "string",
-																												},
-																												"operator": {
-																													Description: "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
-																													Type:        "string",
-																												},
-																												"values": {
-																													Description: "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
-																													Type:        "array",
-																													Items: &extv1.JSONSchemaPropsOrArray{
-																														Schema: &extv1.JSONSchemaProps{
-																															Type: "string",
-																														},
-																													},
-																												},
-																											},
-																											Required: []string{
-																												"key",
-																												"operator",
-																											},
-																										},
-																									},
-																								},
-																								"matchLabels": {
-																									Description: "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
-																									Type:        "object",
-																									AdditionalProperties: &extv1.JSONSchemaPropsOrBool{
-																										Schema: &extv1.JSONSchemaProps{
-																											Type: "string",
-																										},
-																									},
-																								},
-																							},
-																						},
-																						"namespaces": {
-																							Description: "namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means \"this pod's namespace\"",
-																							Type:        "array",
-																							Items: &extv1.JSONSchemaPropsOrArray{
-																								Schema: &extv1.JSONSchemaProps{
-																									Type: "string",
-																								},
-																							},
-																						},
-																						"topologyKey": {
-																							Description: "This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.",
-																							Type:        "string",
-																						},
-																					},
-																					Required: []string{
-																						"topologyKey",
-																					},
+																				"name": {
+																					Type: "string",
 																				},
-																				"weight": {
-																					Description: "weight associated with matching the corresponding podAffinityTerm, in the range 1-100.",
-																					Type:        "integer",
-																					Format:      "int32",
+																				"namespace": {
+																					Type: "string",
 																				},
 																			},
-																			Required: []string{
-																				"podAffinityTerm",
-																				"weight",
-																			},
+																			Required: []string{"name"},
 																		},
 																	},
 																},
-																"requiredDuringSchedulingIgnoredDuringExecution": {
-																	Description: "If the affinity requirements specified by this field are not met at scheduling time, the pod will not be scheduled onto the node. If the affinity requirements specified by this field cease to be met at some point during pod execution (e.g. due to a pod label update), the system may or may not try to eventually evict the pod from its node. When there are multiple elements, the lists of nodes corresponding to each podAffinityTerm are intersected, i.e. all terms must be satisfied.",
-																	Type:        "array",
+																"networkMappings": {
+																	Type: "array",
+																	Description: `NetworkMappings defines the mapping of guest network interfaces to kubevirt networks
+NetworkMappings.Source.Name represents the network field of the GuestNicInfo in vCenter
+NetworkMappings.Source.ID represents the macAddress field of the network adapter`,
 																	Items: &extv1.JSONSchemaPropsOrArray{
 																		Schema: &extv1.JSONSchemaProps{
-																			Description: "Defines a set of pods (namely those matching the labelSelector relative to the given namespace(s)) that this pod should be co-located (affinity) or not co-located (anti-affinity) with, where co-located is defined as running on a node whose value of the label with key <topologyKey> matches that of any node on which a pod of the set of pods is running",
 																			Type:        "object",
+																			Description: `NetworkResourceMappingItem defines the mapping of a single resource from the provider to kubevirt`,
 																			Properties: map[string]extv1.JSONSchemaProps{
-																				"labelSelector": {
-																					Description: "A label query over a set of resources, in this case pods.",
+																				"source": {
+																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
 																					Type:        "object",
 																					Properties: map[string]extv1.JSONSchemaProps{
-																						"matchExpressions": {
-																							Description: "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
-																							Type:        "array",
-																							Items: &extv1.JSONSchemaPropsOrArray{
-																								Schema: &extv1.JSONSchemaProps{
-																									Description: "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
-																									Type:        "object",
-																									Properties: map[string]extv1.JSONSchemaProps{
-																										"key": {
-																											Description: "key is the label key that the selector applies to.",
-																											Type:        "string",
-																										},
-																										"operator": {
-																											Description: "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
-																											Type:        "string",
-																										},
-																										"values": {
-																											Description: "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
-																											Type:        "array",
-																											Items: &extv1.JSONSchemaPropsOrArray{
-																												Schema: &extv1.JSONSchemaProps{
-																													Type: "string",
-																												},
-																											},
-																										},
-																									},
-																									Required: []string{
-																										"key",
-																										"operator",
-																									},
-																								},
-																							},
+																						"id": {
+																							Type: "string",
+																						},
+																						"name": {
+																							Type: "string",
+																						},
+																					},
+																				},
+																				"target": {
+																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
+																					Type:        "object",
+																					Properties: map[string]extv1.JSONSchemaProps{
+																						"name": {
+																							Type: "string",
+																						},
+																						"namespace": {
+																							Type: "string",
+																						},
+																					},
+																					Required: []string{"name"},
+																				},
+																				"type": {
+																					Type: "string",
+																				},
+																				"macPolicy": {
+																					Type:        "string",
+																					Description: `Overrides the MAC policy of the import for the interfaces connected to the mapped network`,
+																					Enum: []extv1.JSON{
+																						{
+																							Raw: []byte(`"Preserve"`),
+																						},
+																						{
+																							Raw: []byte(`"Regenerate"`),
+																						},
+																						{
+																							Raw: []byte(`"PreserveIfUnique"`),
+																						},
+																					},
+																				},
+																				"interfaceModel": {
+																					Type:        "string",
+																					Description: `Model of the interfaces connected to the mapped network, only used for VMware`,
+																					Enum: []extv1.JSON{
+																						{
+																							Raw: []byte(`"e1000"`),
+																						},
+																						{
+																							Raw: []byte(`"e1000e"`),
+																						},
+																						{
+																							Raw: []byte(`"ne2k_pci"`),
+																						},
+																						{
+																							Raw: []byte(`"pcnet"`),
+																						},
+																						{
+																							Raw: []byte(`"rtl8139"`),
+																						},
+																						{
+																							Raw: []byte(`"virtio"`),
+																						},
+																					},
+																				},
+																				"interfaceBinding": {
+																					Type:        "string",
+																					Description: `Defines how the interfaces are connected to the mapped network, only used for VMware`,
+																					Enum: []extv1.JSON{
+																						{
+																							Raw: []byte(`"bridge"`),
+																						},
+																						{
+																							Raw: []byte(`"masquerade"`),
+																						},
+																						{
+																							Raw: []byte(`"sriov"`),
+																						},
+																					},
+																				},
+																			},
+																			Required: []string{"source"},
+																		},
+																	},
+																},
+																"storageMappings": {
+																	Type:        "array",
+																	Description: `StorageMappings defines the mapping of storage domains to storage classes.`,
+																	Items: &extv1.JSONSchemaPropsOrArray{
+																		Schema: &extv1.JSONSchemaProps{
+																			Type:        "object",
+																			Description: `StorageResourceMappingItem defines the mapping of a single resource from the provider to kubevirt`,
+																			Properties: map[string]extv1.JSONSchemaProps{
+																				"source": {
+																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
+																					Type:        "object",
+																					Properties: map[string]extv1.JSONSchemaProps{
+																						"id": {
+																							Type: "string",
 																						},
-																						"matchLabels": {
-																							Description: "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
-																							Type:        "object",
-																							AdditionalProperties: &extv1.JSONSchemaPropsOrBool{
-																								Schema: &extv1.JSONSchemaProps{
-																									Type: "string",
-																								},
-																							},
+																						"name": {
+																							Type: "string",
 																						},
 																					},
 																				},
-																				"namespaces": {
-																					Description: "namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means \"this pod's namespace\"",
-																					Type:        "array",
-																					Items: &extv1.JSONSchemaPropsOrArray{
-																						Schema: &extv1.JSONSchemaProps{
+																				"target": {
+																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
+																					Type:        "object",
+																					Properties: map[string]extv1.JSONSchemaProps{
+																						"name": {
+																							Type: "string",
+																						},
+																						"namespace": {
 																							Type: "string",
 																						},
 																					},
+																					Required: []string{"name"},
 																				},
-																				"topologyKey": {
-																					Description: "This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.",
+																				"type": {
+																					Type: "string",
+																				},
+																				"volumeMode": {
+																					Type: "string",
+																				},
+																				"accessMode": {
+																					Type: "string",
+																				},
+																				"diskBus": {
 																					Type:        "string",
+																					Description: `Bus of the mapped disks, only used for VMware`,
+																					Enum: []extv1.JSON{
+																						{
+																							Raw: []byte(`"sata"`),
+																						},
+																						{
+																							Raw: []byte(`"scsi"`),
+																						},
+																						{
+																							Raw: []byte(`"virtio"`),
+																						},
+																					},
 																				},
 																			},
-																			Required: []string{
-																				"topologyKey",
-																			},
+																			Required: []string{"source"},
 																		},
 																	},
 																},
-															},
-														},
-														"podAntiAffinity": {
-															Description: "Describes pod anti-affinity scheduling rules (e.g. avoid putting this pod in the same node, zone, etc. as some other pod(s)).",
-															Type:        "object",
-															Properties: map[string]extv1.JSONSchemaProps{
-																"preferredDuringSchedulingIgnoredDuringExecution": {
-																	Description: "The scheduler will prefer to schedule pods to nodes that satisfy the anti-affinity expressions specified by this field, but it may choose a node that violates one or more of the expressions. The node that is most preferred is the one with the greatest sum of weights, i.e. for each node that meets all of the scheduling requirements (resource request, requiredDuringScheduling anti-affinity expressions, etc.), compute a sum by iterating through the elements of this field and adding \"weight\" to the sum if the node has pods which matches the corresponding podAffinityTerm; the node(s) with the highest sum are the most preferred.",
-																	Type:        "array",
+																"diskMappings": {
+																	Type: "array",
+																	Description: `DiskMappings defines the mapping of VirtualDisks to storage classes.
+DiskMappings.Source.Name represents the disk name in vCenter
+DiskMappings.Source.ID represents the DiskObjectId or vDiskID of the VirtualDisk in vCenter`,
 																	Items: &extv1.JSONSchemaPropsOrArray{
 																		Schema: &extv1.JSONSchemaProps{
-																			Description: "The weights of all of the matched WeightedPodAffinityTerm fields are added per-node to find the most preferred node(s)",
 																			Type:        "object",
+																			Description: `StorageResourceMappingItem defines the mapping of a single resource from the provider to kubevirt`,
 																			Properties: map[string]extv1.JSONSchemaProps{
-																				"podAffinityTerm": {
-																					Description: "Required. A pod affinity term, associated with the corresponding weight.",
+																				"source": {
+																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
 																					Type:        "object",
 																					Properties: map[string]extv1.JSONSchemaProps{
-																						"labelSelector": {
-																							Description: "A label query over a set of resources, in this case pods.",
-																							Type:        "object",
-																							Properties: map[string]extv1.JSONSchemaProps{
-																								"matchExpressions": {
-																									Description: "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
-																									Type:        "array",
-																									Items: &extv1.JSONSchemaPropsOrArray{
-																										Schema: &extv1.JSONSchemaProps{
-																											Description: "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
-																											Type:        "object",
-																											Properties: map[string]extv1.JSONSchemaProps{
-																												"key": {
-																													Description: "key is the label key that the selector applies to.",
-																													Type:        "string",
-																												},
-																												"operator": {
-																													Description: "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
-																													Type:        "string",
-																												},
-																												"values": {
-																													Description: "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
-																													Type:        "array",
-																													Items: &extv1.JSONSchemaPropsOrArray{
-																														Schema: &extv1.JSONSchemaProps{
-																															Type: "string",
-																														},
-																													},
-																												},
-																											},
-																											Required: []string{
-																												"key",
-																												"operator",
-																											},
-																										},
-																									},
-																								},
-																								"matchLabels": {
-																									Description: "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
-																									Type:        "object",
-																									AdditionalProperties: &extv1.JSONSchemaPropsOrBool{
-																										Schema: &extv1.JSONSchemaProps{
-																											Type: "string",
-																										},
-																									},
-																								},
-																							},
-																						},
-																						"namespaces": {
-																							Description: "namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means \"this pod's namespace\"",
-																							Type:        "array",
-																							Items: &extv1.JSONSchemaPropsOrArray{
-																								Schema: &extv1.JSONSchemaProps{
-																									Type: "string",
-																								},
-																							},
+																						"id": {
+																							Type: "string",
 																						},
-																						"topologyKey": {
-																							Description: "This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.",
-																							Type:        "string",
+																						"name": {
+																							Type: "string",
 																						},
 																					},
-																					Required: []string{
-																						"topologyKey",
+																				},
+																				"target": {
+																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
+																					Type:        "object",
+																					Properties: map[string]extv1.JSONSchemaProps{
+																						"name": {
+																							Type: "string",
+																						},
+																						"namespace": {
+																							Type: "string",
+																						},
 																					},
+																					Required: []string{"name"},
 																				},
-																				"weight": {
-																					Description: "weight associated with matching the corresponding podAffinityTerm, in the range 1-100.",
-																					Type:        "integer",
-																					Format:      "int32",
+																				"type": {
+																					Type: "string",
+																				},
+																				"volumeMode": {
+																					Type: "string",
+																				},
+																				"accessMode": {
+																					Type: "string",
+																				},
+																				"diskBus": {
+																					Type:        "string",
+																					Description: `Bus of the mapped disks, only used for VMware`,
+																					Enum: []extv1.JSON{
+																						{
+																							Raw: []byte(`"sata"`),
+																						},
+																						{
+																							Raw: []byte(`"scsi"`),
+																						},
+																						{
+																							Raw: []byte(`"virtio"`),
+																						},
+																					},
 																				},
 																			},
-																			Required: []string{
-																				"podAffinityTerm",
-																				"weight",
-																			},
+																			Required: []string{"source"},
+																		},
+																	},
+																},
+																"interfaceModel": {
+																	Type:        "string",
+																	Description: `Model of the interfaces of the target VM, virtio by default`,
+																	Enum: []extv1.JSON{
+																		{
+																			Raw: []byte(`"e1000"`),
+																		},
+																		{
+																			Raw: []byte(`"e1000e"`),
+																		},
+																		{
+																			Raw: []byte(`"ne2k_pci"`),
+																		},
+																		{
+																			Raw: []byte(`"pcnet"`),
+																		},
+																		{
+																			Raw: []byte(`"rtl8139"`),
+																		},
+																		{
+																			Raw: []byte(`"virtio"`),
+																		},
+																	},
+																},
+																"interfaceBinding": {
+																	Type:        "string",
+																	Description: `Defines how the interfaces of the target VM are connected to their networks: bridge for multus networks and masquerade for the pod network by default`,
+																	Enum: []extv1.JSON{
+																		{
+																			Raw: []byte(`"bridge"`),
+																		},
+																		{
+																			Raw: []byte(`"masquerade"`),
+																		},
+																		{
+																			Raw: []byte(`"sriov"`),
+																		},
+																	},
+																},
+																"diskBus": {
+																	Type:        "string",
+																	Description: `Bus of the disks of the target VM, virtio by default`,
+																	Enum: []extv1.JSON{
+																		{
+																			Raw: []byte(`"sata"`),
+																		},
+																		{
+																			Raw: []byte(`"scsi"`),
+																		},
+																		{
+																			Raw: []byte(`"virtio"`),
 																		},
 																	},
 																},
-																"requiredDuringSchedulingIgnoredDuringExecution": {
-																	Description: "If the anti-affinity requirements specified by this field are not met at scheduling time, the pod will not be scheduled onto the node. If the anti-affinity requirements specified by this field cease to be met at some point during pod execution (e.g. due to a pod label update), the system may or may not try to eventually evict the pod from its node. When there are multiple elements, the lists of nodes corresponding to each podAffinityTerm are intersected, i.e. all terms must be satisfied.",
+															},
+														},
+														"vm": {
+															Type:        "object",
+															Description: `VirtualMachineImportVmwareSourceVMSpec defines how to identify the VM in vCenter`,
+															Properties: map[string]extv1.JSONSchemaProps{
+																"id": {
+																	Type: "string",
+																},
+																"name": {
+																	Type: "string",
+																},
+															},
+														},
+													},
+													Required: []string{"vm"},
+												},
+											},
+										},
+										"macPolicy": {
+											Type:        "string",
+											Description: `Defines whether the imported VM keeps the MAC addresses of the source VM, Preserve by default`,
+											Enum: []extv1.JSON{
+												{
+													Raw: []byte(`"Preserve"`),
+												},
+												{
+													Raw: []byte(`"Regenerate"`),
+												},
+												{
+													Raw: []byte(`"PreserveIfUnique"`),
+												},
+											},
+										},
+										"sourceVMPolicy": {
+											Type:        "string",
+											Description: `Defines what happens to the source VM once the import succeeded: Stop leaves it stopped, Restore brings it back to its initial power state, Delete deletes it and its disks, which requires bootVerification, and Mark records in the source that it was migrated. Stop by default`,
+											Enum: []extv1.JSON{
+												{
+													Raw: []byte(`"Stop"`),
+												},
+												{
+													Raw: []byte(`"Restore"`),
+												},
+												{
+													Raw: []byte(`"Delete"`),
+												},
+												{
+													Raw: []byte(`"Mark"`),
+												},
+											},
+										},
+										"startVm": {
+											Type:        "boolean",
+											Description: `If true imported virtual machine will be started`,
+										},
+										"rollback": {
+											Type:        "boolean",
+											Description: `If true on a successful import, the imported virtual machine and its data volumes are deleted and the source virtual machine is started again`,
+										},
+										"bootVerification": {
+											Type:        "object",
+											Description: `BootVerificationSpec defines the checks the started VM has to pass before the import is declared successful. Requires startVm.`,
+											Properties: map[string]extv1.JSONSchemaProps{
+												"timeoutSeconds": {
+													Type:        "integer",
+													Format:      "int32",
+													Description: `How long the started VM has to pass the checks. 600 seconds by default`,
+												},
+												"guestAgent": {
+													Type:        "boolean",
+													Description: `Waits for the guest agent of the VM to connect`,
+												},
+												"readinessProbe": {
+													Type:        "object",
+													Description: `Readiness probe set on the VM before it is started. Exactly one of httpGet, tcpSocket and exec must be set.`,
+													Properties: map[string]extv1.JSONSchemaProps{
+														"httpGet": {
+															Type:        "object",
+															Description: `HTTP GET request sent to the VM`,
+															Properties: map[string]extv1.JSONSchemaProps{
+																"path": {
+																	Type:        "string",
+																	Description: `Path of the request, / by default`,
+																},
+																"port": {
+																	Type:        "integer",
+																	Format:      "int32",
+																	Description: `Port of the request`,
+																},
+															},
+															Required: []string{
+																"port",
+															},
+														},
+														"tcpSocket": {
+															Type:        "object",
+															Description: `TCP connection opened to the VM`,
+															Properties: map[string]extv1.JSONSchemaProps{
+																"port": {
+																	Type:        "integer",
+																	Format:      "int32",
+																	Description: `Port of the connection`,
+																},
+															},
+															Required: []string{
+																"port",
+															},
+														},
+														"exec": {
+															Type:        "object",
+															Description: `Command run in the guest through the guest agent. Exit status 0 passes`,
+															Properties: map[string]extv1.JSONSchemaProps{
+																"command": {
 																	Type:        "array",
+																	Description: `Command line to run, not interpreted by a shell`,
 																	Items: &extv1.JSONSchemaPropsOrArray{
 																		Schema: &extv1.JSONSchemaProps{
-																			Description: "Defines a set of pods (namely those matching the labelSelector relative to the given namespace(s)) that this pod should be co-located (affinity) or not co-located (anti-affinity) with, where co-located is defined as running on a node whose value of the label with key <topologyKey> matches that of any node on which a pod of the set of pods is running",
-																			Type:        "object",
-																			Properties: map[string]extv1.JSONSchemaProps{
-																				"labelSelector": {
-																					Description: "A label query over a set of resources, in this case pods.",
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"matchExpressions": {
-																							Description: "matchExpressions is a list of label selector requirements. The requirements are ANDed.",
-																							Type:        "array",
-																							Items: &extv1.JSONSchemaPropsOrArray{
-																								Schema: &extv1.JSONSchemaProps{
-																									Description: "A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.",
-																									Type:        "object",
-																									Properties: map[string]extv1.JSONSchemaProps{
-																										"key": {
-																											Description: "key is the label key that the selector applies to.",
-																											Type:        "string",
-																										},
-																										"operator": {
-																											Description: "operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.",
-																											Type:        "string",
-																										},
-																										"values": {
-																											Description: "values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.",
-																											Type:        "array",
-																											Items: &extv1.JSONSchemaPropsOrArray{
-																												Schema: &extv1.JSONSchemaProps{
-																													Type: "string",
-																												},
-																											},
-																										},
-																									},
-																									Required: []string{
-																										"key",
-																										"operator",
-																									},
-																								},
-																							},
-																						},
-																						"matchLabels": {
-																							Description: "matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is \"key\", the operator is \"In\", and the values array contains only \"value\". The requirements are ANDed.",
-																							Type:        "object",
-																							AdditionalProperties: &extv1.JSONSchemaPropsOrBool{
-																								Schema: &extv1.JSONSchemaProps{
-																									Type: "string",
-																								},
-																							},
-																						},
-																					},
-																				},
-																				"namespaces": {
-																					Description: "namespaces specifies which namespaces the labelSelector applies to (matches against); null or empty list means \"this pod's namespace\"",
-																					Type:        "array",
-																					Items: &extv1.JSONSchemaPropsOrArray{
-																						Schema: &extv1.JSONSchemaProps{
-																							Type: "string",
-																						},
-																					},
-																				},
-																				"topologyKey": {
-																					Description: "This pod should be co-located (affinity) or not co-located (anti-affinity) with the pods matching the labelSelector in the specified namespaces, where co-located is defined as running on a node whose value of the label with key topologyKey matches that of any node on which any of the selected pods is running. Empty topologyKey is not allowed.",
-																					Type:        "string",
-																				},
-																			},
-																			Required: []string{
-																				"topologyKey",
-																			},
+																			Type: "string",
 																		},
 																	},
 																},
 															},
+															Required: []string{
+																"command",
+															},
+														},
+														"periodSeconds": {
+															Type:        "integer",
+															Format:      "int32",
+															Description: `How often the probe is performed. 10 seconds by default`,
 														},
 													},
 												},
-												"nodeSelector": {
-													Description: "nodeSelector is the node selector applied to the relevant kind of pods It specifies a map of key-value pairs: for the pod to be eligible to run on a node, the node must have each of the indicated key-value pairs as labels (it can have additional labels as well). See https://kubernetes.io/docs/concepts/configuration/assign-pod-node/#nodeselector",
+												"rollbackOnFailure": {
+													Type:        "boolean",
+													Description: `Deletes the imported VM and restores the source VM when the verification fails. Otherwise the imported VM is kept for troubleshooting`,
+												},
+											},
+										},
+										"template": {
+											Type:        "object",
+											Description: `TemplateSpec pins the template the imported VM is created from, or constrains the search of the newest template matching the operating system of the source VM`,
+											Properties: map[string]extv1.JSONSchemaProps{
+												"namespace": {
+													Type:        "string",
+													Description: `Namespace of the template, openshift by default`,
+												},
+												"name": {
+													Type:        "string",
+													Description: `Name of the template, skipping the search`,
+												},
+												"labels": {
 													Type:        "object",
+													Description: `Labels the searched templates must carry besides the operating system, workload and flavor ones`,
 													AdditionalProperties: &extv1.JSONSchemaPropsOrBool{
 														Schema: &extv1.JSONSchemaProps{
 															Type: "string",
 														},
 													},
 												},
-												"tolerations": {
-													Description: "tolerations is a list of tolerations applied to the relevant kind of pods See https://kubernetes.io/docs/concepts/configuration/taint-and-toleration/ for more info. These are additional tolerations other than default ones.",
-													Type:        "array",
-													Items: &extv1.JSONSchemaPropsOrArray{
-														Schema: &extv1.JSONSchemaProps{
-															Description: "The pod this Toleration is attached to tolerates any taint that matches the triple <key,value,effect> using the matching operator <operator>.",
+												"workload": {
+													Type:        "string",
+													Description: `Workload searched before the workloads derived from the source VM, e.g. server`,
+												},
+												"flavor": {
+													Type:        "string",
+													Description: `Flavor searched before the default flavors, e.g. large`,
+												},
+											},
+										},
+										"vmPatches": {
+											Type:        "array",
+											Description: `VMPatches are applied in order to the mapped VM before it's created`,
+											Items: &extv1.JSONSchemaPropsOrArray{
+												Schema: &extv1.JSONSchemaProps{
+													Type:        "object",
+													Description: `ResourcePatch defines a patch applied to a resource created by the import. Exactly one of Patch and ConfigMap must be set.`,
+													Properties: map[string]extv1.JSONSchemaProps{
+														"type": {
+															Type:        "string",
+															Description: `Type of the patch, StrategicMerge by default`,
+															Enum: []extv1.JSON{
+																{Raw: []byte(`"StrategicMerge"`)},
+																{Raw: []byte(`"JSON"`)},
+															},
+														},
+														"patch": {
+															Type:        "string",
+															Description: `Patch inline, in JSON or YAML`,
+														},
+														"configMap": {
 															Type:        "object",
+															Description: `ConfigMap holding the patch, in JSON or YAML`,
 															Properties: map[string]extv1.JSONSchemaProps{
-																"effect": {
-																	Description: "Effect indicates the taint effect to match. Empty means match all taint effects. When specified, allowed values are NoSchedule, PreferNoSchedule and NoExecute.",
+																"name": {
 																	Type:        "string",
+																	Description: `Name of the config map`,
 																},
 																"key": {
-																	Description: "Key is the taint key that the toleration applies to. Empty means match all taint keys. If the key is empty, operator must be Exists; this combination means to match all values and all keys.",
-																	Type:        "string",
-																},
-																"operator": {
-																	Description: "Operator represents a key's relationship to the value. Valid operators are Exists and Equal. Defaults to Equal. Exists is equivalent to wildcard for value, so that a pod can tolerate all taints of a particular category.",
-																	Type:        "string",
-																},
-																"tolerationSeconds": {
-																	Description: "TolerationSeconds represents the period of time the toleration (which must be of effect NoExecute, otherwise this field is ignored) tolerates the taint. By default, it is not set, which means tolerate the taint forever (do not evict). Zero and negative values will be treated as 0 (evict immediately) by the system.",
-																	Type:        "integer",
-																	Format:      "int64",
-																},
-																"value": {
-																	Description: "Value is the taint value the toleration matches to. If the operator is Exists, the value should be empty, otherwise just a regular string.",
 																	Type:        "string",
+																	Description: `Key of the patch in the config map`,
 																},
 															},
+															Required: []string{
+																"name",
+																"key",
+															},
 														},
 													},
 												},
 											},
 										},
-									},
-								},
-								"status": sdkopenapi.OperatorConfigStatus(""),
-							},
-						},
-					},
-				},
-			},
-			Names: extv1.CustomResourceDefinitionNames{
-				Kind:     "VMImportConfig",
-				ListKind: "VMImportConfigList",
-				Plural:   "vmimportconfigs",
-				Singular: "vmimportconfig",
-				Categories: []string{
-					"all",
-				},
-			},
-		},
-	}
-}
-
-// CreateVMImport creates the VM Import CRD
-func CreateVMImport() *extv1.CustomResourceDefinition {
-	maxTargetVMName := int64(validation.LabelValueMaxLength)
-	return &extv1.CustomResourceDefinition{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: "apiextensions.k8s.io/v1",
-			Kind:       "CustomResourceDefinition",
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "virtualmachineimports.v2v.kubevirt.io",
-			Labels: map[string]string{
-				"operator.v2v.kubevirt.io": "",
-			},
-		},
-		Spec: extv1.CustomResourceDefinitionSpec{
-			Group: "v2v.kubevirt.io",
-			Scope: "Namespaced",
-			Conversion: &extv1.CustomResourceConversion{
-				Strategy: extv1.NoneConverter,
-			},
-			Versions: []extv1.CustomResourceDefinitionVersion{
-				{
-					Name:    "v1alpha1",
-					Served:  true,
-					Storage: false,
-					Subresources: &extv1.CustomResourceSubresources{
-						Status: &extv1.CustomResourceSubresourceStatus{},
-					},
-					Schema: &extv1.CustomResourceValidation{
-						OpenAPIV3Schema: &extv1.JSONSchemaProps{
-							Type: "object",
-							Properties: map[string]extv1.JSONSchemaProps{
-								"apiVersion": {
-									Type: "string",
-									Description: `APIVersion defines the versioned schema of this representation
-					of an object. Servers should convert recognized schemas to the latest
-					internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources`,
-								},
-								"kind": {
-									Type: "string",
-									Description: `Kind is a string value representing the REST resource this
-					object represents. Servers may infer this from the endpoint the client
-					submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds`,
-								},
-								"metadata": {
-									Type: "object",
-								},
-								"spec": {
-									Type:        "object",
-									Description: "VirtualMachineImportSpec defines the desired state of VirtualMachineImport",
-									Properties: map[string]extv1.JSONSchemaProps{
-										"providerCredentialsSecret": {
-											Type:        "object",
-											Description: "ProviderCredentialsSecret defines how a secret resource should be identified on kubevirt",
-											Properties: map[string]extv1.JSONSchemaProps{
-												"name": {
-													Type: "string",
-												},
-												"namespace": {
-													Type: "string",
-												},
-											},
-											Required: []string{"name"},
-										},
-										"resourceMapping": {
-											Type:        "object",
-											Description: "ObjectIdentifier defines how a resource should be identified",
-											Properties: map[string]extv1.JSONSchemaProps{
-												"name": {
-													Type: "string",
-												},
-												"namespace": {
-													Type: "string",
+										"dataVolumePatches": {
+											Type:        "array",
+											Description: `DataVolumePatches are applied in order to each mapped data volume before it's created`,
+											Items: &extv1.JSONSchemaPropsOrArray{
+												Schema: &extv1.JSONSchemaProps{
+													Type:        "object",
+													Description: `ResourcePatch defines a patch applied to a resource created by the import. Exactly one of Patch and ConfigMap must be set.`,
+													Properties: map[string]extv1.JSONSchemaProps{
+														"type": {
+															Type:        "string",
+															Description: `Type of the patch, StrategicMerge by default`,
+															Enum: []extv1.JSON{
+																{Raw: []byte(`"StrategicMerge"`)},
+																{Raw: []byte(`"JSON"`)},
+															},
+														},
+														"patch": {
+															Type:        "string",
+															Description: `Patch inline, in JSON or YAML`,
+														},
+														"configMap": {
+															Type:        "object",
+															Description: `ConfigMap holding the patch, in JSON or YAML`,
+															Properties: map[string]extv1.JSONSchemaProps{
+																"name": {
+																	Type:        "string",
+																	Description: `Name of the config map`,
+																},
+																"key": {
+																	Type:        "string",
+																	Description: `Key of the patch in the config map`,
+																},
+															},
+															Required: []string{
+																"name",
+																"key",
+															},
+														},
+													},
 												},
 											},
-											Required: []string{"name"},
 										},
-										"source": {
+										"propagation": {
 											Type:        "object",
-											Description: "VirtualMachineImportSourceSpec defines the source provider and the internal mapping resources",
+											Description: `Propagation defines the labels and annotations set on the resources created by the import`,
 											Properties: map[string]extv1.JSONSchemaProps{
-												"ovirt": {
-													Type:        "object",
-													Description: `VirtualMachineImportOvirtSourceSpec defines the mapping resources and the VM identity for oVirt source provider`,
-													Properties: map[string]extv1.JSONSchemaProps{
-														"mappings": {
+												"labels": {
+													Type:        "array",
+													Description: `Labels set on the resources`,
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
 															Type:        "object",
-															Description: "OvirtMappings defines the mappings of ovirt resources to kubevirt",
+															Description: `PropagationRule defines a label or annotation and where its value is taken from. Exactly one of Value, FromImportLabel, FromImportAnnotation and FromSource must be set.`,
 															Properties: map[string]extv1.JSONSchemaProps{
-																"networkMappings": {
-																	Type: "array",
-																	Description: `NetworkMappings defines the mapping of vnic profile
-					to network attachment definition When providing source network by name, the format is 'network name/vnic profile name'. When
-					providing source network by ID, the ID represents the vnic profile ID. A logical network from ovirt can be mapped to multiple network
-					attachment definitions on kubevirt by using vnic profile to network attachment definition mapping.`,
-																	Items: &extv1.JSONSchemaPropsOrArray{
-																		Schema: &extv1.JSONSchemaProps{
-																			Type:        "object",
-																			Description: `ResourceMappingItem defines the mapping of a single resource from the provider to kubevirt`,
-																			Properties: map[string]extv1.JSONSchemaProps{
-																				"source": {
-																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"id": {
-																							Type: "string",
-																						},
-																						"name": {
-																							Type: "string",
-																						},
-																					},
-																				},
-																				"target": {
-																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"name": {
-																							Type: "string",
-																						},
-																						"namespace": {
-																							Type: "string",
-																						},
-																					},
-																					Required: []string{"name"},
-																				},
-																				"type": {
-																					Type: "string",
-																				},
-																			},
-																			Required: []string{"source"},
-																		},
-																	},
+																"key": {
+																	Type:        "string",
+																	Description: `Key of the label or annotation, outside of the kubevirt.io domain and its subdomains`,
 																},
-																"storageMappings": {
-																	Type:        "array",
-																	Description: `StorageMappings defines the mapping of storage domains to storage classes.`,
-																	Items: &extv1.JSONSchemaPropsOrArray{
-																		Schema: &extv1.JSONSchemaProps{
-																			Type:        "object",
-																			Description: `ResourceMappingItem defines the mapping of a single resource from the provider to kubevirt`,
-																			Properties: map[string]extv1.JSONSchemaProps{
-																				"source": {
-																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"id": {
-																							Type: "string",
-																						},
-																						"name": {
-																							Type: "string",
-																						},
-																					},
-																				},
-																				"target": {
-																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"name": {
-																							Type: "string",
-																						},
-																						"namespace": {
-																							Type: "string",
-																						},
-																					},
-																					Required: []string{"name"},
-																				},
-																				"type": {
-																					Type: "string",
-																				},
-																			},
-																			Required: []string{"source"},
+																"value": {
+																	Type:        "string",
+																	Description: `Value of the label or annotation`,
+																},
+																"fromImportLabel": {
+																	Type:        "string",
+																	Description: `FromImportLabel takes the value from the label of the import with this key`,
+																},
+																"fromImportAnnotation": {
+																	Type:        "string",
+																	Description: `FromImportAnnotation takes the value from the annotation of the import with this key`,
+																},
+																"fromSource": {
+																	Type:        "object",
+																	Description: `FromSource takes the value from the metadata of the source VM`,
+																	Properties: map[string]extv1.JSONSchemaProps{
+																		"tag": {
+																			Type:        "string",
+																			Description: `Tag sets the value to true when the source VM carries the oVirt tag with this name`,
+																		},
+																		"customAttribute": {
+																			Type:        "string",
+																			Description: `CustomAttribute takes the value of the vSphere custom attribute, or of the oVirt custom property, with this name`,
+																		},
+																		"folder": {
+																			Type:        "boolean",
+																			Description: `Folder takes the path of the vSphere folder of the source VM`,
 																		},
 																	},
 																},
-																"diskMappings": {
-																	Type: "array",
-																	Description: `DiskMappings defines the mapping of disks to storage
-					classes DiskMappings.Source.ID represents the disk ID on ovirt (as opposed to disk-attachment ID) DiskMappings.Source.Name represents
-					the disk alias on ovirt DiskMappings is respected only when provided in context of a single VM import within VirtualMachineImport.`,
+																"targets": {
+																	Type:        "array",
+																	Description: `Targets the label or annotation is set on, all of them by default`,
 																	Items: &extv1.JSONSchemaPropsOrArray{
 																		Schema: &extv1.JSONSchemaProps{
-																			Type:        "object",
-																			Description: `ResourceMappingItem defines the mapping of a single resource from the provider to kubevirt`,
-																			Properties: map[string]extv1.JSONSchemaProps{
-																				"source": {
-																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"id": {
-																							Type: "string",
-																						},
-																						"name": {
-																							Type: "string",
-																						},
-																					},
-																				},
-																				"target": {
-																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"name": {
-																							Type: "string",
-																						},
-																						"namespace": {
-																							Type: "string",
-																						},
-																					},
-																					Required: []string{"name"},
-																				},
-																				"type": {
-																					Type: "string",
-																				},
+																			Type: "string",
+																			Enum: []extv1.JSON{
+																				{Raw: []byte(`"VirtualMachine"`)},
+																				{Raw: []byte(`"VirtualMachineInstance"`)},
+																				{Raw: []byte(`"DataVolume"`)},
+																				{Raw: []byte(`"PersistentVolumeClaim"`)},
+																				{Raw: []byte(`"GuestConversionPod"`)},
 																			},
-																			Required: []string{"source"},
 																		},
 																	},
 																},
 															},
+															Required: []string{
+																"key",
+															},
 														},
-														"vm": {
+													},
+												},
+												"annotations": {
+													Type:        "array",
+													Description: `Annotations set on the resources`,
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
 															Type:        "object",
-															Description: `VirtualMachineImportOvirtSourceVMSpec defines the definition of the VM info in oVirt`,
+															Description: `PropagationRule defines a label or annotation and where its value is taken from. Exactly one of Value, FromImportLabel, FromImportAnnotation and FromSource must be set.`,
 															Properties: map[string]extv1.JSONSchemaProps{
-																"cluster": {
-																	Description: `VirtualMachineImportOvirtSourceVMClusterSpec defines the source cluster's identity of the VM in oVirt`,
+																"key": {
+																	Type:        "string",
+																	Description: `Key of the label or annotation, outside of the kubevirt.io domain and its subdomains`,
+																},
+																"value": {
+																	Type:        "string",
+																	Description: `Value of the label or annotation`,
+																},
+																"fromImportLabel": {
+																	Type:        "string",
+																	Description: `FromImportLabel takes the value from the label of the import with this key`,
+																},
+																"fromImportAnnotation": {
+																	Type:        "string",
+																	Description: `FromImportAnnotation takes the value from the annotation of the import with this key`,
+																},
+																"fromSource": {
 																	Type:        "object",
+																	Description: `FromSource takes the value from the metadata of the source VM`,
 																	Properties: map[string]extv1.JSONSchemaProps{
-																		"id": {
-																			Type: "string",
+																		"tag": {
+																			Type:        "string",
+																			Description: `Tag sets the value to true when the source VM carries the oVirt tag with this name`,
 																		},
-																		"name": {
-																			Type: "string",
+																		"customAttribute": {
+																			Type:        "string",
+																			Description: `CustomAttribute takes the value of the vSphere custom attribute, or of the oVirt custom property, with this name`,
+																		},
+																		"folder": {
+																			Type:        "boolean",
+																			Description: `Folder takes the path of the vSphere folder of the source VM`,
 																		},
 																	},
 																},
-																"id": {
-																	Type: "string",
+																"targets": {
+																	Type:        "array",
+																	Description: `Targets the label or annotation is set on, all of them by default`,
+																	Items: &extv1.JSONSchemaPropsOrArray{
+																		Schema: &extv1.JSONSchemaProps{
+																			Type: "string",
+																			Enum: []extv1.JSON{
+																				{Raw: []byte(`"VirtualMachine"`)},
+																				{Raw: []byte(`"VirtualMachineInstance"`)},
+																				{Raw: []byte(`"DataVolume"`)},
+																				{Raw: []byte(`"PersistentVolumeClaim"`)},
+																				{Raw: []byte(`"GuestConversionPod"`)},
+																			},
+																		},
+																	},
 																},
-																"name": {
-																	Type: "string",
+															},
+															Required: []string{
+																"key",
+															},
+														},
+													},
+												},
+											},
+										},
+										"naming": {
+											Type:        "object",
+											Description: `NamingSpec defines the Go templates the names of the objects created by the import are built with. The names are normalized to DNS-1123 and suffixed with their index when already taken.`,
+											Properties: map[string]extv1.JSONSchemaProps{
+												"dataVolume": {
+													Type:        "string",
+													Description: `DataVolume is the template of the data volume names, executed with the VMName, DiskName, DiskID and Index of each disk of the source VM, e.g. {{.VMName}}-disk{{.Index}}. The names must depend on the VMName.`,
+												},
+												"interface": {
+													Type:        "string",
+													Description: `Interface is the template of the network interface and network names of the VM, executed with the NICName and Index of each NIC of the source VM, e.g. net{{.Index}}`,
+												},
+											},
+										},
+										"guestConversion": {
+											Type:        "object",
+											Description: `GuestConversionSpec defines how the guest of the imported VM is converted by virt-v2v`,
+											Properties: map[string]extv1.JSONSchemaProps{
+												"enabled": {
+													Type:        "boolean",
+													Description: `Forces the guest conversion on or off. When not set, the conversion of oVirt VMs is decided based on the source VM devices; VMware VMs are always converted.`,
+												},
+												"virtioWin": {
+													Type:        "object",
+													Description: `Source of the virtio-win ISO the Windows drivers are installed from. When not set, the ISO shipped with the virt-v2v image is used.`,
+													Properties: map[string]extv1.JSONSchemaProps{
+														"image": {
+															Type:        "string",
+															Description: `Container image holding the ISO. The image must provide the cp command.`,
+														},
+														"persistentVolumeClaim": {
+															Type:        "string",
+															Description: `Name of a PVC in the namespace of the import holding the ISO`,
+														},
+														"path": {
+															Type:        "string",
+															Description: `Path of the ISO in the image, /virtio-win.iso by default, or on the PVC, virtio-win.iso by default`,
+														},
+													},
+												},
+												"extraArgs": {
+													Type:        "array",
+													Description: `Arguments appended to the arguments of virt-v2v`,
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
+												"firstBootScripts": {
+													Type:        "object",
+													Description: `References a config map in the namespace of the import. Each of its keys is a script run in the guest on its first boot, in the alphabetical order of the keys.`,
+													Properties: map[string]extv1.JSONSchemaProps{
+														"name": {
+															Type: "string",
+														},
+													},
+												},
+												"vmwareTools": {
+													Type:        "string",
+													Description: `Defines whether the VMware Tools are kept in the guest, Keep by default`,
+													Enum: []extv1.JSON{
+														{
+															Raw: []byte(`"Keep"`),
+														},
+														{
+															Raw: []byte(`"Remove"`),
+														},
+													},
+												},
+												"timeoutMinutes": {
+													Type:        "integer",
+													Format:      "int32",
+													Description: `How long the conversion may run before it is failed`,
+												},
+												"pod": guestConversionPodSchema(`Resources and placement of the virt-v2v pod, replacing the ones configured in VMImportConfig`),
+											},
+										},
+										"guestInspection": {
+											Type:        "object",
+											Description: `GuestInspectionSpec defines whether the guest of the imported VM is inspected by virt-inspector`,
+											Properties: map[string]extv1.JSONSchemaProps{
+												"enabled": {
+													Type:        "boolean",
+													Description: `Inspects the guest once its disks are imported and selects the template and the operating system labels of the VM from the inspected operating system`,
+												},
+											},
+										},
+										"customization": {
+											Type:        "object",
+											Description: `CustomizationSpec defines the data the guest of the imported VM is customized with on its first boot. Only one of cloudInit and sysprep may be set.`,
+											Properties: map[string]extv1.JSONSchemaProps{
+												"cloudInit": {
+													Type:        "object",
+													Description: `Attaches cloud-init user data and network data to the VM`,
+													Properties: map[string]extv1.JSONSchemaProps{
+														"type": {
+															Type:        "string",
+															Description: `Type of the cloud-init data source, NoCloud by default`,
+															Enum: []extv1.JSON{
+																{
+																	Raw: []byte(`"NoCloud"`),
+																},
+																{
+																	Raw: []byte(`"ConfigDrive"`),
 																},
 															},
 														},
+														"userDataSecretRef":    localObjectReferenceSchema(`References a secret in the namespace of the import holding the user data under the userdata key`),
+														"networkDataSecretRef": localObjectReferenceSchema(`References a secret in the namespace of the import holding the network data under the networkdata key`),
 													},
-													Required: []string{"vm"},
+												},
+												"sysprep": {
+													Type:        "object",
+													Description: `Attaches a Windows unattend file to the VM, taken from the unattend.xml or autounattend.xml key of a config map or a secret`,
+													Properties: map[string]extv1.JSONSchemaProps{
+														"configMap": localObjectReferenceSchema(`References a config map in the namespace of the import`),
+														"secret":    localObjectReferenceSchema(`References a secret in the namespace of the import`),
+													},
+												},
+												"removeAfterFirstBoot": {
+													Type:        "boolean",
+													Description: `Detaches the customization volume from the VM once it has started successfully`,
+												},
+												"restoreNetwork": {
+													Type:        "boolean",
+													Description: `Re-applies the static IP configuration reported by the source guest tools on the interfaces with the same MAC addresses`,
 												},
 											},
 										},
-										"startVm": {
-											Type: "boolean",
-										},
 										"targetVmName": {
-											Type:      "string",
-											MaxLength: &maxTargetVMName,
+											Description: `Specifies the name of the imported virtual machine`,
+											Type:        "string",
+											MaxLength:   &maxTargetVMName,
+										},
+										"targetNamespace": {
+											Description: `Namespace the virtual machine, its data volumes and the guest conversion pod are created in. Defaults to the namespace of the import. The user who creates the import must be allowed to create virtual machines in it.`,
+											Type:        "string",
+											MaxLength:   &maxTargetNamespace,
 										},
 									},
 									Required: []string{"providerCredentialsSecret", "source"},
@@ -2061,7 +3519,75 @@ func CreateVMImport() *extv1.CustomResourceDefinition {
 													Type: "object",
 													Properties: map[string]extv1.JSONSchemaProps{
 														"name": {
-															Type: "string",
+															Description: `Name of the DataVolume that was created by virtual machine import`,
+															Type:        "string",
+														},
+														"retryCount": {
+															Description: `The number of times the import of the DataVolume has been retried`,
+															Type:        "integer",
+														},
+														"lastFailure": {
+															Description: `The reason of the last failed import attempt`,
+															Type:        "string",
+														},
+														"nextRetryTime": {
+															Description: `The time when the DataVolume import is going to be retried`,
+															Type:        "string",
+															Format:      "date-time",
+														},
+														"sourceDiskID": {
+															Description: `The ID of the source disk imported to the DataVolume`,
+															Type:        "string",
+														},
+														"sourceDiskName": {
+															Description: `The name of the source disk imported to the DataVolume`,
+															Type:        "string",
+														},
+														"pvcName": {
+															Description: `The name of the PersistentVolumeClaim backing the DataVolume`,
+															Type:        "string",
+														},
+														"size": {
+															Description:  `The requested size of the DataVolume`,
+															XIntOrString: true,
+															AnyOf: []extv1.JSONSchemaProps{
+																{Type: "integer"},
+																{Type: "string"},
+															},
+															Pattern: `^(\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))(([KMGTPE]i)|[numkMGTPE]|([eE](\+|-)?(([0-9]+(\.[0-9]*)?)|(\.[0-9]+))))?$`,
+														},
+														"phase": {
+															Description: `The phase of the DataVolume`,
+															Type:        "string",
+														},
+														"progress": {
+															Description: `The import progress of the DataVolume in percents, e.g. "45.00%"`,
+															Type:        "string",
+														},
+														"bytesTransferred": {
+															Description: `The number of bytes transferred so far, estimated from the progress and the size of the DataVolume`,
+															Type:        "integer",
+															Format:      "int64",
+														},
+														"throughput": {
+															Description: `The average transfer rate of the DataVolume import in bytes per second`,
+															Type:        "integer",
+															Format:      "int64",
+														},
+														"startTime": {
+															Description: `The time when the DataVolume import started`,
+															Type:        "string",
+															Format:      "date-time",
+														},
+														"endTime": {
+															Description: `The time when the DataVolume import completed`,
+															Type:        "string",
+															Format:      "date-time",
+														},
+														"estimatedCompletionTime": {
+															Description: `The estimated time when the DataVolume import completes`,
+															Type:        "string",
+															Format:      "date-time",
 														},
 													},
 													Required: []string{"name"},
@@ -2072,530 +3598,327 @@ func CreateVMImport() *extv1.CustomResourceDefinition {
 											Description: "The name of the virtual machine created by the import process",
 											Type:        "string",
 										},
-									},
-								},
-							},
-						},
-					},
-				},
-				{
-					Name:    "v1beta1",
-					Served:  true,
-					Storage: true,
-					Subresources: &extv1.CustomResourceSubresources{
-						Status: &extv1.CustomResourceSubresourceStatus{},
-					},
-					Schema: &extv1.CustomResourceValidation{
-						OpenAPIV3Schema: &extv1.JSONSchemaProps{
-							Type: "object",
-							Properties: map[string]extv1.JSONSchemaProps{
-								"apiVersion": {
-									Type: "string",
-									Description: `APIVersion defines the versioned schema of this representation
-		of an object. Servers should convert recognized schemas to the latest
-		internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources`,
-								},
-								"kind": {
-									Type: "string",
-									Description: `Kind is a string value representing the REST resource this
-		object represents. Servers may infer this from the endpoint the client
-		submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds`,
-								},
-								"metadata": {
-									Type: "object",
-								},
-								"spec": {
-									Type:        "object",
-									Description: "VirtualMachineImportSpec defines the desired state of VirtualMachineImport",
-									Properties: map[string]extv1.JSONSchemaProps{
-										"providerCredentialsSecret": {
+										"warmImport": {
+											Description: "Details about the status of a warm import.",
 											Type:        "object",
-											Description: "ProviderCredentialsSecret defines how a secret resource should be identified on kubevirt",
 											Properties: map[string]extv1.JSONSchemaProps{
-												"name": {
-													Description: "Name of the secret to be used for the virtual machine import",
+												"nextStageTime": {
 													Type:        "string",
+													Format:      "date-time",
+													Description: "The time when the next warm import stage is scheduled.",
 												},
-												"namespace": {
-													Description: "Namespace of the secret to be used for the virtual machine import",
+												"successes": {
+													Type:        "integer",
+													Description: "The total number of successful stages.",
+												},
+												"failures": {
+													Type:        "integer",
+													Description: "The total number of failed stages.",
+												},
+												"consecutiveFailures": {
+													Type:        "integer",
+													Description: "The number of stages that have failed consecutively, reset after a success.",
+												},
+												"rootSnapshot": {
 													Type:        "string",
+													Description: "The ID of the initial snapshot that was created to start the warm import.",
 												},
 											},
-											Required: []string{"name"},
 										},
-										"resourceMapping": {
+										"guestConversion": {
+											Description: "The outcome of the guest conversion, as reported by virt-v2v",
 											Type:        "object",
-											Description: "ObjectIdentifier defines how a resource should be identified",
 											Properties: map[string]extv1.JSONSchemaProps{
-												"name": {
-													Description: "Name of the ResourceMapping to be used for the virtual machine import",
+												"operatingSystem": {
 													Type:        "string",
+													Description: "The operating system virt-v2v found in the guest",
 												},
-												"namespace": {
-													Description: "Namespace of the ResourceMapping to be used for the virtual machine import",
+												"installedDrivers": {
+													Type:        "array",
+													Description: "The drivers of the devices the converted guest uses",
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
+												"warnings": {
+													Type:        "array",
+													Description: "Warnings reported by virt-v2v",
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
+												"logTail": {
 													Type:        "string",
+													Description: "The end of the virt-v2v log",
+												},
+												"logError": {
+													Type:        "string",
+													Description: "Why the virt-v2v log couldn't be read to the end. The summary covers the part read so far and the virt-v2v pod is kept for its complete log.",
 												},
 											},
-											Required: []string{"name"},
-										},
-										"warm": {
-											Type:        "boolean",
-											Description: "Indicates whether this is a warm import.",
-										},
-										"finalizeDate": {
-											Type:        "string",
-											Format:      "date-time",
-											Description: "Indicates when to stop incrementally copying and finalize a warm import.",
 										},
-										"source": {
+										"guestInspection": {
+											Description: "The outcome of the guest inspection, as reported by virt-inspector",
 											Type:        "object",
-											Description: "VirtualMachineImportSourceSpec defines the source provider and the internal mapping resources",
 											Properties: map[string]extv1.JSONSchemaProps{
-												"ovirt": {
-													Type:        "object",
-													Description: `VirtualMachineImportOvirtSourceSpec defines the mapping resources and the VM identity for oVirt source provider`,
-													Properties: map[string]extv1.JSONSchemaProps{
-														"mappings": {
-															Type:        "object",
-															Description: "OvirtMappings defines the mappings of ovirt resources to kubevirt",
-															Properties: map[string]extv1.JSONSchemaProps{
-																"networkMappings": {
-																	Type: "array",
-																	Description: `NetworkMappings defines the mapping of vnic profile
-		to network attachment definition When providing source network by name, the format is 'network name/vnic profile name'. When
-		providing source network by ID, the ID represents the vnic profile ID. A logical network from ovirt can be mapped to multiple network
-		attachment definitions on kubevirt by using vnic profile to network attachment definition mapping.`,
-																	Items: &extv1.JSONSchemaPropsOrArray{
-																		Schema: &extv1.JSONSchemaProps{
-																			Type:        "object",
-																			Description: `ResourceMappingItem defines the mapping of a single resource from the provider to kubevirt`,
-																			Properties: map[string]extv1.JSONSchemaProps{
-																				"source": {
-																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"id": {
-																							Type: "string",
-																						},
-																						"name": {
-																							Type: "string",
-																						},
-																					},
-																				},
-																				"target": {
-																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"name": {
-																							Type: "string",
-																						},
-																						"namespace": {
-																							Type: "string",
-																						},
-																					},
-																					Required: []string{"name"},
-																				},
-																				"type": {
-																					Type: "string",
-																				},
-																			},
-																			Required: []string{"source"},
-																		},
-																	},
-																},
-																"storageMappings": {
-																	Type:        "array",
-																	Description: `StorageMappings defines the mapping of storage domains to storage classes.`,
-																	Items: &extv1.JSONSchemaPropsOrArray{
-																		Schema: &extv1.JSONSchemaProps{
-																			Type:        "object",
-																			Description: `StorageResourceMappingItem defines the mapping of a single storage resource from the provider to kubevirt`,
-																			Properties: map[string]extv1.JSONSchemaProps{
-																				"source": {
-																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"id": {
-																							Type: "string",
-																						},
-																						"name": {
-																							Type: "string",
-																						},
-																					},
-																				},
-																				"target": {
-																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"name": {
-																							Type: "string",
-																						},
-																						"namespace": {
-																							Type: "string",
-																						},
-																					},
-																					Required: []string{"name"},
-																				},
-																				"type": {
-																					Type: "string",
-																				},
-																				"volumeMode": {
-																					Type: "string",
-																				},
-																				"accessMode": {
-																					Type: "string",
-																				},
-																			},
-																			Required: []string{"source"},
-																		},
-																	},
+												"operatingSystem": {
+													Type:        "string",
+													Description: "The libosinfo ID of the operating system found in the guest",
+												},
+												"productName": {
+													Type:        "string",
+													Description: "The product name of the operating system",
+												},
+												"distro": {
+													Type:        "string",
+													Description: "The distribution of the operating system",
+												},
+												"version": {
+													Type:        "string",
+													Description: "The major and minor version of the operating system",
+												},
+												"firmware": {
+													Type:        "string",
+													Description: "The firmware the guest boots with, bios or uefi",
+												},
+												"installedDrivers": {
+													Type:        "array",
+													Description: "The virtio drivers installed in the guest",
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
+												"failure": {
+													Type:        "string",
+													Description: "Why the inspection failed. The import goes on with the operating system reported by the source.",
+												},
+											},
+										},
+										"guestNetwork": {
+											Description: "The IP configuration reported by the guest tools of the source VM",
+											Type:        "object",
+											Properties: map[string]extv1.JSONSchemaProps{
+												"interfaces": {
+													Type:        "array",
+													Description: "The network interfaces of the guest",
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type:     "object",
+															Required: []string{"mac"},
+															Properties: map[string]extv1.JSONSchemaProps{
+																"mac": {
+																	Type:        "string",
+																	Description: "The MAC address of the interface",
 																},
-																"diskMappings": {
-																	Type: "array",
-																	Description: `DiskMappings defines the mapping of disks to storage
-		classes DiskMappings.Source.ID represents the disk ID on ovirt (as opposed to disk-attachment ID) DiskMappings.Source.Name represents
-		the disk alias on ovirt DiskMappings is respected only when provided in context of a single VM import within VirtualMachineImport.`,
+																"dhcp": {
+																	Type:        "boolean",
+																	Description: "Whether the interface gets its IPv4 address from a DHCP server",
+																},
+																"ipAddresses": {
+																	Type:        "array",
+																	Description: "The addresses of the interface in CIDR notation",
 																	Items: &extv1.JSONSchemaPropsOrArray{
 																		Schema: &extv1.JSONSchemaProps{
-																			Type:        "object",
-																			Description: `NetworkResourceMappingItem defines the mapping of a single disk resource from the provider to kubevirt`,
-																			Properties: map[string]extv1.JSONSchemaProps{
-																				"source": {
-																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"id": {
-																							Type: "string",
-																						},
-																						"name": {
-																							Type: "string",
-																						},
-																					},
-																				},
-																				"target": {
-																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"name": {
-																							Type: "string",
-																						},
-																						"namespace": {
-																							Type: "string",
-																						},
-																					},
-																					Required: []string{"name"},
-																				},
-																				"type": {
-																					Type: "string",
-																				},
-																				"volumeMode": {
-																					Type: "string",
-																				},
-																				"accessMode": {
-																					Type: "string",
-																				},
-																			},
-																			Required: []string{"source"},
+																			Type: "string",
 																		},
 																	},
 																},
 															},
 														},
-														"vm": {
-															Type:        "object",
-															Description: `VirtualMachineImportOvirtSourceVMSpec defines the definition of the VM info in oVirt`,
+													},
+												},
+												"routes": {
+													Type:        "array",
+													Description: "The routes of the guest going through a gateway",
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type:     "object",
+															Required: []string{"destination", "gateway"},
 															Properties: map[string]extv1.JSONSchemaProps{
-																"cluster": {
-																	Description: `VirtualMachineImportOvirtSourceVMClusterSpec defines the source cluster's identity of the VM in oVirt`,
-																	Type:        "object",
-																	Properties: map[string]extv1.JSONSchemaProps{
-																		"id": {
-																			Description: `id defines the id of the source cluster where VM resides`,
-																			Type:        "string",
-																		},
-																		"name": {
-																			Description: `name defines the name of the source cluster where VM resides`,
-																			Type:        "string",
-																		},
-																	},
+																"destination": {
+																	Type:        "string",
+																	Description: "The network the route leads to in CIDR notation",
 																},
-																"id": {
-																	Description: `id defines the id of the source VM`,
+																"gateway": {
 																	Type:        "string",
+																	Description: "The address of the next hop",
 																},
-																"name": {
-																	Description: `name defines the name of the source VM`,
+																"mac": {
 																	Type:        "string",
+																	Description: "The MAC address of the interface the route goes through",
 																},
 															},
 														},
 													},
-													Required: []string{"vm"},
 												},
-												"vmware": {
-													Type:        "object",
-													Description: `VirtualMachineImportVmwareSourceSpec defines the mapping resources and the VM identity for vmware source provider`,
-													Properties: map[string]extv1.JSONSchemaProps{
-														"mappings": {
-															Type:        "object",
-															Description: "VmwareMappings defines the mappings of vmware resources to kubevirt",
-															Properties: map[string]extv1.JSONSchemaProps{
-																"networkMappings": {
-																	Type: "array",
-																	Description: `NetworkMappings defines the mapping of guest network interfaces to kubevirt networks
-NetworkMappings.Source.Name represents the network field of the GuestNicInfo in vCenter
-NetworkMappings.Source.ID represents the macAddress field of the network adapter`,
-																	Items: &extv1.JSONSchemaPropsOrArray{
-																		Schema: &extv1.JSONSchemaProps{
-																			Type:        "object",
-																			Description: `NetworkResourceMappingItem defines the mapping of a single resource from the provider to kubevirt`,
-																			Properties: map[string]extv1.JSONSchemaProps{
-																				"source": {
-																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"id": {
-																							Type: "string",
-																						},
-																						"name": {
-																							Type: "string",
-																						},
-																					},
-																				},
-																				"target": {
-																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"name": {
-																							Type: "string",
-																						},
-																						"namespace": {
-																							Type: "string",
-																						},
-																					},
-																					Required: []string{"name"},
-																				},
-																				"type": {
-																					Type: "string",
-																				},
-																			},
-																			Required: []string{"source"},
-																		},
-																	},
-																},
-																"storageMappings": {
-																	Type:        "array",
-																	Description: `StorageMappings defines the mapping of storage domains to storage classes.`,
-																	Items: &extv1.JSONSchemaPropsOrArray{
-																		Schema: &extv1.JSONSchemaProps{
-																			Type:        "object",
-																			Description: `StorageResourceMappingItem defines the mapping of a single resource from the provider to kubevirt`,
-																			Properties: map[string]extv1.JSONSchemaProps{
-																				"source": {
-																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"id": {
-																							Type: "string",
-																						},
-																						"name": {
-																							Type: "string",
-																						},
-																					},
-																				},
-																				"target": {
-																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"name": {
-																							Type: "string",
-																						},
-																						"namespace": {
-																							Type: "string",
-																						},
-																					},
-																					Required: []string{"name"},
-																				},
-																				"type": {
-																					Type: "string",
-																				},
-																				"volumeMode": {
-																					Type: "string",
-																				},
-																				"accessMode": {
-																					Type: "string",
-																				},
-																			},
-																			Required: []string{"source"},
-																		},
-																	},
-																},
-																"diskMappings": {
-																	Type: "array",
-																	Description: `DiskMappings defines the mapping of VirtualDisks to storage classes.
-DiskMappings.Source.Name represents the disk name in vCenter
-DiskMappings.Source.ID represents the DiskObjectId or vDiskID of the VirtualDisk in vCenter`,
-																	Items: &extv1.JSONSchemaPropsOrArray{
-																		Schema: &extv1.JSONSchemaProps{
-																			Type:        "object",
-																			Description: `StorageResourceMappingItem defines the mapping of a single resource from the provider to kubevirt`,
-																			Properties: map[string]extv1.JSONSchemaProps{
-																				"source": {
-																					Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"id": {
-																							Type: "string",
-																						},
-																						"name": {
-																							Type: "string",
-																						},
-																					},
-																				},
-																				"target": {
-																					Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
-																					Type:        "object",
-																					Properties: map[string]extv1.JSONSchemaProps{
-																						"name": {
-																							Type: "string",
-																						},
-																						"namespace": {
-																							Type: "string",
-																						},
-																					},
-																					Required: []string{"name"},
-																				},
-																				"type": {
-																					Type: "string",
-																				},
-																				"volumeMode": {
-																					Type: "string",
-																				},
-																				"accessMode": {
-																					Type: "string",
-																				},
-																			},
-																			Required: []string{"source"},
-																		},
-																	},
-																},
-															},
+												"dnsServers": {
+													Type:        "array",
+													Description: "The addresses of the name servers of the guest",
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
 														},
-														"vm": {
-															Type:        "object",
-															Description: `VirtualMachineImportVmwareSourceVMSpec defines how to identify the VM in vCenter`,
-															Properties: map[string]extv1.JSONSchemaProps{
-																"id": {
-																	Type: "string",
-																},
-																"name": {
-																	Type: "string",
-																},
-															},
+													},
+												},
+												"dnsSearchDomains": {
+													Type:        "array",
+													Description: "The domains the guest appends to the names it resolves",
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
 														},
 													},
-													Required: []string{"vm"},
 												},
 											},
 										},
-										"startVm": {
-											Type:        "boolean",
-											Description: `If true imported virtual machine will be started`,
-										},
-										"targetVmName": {
-											Description: `Specifies the name of the imported virtual machine`,
-											Type:        "string",
-											MaxLength:   &maxTargetVMName,
-										},
-									},
-									Required: []string{"providerCredentialsSecret", "source"},
-								},
-								"status": {
-									Type:        "object",
-									Description: `VirtualMachineImportStatus defines the observed state of VirtualMachineImport`,
-									Properties: map[string]extv1.JSONSchemaProps{
-										"conditions": {
-											Description: "A list of current conditions of the VirtualMachineImport resource",
+										"unmappedNetworks": {
 											Type:        "array",
+											Description: "What happened to the NICs of the source VM connected to networks without a mapping",
 											Items: &extv1.JSONSchemaPropsOrArray{
 												Schema: &extv1.JSONSchemaProps{
-													Type: "object",
+													Type:     "object",
+													Required: []string{"nic", "network", "action"},
 													Properties: map[string]extv1.JSONSchemaProps{
-														"lastHeartbeatTime": {
-															Description: "The last time we got an update on a given condition",
-															Type:        "string",
-															Format:      "date-time",
-														},
-														"lastTransitionTime": {
-															Description: `The last time the condition transit from one status to another`,
+														"nic": {
 															Type:        "string",
-															Format:      "date-time",
+															Description: "The name of the NIC of the source VM",
 														},
-														"message": {
-															Description: `A human-readable message indicating details about last transition`,
+														"network": {
 															Type:        "string",
+															Description: "The source network of the NIC",
 														},
-														"reason": {
-															Description: `A brief CamelCase string that describes why the VM import process is in current condition status`,
+														"action": {
 															Type:        "string",
+															Description: "What happened to the NIC: Fail, Drop, Pod, Multus or Generate",
 														},
-														"status": {
-															Description: "Status of the condition, one of True, False, Unknown",
-															Type:        "string",
+														"target": {
+															Type:        "object",
+															Description: "The network attachment definition the NIC is connected to",
+															Properties: map[string]extv1.JSONSchemaProps{
+																"name": {
+																	Type: "string",
+																},
+																"namespace": {
+																	Type: "string",
+																},
+															},
+															Required: []string{"name"},
 														},
-														"type": {
-															Description: "Type of virtual machine import condition",
-															Type:        "string",
+														"vlan": {
+															Type:        "integer",
+															Format:      "int32",
+															Description: "The VLAN ID of the source network, 0 when untagged, recorded for the networks to generate",
 														},
 													},
-													Required: []string{"status", "type"},
 												},
 											},
 										},
-										"dataVolumes": {
-											Description: `DataVolumeItem defines the details of a data volume created by the VM import process`,
+										"generatedNetworkMappings": {
 											Type:        "array",
+											Description: "The mappings to the network attachment definitions generated for the networks without a mapping",
 											Items: &extv1.JSONSchemaPropsOrArray{
 												Schema: &extv1.JSONSchemaProps{
-													Type: "object",
+													Type:        "object",
+													Description: `NetworkResourceMappingItem defines the mapping of a single network resource from the provider to kubevirt`,
 													Properties: map[string]extv1.JSONSchemaProps{
-														"name": {
-															Description: `Name of the DataVolume that was created by virtual machine import`,
-															Type:        "string",
+														"source": {
+															Description: `Source defines how to identify a resource on the provider, either by ID or by name`,
+															Type:        "object",
+															Properties: map[string]extv1.JSONSchemaProps{
+																"id": {
+																	Type: "string",
+																},
+																"name": {
+																	Type: "string",
+																},
+															},
+														},
+														"target": {
+															Description: `ObjectIdentifier defines how a resource should be identified on kubevirt`,
+															Type:        "object",
+															Properties: map[string]extv1.JSONSchemaProps{
+																"name": {
+																	Type: "string",
+																},
+																"namespace": {
+																	Type: "string",
+																},
+															},
+															Required: []string{"name"},
+														},
+														"type": {
+															Type: "string",
 														},
 													},
-													Required: []string{"name"},
+													Required: []string{"source", "target"},
 												},
 											},
 										},
-										"targetVmName": {
-											Description: "The name of the virtual machine created by the import process",
-											Type:        "string",
-										},
-										"warmImport": {
-											Description: "Details about the status of a warm import.",
+										"bootVerification": {
 											Type:        "object",
+											Description: "The progress of the verification of the boot of the imported VM",
 											Properties: map[string]extv1.JSONSchemaProps{
-												"nextStageTime": {
+												"startTime": {
 													Type:        "string",
 													Format:      "date-time",
-													Description: "The time when the next warm import stage is scheduled.",
+													Description: "When the VMI was first seen running and the verification began",
 												},
-												"successes": {
-													Type:        "integer",
-													Description: "The total number of successful stages.",
+												"failure": {
+													Type:        "string",
+													Description: "Why the verification failed",
 												},
-												"failures": {
-													Type:        "integer",
-													Description: "The total number of failed stages.",
+											},
+										},
+										"template": {
+											Type:        "object",
+											Description: "The template the imported VM was created from and why it was chosen",
+											Required:    []string{"reason"},
+											Properties: map[string]extv1.JSONSchemaProps{
+												"name": {
+													Type:        "string",
+													Description: "The name of the template, empty when the VM was created without a template",
 												},
-												"consecutiveFailures": {
-													Type:        "integer",
-													Description: "The number of stages that have failed consecutively, reset after a success.",
+												"namespace": {
+													Type:        "string",
+													Description: "The namespace of the template",
 												},
-												"rootSnapshot": {
+												"reason": {
 													Type:        "string",
-													Description: "The ID of the initial snapshot that was created to start the warm import.",
+													Description: "Why the template was chosen",
+												},
+											},
+										},
+										"sourceMetadata": {
+											Type:        "object",
+											Description: "The metadata of the source VM the propagation rules take their values from, read once per import",
+											Properties: map[string]extv1.JSONSchemaProps{
+												"tags": {
+													Type:        "array",
+													Description: "The tags of the oVirt VM",
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
+												"customAttributes": {
+													Type:        "object",
+													Description: "The custom attributes of the vSphere VM, or the custom properties of the oVirt VM, by name",
+													AdditionalProperties: &extv1.JSONSchemaPropsOrBool{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
+												"folder": {
+													Type:        "string",
+													Description: "The folder path of the vSphere VM",
 												},
 											},
 										},
@@ -2845,6 +4168,46 @@ func CreateResourceMapping() *extv1.CustomResourceDefinition {
 											Type:        "object",
 											Description: "OvirtMappings defines the mappings of ovirt resources to kubevirt",
 											Properties: map[string]extv1.JSONSchemaProps{
+												"unmappedNetworks": {
+													Type:        "object",
+													Description: `Defines what happens to the NICs connected to networks without a mapping`,
+													Properties: map[string]extv1.JSONSchemaProps{
+														"action": {
+															Type:        "string",
+															Description: `Fail blocks the import, Drop skips the NICs, Pod connects them to the pod network, Multus to the target network and Generate to network attachment definitions generated from the VLANs of their source networks. Fail by default`,
+															Enum: []extv1.JSON{
+																{
+																	Raw: []byte(`"Fail"`),
+																},
+																{
+																	Raw: []byte(`"Drop"`),
+																},
+																{
+																	Raw: []byte(`"Pod"`),
+																},
+																{
+																	Raw: []byte(`"Multus"`),
+																},
+																{
+																	Raw: []byte(`"Generate"`),
+																},
+															},
+														},
+														"target": {
+															Type:        "object",
+															Description: `Network attachment definition the NICs are connected to with the Multus action`,
+															Properties: map[string]extv1.JSONSchemaProps{
+																"name": {
+																	Type: "string",
+																},
+																"namespace": {
+																	Type: "string",
+																},
+															},
+															Required: []string{"name"},
+														},
+													},
+												},
 												"networkMappings": {
 													Type: "array",
 													Description: `NetworkMappings defines the mapping of vnic profile
@@ -2884,6 +4247,60 @@ func CreateResourceMapping() *extv1.CustomResourceDefinition {
 																"type": {
 																	Type: "string",
 																},
+																"macPolicy": {
+																	Type:        "string",
+																	Description: `Overrides the MAC policy of the import for the interfaces connected to the mapped network`,
+																	Enum: []extv1.JSON{
+																		{
+																			Raw: []byte(`"Preserve"`),
+																		},
+																		{
+																			Raw: []byte(`"Regenerate"`),
+																		},
+																		{
+																			Raw: []byte(`"PreserveIfUnique"`),
+																		},
+																	},
+																},
+																"interfaceModel": {
+																	Type:        "string",
+																	Description: `Model of the interfaces connected to the mapped network, only used for VMware`,
+																	Enum: []extv1.JSON{
+																		{
+																			Raw: []byte(`"e1000"`),
+																		},
+																		{
+																			Raw: []byte(`"e1000e"`),
+																		},
+																		{
+																			Raw: []byte(`"ne2k_pci"`),
+																		},
+																		{
+																			Raw: []byte(`"pcnet"`),
+																		},
+																		{
+																			Raw: []byte(`"rtl8139"`),
+																		},
+																		{
+																			Raw: []byte(`"virtio"`),
+																		},
+																	},
+																},
+																"interfaceBinding": {
+																	Type:        "string",
+																	Description: `Defines how the interfaces are connected to the mapped network, only used for VMware`,
+																	Enum: []extv1.JSON{
+																		{
+																			Raw: []byte(`"bridge"`),
+																		},
+																		{
+																			Raw: []byte(`"masquerade"`),
+																		},
+																		{
+																			Raw: []byte(`"sriov"`),
+																		},
+																	},
+																},
 															},
 															Required: []string{"source", "target"},
 														},
@@ -2931,6 +4348,21 @@ func CreateResourceMapping() *extv1.CustomResourceDefinition {
 																"accessMode": {
 																	Type: "string",
 																},
+																"diskBus": {
+																	Type:        "string",
+																	Description: `Bus of the mapped disks, only used for VMware`,
+																	Enum: []extv1.JSON{
+																		{
+																			Raw: []byte(`"sata"`),
+																		},
+																		{
+																			Raw: []byte(`"scsi"`),
+																		},
+																		{
+																			Raw: []byte(`"virtio"`),
+																		},
+																	},
+																},
 															},
 															Required: []string{"source", "target"},
 														},
@@ -2980,6 +4412,21 @@ func CreateResourceMapping() *extv1.CustomResourceDefinition {
 																"accessMode": {
 																	Type: "string",
 																},
+																"diskBus": {
+																	Type:        "string",
+																	Description: `Bus of the mapped disks, only used for VMware`,
+																	Enum: []extv1.JSON{
+																		{
+																			Raw: []byte(`"sata"`),
+																		},
+																		{
+																			Raw: []byte(`"scsi"`),
+																		},
+																		{
+																			Raw: []byte(`"virtio"`),
+																		},
+																	},
+																},
 															},
 															Required: []string{"source", "target"},
 														},
@@ -2991,6 +4438,46 @@ func CreateResourceMapping() *extv1.CustomResourceDefinition {
 											Type:        "object",
 											Description: "VmwareMappings defines the mappings of vmware resources to kubevirt",
 											Properties: map[string]extv1.JSONSchemaProps{
+												"unmappedNetworks": {
+													Type:        "object",
+													Description: `Defines what happens to the NICs connected to networks without a mapping`,
+													Properties: map[string]extv1.JSONSchemaProps{
+														"action": {
+															Type:        "string",
+															Description: `Fail blocks the import, Drop skips the NICs, Pod connects them to the pod network, Multus to the target network and Generate to network attachment definitions generated from the VLANs of their source networks. Fail by default`,
+															Enum: []extv1.JSON{
+																{
+																	Raw: []byte(`"Fail"`),
+																},
+																{
+																	Raw: []byte(`"Drop"`),
+																},
+																{
+																	Raw: []byte(`"Pod"`),
+																},
+																{
+																	Raw: []byte(`"Multus"`),
+																},
+																{
+																	Raw: []byte(`"Generate"`),
+																},
+															},
+														},
+														"target": {
+															Type:        "object",
+															Description: `Network attachment definition the NICs are connected to with the Multus action`,
+															Properties: map[string]extv1.JSONSchemaProps{
+																"name": {
+																	Type: "string",
+																},
+																"namespace": {
+																	Type: "string",
+																},
+															},
+															Required: []string{"name"},
+														},
+													},
+												},
 												"networkMappings": {
 													Type: "array",
 													Description: `NetworkMappings defines the mapping of guest network interfaces to kubevirt networks
@@ -3029,6 +4516,60 @@ NetworkMappings.Source.ID represents the macAddress field of the network adapter
 																"type": {
 																	Type: "string",
 																},
+																"macPolicy": {
+																	Type:        "string",
+																	Description: `Overrides the MAC policy of the import for the interfaces connected to the mapped network`,
+																	Enum: []extv1.JSON{
+																		{
+																			Raw: []byte(`"Preserve"`),
+																		},
+																		{
+																			Raw: []byte(`"Regenerate"`),
+																		},
+																		{
+																			Raw: []byte(`"PreserveIfUnique"`),
+																		},
+																	},
+																},
+																"interfaceModel": {
+																	Type:        "string",
+																	Description: `Model of the interfaces connected to the mapped network, only used for VMware`,
+																	Enum: []extv1.JSON{
+																		{
+																			Raw: []byte(`"e1000"`),
+																		},
+																		{
+																			Raw: []byte(`"e1000e"`),
+																		},
+																		{
+																			Raw: []byte(`"ne2k_pci"`),
+																		},
+																		{
+																			Raw: []byte(`"pcnet"`),
+																		},
+																		{
+																			Raw: []byte(`"rtl8139"`),
+																		},
+																		{
+																			Raw: []byte(`"virtio"`),
+																		},
+																	},
+																},
+																"interfaceBinding": {
+																	Type:        "string",
+																	Description: `Defines how the interfaces are connected to the mapped network, only used for VMware`,
+																	Enum: []extv1.JSON{
+																		{
+																			Raw: []byte(`"bridge"`),
+																		},
+																		{
+																			Raw: []byte(`"masquerade"`),
+																		},
+																		{
+																			Raw: []byte(`"sriov"`),
+																		},
+																	},
+																},
 															},
 															Required: []string{"source", "target"},
 														},
@@ -3076,6 +4617,21 @@ NetworkMappings.Source.ID represents the macAddress field of the network adapter
 																"accessMode": {
 																	Type: "string",
 																},
+																"diskBus": {
+																	Type:        "string",
+																	Description: `Bus of the mapped disks, only used for VMware`,
+																	Enum: []extv1.JSON{
+																		{
+																			Raw: []byte(`"sata"`),
+																		},
+																		{
+																			Raw: []byte(`"scsi"`),
+																		},
+																		{
+																			Raw: []byte(`"virtio"`),
+																		},
+																	},
+																},
 															},
 															Required: []string{"source", "target"},
 														},
@@ -3125,11 +4681,80 @@ DiskMappings.Source.ID represents the DiskObjectId or vDiskID of the VirtualDisk
 																"accessMode": {
 																	Type: "string",
 																},
+																"diskBus": {
+																	Type:        "string",
+																	Description: `Bus of the mapped disks, only used for VMware`,
+																	Enum: []extv1.JSON{
+																		{
+																			Raw: []byte(`"sata"`),
+																		},
+																		{
+																			Raw: []byte(`"scsi"`),
+																		},
+																		{
+																			Raw: []byte(`"virtio"`),
+																		},
+																	},
+																},
 															},
 															Required: []string{"source", "target"},
 														},
 													},
 												},
+												"interfaceModel": {
+													Type:        "string",
+													Description: `Model of the interfaces of the target VM, virtio by default`,
+													Enum: []extv1.JSON{
+														{
+															Raw: []byte(`"e1000"`),
+														},
+														{
+															Raw: []byte(`"e1000e"`),
+														},
+														{
+															Raw: []byte(`"ne2k_pci"`),
+														},
+														{
+															Raw: []byte(`"pcnet"`),
+														},
+														{
+															Raw: []byte(`"rtl8139"`),
+														},
+														{
+															Raw: []byte(`"virtio"`),
+														},
+													},
+												},
+												"interfaceBinding": {
+													Type:        "string",
+													Description: `Defines how the interfaces of the target VM are connected to their networks: bridge for multus networks and masquerade for the pod network by default`,
+													Enum: []extv1.JSON{
+														{
+															Raw: []byte(`"bridge"`),
+														},
+														{
+															Raw: []byte(`"masquerade"`),
+														},
+														{
+															Raw: []byte(`"sriov"`),
+														},
+													},
+												},
+												"diskBus": {
+													Type:        "string",
+													Description: `Bus of the disks of the target VM, virtio by default`,
+													Enum: []extv1.JSON{
+														{
+															Raw: []byte(`"sata"`),
+														},
+														{
+															Raw: []byte(`"scsi"`),
+														},
+														{
+															Raw: []byte(`"virtio"`),
+														},
+													},
+												},
 											},
 										},
 									},
@@ -4,7 +4,9 @@ import (
 	"fmt"
 
 	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/guestconversion"
 	vmioperator "github.com/kubevirt/vm-import-operator/pkg/operator/resources/operator"
+	corev1 "k8s.io/api/core/v1"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 
 	"github.com/RHsyseng/operator-utils/pkg/validation"
@@ -78,6 +80,33 @@ var _ = Describe("Operator resource test", func() {
 		Expect(err).ToNot(HaveOccurred())
 	})
 
+	It("Test controller deployment exports traces when tracing is configured", func() {
+		tracing := &v2vv1.TracingConfig{Endpoint: "otel-collector:4317", Insecure: true}
+
+		deployment := vmioperator.CreateControllerDeployment(vmioperator.ControllerName, "kubevirt-hyperconverged", "vm-import-controller", "virt-v2v", "IfNotPresent", int32(1), nil, tracing, nil)
+
+		env := deployment.Spec.Template.Spec.Containers[0].Env
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "otel-collector:4317"}))
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_INSECURE", Value: "true"}))
+	})
+
+	It("Test controller deployment doesn't export traces by default", func() {
+		deployment := vmioperator.CreateControllerDeployment(vmioperator.ControllerName, "kubevirt-hyperconverged", "vm-import-controller", "virt-v2v", "IfNotPresent", int32(1), nil, nil, nil)
+
+		for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+			Expect(env.Name).ToNot(Equal("OTEL_EXPORTER_OTLP_ENDPOINT"))
+		}
+	})
+
+	It("Test controller deployment passes the guest conversion pod configuration", func() {
+		podConfig := &v2vv1.GuestConversionPodConfig{PriorityClassName: "conversion"}
+
+		deployment := vmioperator.CreateControllerDeployment(vmioperator.ControllerName, "kubevirt-hyperconverged", "vm-import-controller", "virt-v2v", "IfNotPresent", int32(1), nil, nil, podConfig)
+
+		env := deployment.Spec.Template.Spec.Containers[0].Env
+		Expect(env).To(ContainElement(corev1.EnvVar{Name: guestconversion.PodConfigEnvVar, Value: `{"priorityClassName":"conversion"}`}))
+	})
+
 	It("Test invalid ResourceMapping custom resource", func() {
 		crFileName := []byte(`{
 		  "apiVersion":"v2v.kubevirt.io/v1beta1",
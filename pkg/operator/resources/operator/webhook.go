@@ -0,0 +1,124 @@
+package operator
+
+import (
+	"github.com/kubevirt/vm-import-operator/pkg/requester"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// WebhookName is the name of the mutating webhook configuration and of the service of the controller webhook
+	WebhookName = requester.WebhookName
+	// WebhookCertSecretName is the name of the secret holding the serving certificate of the controller webhook
+	WebhookCertSecretName = "vm-import-controller-webhook-cert"
+
+	webhookCertVolumeName = "webhook-cert"
+	// servingCertAnnotation makes the service CA operator issue the serving certificate of a service into a secret
+	servingCertAnnotation = "service.beta.openshift.io/serving-cert-secret-name"
+	// injectCABundleAnnotation makes the service CA operator inject its CA bundle into a webhook configuration
+	injectCABundleAnnotation = "service.beta.openshift.io/inject-cabundle"
+)
+
+// CreateWebhookService creates the service of the controller webhook, whose serving certificate is issued by the
+// service CA operator on OpenShift and signed by the controller elsewhere
+func CreateWebhookService(namespace string) *corev1.Service {
+	service := resourceBuilder.CreateService(WebhookName, "v2v.kubevirt.io", ControllerName, nil)
+	service.Annotations = map[string]string{
+		servingCertAnnotation: WebhookCertSecretName,
+	}
+	service.Spec.Ports = []corev1.ServicePort{
+		{Port: 443, Name: "webhook", Protocol: corev1.ProtocolTCP, TargetPort: intstr.FromInt(requester.WebhookPort)},
+	}
+	service.SetNamespace(namespace)
+	return service
+}
+
+// CreateMutatingWebhookConfiguration creates the webhook recording the user who creates a labeled VM import, whom the
+// controller checks the access of to the target namespace of the import
+func CreateMutatingWebhookConfiguration(namespace string) *admissionregistrationv1.MutatingWebhookConfiguration {
+	path := requester.WebhookPath
+	port := int32(443)
+	failurePolicy := admissionregistrationv1.Fail
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	scope := admissionregistrationv1.NamespacedScope
+	return &admissionregistrationv1.MutatingWebhookConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "admissionregistration.k8s.io/v1",
+			Kind:       "MutatingWebhookConfiguration",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   WebhookName,
+			Labels: resourceBuilder.WithCommonLabels(nil),
+			Annotations: map[string]string{
+				injectCABundleAnnotation: "true",
+			},
+		},
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{
+				Name: "requester.virtualmachineimports.v2v.kubevirt.io",
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service: &admissionregistrationv1.ServiceReference{
+						Namespace: namespace,
+						Name:      WebhookName,
+						Path:      &path,
+						Port:      &port,
+					},
+				},
+				Rules: []admissionregistrationv1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1.OperationType{
+							admissionregistrationv1.Create,
+							admissionregistrationv1.Update,
+						},
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   []string{"v2v.kubevirt.io"},
+							APIVersions: []string{"v1beta1"},
+							Resources:   []string{"virtualmachineimports"},
+							Scope:       &scope,
+						},
+					},
+				},
+				// Only the imports asking for their requester to be recorded depend on the webhook to be admitted
+				ObjectSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: requester.Label, Operator: metav1.LabelSelectorOpExists},
+					},
+				},
+				// The requester must never be missing or forged, so the labeled imports can't be admitted without the webhook
+				FailurePolicy:           &failurePolicy,
+				SideEffects:             &sideEffects,
+				AdmissionReviewVersions: []string{"v1beta1"},
+			},
+		},
+	}
+}
+
+// addWebhookCert mounts the serving certificate of the controller webhook into the controller pod. The secret only
+// exists on OpenShift, so it's optional.
+func addWebhookCert(podSpec *corev1.PodSpec) {
+	optional := true
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: webhookCertVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: WebhookCertSecretName,
+				Optional:   &optional,
+			},
+		},
+	})
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      webhookCertVolumeName,
+			MountPath: requester.CertDir,
+			ReadOnly:  true,
+		})
+		container.Ports = append(container.Ports, corev1.ContainerPort{
+			Name:          "webhook",
+			ContainerPort: requester.WebhookPort,
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
+}
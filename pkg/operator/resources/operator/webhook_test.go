@@ -0,0 +1,61 @@
+package operator_test
+
+import (
+	vmioperator "github.com/kubevirt/vm-import-operator/pkg/operator/resources/operator"
+	"github.com/kubevirt/vm-import-operator/pkg/requester"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhook resources", func() {
+	It("should create the webhook service with a serving certificate", func() {
+		service := vmioperator.CreateWebhookService("kubevirt-hyperconverged")
+
+		Expect(service.Namespace).To(Equal("kubevirt-hyperconverged"))
+		Expect(service.Annotations).To(HaveKeyWithValue("service.beta.openshift.io/serving-cert-secret-name", vmioperator.WebhookCertSecretName))
+		Expect(service.Spec.Selector).To(HaveKeyWithValue("v2v.kubevirt.io", vmioperator.ControllerName))
+		Expect(service.Spec.Ports).To(HaveLen(1))
+		Expect(service.Spec.Ports[0].TargetPort.IntValue()).To(Equal(requester.WebhookPort))
+	})
+
+	It("should record the requester of the labeled imports on create and update", func() {
+		configuration := vmioperator.CreateMutatingWebhookConfiguration("kubevirt-hyperconverged")
+
+		Expect(configuration.Annotations).To(HaveKeyWithValue("service.beta.openshift.io/inject-cabundle", "true"))
+		Expect(configuration.Webhooks).To(HaveLen(1))
+		webhook := configuration.Webhooks[0]
+		Expect(*webhook.FailurePolicy).To(Equal(admissionregistrationv1.Fail))
+		Expect(webhook.ObjectSelector.MatchExpressions).To(ConsistOf(metav1.LabelSelectorRequirement{
+			Key:      requester.Label,
+			Operator: metav1.LabelSelectorOpExists,
+		}))
+		Expect(webhook.ClientConfig.Service.Namespace).To(Equal("kubevirt-hyperconverged"))
+		Expect(webhook.ClientConfig.Service.Name).To(Equal(vmioperator.WebhookName))
+		Expect(*webhook.ClientConfig.Service.Path).To(Equal(requester.WebhookPath))
+		Expect(webhook.Rules).To(HaveLen(1))
+		Expect(webhook.Rules[0].Operations).To(ConsistOf(admissionregistrationv1.Create, admissionregistrationv1.Update))
+		Expect(webhook.Rules[0].Resources).To(ConsistOf("virtualmachineimports"))
+	})
+
+	It("should mount the optional serving certificate into the controller", func() {
+		optional := true
+		deployment := vmioperator.CreateControllerDeployment(vmioperator.ControllerName, "kubevirt-hyperconverged", "image", "virt-v2v", "IfNotPresent", 1, nil, nil, nil)
+
+		podSpec := deployment.Spec.Template.Spec
+		Expect(podSpec.Volumes).To(ContainElement(corev1.Volume{
+			Name: "webhook-cert",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: vmioperator.WebhookCertSecretName, Optional: &optional},
+			},
+		}))
+		Expect(podSpec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      "webhook-cert",
+			MountPath: requester.CertDir,
+			ReadOnly:  true,
+		}))
+	})
+})
@@ -0,0 +1,14 @@
+package os
+
+import (
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+)
+
+// InspectedOperatingSystem returns the operating system found by the inspection of the guest, if any. The inspection
+// reports the libosinfo ID of the operating system, which the common templates are labeled with.
+func InspectedOperatingSystem(inspection *v2vv1.GuestInspectionStatus) (string, bool) {
+	if inspection == nil || inspection.OperatingSystem == "" {
+		return "", false
+	}
+	return inspection.OperatingSystem, true
+}
@@ -3,6 +3,9 @@ package ownerreferences
 import (
 	"context"
 
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/utils"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	kubevirtv1 "kubevirt.io/client-go/api/v1"
@@ -22,7 +25,8 @@ func NewOwnerReferenceManager(client client.Client) OwnerReferenceManager {
 	}
 }
 
-// PurgeOwnerReferences cleans the owner references of the Virtual Machine.
+// PurgeOwnerReferences cleans the owner references of the Virtual Machine, and the labels tracking it when it was imported
+// from another namespace.
 func (m *OwnerReferenceManager) PurgeOwnerReferences(vmName types.NamespacedName) []error {
 	var errs []error
 
@@ -72,10 +76,10 @@ func (m *OwnerReferenceManager) removeDataVolumesOwnerReferences(vm *kubevirtv1.
 }
 
 func (m *OwnerReferenceManager) removeVMOwnerReference(vm *kubevirtv1.VirtualMachine) error {
+	vmCopy := vm.DeepCopy()
 	refs := vm.GetOwnerReferences()
 	newRefs := removeControllerReference(refs)
-	if len(newRefs) < len(refs) {
-		vmCopy := vm.DeepCopy()
+	if utils.RemoveImportTracking(vmCopy) || len(newRefs) < len(refs) {
 		vmCopy.SetOwnerReferences(newRefs)
 		patch := client.MergeFrom(vm)
 		return m.client.Patch(context.TODO(), vmCopy, patch)
@@ -90,10 +94,10 @@ func (m *OwnerReferenceManager) removeDataVolumeOwnerReference(namespace string,
 		return err
 	}
 
+	dvCopy := dv.DeepCopy()
 	refs := dv.GetOwnerReferences()
 	newRefs := removeControllerReference(refs)
-	if len(newRefs) < len(refs) {
-		dvCopy := dv.DeepCopy()
+	if utils.RemoveImportTracking(dvCopy) || len(newRefs) < len(refs) {
 		dvCopy.SetOwnerReferences(newRefs)
 		patch := client.MergeFrom(dv)
 		return m.client.Patch(context.TODO(), dvCopy, patch)
@@ -152,3 +156,13 @@ func NewVMImportControllerReference(typeMeta metav1.TypeMeta, objectMeta metav1.
 		BlockOwnerDeletion: &blockOwnerDeletion,
 	}
 }
+
+// SetVMImportReference sets the reference to the VM import made by newReference as the owner of the object. An object
+// created in another namespace than the VM import can't be owned by it, so it's labeled with the VM import instead.
+func SetVMImportReference(obj metav1.Object, typeMeta metav1.TypeMeta, instance *v2vv1.VirtualMachineImport, newReference func(metav1.TypeMeta, metav1.ObjectMeta) metav1.OwnerReference) {
+	if utils.IsCrossNamespace(instance) {
+		utils.SetImportTracking(obj, instance)
+		return
+	}
+	obj.SetOwnerReferences([]metav1.OwnerReference{newReference(typeMeta, instance.ObjectMeta)})
+}
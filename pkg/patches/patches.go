@@ -0,0 +1,145 @@
+package patches
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// Patch is a patch read from the import, in JSON
+type Patch struct {
+	Type v2vv1.PatchType
+	Data []byte
+}
+
+// InvalidError is returned for a patch that can't be read or applied, whatever the number of attempts, e.g. one
+// referencing a config map or key that doesn't exist
+type InvalidError struct {
+	Err error
+}
+
+func (e *InvalidError) Error() string {
+	return e.Err.Error()
+}
+
+// IsInvalid returns whether the error is caused by an invalid patch, rather than by a failure to read it
+func IsInvalid(err error) bool {
+	_, ok := err.(*InvalidError)
+	return ok
+}
+
+// Validate checks that each patch is of a known type and names exactly one source
+func Validate(field string, patches []v2vv1.ResourcePatch) error {
+	for i, patch := range patches {
+		switch patch.Type {
+		case "", v2vv1.PatchTypeStrategicMerge, v2vv1.PatchTypeJSON:
+		default:
+			return fmt.Errorf("`%s[%d]` has the unknown type %s", field, i, patch.Type)
+		}
+		if (patch.Patch == nil) == (patch.ConfigMap == nil) {
+			return fmt.Errorf("exactly one of `%s[%d].patch` and `%s[%d].configMap` must be set", field, i, field, i)
+		}
+	}
+	return nil
+}
+
+// Load reads the patches, from the config maps in the given namespace for the ones referencing a config map. A missing
+// config map or key and an unparsable patch are returned as an InvalidError, and the other errors as is.
+func Load(client client.Client, namespace string, patches []v2vv1.ResourcePatch) ([]Patch, error) {
+	var result []Patch
+	for _, patch := range patches {
+		var raw string
+		if patch.ConfigMap != nil {
+			configMap := corev1.ConfigMap{}
+			err := client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: patch.ConfigMap.Name}, &configMap)
+			if k8serrors.IsNotFound(err) {
+				return nil, &InvalidError{Err: fmt.Errorf("config map %s/%s doesn't exist", namespace, patch.ConfigMap.Name)}
+			}
+			if err != nil {
+				return nil, err
+			}
+			var ok bool
+			if raw, ok = configMap.Data[patch.ConfigMap.Key]; !ok {
+				return nil, &InvalidError{Err: fmt.Errorf("config map %s/%s has no %s key", namespace, patch.ConfigMap.Name, patch.ConfigMap.Key)}
+			}
+		} else if patch.Patch != nil {
+			raw = *patch.Patch
+		}
+		data, err := yaml.YAMLToJSON([]byte(raw))
+		if err != nil {
+			return nil, &InvalidError{Err: fmt.Errorf("invalid patch: %v", err)}
+		}
+		patchType := patch.Type
+		if patchType == "" {
+			patchType = v2vv1.PatchTypeStrategicMerge
+		}
+		result = append(result, Patch{Type: patchType, Data: data})
+	}
+	return result, nil
+}
+
+// ApplyToVM applies the patches in order to the VM, keeping its name and namespace
+func ApplyToVM(vm *kubevirtv1.VirtualMachine, patches []Patch) error {
+	if len(patches) == 0 {
+		return nil
+	}
+	patched := kubevirtv1.VirtualMachine{}
+	if err := apply(vm, &patched, patches); err != nil {
+		return &InvalidError{Err: fmt.Errorf("cannot patch VM %s: %v", vm.Name, err)}
+	}
+	patched.Name, patched.Namespace = vm.Name, vm.Namespace
+	*vm = patched
+	return nil
+}
+
+// ApplyToDataVolume applies the patches in order to the data volume, keeping its name and namespace
+func ApplyToDataVolume(dv *cdiv1.DataVolume, patches []Patch) error {
+	if len(patches) == 0 {
+		return nil
+	}
+	patched := cdiv1.DataVolume{}
+	if err := apply(dv, &patched, patches); err != nil {
+		return &InvalidError{Err: fmt.Errorf("cannot patch data volume %s: %v", dv.Name, err)}
+	}
+	patched.Name, patched.Namespace = dv.Name, dv.Namespace
+	*dv = patched
+	return nil
+}
+
+// apply patches the original object into the patched one, which also serves as the schema of the strategic merges
+func apply(original interface{}, patched interface{}, patches []Patch) error {
+	data, err := json.Marshal(original)
+	if err != nil {
+		return err
+	}
+	for _, patch := range patches {
+		switch patch.Type {
+		case v2vv1.PatchTypeJSON:
+			operations, err := jsonpatch.DecodePatch(patch.Data)
+			if err != nil {
+				return err
+			}
+			data, err = operations.Apply(data)
+			if err != nil {
+				return err
+			}
+		default:
+			data, err = strategicpatch.StrategicMergePatch(data, patch.Data, patched)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return json.Unmarshal(data, patched)
+}
@@ -0,0 +1,143 @@
+package patches_test
+
+import (
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/patches"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Patches", func() {
+	inline := func(patchType v2vv1.PatchType, patch string) v2vv1.ResourcePatch {
+		return v2vv1.ResourcePatch{Type: patchType, Patch: &patch}
+	}
+
+	table.DescribeTable("should validate", func(patch v2vv1.ResourcePatch, valid bool) {
+		err := patches.Validate("vmPatches", []v2vv1.ResourcePatch{patch})
+
+		if valid {
+			Expect(err).ToNot(HaveOccurred())
+		} else {
+			Expect(err).To(HaveOccurred())
+		}
+	},
+		table.Entry("an inline strategic merge patch", inline("", "{}"), true),
+		table.Entry("an inline JSON patch", inline(v2vv1.PatchTypeJSON, "[]"), true),
+		table.Entry("a config map patch", v2vv1.ResourcePatch{ConfigMap: &v2vv1.ConfigMapPatchSource{Name: "patches", Key: "vm"}}, true),
+		table.Entry("an unknown type", inline("Merge", "{}"), false),
+		table.Entry("no source", v2vv1.ResourcePatch{}, false),
+		table.Entry("both sources", v2vv1.ResourcePatch{Patch: &[]string{"{}"}[0], ConfigMap: &v2vv1.ConfigMapPatchSource{Name: "patches", Key: "vm"}}, false),
+	)
+
+	Describe("loading", func() {
+		load := func(resourcePatches ...v2vv1.ResourcePatch) ([]patches.Patch, error) {
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			client := fake.NewFakeClientWithScheme(scheme, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "patches", Namespace: "prod"},
+				Data:       map[string]string{"vm": "spec:\n  running: false\n"},
+			})
+			return patches.Load(client, "prod", resourcePatches)
+		}
+
+		It("should read the patches in JSON", func() {
+			loaded, err := load(
+				v2vv1.ResourcePatch{ConfigMap: &v2vv1.ConfigMapPatchSource{Name: "patches", Key: "vm"}},
+				inline(v2vv1.PatchTypeJSON, `[{"op": "remove", "path": "/spec/running"}]`),
+			)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(loaded).To(HaveLen(2))
+			Expect(loaded[0].Type).To(Equal(v2vv1.PatchTypeStrategicMerge))
+			Expect(loaded[0].Data).To(MatchJSON(`{"spec": {"running": false}}`))
+			Expect(loaded[1].Type).To(Equal(v2vv1.PatchTypeJSON))
+			Expect(loaded[1].Data).To(MatchJSON(`[{"op": "remove", "path": "/spec/running"}]`))
+		})
+
+		It("should fail on a missing config map key", func() {
+			_, err := load(v2vv1.ResourcePatch{ConfigMap: &v2vv1.ConfigMapPatchSource{Name: "patches", Key: "dv"}})
+
+			Expect(err).To(HaveOccurred())
+			Expect(patches.IsInvalid(err)).To(BeTrue())
+		})
+
+		It("should fail on a missing config map", func() {
+			_, err := load(v2vv1.ResourcePatch{ConfigMap: &v2vv1.ConfigMapPatchSource{Name: "missing", Key: "vm"}})
+
+			Expect(err).To(HaveOccurred())
+			Expect(patches.IsInvalid(err)).To(BeTrue())
+		})
+	})
+
+	Describe("applying", func() {
+		var vm *kubevirtv1.VirtualMachine
+
+		BeforeEach(func() {
+			vm = &kubevirtv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "vm", Labels: map[string]string{"app": "db"}},
+				Spec: kubevirtv1.VirtualMachineSpec{
+					Template: &kubevirtv1.VirtualMachineInstanceTemplateSpec{
+						Spec: kubevirtv1.VirtualMachineInstanceSpec{
+							Domain: kubevirtv1.DomainSpec{
+								Devices: kubevirtv1.Devices{
+									Disks: []kubevirtv1.Disk{{Name: "disk1"}, {Name: "disk2"}},
+								},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("should merge the strategic merge patches", func() {
+			err := patches.ApplyToVM(vm, []patches.Patch{
+				{Type: v2vv1.PatchTypeStrategicMerge, Data: []byte(`{"metadata": {"labels": {"site": "east"}}}`)},
+				{Type: v2vv1.PatchTypeStrategicMerge, Data: []byte(`{"spec": {"template": {"spec": {"nodeSelector": {"site": "east"}, "domain": {"cpu": {"dedicatedCpuPlacement": true}}}}}}`)},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vm.Labels).To(Equal(map[string]string{"app": "db", "site": "east"}))
+			Expect(vm.Spec.Template.Spec.NodeSelector).To(HaveKeyWithValue("site", "east"))
+			Expect(vm.Spec.Template.Spec.Domain.CPU.DedicatedCPUPlacement).To(BeTrue())
+			Expect(vm.Spec.Template.Spec.Domain.Devices.Disks).To(HaveLen(2))
+		})
+
+		It("should apply the JSON patches", func() {
+			err := patches.ApplyToVM(vm, []patches.Patch{
+				{Type: v2vv1.PatchTypeJSON, Data: []byte(`[{"op": "add", "path": "/spec/template/spec/priorityClassName", "value": "critical"}, {"op": "remove", "path": "/metadata/labels/app"}]`)},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vm.Spec.Template.Spec.PriorityClassName).To(Equal("critical"))
+			Expect(vm.Labels).To(BeEmpty())
+		})
+
+		It("should leave the VM as is on a failing patch", func() {
+			err := patches.ApplyToVM(vm, []patches.Patch{
+				{Type: v2vv1.PatchTypeJSON, Data: []byte(`[{"op": "remove", "path": "/metadata/annotations/missing"}]`)},
+			})
+
+			Expect(err).To(HaveOccurred())
+			Expect(vm.Labels).To(HaveKeyWithValue("app", "db"))
+		})
+
+		It("should patch a data volume without renaming it", func() {
+			dv := &cdiv1.DataVolume{ObjectMeta: metav1.ObjectMeta{Name: "dv"}}
+
+			err := patches.ApplyToDataVolume(dv, []patches.Patch{
+				{Type: v2vv1.PatchTypeStrategicMerge, Data: []byte(`{"metadata": {"name": "renamed", "annotations": {"cdi.kubevirt.io/storage.bind.immediate.requested": "true"}}}`)},
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(dv.Name).To(Equal("dv"))
+			Expect(dv.Annotations).To(HaveKeyWithValue("cdi.kubevirt.io/storage.bind.immediate.requested", "true"))
+		})
+	})
+})
@@ -3,23 +3,31 @@ package pods
 import (
 	"context"
 	"fmt"
+	"io"
 
 	corev1 "k8s.io/api/core/v1"
 
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	"github.com/kubevirt/vm-import-operator/pkg/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
 	prefix       = "vmimport.v2v.kubevirt.io"
 	vmiNameLabel = prefix + "/vmi-name"
+	// roleLabel tells apart the Pods of an import serving different purposes
+	roleLabel      = prefix + "/role"
+	inspectionRole = "guest-inspection"
 )
 
 // Manager provides operations on Pods
 type Manager struct {
 	client client.Client
+	role   string
 }
 
 // NewManager creates new Pod manager
@@ -27,14 +35,25 @@ func NewManager(client client.Client) Manager {
 	return Manager{client: client}
 }
 
-// FindFor retrieves a Pod matching given labels. If none can be found, both error and pointer will be nil. When there is more than 1 matching Pod, error will be returned.
-func (m *Manager) FindFor(vmiCrName types.NamespacedName) (*corev1.Pod, error) {
+// NewInspectionManager creates new manager of the Pods inspecting the guests. Its Pods are invisible to the managers
+// created with NewManager, and the other way around.
+func NewInspectionManager(client client.Client) Manager {
+	return Manager{client: client, role: inspectionRole}
+}
+
+// FindFor retrieves the Pod of the VM import in its target namespace. If none can be found, both error and pointer will be nil. When there is more than 1 matching Pod, error will be returned.
+func (m *Manager) FindFor(cr *v2vv1.VirtualMachineImport) (*corev1.Pod, error) {
 	podList := corev1.PodList{}
-	labels := client.MatchingLabels{
-		vmiNameLabel: utils.EnsureLabelValueLength(vmiCrName.Name),
+	selector := utils.ImportSelector(cr, map[string]string{vmiNameLabel: utils.EnsureLabelValueLength(cr.Name)})
+	var role *labels.Requirement
+	if m.role == "" {
+		role, _ = labels.NewRequirement(roleLabel, selection.DoesNotExist, nil)
+	} else {
+		role, _ = labels.NewRequirement(roleLabel, selection.Equals, []string{m.role})
 	}
+	selector = selector.Add(*role)
 
-	err := m.client.List(context.TODO(), &podList, labels, client.InNamespace(vmiCrName.Namespace))
+	err := m.client.List(context.TODO(), &podList, client.MatchingLabelsSelector{Selector: selector}, client.InNamespace(utils.TargetNamespace(cr)))
 	if err != nil {
 		return nil, err
 	}
@@ -44,13 +63,13 @@ func (m *Manager) FindFor(vmiCrName types.NamespacedName) (*corev1.Pod, error) {
 	case 0:
 		return nil, nil
 	default:
-		return nil, fmt.Errorf("too many pods matching given labels: %v", labels)
+		return nil, fmt.Errorf("too many pods matching given labels: %v", selector)
 	}
 }
 
-// CreateFor creates given Pod, overriding given Name with a generated one. The Pod will be associated with vmiCrName.
-func (m *Manager) CreateFor(pod *corev1.Pod, vmiCrName types.NamespacedName) error {
-	pod.Namespace = vmiCrName.Namespace
+// CreateFor creates given Pod in the target namespace of the VM import, overriding given Name with a generated one. The Pod will be associated with the VM import.
+func (m *Manager) CreateFor(pod *corev1.Pod, cr *v2vv1.VirtualMachineImport) error {
+	pod.Namespace = utils.TargetNamespace(cr)
 	// Force generation
 	pod.GenerateName = prefix
 	pod.Name = ""
@@ -58,14 +77,20 @@ func (m *Manager) CreateFor(pod *corev1.Pod, vmiCrName types.NamespacedName) err
 	if pod.Labels == nil {
 		pod.Labels = make(map[string]string)
 	}
-	pod.Labels[vmiNameLabel] = utils.EnsureLabelValueLength(vmiCrName.Name)
+	pod.Labels[vmiNameLabel] = utils.EnsureLabelValueLength(cr.Name)
+	if utils.IsCrossNamespace(cr) {
+		utils.AppendMap(pod.Labels, utils.ImportTrackingLabels(cr))
+	}
+	if m.role != "" {
+		pod.Labels[roleLabel] = m.role
+	}
 
 	return m.client.Create(context.TODO(), pod)
 }
 
-// DeleteFor removes the Pod created for vmiCrName
-func (m *Manager) DeleteFor(vmiCrName types.NamespacedName) error {
-	pod, err := m.FindFor(vmiCrName)
+// DeleteFor removes the Pod created for the VM import
+func (m *Manager) DeleteFor(cr *v2vv1.VirtualMachineImport) error {
+	pod, err := m.FindFor(cr)
 	if err != nil {
 		return err
 	}
@@ -76,3 +101,18 @@ func (m *Manager) DeleteFor(vmiCrName types.NamespacedName) error {
 	}
 	return nil
 }
+
+// LogReader reads the logs of Pods
+type LogReader struct {
+	clientset kubernetes.Interface
+}
+
+// NewLogReader creates new Pod log reader
+func NewLogReader(clientset kubernetes.Interface) LogReader {
+	return LogReader{clientset: clientset}
+}
+
+// Stream streams the log of the container of the Pod. The caller must close the returned stream.
+func (r *LogReader) Stream(pod *corev1.Pod, container string) (io.ReadCloser, error) {
+	return r.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{Container: container}).Stream(context.TODO())
+}
@@ -3,15 +3,17 @@ package pods
 import (
 	"context"
 
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	corev1 "k8s.io/api/core/v1"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var (
@@ -21,14 +23,14 @@ var (
 var _ = Describe("Pods Manager", func() {
 
 	manager := NewManager(mockClient{})
-	vmiCrName := types.NamespacedName{Name: "test", Namespace: "test"}
+	instance := &v2vv1.VirtualMachineImport{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}
 
 	Describe("FindFor", func() {
 		It("should return nil, nil when there are no pods found", func() {
 			list = func(context.Context, runtime.Object) error {
 				return nil
 			}
-			pod, err := manager.FindFor(vmiCrName)
+			pod, err := manager.FindFor(instance)
 			Expect(pod).To(BeNil())
 			Expect(err).To(BeNil())
 		})
@@ -40,10 +42,10 @@ var _ = Describe("Pods Manager", func() {
 				}
 				return nil
 			}
-			pod, err := manager.FindFor(vmiCrName)
+			pod, err := manager.FindFor(instance)
 			Expect(pod).To(BeNil())
 			Expect(err).ToNot(BeNil())
-			Expect(err.Error()).To(Equal("too many pods matching given labels: map[vmimport.v2v.kubevirt.io/vmi-name:test]"))
+			Expect(err.Error()).To(Equal("too many pods matching given labels: !vmimport.v2v.kubevirt.io/import-namespace,!vmimport.v2v.kubevirt.io/role,vmimport.v2v.kubevirt.io/vmi-name=test"))
 		})
 
 		It("should return the pod if one is found", func() {
@@ -58,7 +60,7 @@ var _ = Describe("Pods Manager", func() {
 				}
 				return nil
 			}
-			pod, err := manager.FindFor(vmiCrName)
+			pod, err := manager.FindFor(instance)
 			Expect(pod).ToNot(BeNil())
 			Expect(err).To(BeNil())
 			Expect(pod.Name).To(Equal(testPod.Name))
@@ -73,11 +75,104 @@ var _ = Describe("Pods Manager", func() {
 				},
 			}
 
-			err := manager.CreateFor(testPod, vmiCrName)
+			err := manager.CreateFor(testPod, instance)
 			Expect(err).To(BeNil())
 			Expect(testPod.GenerateName).To(Equal(prefix))
 			Expect(testPod.Name).To(Equal(""))
-			Expect(testPod.Labels[vmiNameLabel]).To(Equal(vmiCrName.Name))
+			Expect(testPod.Labels[vmiNameLabel]).To(Equal(instance.Name))
+		})
+	})
+
+	Describe("Inspection Manager", func() {
+		inspectionManager := NewInspectionManager(mockClient{})
+		inspectionPodLabels := labels.Set{vmiNameLabel: instance.Name, roleLabel: inspectionRole}
+		podLabels := labels.Set{vmiNameLabel: instance.Name}
+
+		BeforeEach(func() {
+			list = func(context.Context, runtime.Object) error {
+				return nil
+			}
+		})
+
+		It("should set the role label", func() {
+			testPod := &corev1.Pod{}
+
+			err := inspectionManager.CreateFor(testPod, instance)
+
+			Expect(err).To(BeNil())
+			Expect(testPod.Labels).To(Equal(map[string]string(inspectionPodLabels)))
+		})
+
+		It("should find only the inspection pods", func() {
+			_, err := inspectionManager.FindFor(instance)
+
+			Expect(err).To(BeNil())
+			selector := listSelector()
+			Expect(selector.Matches(inspectionPodLabels)).To(BeTrue())
+			Expect(selector.Matches(podLabels)).To(BeFalse())
+		})
+
+		It("should be invisible to the default manager", func() {
+			_, err := manager.FindFor(instance)
+
+			Expect(err).To(BeNil())
+			selector := listSelector()
+			Expect(selector.Matches(inspectionPodLabels)).To(BeFalse())
+			Expect(selector.Matches(podLabels)).To(BeTrue())
+		})
+	})
+
+	Describe("imports of the same name sharing the target namespace", func() {
+		var (
+			rclient       client.Client
+			fakeManager   Manager
+			migration     *v2vv1.VirtualMachineImport
+			staging       *v2vv1.VirtualMachineImport
+			sameNamespace *v2vv1.VirtualMachineImport
+		)
+		targetNamespace := "db"
+
+		newImport := func(namespace string) *v2vv1.VirtualMachineImport {
+			return &v2vv1.VirtualMachineImport{
+				ObjectMeta: metav1.ObjectMeta{Name: "db-import", Namespace: namespace},
+				Spec:       v2vv1.VirtualMachineImportSpec{TargetNamespace: &targetNamespace},
+			}
+		}
+
+		BeforeEach(func() {
+			rclient = fake.NewFakeClient()
+			fakeManager = NewManager(rclient)
+			migration = newImport("migration")
+			staging = newImport("staging")
+			sameNamespace = newImport(targetNamespace)
+			Expect(fakeManager.CreateFor(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "migration"}}, migration)).To(Succeed())
+			Expect(fakeManager.CreateFor(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "staging"}}, staging)).To(Succeed())
+		})
+
+		It("should only find the pod of the import", func() {
+			pod, err := fakeManager.FindFor(migration)
+
+			Expect(err).To(BeNil())
+			Expect(pod).ToNot(BeNil())
+			Expect(pod.Namespace).To(Equal(targetNamespace))
+			Expect(pod.Labels).To(HaveKeyWithValue("vmimport.v2v.kubevirt.io/import-namespace", "migration"))
+		})
+
+		It("should not find the pods of the imports from other namespaces for an import in the target namespace", func() {
+			pod, err := fakeManager.FindFor(sameNamespace)
+
+			Expect(err).To(BeNil())
+			Expect(pod).To(BeNil())
+		})
+
+		It("should only delete the pod of the import", func() {
+			err := fakeManager.DeleteFor(migration)
+
+			Expect(err).To(BeNil())
+			podList := corev1.PodList{}
+			Expect(rclient.List(context.TODO(), &podList, client.InNamespace(targetNamespace))).To(Succeed())
+			Expect(podList.Items).To(HaveLen(1))
+			Expect(podList.Items[0].Labels).To(HaveKeyWithValue("vmimport.v2v.kubevirt.io/import-namespace", "staging"))
 		})
 	})
 
@@ -90,7 +185,7 @@ var _ = Describe("Pods Manager", func() {
 			list = func(context.Context, runtime.Object) error {
 				return nil
 			}
-			err := manager.DeleteFor(vmiCrName)
+			err := manager.DeleteFor(instance)
 			Expect(deleteCalled).To(BeFalse())
 			Expect(err).To(BeNil())
 		})
@@ -102,10 +197,10 @@ var _ = Describe("Pods Manager", func() {
 				}
 				return nil
 			}
-			err := manager.DeleteFor(vmiCrName)
+			err := manager.DeleteFor(instance)
 			Expect(deleteCalled).To(BeFalse())
 			Expect(err).ToNot(BeNil())
-			Expect(err.Error()).To(Equal("too many pods matching given labels: map[vmimport.v2v.kubevirt.io/vmi-name:test]"))
+			Expect(err.Error()).To(Equal("too many pods matching given labels: !vmimport.v2v.kubevirt.io/import-namespace,!vmimport.v2v.kubevirt.io/role,vmimport.v2v.kubevirt.io/vmi-name=test"))
 		})
 
 		It("should return nil if one pod is found", func() {
@@ -120,16 +215,23 @@ var _ = Describe("Pods Manager", func() {
 				}
 				return nil
 			}
-			err := manager.DeleteFor(vmiCrName)
+			err := manager.DeleteFor(instance)
 			Expect(deleteCalled).To(BeTrue())
 			Expect(err).To(BeNil())
 		})
 	})
 })
 
+func listSelector() labels.Selector {
+	options := &client.ListOptions{}
+	options.ApplyOptions(listOptions)
+	return options.LabelSelector
+}
+
 type mockClient struct{}
 
 var list func(context.Context, runtime.Object) error
+var listOptions []client.ListOption
 
 // Create implements client.Client
 func (c mockClient) Create(ctx context.Context, obj runtime.Object, opts ...client.CreateOption) error {
@@ -164,6 +266,7 @@ func (c mockClient) Get(ctx context.Context, key client.ObjectKey, obj runtime.O
 
 // List implements client.Client
 func (c mockClient) List(ctx context.Context, objectList runtime.Object, opts ...client.ListOption) error {
+	listOptions = opts
 	return list(ctx, objectList)
 }
 
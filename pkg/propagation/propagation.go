@@ -0,0 +1,245 @@
+package propagation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
+)
+
+var (
+	allTargets = []v2vv1.PropagationTarget{
+		v2vv1.PropagationTargetVirtualMachine,
+		v2vv1.PropagationTargetVirtualMachineInstance,
+		v2vv1.PropagationTargetDataVolume,
+		v2vv1.PropagationTargetPersistentVolumeClaim,
+		v2vv1.PropagationTargetGuestConversionPod,
+	}
+	invalidLabelValueChars = regexp.MustCompile("[^-A-Za-z0-9_.]+")
+)
+
+// reservedDomain is the domain of the labels and annotations set by the operator, KubeVirt and CDI, e.g. the tracking
+// labels the deletion and the rollback of the import rely on
+const reservedDomain = "kubevirt.io"
+
+// SourceMetadata holds the metadata of the source VM the labels and annotations can be taken from
+type SourceMetadata struct {
+	// Tags of the oVirt VM
+	Tags []string
+	// CustomAttributes of the vSphere VM, or custom properties of the oVirt VM, by name
+	CustomAttributes map[string]string
+	// Folder path of the vSphere VM
+	Folder string
+}
+
+// Metadata holds the labels and annotations propagated to a target
+type Metadata struct {
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// Validate checks that each rule has a key outside of the reserved domain, a single source of value and known targets
+func Validate(spec *v2vv1.PropagationSpec) error {
+	if spec == nil {
+		return nil
+	}
+	if err := validateRules("labels", spec.Labels); err != nil {
+		return err
+	}
+	return validateRules("annotations", spec.Annotations)
+}
+
+func validateRules(field string, rules []v2vv1.PropagationRule) error {
+	for i, rule := range rules {
+		if errs := k8svalidation.IsQualifiedName(rule.Key); len(errs) > 0 {
+			return fmt.Errorf("`propagation.%s[%d].key` is invalid: %s", field, i, strings.Join(errs, ", "))
+		}
+		if isReserved(rule.Key) {
+			return fmt.Errorf("`propagation.%s[%d].key` %s is reserved: the keys of the %s domain and its subdomains can't be propagated", field, i, rule.Key, reservedDomain)
+		}
+		sources := 0
+		if rule.Value != nil {
+			sources++
+		}
+		if rule.FromImportLabel != nil {
+			sources++
+		}
+		if rule.FromImportAnnotation != nil {
+			sources++
+		}
+		if rule.FromSource != nil {
+			sources++
+			if err := validateSourceSelector(rule.FromSource); err != nil {
+				return fmt.Errorf("`propagation.%s[%d].fromSource`: %v", field, i, err)
+			}
+		}
+		if sources != 1 {
+			return fmt.Errorf("exactly one of `value`, `fromImportLabel`, `fromImportAnnotation` and `fromSource` must be set in `propagation.%s[%d]`", field, i)
+		}
+		for _, target := range rule.Targets {
+			if !isKnownTarget(target) {
+				return fmt.Errorf("`propagation.%s[%d]` has the unknown target %s", field, i, target)
+			}
+		}
+	}
+	return nil
+}
+
+func isReserved(key string) bool {
+	i := strings.Index(key, "/")
+	if i < 0 {
+		return false
+	}
+	prefix := key[:i]
+	return prefix == reservedDomain || strings.HasSuffix(prefix, "."+reservedDomain)
+}
+
+func validateSourceSelector(selector *v2vv1.SourceMetadataSelector) error {
+	selected := 0
+	if selector.Tag != nil {
+		selected++
+	}
+	if selector.CustomAttribute != nil {
+		selected++
+	}
+	if selector.Folder {
+		selected++
+	}
+	if selected != 1 {
+		return fmt.Errorf("exactly one of `tag`, `customAttribute` and `folder` must be set")
+	}
+	return nil
+}
+
+func isKnownTarget(target v2vv1.PropagationTarget) bool {
+	for _, known := range allTargets {
+		if target == known {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsSourceMetadata returns whether a rule takes its value from the metadata of the source VM
+func NeedsSourceMetadata(spec *v2vv1.PropagationSpec) bool {
+	if spec == nil {
+		return false
+	}
+	for _, rules := range [][]v2vv1.PropagationRule{spec.Labels, spec.Annotations} {
+		for _, rule := range rules {
+			if rule.FromSource != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Resolve returns the labels and annotations the rules of the import propagate to the target. The source metadata is
+// only read by the rules taking their value from it.
+func Resolve(instance *v2vv1.VirtualMachineImport, source *SourceMetadata, target v2vv1.PropagationTarget) Metadata {
+	metadata := Metadata{
+		Labels:      map[string]string{},
+		Annotations: map[string]string{},
+	}
+	spec := instance.Spec.Propagation
+	if spec == nil {
+		return metadata
+	}
+	for _, rule := range spec.Labels {
+		if value, ok := resolveValue(instance, source, rule, target); ok {
+			metadata.Labels[rule.Key] = labelValue(value)
+		}
+	}
+	for _, rule := range spec.Annotations {
+		if value, ok := resolveValue(instance, source, rule, target); ok {
+			metadata.Annotations[rule.Key] = value
+		}
+	}
+	return metadata
+}
+
+func resolveValue(instance *v2vv1.VirtualMachineImport, source *SourceMetadata, rule v2vv1.PropagationRule, target v2vv1.PropagationTarget) (string, bool) {
+	if len(rule.Targets) > 0 && !hasTarget(rule.Targets, target) {
+		return "", false
+	}
+	switch {
+	case rule.Value != nil:
+		return *rule.Value, true
+	case rule.FromImportLabel != nil:
+		value, ok := instance.Labels[*rule.FromImportLabel]
+		return value, ok
+	case rule.FromImportAnnotation != nil:
+		value, ok := instance.Annotations[*rule.FromImportAnnotation]
+		return value, ok
+	case rule.FromSource != nil && source != nil:
+		return sourceValue(source, rule.FromSource)
+	}
+	return "", false
+}
+
+func sourceValue(source *SourceMetadata, selector *v2vv1.SourceMetadataSelector) (string, bool) {
+	switch {
+	case selector.Tag != nil:
+		for _, tag := range source.Tags {
+			if tag == *selector.Tag {
+				return "true", true
+			}
+		}
+	case selector.CustomAttribute != nil:
+		value, ok := source.CustomAttributes[*selector.CustomAttribute]
+		return value, ok
+	case selector.Folder:
+		return source.Folder, source.Folder != ""
+	}
+	return "", false
+}
+
+func hasTarget(targets []v2vv1.PropagationTarget, target v2vv1.PropagationTarget) bool {
+	for _, t := range targets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
+// labelValue turns the value into a valid label value, replacing the invalid characters with underscores
+func labelValue(value string) string {
+	if len(k8svalidation.IsValidLabelValue(value)) == 0 {
+		return value
+	}
+	value = invalidLabelValueChars.ReplaceAllString(value, "_")
+	value = strings.TrimFunc(value, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.'
+	})
+	return utils.EnsureLabelValueLength(value)
+}
+
+// Apply sets the labels and annotations on the object metadata. It returns whether the metadata changed.
+func Apply(meta *metav1.ObjectMeta, metadata Metadata) bool {
+	changed := false
+	if len(metadata.Labels) > 0 && meta.Labels == nil {
+		meta.Labels = map[string]string{}
+	}
+	for key, value := range metadata.Labels {
+		if current, ok := meta.Labels[key]; !ok || current != value {
+			meta.Labels[key] = value
+			changed = true
+		}
+	}
+	if len(metadata.Annotations) > 0 && meta.Annotations == nil {
+		meta.Annotations = map[string]string{}
+	}
+	for key, value := range metadata.Annotations {
+		if current, ok := meta.Annotations[key]; !ok || current != value {
+			meta.Annotations[key] = value
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,146 @@
+package propagation_test
+
+import (
+	"strings"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/propagation"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Propagation", func() {
+	str := func(value string) *string {
+		return &value
+	}
+
+	table.DescribeTable("should validate", func(rule v2vv1.PropagationRule, valid bool) {
+		err := propagation.Validate(&v2vv1.PropagationSpec{Annotations: []v2vv1.PropagationRule{rule}})
+
+		if valid {
+			Expect(err).ToNot(HaveOccurred())
+		} else {
+			Expect(err).To(HaveOccurred())
+		}
+	},
+		table.Entry("a constant value", v2vv1.PropagationRule{Key: "example.com/team", Value: str("db")}, true),
+		table.Entry("a value from an import label", v2vv1.PropagationRule{Key: "team", FromImportLabel: str("team")}, true),
+		table.Entry("a value from the source folder", v2vv1.PropagationRule{Key: "folder", FromSource: &v2vv1.SourceMetadataSelector{Folder: true}}, true),
+		table.Entry("known targets", v2vv1.PropagationRule{Key: "team", Value: str("db"), Targets: []v2vv1.PropagationTarget{v2vv1.PropagationTargetDataVolume, v2vv1.PropagationTargetGuestConversionPod}}, true),
+		table.Entry("an invalid key", v2vv1.PropagationRule{Key: "team name", Value: str("db")}, false),
+		table.Entry("no value", v2vv1.PropagationRule{Key: "team"}, false),
+		table.Entry("two values", v2vv1.PropagationRule{Key: "team", Value: str("db"), FromImportAnnotation: str("team")}, false),
+		table.Entry("an empty source selector", v2vv1.PropagationRule{Key: "team", FromSource: &v2vv1.SourceMetadataSelector{}}, false),
+		table.Entry("two source selectors", v2vv1.PropagationRule{Key: "team", FromSource: &v2vv1.SourceMetadataSelector{Tag: str("db"), Folder: true}}, false),
+		table.Entry("an unknown target", v2vv1.PropagationRule{Key: "team", Value: str("db"), Targets: []v2vv1.PropagationTarget{"Service"}}, false),
+		table.Entry("a tracking label", v2vv1.PropagationRule{Key: "vmimport.v2v.kubevirt.io/import-name", Value: str("db")}, false),
+		table.Entry("a key of the kubevirt.io domain", v2vv1.PropagationRule{Key: "kubevirt.io/domain", Value: str("db")}, false),
+		table.Entry("a key of a domain ending like kubevirt.io", v2vv1.PropagationRule{Key: "notkubevirt.io/team", Value: str("db")}, true),
+	)
+
+	It("should tell whether the source metadata is needed", func() {
+		Expect(propagation.NeedsSourceMetadata(nil)).To(BeFalse())
+		Expect(propagation.NeedsSourceMetadata(&v2vv1.PropagationSpec{
+			Labels: []v2vv1.PropagationRule{{Key: "team", Value: str("db")}},
+		})).To(BeFalse())
+		Expect(propagation.NeedsSourceMetadata(&v2vv1.PropagationSpec{
+			Labels:      []v2vv1.PropagationRule{{Key: "team", Value: str("db")}},
+			Annotations: []v2vv1.PropagationRule{{Key: "folder", FromSource: &v2vv1.SourceMetadataSelector{Folder: true}}},
+		})).To(BeTrue())
+	})
+
+	Describe("resolving", func() {
+		var instance *v2vv1.VirtualMachineImport
+		var source *propagation.SourceMetadata
+
+		BeforeEach(func() {
+			instance = &v2vv1.VirtualMachineImport{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      map[string]string{"team": "db"},
+					Annotations: map[string]string{"owner": "Jane Doe"},
+				},
+			}
+			source = &propagation.SourceMetadata{
+				Tags:             []string{"backup"},
+				CustomAttributes: map[string]string{"cost-center": "42"},
+				Folder:           "/DC/vm/prod",
+			}
+		})
+
+		It("should resolve the values of the rules", func() {
+			instance.Spec.Propagation = &v2vv1.PropagationSpec{
+				Labels: []v2vv1.PropagationRule{
+					{Key: "env", Value: str("prod")},
+					{Key: "team", FromImportLabel: str("team")},
+					{Key: "backup", FromSource: &v2vv1.SourceMetadataSelector{Tag: str("backup")}},
+					{Key: "cost-center", FromSource: &v2vv1.SourceMetadataSelector{CustomAttribute: str("cost-center")}},
+				},
+				Annotations: []v2vv1.PropagationRule{
+					{Key: "owner", FromImportAnnotation: str("owner")},
+					{Key: "folder", FromSource: &v2vv1.SourceMetadataSelector{Folder: true}},
+				},
+			}
+
+			metadata := propagation.Resolve(instance, source, v2vv1.PropagationTargetVirtualMachine)
+
+			Expect(metadata.Labels).To(Equal(map[string]string{"env": "prod", "team": "db", "backup": "true", "cost-center": "42"}))
+			Expect(metadata.Annotations).To(Equal(map[string]string{"owner": "Jane Doe", "folder": "/DC/vm/prod"}))
+		})
+
+		It("should skip the rules without a value", func() {
+			instance.Spec.Propagation = &v2vv1.PropagationSpec{
+				Labels: []v2vv1.PropagationRule{
+					{Key: "site", FromImportLabel: str("site")},
+					{Key: "archive", FromSource: &v2vv1.SourceMetadataSelector{Tag: str("archive")}},
+					{Key: "missing", FromSource: &v2vv1.SourceMetadataSelector{CustomAttribute: str("missing")}},
+				},
+			}
+
+			metadata := propagation.Resolve(instance, source, v2vv1.PropagationTargetVirtualMachine)
+
+			Expect(metadata.Labels).To(BeEmpty())
+		})
+
+		It("should only resolve the rules of the target", func() {
+			instance.Spec.Propagation = &v2vv1.PropagationSpec{
+				Labels: []v2vv1.PropagationRule{
+					{Key: "env", Value: str("prod")},
+					{Key: "backup", Value: str("daily"), Targets: []v2vv1.PropagationTarget{v2vv1.PropagationTargetPersistentVolumeClaim}},
+				},
+			}
+
+			Expect(propagation.Resolve(instance, source, v2vv1.PropagationTargetDataVolume).Labels).To(Equal(map[string]string{"env": "prod"}))
+			Expect(propagation.Resolve(instance, source, v2vv1.PropagationTargetPersistentVolumeClaim).Labels).To(Equal(map[string]string{"env": "prod", "backup": "daily"}))
+		})
+
+		It("should turn the values into valid label values", func() {
+			instance.Spec.Propagation = &v2vv1.PropagationSpec{
+				Labels: []v2vv1.PropagationRule{
+					{Key: "folder", FromSource: &v2vv1.SourceMetadataSelector{Folder: true}},
+					{Key: "owner", FromImportAnnotation: str("owner")},
+					{Key: "long", Value: str(strings.Repeat("a", 100))},
+				},
+			}
+
+			metadata := propagation.Resolve(instance, source, v2vv1.PropagationTargetVirtualMachine)
+
+			Expect(metadata.Labels).To(HaveKeyWithValue("folder", "DC_vm_prod"))
+			Expect(metadata.Labels).To(HaveKeyWithValue("owner", "Jane_Doe"))
+			Expect(len(metadata.Labels["long"])).To(BeNumerically("<=", 63))
+		})
+	})
+
+	It("should apply the metadata and tell whether it changed", func() {
+		meta := metav1.ObjectMeta{Labels: map[string]string{"env": "prod"}}
+		metadata := propagation.Metadata{
+			Labels:      map[string]string{"env": "prod"},
+			Annotations: map[string]string{"owner": "Jane Doe"},
+		}
+
+		Expect(propagation.Apply(&meta, metadata)).To(BeTrue())
+		Expect(meta.Annotations).To(HaveKeyWithValue("owner", "Jane Doe"))
+		Expect(propagation.Apply(&meta, metadata)).To(BeFalse())
+	})
+})
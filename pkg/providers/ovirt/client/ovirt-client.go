@@ -1,11 +1,15 @@
 package ovirtclient
 
 import (
+	"context"
 	"fmt"
 	"runtime/debug"
+	"strings"
 	"time"
 
+	"github.com/kubevirt/vm-import-operator/pkg/tracing"
 	ovirtsdk "github.com/ovirt/go-ovirt"
+	"go.opentelemetry.io/otel/label"
 )
 
 const (
@@ -13,6 +17,8 @@ const (
 	vmStopTimeout = 5
 	// Vm poll interval in seconds
 	vmPollInterval = 5
+	// migratedTag is the tag of the VMs migrated to KubeVirt
+	migratedTag = "migrated-to-kubevirt"
 )
 
 // ConnectionSettings wrap information required to make oVirt API connection
@@ -46,70 +52,56 @@ func (client *richOvirtClient) Close() error {
 }
 
 // GetVM retrieves oVirt VM data for given id or name and cluster. VM will have certain links followed and updated.
-func (client *richOvirtClient) GetVM(id *string, name *string, cluster *string, clusterID *string) (_ interface{}, e error) {
+// The spans of the API calls are started from the given context.
+func (client *richOvirtClient) GetVM(ctx context.Context, id *string, name *string, cluster *string, clusterID *string) (_ interface{}, e error) {
+	ctx, span := tracing.Start(ctx, "ovirt.GetVM")
+	defer func() { tracing.EndSpan(span, e) }()
 	defer func() {
 		if err := recover(); err != nil {
 			e = fmt.Errorf("ovirt client panicked GetVM: %v", err)
 			debug.PrintStack()
 		}
 	}()
-	vm, err := client.fetchVM(id, name, cluster, clusterID)
-	if err != nil {
-		return nil, err
-	}
-	err = client.populateNics(vm)
-	if err != nil {
-		return nil, err
-	}
-	err = client.populateDiskAttachments(vm)
-	if err != nil {
-		return nil, err
-	}
-	err = client.populateCdRoms(vm)
-	if err != nil {
-		return nil, err
-	}
-	err = client.populateFloppies(vm)
-	if err != nil {
-		return nil, err
-	}
-	err = client.populateHostDevices(vm)
-	if err != nil {
-		return nil, err
-	}
-	err = client.populateReportedDevices(vm)
-	if err != nil {
-		return nil, err
-	}
-	err = client.populateQuota(vm)
-	if err != nil {
-		return nil, err
-	}
-	err = client.populateWatchdogs(vm)
-	if err != nil {
-		return nil, err
-	}
-	err = client.populateGraphicsConsoles(vm)
-	if err != nil {
-		return nil, err
-	}
-	err = client.populateInstanceType(vm)
-	if err != nil {
-		return nil, err
-	}
-	err = client.populateTags(vm)
+	var vm *ovirtsdk.Vm
+	err := client.call(ctx, "ovirt.fetchVM", func() error {
+		var err error
+		vm, err = client.fetchVM(id, name, cluster, clusterID)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	err = client.populateCluster(vm)
-	if err != nil {
-		return nil, err
+	populators := []struct {
+		name     string
+		populate func(*ovirtsdk.Vm) error
+	}{
+		{"ovirt.populateNics", client.populateNics},
+		{"ovirt.populateDiskAttachments", client.populateDiskAttachments},
+		{"ovirt.populateCdRoms", client.populateCdRoms},
+		{"ovirt.populateFloppies", client.populateFloppies},
+		{"ovirt.populateHostDevices", client.populateHostDevices},
+		{"ovirt.populateReportedDevices", client.populateReportedDevices},
+		{"ovirt.populateQuota", client.populateQuota},
+		{"ovirt.populateWatchdogs", client.populateWatchdogs},
+		{"ovirt.populateGraphicsConsoles", client.populateGraphicsConsoles},
+		{"ovirt.populateInstanceType", client.populateInstanceType},
+		{"ovirt.populateTags", client.populateTags},
+		{"ovirt.populateCluster", client.populateCluster},
+	}
+	for _, populator := range populators {
+		populate := populator.populate
+		err = client.call(ctx, populator.name, func() error { return populate(vm) })
+		if err != nil {
+			return nil, err
+		}
 	}
 	return vm, nil
 }
 
 // StopVM stop the VM and wait for the vm to be stopped
-func (client *richOvirtClient) StopVM(id string) (e error) {
+func (client *richOvirtClient) StopVM(ctx context.Context, id string) (e error) {
+	_, span := tracing.Start(ctx, "ovirt.StopVM", label.String("vm.id", id))
+	defer func() { tracing.EndSpan(span, e) }()
 	defer func() {
 		if err := recover(); err != nil {
 			e = fmt.Errorf("ovirt client panicked in StopVM: %v", err)
@@ -160,7 +152,9 @@ func (client *richOvirtClient) StopVM(id string) (e error) {
 }
 
 // StartVM requests VM start and doesn't wait for it to be UP
-func (client *richOvirtClient) StartVM(id string) (e error) {
+func (client *richOvirtClient) StartVM(ctx context.Context, id string) (e error) {
+	_, span := tracing.Start(ctx, "ovirt.StartVM", label.String("vm.id", id))
+	defer func() { tracing.EndSpan(span, e) }()
 	defer func() {
 		if err := recover(); err != nil {
 			e = fmt.Errorf("ovirt client panicked in StartVM: %v", err)
@@ -185,9 +179,147 @@ func (client *richOvirtClient) StartVM(id string) (e error) {
 	return nil
 }
 
+// DeleteVM removes the VM along with its disks
+func (client *richOvirtClient) DeleteVM(ctx context.Context, id string) (e error) {
+	_, span := tracing.Start(ctx, "ovirt.DeleteVM", label.String("vm.id", id))
+	defer func() { tracing.EndSpan(span, e) }()
+	defer func() {
+		if err := recover(); err != nil {
+			e = fmt.Errorf("ovirt client panicked in DeleteVM: %v", err)
+			debug.PrintStack()
+		}
+	}()
+	_, err := client.connection.SystemService().VmsService().VmService(id).Remove().Send()
+	return err
+}
+
+// MarkVMMigrated records that the VM was migrated to the target by tagging it and appending a note to its description
+func (client *richOvirtClient) MarkVMMigrated(ctx context.Context, id string, target string) (e error) {
+	_, span := tracing.Start(ctx, "ovirt.MarkVMMigrated", label.String("vm.id", id))
+	defer func() { tracing.EndSpan(span, e) }()
+	defer func() {
+		if err := recover(); err != nil {
+			e = fmt.Errorf("ovirt client panicked in MarkVMMigrated: %v", err)
+			debug.PrintStack()
+		}
+	}()
+	err := client.ensureTag(migratedTag)
+	if err != nil {
+		return err
+	}
+	vmService := client.connection.SystemService().VmsService().VmService(id)
+	assignedTags, err := vmService.TagsService().List().Send()
+	if err != nil {
+		return err
+	}
+	if !hasTag(assignedTags.MustTags(), migratedTag) {
+		_, err = vmService.TagsService().Add().Tag(ovirtsdk.NewTagBuilder().Name(migratedTag).MustBuild()).Send()
+		if err != nil {
+			return err
+		}
+	}
+
+	vmResponse, err := vmService.Get().Send()
+	if err != nil {
+		return err
+	}
+	description, _ := vmResponse.MustVm().Description()
+	note := "Migrated to " + target
+	if strings.Contains(description, note) {
+		return nil
+	}
+	if description != "" {
+		description += "\n"
+	}
+	_, err = vmService.Update().Vm(ovirtsdk.NewVmBuilder().Description(description + note).MustBuild()).Send()
+	return err
+}
+
+// UnmarkVMMigrated removes the tag of MarkVMMigrated from the VM and notes in its description that the migration was
+// rolled back
+func (client *richOvirtClient) UnmarkVMMigrated(ctx context.Context, id string, target string) (e error) {
+	_, span := tracing.Start(ctx, "ovirt.UnmarkVMMigrated", label.String("vm.id", id))
+	defer func() { tracing.EndSpan(span, e) }()
+	defer func() {
+		if err := recover(); err != nil {
+			e = fmt.Errorf("ovirt client panicked in UnmarkVMMigrated: %v", err)
+			debug.PrintStack()
+		}
+	}()
+	vmService := client.connection.SystemService().VmsService().VmService(id)
+	assignedTags, err := vmService.TagsService().List().Send()
+	if err != nil {
+		return err
+	}
+	for _, tag := range assignedTags.MustTags().Slice() {
+		tagName, _ := tag.Name()
+		tagID, ok := tag.Id()
+		if tagName != migratedTag || !ok {
+			continue
+		}
+		_, err = vmService.TagsService().TagService(tagID).Remove().Send()
+		if err != nil {
+			return err
+		}
+	}
+
+	vmResponse, err := vmService.Get().Send()
+	if err != nil {
+		return err
+	}
+	description, _ := vmResponse.MustVm().Description()
+	unmarked := replaceNote(description, "Migrated to "+target, "Migration to "+target+" rolled back")
+	if unmarked == description {
+		return nil
+	}
+	_, err = vmService.Update().Vm(ovirtsdk.NewVmBuilder().Description(unmarked).MustBuild()).Send()
+	return err
+}
+
+// replaceNote replaces the lines holding the note in the text
+func replaceNote(text string, note string, replacement string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == note {
+			lines[i] = replacement
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (client *richOvirtClient) ensureTag(name string) error {
+	tagsService := client.connection.SystemService().TagsService()
+	tags, err := tagsService.List().Send()
+	if err != nil {
+		return err
+	}
+	if hasTag(tags.MustTags(), name) {
+		return nil
+	}
+	_, err = tagsService.Add().Tag(ovirtsdk.NewTagBuilder().Name(name).Description("VMs migrated to KubeVirt").MustBuild()).Send()
+	return err
+}
+
+func hasTag(tags *ovirtsdk.TagSlice, name string) bool {
+	for _, tag := range tags.Slice() {
+		if tagName, _ := tag.Name(); tagName == name {
+			return true
+		}
+	}
+	return false
+}
+
 // TestConnection checks the connectivity to oVirt provider
-func (client *richOvirtClient) TestConnection() error {
-	return client.connection.Test()
+func (client *richOvirtClient) TestConnection(ctx context.Context) error {
+	return client.call(ctx, "ovirt.TestConnection", client.connection.Test)
+}
+
+// call runs the oVirt API call within a span
+func (client *richOvirtClient) call(ctx context.Context, name string, apiCall func() error) error {
+	_, span := tracing.Start(ctx, name)
+	err := apiCall()
+	tracing.EndSpan(span, err)
+	return err
 }
 
 func (client *richOvirtClient) fetchVM(id *string, name *string, clusterName *string, clusterID *string) (*ovirtsdk.Vm, error) {
@@ -1,6 +1,8 @@
 package ovirtclient
 
 import (
+	"context"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -14,20 +16,38 @@ var _ = Describe("oVirt client", func() {
 
 	It("should recover from VM retrieval panic", func() {
 		vmID := "any"
-		vm, err := client.GetVM(&vmID, nil, nil, nil)
+		vm, err := client.GetVM(context.Background(), &vmID, nil, nil, nil)
 
 		Expect(err).To(HaveOccurred())
 		Expect(err.Error()).To(ContainSubstring("panicked"))
 		Expect(vm).To(BeNil())
 	})
 	It("should recover from VM starting panic", func() {
-		err := client.StartVM("any")
+		err := client.StartVM(context.Background(), "any")
 
 		Expect(err).To(HaveOccurred())
 		Expect(err.Error()).To(ContainSubstring("panicked"))
 	})
 	It("should recover from VM stopping panic", func() {
-		err := client.StopVM("any")
+		err := client.StopVM(context.Background(), "any")
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("panicked"))
+	})
+	It("should recover from VM deletion panic", func() {
+		err := client.DeleteVM(context.Background(), "any")
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("panicked"))
+	})
+	It("should recover from VM marking panic", func() {
+		err := client.MarkVMMigrated(context.Background(), "any", "cluster/default/vm")
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("panicked"))
+	})
+	It("should recover from VM unmarking panic", func() {
+		err := client.UnmarkVMMigrated(context.Background(), "any", "cluster/default/vm")
 
 		Expect(err).To(HaveOccurred())
 		Expect(err.Error()).To(ContainSubstring("panicked"))
@@ -0,0 +1,104 @@
+package ovirtprovider
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	ovirtsdk "github.com/ovirt/go-ovirt"
+)
+
+// GetGuestNetwork returns the IP configuration the guest agent reports for the interfaces of the source VM, or nil
+// when no agent reports it
+func (o *OvirtProvider) GetGuestNetwork() (*v2vv1.GuestNetworkStatus, error) {
+	vm, err := o.getVM()
+	if err != nil {
+		return nil, err
+	}
+	return makeGuestNetwork(vm), nil
+}
+
+// makeGuestNetwork reads the network devices reported by the guest agent. oVirt doesn't tell how the addresses were
+// assigned, nor the DNS configuration of the guest, so every reported address is treated as static.
+func makeGuestNetwork(vm *ovirtsdk.Vm) *v2vv1.GuestNetworkStatus {
+	devices, ok := vm.ReportedDevices()
+	if !ok {
+		return nil
+	}
+	network := v2vv1.GuestNetworkStatus{}
+	for _, device := range devices.Slice() {
+		mac, ok := device.Mac()
+		if !ok {
+			continue
+		}
+		address, ok := mac.Address()
+		if !ok {
+			continue
+		}
+		nic := v2vv1.GuestNetworkInterface{MAC: strings.ToLower(address)}
+		if ips, ok := device.Ips(); ok {
+			for _, ip := range ips.Slice() {
+				cidr := ipToCIDR(ip)
+				if cidr == "" {
+					continue
+				}
+				nic.IPAddresses = append(nic.IPAddresses, cidr)
+				if gateway, ok := ip.Gateway(); ok && gateway != "" {
+					network.Routes = appendDefaultRoute(network.Routes, gateway, nic.MAC)
+				}
+			}
+		}
+		network.Interfaces = append(network.Interfaces, nic)
+	}
+	if len(network.Interfaces) == 0 {
+		return nil
+	}
+	return &network
+}
+
+// ipToCIDR returns the address in CIDR notation, or an empty string for link-local addresses and for the ones
+// without a netmask
+func ipToCIDR(ip *ovirtsdk.Ip) string {
+	address, ok := ip.Address()
+	if !ok {
+		return ""
+	}
+	parsed := net.ParseIP(address)
+	if parsed == nil || parsed.IsLinkLocalUnicast() || parsed.IsLoopback() {
+		return ""
+	}
+	netmask, ok := ip.Netmask()
+	if !ok {
+		return ""
+	}
+	prefix, err := strconv.Atoi(netmask)
+	if err != nil {
+		// IPv4 netmasks are reported in dotted notation, e.g. 255.255.255.0
+		mask := net.ParseIP(netmask)
+		if mask == nil || mask.To4() == nil {
+			return ""
+		}
+		ones, bits := net.IPMask(mask.To4()).Size()
+		if bits == 0 {
+			return ""
+		}
+		prefix = ones
+	}
+	return address + "/" + strconv.Itoa(prefix)
+}
+
+func appendDefaultRoute(routes []v2vv1.GuestNetworkRoute, gateway string, mac string) []v2vv1.GuestNetworkRoute {
+	destination := "0.0.0.0/0"
+	if parsed := net.ParseIP(gateway); parsed == nil {
+		return routes
+	} else if parsed.To4() == nil {
+		destination = "::/0"
+	}
+	for _, route := range routes {
+		if route.Destination == destination && route.Gateway == gateway {
+			return routes
+		}
+	}
+	return append(routes, v2vv1.GuestNetworkRoute{Destination: destination, Gateway: gateway, MAC: mac})
+}
@@ -9,6 +9,10 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/customization"
+	"github.com/kubevirt/vm-import-operator/pkg/macaddress"
+	"github.com/kubevirt/vm-import-operator/pkg/mappings"
+	"github.com/kubevirt/vm-import-operator/pkg/naming"
 	outils "github.com/kubevirt/vm-import-operator/pkg/providers/ovirt/utils"
 	"github.com/kubevirt/vm-import-operator/pkg/utils"
 	ovirtsdk "github.com/ovirt/go-ovirt"
@@ -38,6 +42,8 @@ const (
 	AnnotationSso = "sso"
 	// DefaultStorageClassTargetName define the storage target name value that forces using default storage class
 	DefaultStorageClassTargetName = ""
+	// busTypeVirtio defines the disk bus and NIC model the devices are switched to after the guest conversion
+	busTypeVirtio = "virtio"
 )
 
 var (
@@ -49,6 +55,12 @@ var (
 // DiskInterfaceModelMapping defines mapping of disk interface models between oVirt and kubevirt domains
 var DiskInterfaceModelMapping = map[string]string{"sata": "sata", "virtio_scsi": "scsi", "virtio": "virtio"}
 
+// virtioDiskInterfaces defines the oVirt disk interfaces served by the virtio drivers
+var virtioDiskInterfaces = map[ovirtsdk.DiskInterface]bool{
+	ovirtsdk.DISKINTERFACE_VIRTIO:      true,
+	ovirtsdk.DISKINTERFACE_VIRTIO_SCSI: true,
+}
+
 // BiosTypeMapping defines mapping of BIOS types between oVirt and kubevirt domains
 var BiosTypeMapping = map[string]*kubevirtv1.Bootloader{
 	"q35_sea_bios":    {BIOS: &kubevirtv1.BIOS{}},
@@ -81,17 +93,52 @@ type OvirtMapper struct {
 	creds     DataVolumeCredentials
 	namespace string
 	osFinder  oos.OSFinder
-}
-
-// NewOvirtMapper create ovirt mapper object
-func NewOvirtMapper(vm *ovirtsdk.Vm, mappings *v2vv1.OvirtMappings, creds DataVolumeCredentials, namespace string, osFinder oos.OSFinder) *OvirtMapper {
+	// convertGuest indicates whether the guest undergoes conversion, after which the disks and NICs use virtio
+	convertGuest bool
+	// customization is the data the guest is customized with on its first boot
+	customization *v2vv1.CustomizationSpec
+	// guestNetwork is the IP configuration reported by the guest agent, restored by the customization
+	guestNetwork *v2vv1.GuestNetworkStatus
+	// naming holds the templates of the data volume and interface names
+	naming *v2vv1.NamingSpec
+}
+
+// NewOvirtMapper create ovirt mapper object; the target namespace, customization, guest network and naming are taken from the import
+func NewOvirtMapper(vm *ovirtsdk.Vm, mappings *v2vv1.OvirtMappings, creds DataVolumeCredentials, osFinder oos.OSFinder, convertGuest bool, instance *v2vv1.VirtualMachineImport) *OvirtMapper {
 	return &OvirtMapper{
-		vm:        vm,
-		mappings:  mappings,
-		creds:     creds,
-		namespace: namespace,
-		osFinder:  osFinder,
+		vm:            vm,
+		mappings:      mappings,
+		creds:         creds,
+		namespace:     utils.TargetNamespace(instance),
+		osFinder:      osFinder,
+		convertGuest:  convertGuest,
+		customization: instance.Spec.Customization,
+		guestNetwork:  instance.Status.GuestNetwork,
+		naming:        instance.Spec.Naming,
+	}
+}
+
+// UsesNonVirtioDevices returns whether any disk or NIC of the VM is attached through a non-virtio interface, which
+// indicates that the guest may lack the virtio drivers.
+func UsesNonVirtioDevices(vm *ovirtsdk.Vm) bool {
+	if diskAttachments, ok := vm.DiskAttachments(); ok {
+		for _, diskAttachment := range diskAttachments.Slice() {
+			if iface, ok := diskAttachment.Interface(); ok && !virtioDiskInterfaces[iface] {
+				return true
+			}
+		}
+	}
+	if nics, ok := vm.Nics(); ok {
+		for _, nic := range nics.Slice() {
+			if vNicProfile, ok := nic.VnicProfile(); ok && outils.IsSRIOV(vNicProfile) {
+				continue
+			}
+			if iface, ok := nic.Interface(); ok && iface != ovirtsdk.NICINTERFACE_VIRTIO {
+				return true
+			}
+		}
 	}
+	return false
 }
 
 // CreateEmptyVM creates empty virtual machine definition
@@ -189,6 +236,11 @@ func (o *OvirtMapper) MapVM(targetVMName *string, vmSpec *kubevirtv1.VirtualMach
 	networkToType := o.mapNetworksToTypes(vmSpec.Spec.Template.Spec.Networks)
 	vmSpec.Spec.Template.Spec.Domain.Devices.Interfaces = o.mapNics(networkToType)
 
+	// Attach the customization data
+	if err := customization.AddVolume(vmSpec, o.customization, o.guestNetwork); err != nil {
+		return nil, err
+	}
+
 	return vmSpec, nil
 }
 
@@ -208,13 +260,18 @@ func (o *OvirtMapper) MapDisk(vmSpec *kubevirtv1.VirtualMachine, dv cdiv1.DataVo
 
 	// Map disks
 	diskAttachments, _ := o.vm.DiskAttachments()
-	diskAttachment := getDiskAttachmentByID(dv.Name, diskAttachments, vmSpec.ObjectMeta.Name)
+	dvNames, _ := o.dataVolumeNames(vmSpec.ObjectMeta.Name)
+	diskAttachment := getDiskAttachmentByDataVolumeName(dv.Name, diskAttachments, dvNames)
 	iface, _ := diskAttachment.Interface()
+	bus := DiskInterfaceModelMapping[string(iface)]
+	if o.convertGuest {
+		bus = busTypeVirtio
+	}
 	disk := kubevirtv1.Disk{
 		Name: name,
 		DiskDevice: kubevirtv1.DiskDevice{
 			Disk: &kubevirtv1.DiskTarget{
-				Bus: DiskInterfaceModelMapping[string(iface)],
+				Bus: bus,
 			},
 		},
 	}
@@ -247,17 +304,22 @@ func (o *OvirtMapper) getAccessMode(diskAttachment *ovirtsdk.DiskAttachment, map
 }
 
 // MapDataVolumes map the oVirt VM disks to the map of CDI DataVolumes specification, where
-// map key is the target-vm-name + id of the oVirt disk
+// map key is the target-vm-name + id of the oVirt disk, or the name built with the naming template
 func (o *OvirtMapper) MapDataVolumes(targetVMName *string, filesystemOverhead cdiv1.FilesystemOverhead) (map[string]cdiv1.DataVolume, error) {
 	// TODO: stateless, boot_devices, floppy/cdrom
 	diskAttachments, _ := o.vm.DiskAttachments()
 	dvs := make(map[string]cdiv1.DataVolume, len(diskAttachments.Slice()))
+	dvNames, err := o.dataVolumeNames(*targetVMName)
+	if err != nil {
+		return dvs, err
+	}
 
 	for _, diskAttachment := range diskAttachments.Slice() {
 		diskAttachID, _ := diskAttachment.Id()
-		dvName := buildDataVolumeName(*targetVMName, diskAttachID)
+		dvName := dvNames[diskAttachID]
 		disk, _ := diskAttachment.Disk()
 		diskID, _ := disk.Id()
+		diskName, _ := disk.Name()
 
 		mapping := o.getMapping(disk, o.mappings)
 		accessMode := o.getAccessMode(diskAttachment, mapping)
@@ -282,6 +344,10 @@ func (o *OvirtMapper) MapDataVolumes(targetVMName *string, filesystemOverhead cd
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      dvName,
 				Namespace: o.namespace,
+				Annotations: map[string]string{
+					utils.SourceDiskIDAnnotation:   diskID,
+					utils.SourceDiskNameAnnotation: diskName,
+				},
 			},
 			Spec: cdiv1.DataVolumeSpec{
 				Source: cdiv1.DataVolumeSource{
@@ -312,10 +378,47 @@ func (o *OvirtMapper) MapDataVolumes(targetVMName *string, filesystemOverhead cd
 	return dvs, nil
 }
 
+// dataVolumeNames returns the name of the data volume of each disk of the source VM, by disk attachment ID
+func (o *OvirtMapper) dataVolumeNames(targetVMName string) (map[string]string, error) {
+	diskAttachments, _ := o.vm.DiskAttachments()
+	namer := naming.NewDataVolumeNamer(o.naming)
+	names := make(map[string]string, len(diskAttachments.Slice()))
+	for i, diskAttachment := range diskAttachments.Slice() {
+		diskAttachID, _ := diskAttachment.Id()
+		data := naming.DataVolume{VMName: targetVMName, DiskID: diskAttachID, Index: i}
+		if disk, ok := diskAttachment.Disk(); ok {
+			data.DiskName, _ = disk.Name()
+		}
+		name, err := namer.Name(data, buildDataVolumeName(targetVMName, diskAttachID))
+		if err != nil {
+			return nil, fmt.Errorf("cannot name the data volume of disk %s: %v", diskAttachID, err)
+		}
+		names[diskAttachID] = name
+	}
+	return names, nil
+}
+
+// interfaceNames returns the name of the interface of each NIC of the source VM, in the order of the NICs
+func (o *OvirtMapper) interfaceNames(nics []*ovirtsdk.Nic) []string {
+	namer := naming.NewInterfaceNamer(o.naming)
+	names := make([]string, len(nics))
+	for i, nic := range nics {
+		nicName, _ := nic.Name()
+		defaultName, _ := utils.NormalizeName(nicName)
+		name, err := namer.Name(naming.Interface{NICName: nicName, Index: i}, defaultName)
+		if err != nil {
+			name = defaultName
+		}
+		names[i] = name
+	}
+	return names
+}
+
 func (o *OvirtMapper) mapNics(networkToType map[string]string) []kubevirtv1.Interface {
 	var kubevirtNics []kubevirtv1.Interface
 	nics, _ := o.vm.Nics()
-	for _, nic := range nics.Slice() {
+	names := o.interfaceNames(nics.Slice())
+	for i, nic := range nics.Slice() {
 		sriov := false
 
 		// This network interface doesn't have any vnic profile specified.
@@ -326,16 +429,23 @@ func (o *OvirtMapper) mapNics(networkToType map[string]string) []kubevirtv1.Inte
 		}
 
 		kubevirtNic := kubevirtv1.Interface{}
-		nicName, _ := nic.Name()
-		kubevirtNic.Name, _ = utils.NormalizeName(nicName)
+		kubevirtNic.Name = names[i]
+		networkType, found := networkToType[kubevirtNic.Name]
+		// The network of the NIC has no mapping and the NIC is dropped
+		if !found {
+			continue
+		}
 		if nicMac, ok := nic.Mac(); ok {
 			kubevirtNic.MacAddress, _ = nicMac.Address()
 		}
 		if nicInterface, ok := nic.Interface(); ok && !sriov {
 			kubevirtNic.Model = string(nicInterface)
+			if o.convertGuest {
+				kubevirtNic.Model = busTypeVirtio
+			}
 		}
 
-		switch networkToType[kubevirtNic.Name] {
+		switch networkType {
 		case networkTypeMultus:
 			if sriov {
 				kubevirtNic.SRIOV = &kubevirtv1.InterfaceSRIOV{}
@@ -355,16 +465,19 @@ func (o *OvirtMapper) mapNics(networkToType map[string]string) []kubevirtv1.Inte
 func (o *OvirtMapper) mapNetworks() []kubevirtv1.Network {
 	var kubevirtNetworks []kubevirtv1.Network
 	nics, _ := o.vm.Nics()
-	for _, nic := range nics.Slice() {
+	names := o.interfaceNames(nics.Slice())
+	for i, nic := range nics.Slice() {
 		// This network interface don't have any network specified.
 		nicProfile, ok := nic.VnicProfile()
 		if !ok {
 			continue
 		}
 
-		kubevirtNet := o.getNetworkForNic(nicProfile)
-		nicName, _ := nic.Name()
-		kubevirtNet.Name, _ = utils.NormalizeName(nicName)
+		kubevirtNet, mapped := o.getNetworkForNic(nicProfile)
+		if !mapped {
+			continue
+		}
+		kubevirtNet.Name = names[i]
 		kubevirtNetworks = append(kubevirtNetworks, kubevirtNet)
 	}
 
@@ -383,24 +496,112 @@ func (o *OvirtMapper) mapNetworksToTypes(networks []kubevirtv1.Network) map[stri
 	return networkToType
 }
 
-func (o *OvirtMapper) getNetworkForNic(vnicProfile *ovirtsdk.VnicProfile) kubevirtv1.Network {
+// getNetworkForNic returns the network of a NIC, and whether the NIC is connected to any network. The NICs whose vNIC
+// profile has no mapping are handled according to the unmapped network policy.
+func (o *OvirtMapper) getNetworkForNic(vnicProfile *ovirtsdk.VnicProfile) (kubevirtv1.Network, bool) {
 	kubevirtNet := kubevirtv1.Network{}
+	sriov := outils.IsSRIOV(vnicProfile)
+	nicMappings := o.getNetworkMappingsForNic(vnicProfile)
+	if len(nicMappings) == 0 {
+		unmappedNetworkMapping := mappings.UnmappedNetworkMapping(o.mappings.UnmappedNetworks)
+		if unmappedNetworkMapping == nil {
+			return kubevirtNet, false
+		}
+		nicMappings = append(nicMappings, *unmappedNetworkMapping)
+	}
+	for _, mapping := range nicMappings {
+		o.mapNetworkType(mapping, &kubevirtNet, sriov)
+	}
+	return kubevirtNet, true
+}
+
+// MapUnmappedNetworks returns what happens to the NICs whose vNIC profile has no mapping
+func (o *OvirtMapper) MapUnmappedNetworks() []v2vv1.UnmappedNetworkStatus {
+	var unmapped []v2vv1.UnmappedNetworkStatus
+	nics, ok := o.vm.Nics()
+	if !ok {
+		return unmapped
+	}
+	for _, nic := range nics.Slice() {
+		vnicProfile, ok := nic.VnicProfile()
+		if !ok || len(o.getNetworkMappingsForNic(vnicProfile)) > 0 {
+			continue
+		}
+		nicName, _ := nic.Name()
+		network, _ := vnicProfile.Network()
+		networkName, _ := network.Name()
+		vnicProfileName, _ := vnicProfile.Name()
+		status := mappings.NewUnmappedNetworkStatus(nicName, outils.GetNetworkMappingName(networkName, vnicProfileName), o.mappings.UnmappedNetworks)
+		if status.Action == v2vv1.UnmappedNetworkGenerate {
+			status.VLAN = getVLAN(network)
+		}
+		unmapped = append(unmapped, status)
+	}
+	return unmapped
+}
+
+// getVLAN returns the VLAN ID of a logical network, 0 when it isn't tagged
+func getVLAN(network *ovirtsdk.Network) *int32 {
+	var vlanID int32
+	if vlan, ok := network.Vlan(); ok {
+		if id, ok := vlan.Id(); ok {
+			vlanID = int32(id)
+		}
+	}
+	return &vlanID
+}
+
+// getNetworkMappingsForNic returns the network mappings matching the vNIC profile of a NIC, by name or by ID
+func (o *OvirtMapper) getNetworkMappingsForNic(vnicProfile *ovirtsdk.VnicProfile) []v2vv1.NetworkResourceMappingItem {
+	var mappings []v2vv1.NetworkResourceMappingItem
+	if o.mappings.NetworkMappings == nil {
+		return mappings
+	}
 	network, _ := vnicProfile.Network()
 	nicNetworkName, _ := network.Name()
 	vnicProfileName, _ := vnicProfile.Name()
-	sriov := outils.IsSRIOV(vnicProfile)
 	nicMappingName := outils.GetNetworkMappingName(nicNetworkName, vnicProfileName)
 	for _, mapping := range *o.mappings.NetworkMappings {
 		if mapping.Source.Name != nil && nicMappingName == *mapping.Source.Name {
-			o.mapNetworkType(mapping, &kubevirtNet, sriov)
+			mappings = append(mappings, mapping)
 		}
 		if mapping.Source.ID != nil {
 			if vnicProfileID, _ := vnicProfile.Id(); vnicProfileID == *mapping.Source.ID {
-				o.mapNetworkType(mapping, &kubevirtNet, sriov)
+				mappings = append(mappings, mapping)
+			}
+		}
+	}
+	return mappings
+}
+
+// MapMACAddresses returns the MAC address of each NIC of the source VM and the policy it is imported with
+func (o *OvirtMapper) MapMACAddresses(importPolicy v2vv1.MACPolicy) []macaddress.NIC {
+	var macs []macaddress.NIC
+	nics, ok := o.vm.Nics()
+	if !ok {
+		return macs
+	}
+	names := o.interfaceNames(nics.Slice())
+	for i, nic := range nics.Slice() {
+		vnicProfile, ok := nic.VnicProfile()
+		if !ok {
+			continue
+		}
+		mapped := macaddress.NIC{}
+		mapped.Name = names[i]
+		if nicMac, ok := nic.Mac(); ok {
+			mapped.MAC, _ = nicMac.Address()
+		}
+		var mappingPolicy *v2vv1.MACPolicy
+		for _, mapping := range o.getNetworkMappingsForNic(vnicProfile) {
+			if mapping.MACPolicy != nil {
+				mappingPolicy = mapping.MACPolicy
 			}
 		}
+		mapped.Policy = macaddress.ResolvePolicy(importPolicy, mappingPolicy)
+		macs = append(macs, mapped)
 	}
-	return kubevirtNet
+	return macs
 }
 
 func (o *OvirtMapper) mapNetworkType(mapping v2vv1.NetworkResourceMappingItem, kubevirtNet *kubevirtv1.Network, sriov bool) {
@@ -804,9 +1005,9 @@ func (o *OvirtMapper) mapTimeZone() *kubevirtv1.Clock {
 	return &clock
 }
 
-func getDiskAttachmentByID(id string, diskAttachments *ovirtsdk.DiskAttachmentSlice, targetVMName string) *ovirtsdk.DiskAttachment {
+func getDiskAttachmentByDataVolumeName(name string, diskAttachments *ovirtsdk.DiskAttachmentSlice, dvNames map[string]string) *ovirtsdk.DiskAttachment {
 	for _, diskAttachment := range diskAttachments.Slice() {
-		if diskID, ok := diskAttachment.Id(); ok && buildDataVolumeName(targetVMName, diskID) == id {
+		if diskID, ok := diskAttachment.Id(); ok && dvNames[diskID] == name {
 			return diskAttachment
 		}
 	}
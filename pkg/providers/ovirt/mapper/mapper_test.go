@@ -55,7 +55,7 @@ var _ = Describe("Test mapping virtual machine bios type", func() {
 			ConfigMapName: "config-map",
 		}
 		namespace := "the-namespace"
-		mapper := mapper.NewOvirtMapper(vm, &mappings, credentials, namespace, &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, credentials, &osFinder, false, newImport(namespace, v2vv1.VirtualMachineImportSpec{}))
 		vmSpec, _ := mapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 
 		Expect(vmSpec.Spec.Template.Spec.Domain.Features).ToNot(BeNil())
@@ -82,7 +82,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 	BeforeEach(func() {
 		vm = createVM()
 		mappings = createMappings()
-		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, "", &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
 
 		findOs = func(vm *ovirtsdk.Vm) (string, error) {
 			return "linux", nil
@@ -107,7 +107,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 		vm = createVM()
 		vm.SetCustomEmulatedMachine("pc-i440fx-rhel7.6.0")
 
-		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, "", &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
 		vmSpec, _ = mapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 
 		Expect(vmSpec.Spec.Template.Spec.Domain.Machine.Type).To(Equal("q35"))
@@ -120,7 +120,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 				VcpuPinsOfAny(
 					ovirtsdk.NewVcpuPinBuilder().CpuSet("0").Vcpu(0).MustBuild()).
 				MustBuild())
-		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, "", &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
 		vmSpec, _ = mapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 
 		vmSpecCPU := vmSpec.Spec.Template.Spec.Domain.CPU
@@ -135,7 +135,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 		vm = createVM()
 		vm.SetFqdn(fqdn)
 
-		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, "", &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
 		vmSpec, _ = mapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 
 		Expect(vmSpec.Spec.Template.Spec.Hostname).To(Equal(norm))
@@ -180,7 +180,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 		findOs = func(vm *ovirtsdk.Vm) (string, error) {
 			return "Win2k19", nil
 		}
-		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, "", &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
 		vmSpec, _ := mapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 
 		devices := vmSpec.Spec.Template.Spec.Domain.Devices
@@ -210,7 +210,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 		vm = createVM()
 		vm.SetTimeZone(ovirtsdk.NewTimeZoneBuilder().
 			Name("Etc/GMT").MustBuild())
-		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, "", &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
 		vmSpec, _ = mapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 
 		clock := vmSpec.Spec.Template.Spec.Domain.Clock
@@ -227,7 +227,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 		vm.SetCluster(
 			ovirtsdk.NewClusterBuilder().BiosType(ovirtsdk.BIOSTYPE_Q35_SEA_BIOS).MustBuild())
 
-		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, "", &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
 		vmSpec, _ = mapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 
 		Expect(vmSpec.Spec.Template.Spec.Domain.Firmware.Bootloader.BIOS).To(Equal(&kubevirtv1.BIOS{}))
@@ -242,7 +242,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 		vm.SetCluster(
 			ovirtsdk.NewClusterBuilder().BiosType(ovirtsdk.BIOSTYPE_Q35_OVMF).MustBuild())
 
-		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, "", &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
 		vmSpec, _ = mapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 
 		Expect(vmSpec.Spec.Template.Spec.Domain.Features.SMM.Enabled).To(Equal(&_true))
@@ -252,7 +252,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 		vm = createVM()
 		vm.SetTimeZone(ovirtsdk.NewTimeZoneBuilder().
 			UtcOffset("illegal").MustBuild())
-		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, "", &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
 		vmSpec, _ = mapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 
 		clock := vmSpec.Spec.Template.Spec.Domain.Clock
@@ -264,7 +264,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 	It("should create UTC clock when no clock in source VM", func() {
 		vm = createVM()
 		vm.SetTimeZone(nil)
-		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, "", &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
 		vmSpec, _ = mapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 
 		clock := vmSpec.Spec.Template.Spec.Domain.Clock
@@ -336,7 +336,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 		}
 		slice.SetSlice(nics)
 		vm.SetNics(slice)
-		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, "", &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
 		vmSpec, _ = mapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 
 		interfaces := vmSpec.Spec.Template.Spec.Domain.Devices.Interfaces
@@ -350,6 +350,74 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 
 		Expect(networks[0].Multus.NetworkName).To(Equal(vmSpec.Namespace + "/" + networkMapping[0].Target.Name))
 	})
+
+	It("should drop the NICs whose network has no mapping", func() {
+		mappings.NetworkMappings = &[]v2vv1.NetworkResourceMappingItem{(*mappings.NetworkMappings)[0]}
+		mappings.UnmappedNetworks = &v2vv1.UnmappedNetworkPolicy{Action: v2vv1.UnmappedNetworkDrop}
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
+		vmSpec, _ = mapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
+
+		Expect(vmSpec.Spec.Template.Spec.Networks).To(HaveLen(1))
+		Expect(vmSpec.Spec.Template.Spec.Domain.Devices.Interfaces).To(HaveLen(1))
+		Expect(vmSpec.Spec.Template.Spec.Domain.Devices.Interfaces[0].Name).To(Equal("nic1"))
+		Expect(mapper.MapUnmappedNetworks()).To(ConsistOf(v2vv1.UnmappedNetworkStatus{
+			NIC:     "nic2",
+			Network: "network2/profile2",
+			Action:  v2vv1.UnmappedNetworkDrop,
+		}))
+	})
+
+	It("should connect the NICs whose network has no mapping to the default network", func() {
+		mappings.NetworkMappings = &[]v2vv1.NetworkResourceMappingItem{(*mappings.NetworkMappings)[1]}
+		target := v2vv1.ObjectIdentifier{Name: "default-net"}
+		mappings.UnmappedNetworks = &v2vv1.UnmappedNetworkPolicy{Action: v2vv1.UnmappedNetworkMultus, Target: &target}
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
+		vmSpec, _ = mapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
+
+		networks := vmSpec.Spec.Template.Spec.Networks
+		interfaces := vmSpec.Spec.Template.Spec.Domain.Devices.Interfaces
+		Expect(networks).To(HaveLen(2))
+		Expect(networks[0].Multus.NetworkName).To(Equal("default-net"))
+		Expect(interfaces[0].Bridge).To(Not(BeNil()))
+		Expect(networks[1].Pod).To(Not(BeNil()))
+		Expect(mapper.MapUnmappedNetworks()[0].Target).To(Equal(&target))
+	})
+
+	It("should record the VLAN of the networks to generate", func() {
+		vm = createVM()
+		network, _ := vm.MustNics().Slice()[1].MustVnicProfile().Network()
+		network.SetVlan(ovirtsdk.NewVlanBuilder().Id(100).MustBuild())
+		mappings.NetworkMappings = &[]v2vv1.NetworkResourceMappingItem{(*mappings.NetworkMappings)[0]}
+		mappings.UnmappedNetworks = &v2vv1.UnmappedNetworkPolicy{Action: v2vv1.UnmappedNetworkGenerate}
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
+		vmSpec, _ = mapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
+
+		vlan := int32(100)
+		Expect(vmSpec.Spec.Template.Spec.Domain.Devices.Interfaces).To(HaveLen(1))
+		Expect(mapper.MapUnmappedNetworks()).To(ConsistOf(v2vv1.UnmappedNetworkStatus{
+			NIC:     "nic2",
+			Network: "network2/profile2",
+			Action:  v2vv1.UnmappedNetworkGenerate,
+			VLAN:    &vlan,
+		}))
+	})
+
+	It("should map MAC addresses with their policy", func() {
+		vm = createVM()
+		vm.MustNics().Slice()[0].SetMac(ovirtsdk.NewMacBuilder().Address("56:6f:05:0f:00:05").MustBuild())
+		regenerate := v2vv1.MACPolicyRegenerate
+		(*mappings.NetworkMappings)[1].MACPolicy = &regenerate
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
+
+		nics := mapper.MapMACAddresses(v2vv1.MACPolicyPreserveIfUnique)
+
+		Expect(nics).To(HaveLen(2))
+		Expect(nics[0].Name).To(Equal("nic1"))
+		Expect(nics[0].MAC).To(Equal("56:6f:05:0f:00:05"))
+		Expect(nics[0].Policy).To(Equal(v2vv1.MACPolicyPreserveIfUnique))
+		Expect(nics[1].Name).To(Equal("nic2"))
+		Expect(nics[1].Policy).To(Equal(v2vv1.MACPolicyRegenerate))
+	})
 })
 
 var _ = Describe("Test pvc accessmodes", func() {
@@ -363,7 +431,7 @@ var _ = Describe("Test pvc accessmodes", func() {
 			ConfigMapName: "config-map",
 		}
 		namespace := "the-namespace"
-		mapper := mapper.NewOvirtMapper(vm, &mappings, credentials, namespace, &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, credentials, &osFinder, false, newImport(namespace, v2vv1.VirtualMachineImportSpec{}))
 		daName := expectedDVName
 		dvs, _ := mapper.MapDataVolumes(&targetVMName, filesystemOverhead)
 
@@ -403,7 +471,7 @@ var _ = Describe("Test mapping disks", func() {
 			ConfigMapName: "config-map",
 		}
 		namespace := "the-namespace"
-		mapper := mapper.NewOvirtMapper(vm, &mappings, credentials, namespace, &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, credentials, &osFinder, false, newImport(namespace, v2vv1.VirtualMachineImportSpec{}))
 		daName := expectedDVName
 		dvs, _ := mapper.MapDataVolumes(&targetVMName, filesystemOverhead)
 
@@ -420,6 +488,8 @@ var _ = Describe("Test mapping disks", func() {
 		Expect(imageio.CertConfigMap).To(Equal(credentials.ConfigMapName))
 		Expect(imageio.SecretRef).To(Equal(credentials.SecretName))
 		Expect(imageio.DiskID).To(Equal("disk-ID"))
+		Expect(dv.Annotations).To(HaveKeyWithValue(utils.SourceDiskIDAnnotation, "disk-ID"))
+		Expect(dv.Annotations).To(HaveKeyWithValue(utils.SourceDiskNameAnnotation, "mydisk"))
 
 		Expect(dv.Spec.PVC.AccessModes).To(ContainElement(corev1.ReadWriteMany))
 		Expect(dv.Spec.PVC.AccessModes).To(HaveLen(1))
@@ -439,7 +509,7 @@ var _ = Describe("Test mapping disks", func() {
 			ConfigMapName: "config-map",
 		}
 		namespace := "the-namespace"
-		mapper := mapper.NewOvirtMapper(vm, &mappings, credentials, namespace, &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, credentials, &osFinder, false, newImport(namespace, v2vv1.VirtualMachineImportSpec{}))
 		daName := expectedDVName
 
 		// request 100% overhead, resulting in a disk of twice the size.
@@ -469,7 +539,7 @@ var _ = Describe("Test mapping disks", func() {
 			ConfigMapName: "config-map",
 		}
 		namespace := "the-namespace"
-		mapper := mapper.NewOvirtMapper(vm, &mappings, credentials, namespace, &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, credentials, &osFinder, false, newImport(namespace, v2vv1.VirtualMachineImportSpec{}))
 		daName := expectedDVName
 		scName := "storageclassname"
 		// request 100% overhead for the storage class, resulting in a disk of twice the size.
@@ -513,7 +583,7 @@ var _ = Describe("Test mapping disks", func() {
 			DiskMappings:    &disks,
 			StorageMappings: &[]v2vv1.StorageResourceMappingItem{},
 		}
-		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, "", &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
 
 		dvs, _ := mapper.MapDataVolumes(&targetVMName, filesystemOverhead)
 
@@ -542,7 +612,7 @@ var _ = Describe("Test mapping disks", func() {
 			DiskMappings:    &disks,
 			StorageMappings: &[]v2vv1.StorageResourceMappingItem{},
 		}
-		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, "", &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
 
 		dvs, err := mapper.MapDataVolumes(&targetVMName, filesystemOverhead)
 
@@ -568,7 +638,7 @@ var _ = Describe("Test mapping disks", func() {
 			DiskMappings:    &[]v2vv1.StorageResourceMappingItem{},
 			StorageMappings: &domains,
 		}
-		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, "", &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
 
 		dvs, err := mapper.MapDataVolumes(&targetVMName, filesystemOverhead)
 
@@ -581,7 +651,7 @@ var _ = Describe("Test mapping disks", func() {
 			DiskMappings:    &[]v2vv1.StorageResourceMappingItem{},
 			StorageMappings: &[]v2vv1.StorageResourceMappingItem{},
 		}
-		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, "", &osFinder)
+		mapper := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
 
 		dvs, err := mapper.MapDataVolumes(&targetVMName, filesystemOverhead)
 
@@ -616,7 +686,7 @@ var _ = Describe("Test mapping disks", func() {
 			StorageMappings: &[]v2vv1.StorageResourceMappingItem{},
 		}
 
-		mapper_ := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, "", &osFinder)
+		mapper_ := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{}))
 		dvs, _ := mapper_.MapDataVolumes(&targetVMName, filesystemOverhead)
 		mapper_.MapDisk(vmSpec, dvs[expectedDVName])
 		Expect(vmSpec.Spec.Template.Spec.Domain.Devices.Disks[0].Disk.Bus).To(Equal(mapper.DiskInterfaceModelMapping[string(diskInterface)]))
@@ -625,6 +695,109 @@ var _ = Describe("Test mapping disks", func() {
 		table.Entry("sata to sata", ovirtsdk.DISKINTERFACE_SATA),
 		table.Entry("virtio_scsi to scsi", ovirtsdk.DISKINTERFACE_VIRTIO_SCSI),
 	)
+
+	It("should map disks and nics to virtio when the guest is converted", func() {
+		vm := createVMGeneric(ovirtsdk.VMAFFINITY_MIGRATABLE, false, ovirtsdk.BIOSTYPE_Q35_SEA_BIOS, ovirtsdk.DISKINTERFACE_SATA)
+		vm.MustNics().Slice()[0].SetInterface(ovirtsdk.NICINTERFACE_E1000)
+		mappings := createMappings()
+		findOs = func(vm *ovirtsdk.Vm) (string, error) {
+			return "linux", nil
+		}
+
+		mapper_ := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, true, newImport("", v2vv1.VirtualMachineImportSpec{}))
+		vmSpec, err := mapper_.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
+		Expect(err).To(BeNil())
+		dvs, _ := mapper_.MapDataVolumes(&targetVMName, filesystemOverhead)
+		mapper_.MapDisk(vmSpec, dvs[expectedDVName])
+
+		Expect(vmSpec.Spec.Template.Spec.Domain.Devices.Disks[0].Disk.Bus).To(Equal("virtio"))
+		for _, iface := range vmSpec.Spec.Template.Spec.Domain.Devices.Interfaces {
+			Expect(iface.Model).To(Equal("virtio"))
+		}
+	})
+
+	It("should attach the customization data", func() {
+		vm := createVM()
+		mappings := createMappings()
+		customization := &v2vv1.CustomizationSpec{
+			CloudInit: &v2vv1.CloudInitCustomization{UserDataSecretRef: &corev1.LocalObjectReference{Name: "user-data"}},
+		}
+
+		mapper_ := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{Customization: customization}))
+		vmSpec, err := mapper_.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
+		Expect(err).To(BeNil())
+
+		Expect(vmSpec.Spec.Template.Spec.Volumes).To(HaveLen(1))
+		Expect(vmSpec.Spec.Template.Spec.Volumes[0].CloudInitNoCloud.UserDataSecretRef.Name).To(Equal("user-data"))
+		Expect(vmSpec.Spec.Template.Spec.Domain.Devices.Disks).To(HaveLen(1))
+		Expect(vmSpec.Spec.Template.Spec.Domain.Devices.Disks[0].CDRom).ToNot(BeNil())
+	})
+
+	It("should name the data volumes with the naming template", func() {
+		vm := createVM()
+		mappings := createMappings()
+		dvTemplate := "{{.VMName}}-{{.DiskName}}-{{.Index}}"
+		vmSpec := &kubevirtv1.VirtualMachine{ObjectMeta: v1.ObjectMeta{Name: targetVMName}}
+		vmSpec.Spec.Template = &kubevirtv1.VirtualMachineInstanceTemplateSpec{}
+
+		mapper_ := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{Naming: &v2vv1.NamingSpec{DataVolume: &dvTemplate}}))
+		dvs, err := mapper_.MapDataVolumes(&targetVMName, filesystemOverhead)
+		Expect(err).To(BeNil())
+		Expect(dvs).To(HaveKey("myvm-mydisk-0"))
+		mapper_.MapDisk(vmSpec, dvs["myvm-mydisk-0"])
+
+		Expect(vmSpec.Spec.Template.Spec.Volumes[0].DataVolume.Name).To(Equal("myvm-mydisk-0"))
+		Expect(vmSpec.Spec.Template.Spec.Domain.Devices.Disks[0].Name).To(Equal("dv-myvm-mydisk-0"))
+	})
+
+	It("should name the interfaces and networks with the naming template", func() {
+		vm := createVM()
+		mappings := createMappings()
+		interfaceTemplate := "net{{.Index}}"
+
+		mapper_ := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{Naming: &v2vv1.NamingSpec{Interface: &interfaceTemplate}}))
+		vmSpec, err := mapper_.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
+		Expect(err).To(BeNil())
+
+		interfaces := vmSpec.Spec.Template.Spec.Domain.Devices.Interfaces
+		Expect(interfaces).To(HaveLen(2))
+		Expect(interfaces[0].Name).To(Equal("net0"))
+		Expect(interfaces[1].Name).To(Equal("net1"))
+		networks := vmSpec.Spec.Template.Spec.Networks
+		Expect(networks).To(HaveLen(2))
+		Expect(networks[0].Name).To(Equal("net0"))
+		Expect(networks[0].Multus).ToNot(BeNil())
+		Expect(networks[1].Name).To(Equal("net1"))
+		Expect(networks[1].Pod).ToNot(BeNil())
+		macs := mapper_.MapMACAddresses(v2vv1.MACPolicyPreserve)
+		Expect(macs[0].Name).To(Equal("net0"))
+		Expect(macs[1].Name).To(Equal("net1"))
+	})
+
+	It("should fail to map the data volumes when the naming template yields no name", func() {
+		vm := createVM()
+		mappings := createMappings()
+		dvTemplate := "{{.DiskID}}"
+		vm.MustDiskAttachments().Slice()[0].SetId("---")
+
+		mapper_ := mapper.NewOvirtMapper(vm, &mappings, mapper.DataVolumeCredentials{}, &osFinder, false, newImport("", v2vv1.VirtualMachineImportSpec{Naming: &v2vv1.NamingSpec{DataVolume: &dvTemplate}}))
+		_, err := mapper_.MapDataVolumes(&targetVMName, filesystemOverhead)
+
+		Expect(err).ToNot(BeNil())
+	})
+
+	table.DescribeTable("should detect non-virtio devices: ", func(diskInterface ovirtsdk.DiskInterface, nicInterface ovirtsdk.NicInterface, expected bool) {
+		vm := createVMGeneric(ovirtsdk.VMAFFINITY_MIGRATABLE, false, ovirtsdk.BIOSTYPE_Q35_SEA_BIOS, diskInterface)
+		vm.MustNics().Slice()[1].SetInterface(nicInterface)
+
+		Expect(mapper.UsesNonVirtioDevices(vm)).To(Equal(expected))
+	},
+		table.Entry("virtio disk and nic", ovirtsdk.DISKINTERFACE_VIRTIO, ovirtsdk.NICINTERFACE_VIRTIO, false),
+		table.Entry("virtio_scsi disk", ovirtsdk.DISKINTERFACE_VIRTIO_SCSI, ovirtsdk.NICINTERFACE_VIRTIO, false),
+		table.Entry("sata disk", ovirtsdk.DISKINTERFACE_SATA, ovirtsdk.NICINTERFACE_VIRTIO, true),
+		table.Entry("e1000 nic", ovirtsdk.DISKINTERFACE_VIRTIO, ovirtsdk.NICINTERFACE_E1000, true),
+		table.Entry("rtl8139 nic", ovirtsdk.DISKINTERFACE_VIRTIO, ovirtsdk.NICINTERFACE_RTL8139, true),
+	)
 })
 
 func createVM() *ovirtsdk.Vm {
@@ -773,3 +946,10 @@ type mockOsFinder struct{}
 func (o *mockOsFinder) FindOperatingSystem(vm *ovirtsdk.Vm) (string, error) {
 	return findOs(vm)
 }
+
+func newImport(namespace string, spec v2vv1.VirtualMachineImportSpec) *v2vv1.VirtualMachineImport {
+	return &v2vv1.VirtualMachineImport{
+		ObjectMeta: v1.ObjectMeta{Namespace: namespace},
+		Spec:       spec,
+	}
+}
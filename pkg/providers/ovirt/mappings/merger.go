@@ -19,9 +19,10 @@ func MergeMappings(externalMappingSpec *v2vv1.ResourceMappingSpec, vmiMapping *v
 	diskMappings := primaryMappings.DiskMappings
 
 	ovirtMappings := v2vv1.OvirtMappings{
-		NetworkMappings: networkMappings,
-		StorageMappings: storageMappings,
-		DiskMappings:    diskMappings,
+		NetworkMappings:  networkMappings,
+		StorageMappings:  storageMappings,
+		DiskMappings:     diskMappings,
+		UnmappedNetworks: mappings.MergeUnmappedNetworkPolicy(primaryMappings.UnmappedNetworks, secondaryMappings.UnmappedNetworks),
 	}
 	return &ovirtMappings
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	"github.com/kubevirt/vm-import-operator/pkg/os"
 
 	ovirtsdk "github.com/ovirt/go-ovirt"
@@ -23,10 +24,15 @@ type OSFinder interface {
 // OVirtOSFinder provides oVirt VM OS information
 type OVirtOSFinder struct {
 	OsMapProvider os.OSMapProvider
+	// Inspection of the guest, if any, takes precedence over the OS information of the VM
+	Inspection *v2vv1.GuestInspectionStatus
 }
 
 // FindOperatingSystem tries to find operating system name of the given oVirt VM
 func (o *OVirtOSFinder) FindOperatingSystem(vm *ovirtsdk.Vm) (string, error) {
+	if inspected, found := os.InspectedOperatingSystem(o.Inspection); found {
+		return inspected, nil
+	}
 	guestOsToCommon, osInfoToCommon, err := o.OsMapProvider.GetOSMaps()
 	if err != nil {
 		return "", err
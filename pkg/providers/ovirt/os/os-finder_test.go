@@ -3,6 +3,7 @@ package os_test
 import (
 	"fmt"
 
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	"github.com/kubevirt/vm-import-operator/pkg/providers/ovirt/os"
 
 	. "github.com/onsi/ginkgo"
@@ -40,6 +41,24 @@ var _ = Describe("OS finder ", func() {
 		Expect(os).To(BeEquivalentTo("rhel7.7"))
 	})
 
+	It("should prefer the OS found by the inspection of the guest", func() {
+		vm := ovirtsdk.NewVmBuilder().
+			GuestOperatingSystemBuilder(
+				ovirtsdk.NewGuestOperatingSystemBuilder().
+					Distribution("Red Hat Enterprise Linux Server").
+					VersionBuilder(ovirtsdk.NewVersionBuilder().FullVersion("7.7"))).
+			MustBuild()
+		inspectedFinder := os.OVirtOSFinder{
+			OsMapProvider: &mockOsMapProvider{},
+			Inspection:    &v2vv1.GuestInspectionStatus{OperatingSystem: "rhel7.8"},
+		}
+
+		os, err := inspectedFinder.FindOperatingSystem(vm)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os).To(BeEquivalentTo("rhel7.8"))
+	})
+
 	It("should find OS from OS type and mapping present", func() {
 		vm := ovirtsdk.NewVmBuilder().
 			OsBuilder(
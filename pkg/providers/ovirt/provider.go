@@ -1,9 +1,10 @@
 package ovirtprovider
 
 import (
+	"context"
+	"encoding/xml"
 	"errors"
 	"fmt"
-	"strings"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
@@ -23,7 +24,11 @@ import (
 	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	pclient "github.com/kubevirt/vm-import-operator/pkg/client"
 	"github.com/kubevirt/vm-import-operator/pkg/configmaps"
+	"github.com/kubevirt/vm-import-operator/pkg/customization"
 	"github.com/kubevirt/vm-import-operator/pkg/datavolumes"
+	"github.com/kubevirt/vm-import-operator/pkg/guestconversion"
+	"github.com/kubevirt/vm-import-operator/pkg/pods"
+	"github.com/kubevirt/vm-import-operator/pkg/propagation"
 	provider "github.com/kubevirt/vm-import-operator/pkg/providers"
 	"github.com/kubevirt/vm-import-operator/pkg/providers/ovirt/mapper"
 	"github.com/kubevirt/vm-import-operator/pkg/providers/ovirt/mappings"
@@ -32,6 +37,7 @@ import (
 	"github.com/kubevirt/vm-import-operator/pkg/providers/ovirt/validation/validators"
 	"github.com/kubevirt/vm-import-operator/pkg/secrets"
 	templates "github.com/kubevirt/vm-import-operator/pkg/templates"
+	"github.com/kubevirt/vm-import-operator/pkg/tracing"
 	"github.com/kubevirt/vm-import-operator/pkg/utils"
 	"github.com/kubevirt/vm-import-operator/pkg/virtualmachines"
 	templatev1 "github.com/openshift/api/template/v1"
@@ -51,6 +57,8 @@ const (
 	keyAccessKey   = "accessKeyId"
 	keySecretKey   = "secretKey"
 	diskNameFormat = "disk-%v"
+	// libvirtDomainKey is the config map key the guest conversion pod reads the libvirt domain from
+	libvirtDomainKey = "input.xml"
 )
 
 var (
@@ -68,13 +76,14 @@ type OvirtProvider struct {
 	vmiObjectMeta         metav1.ObjectMeta
 	vmiTypeMeta           metav1.TypeMeta
 	resourceMapping       *v2vv1.OvirtMappings
-	osFinder              oos.OSFinder
+	osFinder              *oos.OVirtOSFinder
 	templateFinder        *otemplates.TemplateFinder
 	templateHandler       *templates.TemplateHandler
 	secretsManager        provider.SecretsManager
 	configMapsManager     provider.ConfigMapsManager
 	datavolumesManager    provider.DataVolumesManager
 	virtualMachineManager provider.VirtualMachineManager
+	podsManager           provider.PodsManager
 	factory               pclient.Factory
 	instance              *v2vv1.VirtualMachineImport
 }
@@ -86,19 +95,23 @@ func NewOvirtProvider(vmiObjectMeta metav1.ObjectMeta, vmiTypeMeta metav1.TypeMe
 	configMapsManager := configmaps.NewManager(client)
 	datavolumesManager := datavolumes.NewManager(client)
 	virtualMachineManager := virtualmachines.NewManager(client)
-	templateProvider := templates.NewTemplateProvider(tempClient)
+	podsManager := pods.NewManager(client)
+	templateProvider := templates.NewTemplateProviderFor(ctrlConfig.TemplatesBackend(), client, tempClient)
 	osFinder := oos.OVirtOSFinder{OsMapProvider: os.NewOSMapProvider(client, ctrlConfig.OsConfigMapName(), ctrlConfig.OsConfigMapNamespace())}
+	templateFinder := otemplates.NewTemplateFinder(templateProvider, &osFinder)
+	templateFinder.Namespace = ctrlConfig.TemplatesNamespace()
 	return OvirtProvider{
 		vmiObjectMeta:         vmiObjectMeta,
 		vmiTypeMeta:           vmiTypeMeta,
 		validator:             validation.NewVirtualMachineImportValidator(validator),
 		osFinder:              &osFinder,
-		templateFinder:        otemplates.NewTemplateFinder(templateProvider, &osFinder),
+		templateFinder:        templateFinder,
 		templateHandler:       templates.NewTemplateHandler(templateProvider),
 		secretsManager:        &secretsManager,
 		configMapsManager:     &configMapsManager,
 		datavolumesManager:    &datavolumesManager,
 		virtualMachineManager: &virtualMachineManager,
+		podsManager:           &podsManager,
 		factory:               factory,
 	}
 }
@@ -152,16 +165,27 @@ func (o *OvirtProvider) Init(secret *corev1.Secret, instance *v2vv1.VirtualMachi
 		return fmt.Errorf("oVirt secret caCert cannot be empty")
 	}
 	o.instance = instance
+	if o.templateFinder != nil {
+		o.templateFinder.Spec = instance.Spec.Template
+	}
+	o.setInspection(instance.Status.GuestInspection)
 	return nil
 }
 
+// setInspection feeds the inspection of the guest to the OS finder, shared by the template finder and the mapper
+func (o *OvirtProvider) setInspection(inspection *v2vv1.GuestInspectionStatus) {
+	if o.osFinder != nil {
+		o.osFinder.Inspection = inspection
+	}
+}
+
 // TestConnection tests the connection to ovirt provider
 func (o *OvirtProvider) TestConnection() error {
 	client, err := o.getClient()
 	if err != nil {
 		return err
 	}
-	err = client.TestConnection()
+	err = client.TestConnection(o.traceContext())
 	if err != nil {
 		return err
 	}
@@ -179,6 +203,14 @@ func (o *OvirtProvider) getClient() (pclient.VMClient, error) {
 	return o.ovirtClient, nil
 }
 
+// traceContext returns the context the spans of the calls to oVirt are started from
+func (o *OvirtProvider) traceContext() context.Context {
+	if o.instance == nil {
+		return context.Background()
+	}
+	return tracing.ImportContext(o.instance.UID)
+}
+
 func (o *OvirtProvider) getVM() (*ovirtsdk.Vm, error) {
 	if o.vm == nil {
 		err := o.LoadVM(o.instance.Spec.Source)
@@ -221,7 +253,7 @@ func (o *OvirtProvider) LoadVM(sourceSpec v2vv1.VirtualMachineImportSourceSpec)
 	if err != nil {
 		return err
 	}
-	vm, err := client.GetVM(sourceVMID, sourceVMName, sourceVMClusterName, sourceVMClusterID)
+	vm, err := client.GetVM(o.traceContext(), sourceVMID, sourceVMName, sourceVMClusterName, sourceVMClusterID)
 	if err != nil {
 		return err
 	}
@@ -235,7 +267,7 @@ func (o *OvirtProvider) PrepareResourceMapping(externalResourceMapping *v2vv1.Re
 }
 
 // ValidateDiskStatus validate current status of the disk in oVirt env:
-func (o *OvirtProvider) ValidateDiskStatus(diskName string) (bool, error) {
+func (o *OvirtProvider) ValidateDiskStatus(diskID string) (bool, error) {
 	// Refresh cached VM data:
 	err := o.LoadVM(o.instance.Spec.Source)
 	if err != nil {
@@ -244,10 +276,10 @@ func (o *OvirtProvider) ValidateDiskStatus(diskName string) (bool, error) {
 
 	// Find the disk by ID and validate the status:
 	if diskAttachments, ok := o.vm.DiskAttachments(); ok {
-		for _, disk := range diskAttachments.Slice() {
-			if diskID, ok := disk.Id(); ok {
-				if strings.Contains(diskName, diskID) {
-					return o.validator.Validator.ValidateDiskStatus(*disk), nil
+		for _, diskAttachment := range diskAttachments.Slice() {
+			if disk, ok := diskAttachment.Disk(); ok {
+				if id, ok := disk.Id(); ok && id == diskID {
+					return o.validator.Validator.ValidateDiskStatus(*diskAttachment), nil
 				}
 			}
 		}
@@ -265,7 +297,8 @@ func (o *OvirtProvider) Validate() ([]v2vv1.VirtualMachineImportCondition, error
 	if vm == nil {
 		return []v2vv1.VirtualMachineImportCondition{}, errors.New("VM has not been loaded")
 	}
-	vmiName := o.GetVmiNamespacedName()
+	// The network mappings without a namespace refer to the target namespace
+	vmiName := utils.TargetNamespacedName(o.instance)
 	return o.validator.Validate(vm, &vmiName, o.resourceMapping, o.templateFinder), nil
 }
 
@@ -284,7 +317,7 @@ func (o *OvirtProvider) StopVM(instance *v2vv1.VirtualMachineImport, rclient rcl
 	if err != nil {
 		return err
 	}
-	err = client.StopVM(vmID)
+	err = client.StopVM(o.traceContext(), vmID)
 	if err != nil {
 		return err
 	}
@@ -295,11 +328,11 @@ func (o *OvirtProvider) StopVM(instance *v2vv1.VirtualMachineImport, rclient rcl
 	return nil
 }
 
-// FindTemplate attempts to find best match for a template based on the source VM
-func (o *OvirtProvider) FindTemplate() (*templatev1.Template, error) {
+// FindTemplate attempts to find best match for a template based on the source VM, and returns why it was chosen
+func (o *OvirtProvider) FindTemplate() (*templatev1.Template, string, error) {
 	vm, err := o.getVM()
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	return o.templateFinder.FindTemplate(vm)
 }
@@ -323,22 +356,57 @@ func (o *OvirtProvider) ProcessTemplate(template *templatev1.Template, vmName *s
 	return vm, nil
 }
 
+// UpdateOperatingSystem relabels the VM after the operating system found by the inspection of its guest, using the
+// metadata of the template matching that operating system
+func (o *OvirtProvider) UpdateOperatingSystem(vm *kubevirtv1.VirtualMachine, inspection *v2vv1.GuestInspectionStatus) error {
+	o.setInspection(inspection)
+	sourceVM, err := o.getVM()
+	if err != nil {
+		return err
+	}
+	template, _, err := o.templateFinder.FindTemplate(sourceVM)
+	if err != nil {
+		return err
+	}
+	labels, annotations, err := o.templateFinder.GetMetadata(template, sourceVM)
+	if err != nil {
+		return err
+	}
+	templates.UpdateMetadata(vm, template, labels, annotations)
+	return nil
+}
+
 // GetVmiNamespacedName return the namespaced name of the VM import object
 func (o *OvirtProvider) GetVmiNamespacedName() types.NamespacedName {
 	return types.NamespacedName{Name: o.vmiObjectMeta.Name, Namespace: o.vmiObjectMeta.Namespace}
 }
 
-// CreateMapper create the mapper for ovirt provider
+// CreateMapper create the mapper for ovirt provider, along with the credentials its data volumes read in the target
+// namespace
 func (o *OvirtProvider) CreateMapper() (provider.Mapper, error) {
 	credentials, err := o.prepareDataVolumeCredentials()
 	if err != nil {
 		return nil, err
 	}
+	return o.createMapper(credentials)
+}
+
+// CreateValidationMapper creates the mapper validating the import. The credentials of the data volumes are left out,
+// so they aren't copied into the target namespace of an import that may be blocked.
+func (o *OvirtProvider) CreateValidationMapper() (provider.Mapper, error) {
+	return o.createMapper(mapper.DataVolumeCredentials{})
+}
+
+func (o *OvirtProvider) createMapper(credentials mapper.DataVolumeCredentials) (provider.Mapper, error) {
 	vm, err := o.getVM()
 	if err != nil {
 		return nil, err
 	}
-	return mapper.NewOvirtMapper(vm, o.resourceMapping, credentials, o.vmiObjectMeta.Namespace, o.osFinder), nil
+	needsGuestConversion, err := o.NeedsGuestConversion()
+	if err != nil {
+		return nil, err
+	}
+	return mapper.NewOvirtMapper(vm, o.resourceMapping, credentials, o.osFinder, needsGuestConversion, o.instance), nil
 }
 
 // StartVM starts the source VM
@@ -352,14 +420,89 @@ func (o *OvirtProvider) StartVM() error {
 		if err != nil {
 			return err
 		}
-		err = client.StartVM(id)
+		err = client.StartVM(o.traceContext(), id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteVM removes the source VM
+func (o *OvirtProvider) DeleteVM() error {
+	vm, err := o.getVM()
+	if err != nil {
+		return err
+	}
+	if id, ok := vm.Id(); ok {
+		client, err := o.getClient()
+		if err != nil {
+			return err
+		}
+		return client.DeleteVM(o.traceContext(), id)
+	}
+	return nil
+}
+
+// MarkVMMigrated tags the source VM and notes in its description that it was migrated to the target
+func (o *OvirtProvider) MarkVMMigrated(target string) error {
+	vm, err := o.getVM()
+	if err != nil {
+		return err
+	}
+	if id, ok := vm.Id(); ok {
+		client, err := o.getClient()
+		if err != nil {
+			return err
+		}
+		return client.MarkVMMigrated(o.traceContext(), id, target)
+	}
+	return nil
+}
+
+// UnmarkVMMigrated removes the tag of MarkVMMigrated from the source VM and notes in its description that the migration
+// was rolled back
+func (o *OvirtProvider) UnmarkVMMigrated(target string) error {
+	vm, err := o.getVM()
+	if err != nil {
+		return err
+	}
+	if id, ok := vm.Id(); ok {
+		client, err := o.getClient()
 		if err != nil {
 			return err
 		}
+		return client.UnmarkVMMigrated(o.traceContext(), id, target)
 	}
 	return nil
 }
 
+// GetSourceMetadata provides the tags and the custom properties of the source VM. oVirt VMs have no folder.
+func (o *OvirtProvider) GetSourceMetadata() (*propagation.SourceMetadata, error) {
+	vm, err := o.getVM()
+	if err != nil {
+		return nil, err
+	}
+	metadata := &propagation.SourceMetadata{
+		CustomAttributes: make(map[string]string),
+	}
+	if tags, ok := vm.Tags(); ok {
+		for _, tag := range tags.Slice() {
+			if name, ok := tag.Name(); ok {
+				metadata.Tags = append(metadata.Tags, name)
+			}
+		}
+	}
+	if properties, ok := vm.CustomProperties(); ok {
+		for _, property := range properties.Slice() {
+			name, _ := property.Name()
+			value, _ := property.Value()
+			metadata.CustomAttributes[name] = value
+		}
+	}
+	return metadata, nil
+}
+
 // CleanUp removes transient resources created for import
 func (o *OvirtProvider) CleanUp(failure bool, cr *v2vv1.VirtualMachineImport, client rclient.Client) error {
 	var errs []error
@@ -370,16 +513,25 @@ func (o *OvirtProvider) CleanUp(failure bool, cr *v2vv1.VirtualMachineImport, cl
 	}
 
 	vmiName := o.GetVmiNamespacedName()
-	err = o.secretsManager.DeleteFor(vmiName)
+	err = o.secretsManager.DeleteFor(cr)
 	if err != nil {
 		errs = append(errs, err)
 	}
 
-	err = o.configMapsManager.DeleteFor(vmiName)
+	err = o.configMapsManager.DeleteFor(cr)
 	if err != nil {
 		errs = append(errs, err)
 	}
 
+	// on failure, only clean up the pod once its log is stored
+	// in the status, since the log is important for debugging
+	if !failure || guestconversion.IsLogStored(cr.Status.GuestConversion) {
+		err = o.podsManager.DeleteFor(cr)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	if failure {
 		err = o.datavolumesManager.DeleteFor(vmiName)
 		if err != nil {
@@ -400,19 +552,95 @@ func (o *OvirtProvider) CleanUp(failure bool, cr *v2vv1.VirtualMachineImport, cl
 	return nil
 }
 
-// NeedsGuestConversion indicates whether a VM from this provider must undergo guest conversion.
-func (o *OvirtProvider) NeedsGuestConversion() bool {
-	return false
+// NeedsGuestConversion indicates whether a VM from this provider must undergo guest conversion. Unless the import
+// spec forces it on or off, the guest is converted when the VM uses non-virtio disks or NICs.
+func (o *OvirtProvider) NeedsGuestConversion() (bool, error) {
+	if o.instance != nil && o.instance.Spec.GuestConversion != nil && o.instance.Spec.GuestConversion.Enabled != nil {
+		return *o.instance.Spec.GuestConversion.Enabled, nil
+	}
+	vm, err := o.getVM()
+	if err != nil {
+		return false, err
+	}
+	return mapper.UsesNonVirtioDevices(vm), nil
 }
 
-// GetGuestConversionJob is not implemented.
+// GetGuestConversionPod gets the guest conversion pod created for the import
 func (o *OvirtProvider) GetGuestConversionPod() (*corev1.Pod, error) {
-	return nil, nil
+	return o.podsManager.FindFor(o.instance)
 }
 
-// LaunchGuestConversionJob is not implemented.
-func (o *OvirtProvider) LaunchGuestConversionPod(_ *kubevirtv1.VirtualMachine, _ map[string]cdiv1.DataVolume) (*corev1.Pod, error) {
-	return nil, nil
+// LaunchGuestConversionPod creates the guest conversion pod, along with the libvirt domain of the VM the pod reads
+func (o *OvirtProvider) LaunchGuestConversionPod(vmSpec *kubevirtv1.VirtualMachine, dataVolumes map[string]cdiv1.DataVolume) (*corev1.Pod, error) {
+	configMap, err := o.ensureLibvirtDomainIsPresent(vmSpec, dataVolumes)
+	if err != nil {
+		return nil, err
+	}
+	return o.ensureGuestConversionPodIsPresent(vmSpec, dataVolumes, configMap)
+}
+
+// ensureLibvirtDomainIsPresent adds the libvirt domain to the config map of the import. The config map already
+// holds the CA certificate of the data volumes, and there can only be one config map per import.
+func (o *OvirtProvider) ensureLibvirtDomainIsPresent(vmSpec *kubevirtv1.VirtualMachine, dataVolumes map[string]cdiv1.DataVolume) (*corev1.ConfigMap, error) {
+	configMap, err := o.configMapsManager.FindFor(o.instance)
+	if err != nil {
+		return nil, err
+	}
+	if configMap != nil {
+		if _, found := configMap.BinaryData[libvirtDomainKey]; found {
+			return configMap, nil
+		}
+	}
+
+	domXML, err := xml.Marshal(guestconversion.MakeLibvirtDomain(vmSpec, dataVolumes))
+	if err != nil {
+		return nil, err
+	}
+	if configMap == nil {
+		configMap = &corev1.ConfigMap{
+			BinaryData: map[string][]byte{
+				libvirtDomainKey: domXML,
+			},
+		}
+		if script := customization.RestoreNetworkScript(o.instance.Spec.Customization, o.instance.Status.GuestNetwork); script != nil {
+			configMap.BinaryData[guestconversion.RestoreNetworkScriptKey] = script
+		}
+		ownerreferences.SetVMImportReference(configMap, o.vmiTypeMeta, o.instance, ownerreferences.NewVMImportOwnerReference)
+		err = o.configMapsManager.CreateFor(configMap, o.instance)
+		if err != nil {
+			return nil, err
+		}
+		return configMap, nil
+	}
+	configMap = configMap.DeepCopy()
+	if configMap.BinaryData == nil {
+		configMap.BinaryData = make(map[string][]byte)
+	}
+	configMap.BinaryData[libvirtDomainKey] = domXML
+	if script := customization.RestoreNetworkScript(o.instance.Spec.Customization, o.instance.Status.GuestNetwork); script != nil {
+		configMap.BinaryData[guestconversion.RestoreNetworkScriptKey] = script
+	}
+	err = o.configMapsManager.Update(configMap)
+	if err != nil {
+		return nil, err
+	}
+	return configMap, nil
+}
+
+func (o *OvirtProvider) ensureGuestConversionPodIsPresent(vmSpec *kubevirtv1.VirtualMachine, dataVolumes map[string]cdiv1.DataVolume, libvirtConfigMap *corev1.ConfigMap) (*corev1.Pod, error) {
+	pod, err := o.podsManager.FindFor(o.instance)
+	if err != nil {
+		return nil, err
+	}
+	if pod == nil {
+		pod = guestconversion.MakeGuestConversionPodSpec(vmSpec, dataVolumes, libvirtConfigMap, o.instance.Spec.GuestConversion)
+		ownerreferences.SetVMImportReference(pod, o.vmiTypeMeta, o.instance, ownerreferences.NewVMImportControllerReference)
+		err = o.podsManager.CreateFor(pod, o.instance)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pod, nil
 }
 
 // SupportsWarmMigration returns whether this provider supports warm migrations.
@@ -450,7 +678,7 @@ func (o *OvirtProvider) prepareDataVolumeCredentials() (mapper.DataVolumeCredent
 }
 
 func (o *OvirtProvider) ensureSecretIsPresent(keyAccess string, keySecret string) (*corev1.Secret, error) {
-	secret, err := o.secretsManager.FindFor(o.GetVmiNamespacedName())
+	secret, err := o.secretsManager.FindFor(o.instance)
 	if err != nil {
 		return nil, err
 	}
@@ -463,6 +691,8 @@ func (o *OvirtProvider) ensureSecretIsPresent(keyAccess string, keySecret string
 	return secret, nil
 }
 
+// createSecret copies the credentials of the provider into the target namespace, limited to the access and secret keys
+// CDI reads
 func (o *OvirtProvider) createSecret(keyAccess string, keySecret string) (*corev1.Secret, error) {
 	newSecret := corev1.Secret{
 		Data: map[string][]byte{
@@ -470,10 +700,8 @@ func (o *OvirtProvider) createSecret(keyAccess string, keySecret string) (*corev
 			keySecretKey: []byte(keySecret),
 		},
 	}
-	newSecret.OwnerReferences = []metav1.OwnerReference{
-		ownerreferences.NewVMImportOwnerReference(o.vmiTypeMeta, o.vmiObjectMeta),
-	}
-	err := o.secretsManager.CreateFor(&newSecret, o.GetVmiNamespacedName())
+	ownerreferences.SetVMImportReference(&newSecret, o.vmiTypeMeta, o.instance, ownerreferences.NewVMImportOwnerReference)
+	err := o.secretsManager.CreateFor(&newSecret, o.instance)
 	if err != nil {
 		return nil, err
 	}
@@ -481,7 +709,7 @@ func (o *OvirtProvider) createSecret(keyAccess string, keySecret string) (*corev
 }
 
 func (o *OvirtProvider) ensureConfigMapIsPresent(caCert string) (*corev1.ConfigMap, error) {
-	configMap, err := o.configMapsManager.FindFor(o.GetVmiNamespacedName())
+	configMap, err := o.configMapsManager.FindFor(o.instance)
 	if err != nil {
 		return nil, err
 	}
@@ -500,11 +728,9 @@ func (o *OvirtProvider) createConfigMap(caCert string) (*corev1.ConfigMap, error
 			"ca.pem": caCert,
 		},
 	}
-	newConfigMap.OwnerReferences = []metav1.OwnerReference{
-		ownerreferences.NewVMImportOwnerReference(o.vmiTypeMeta, o.vmiObjectMeta),
-	}
+	ownerreferences.SetVMImportReference(&newConfigMap, o.vmiTypeMeta, o.instance, ownerreferences.NewVMImportOwnerReference)
 
-	err := o.configMapsManager.CreateFor(&newConfigMap, o.GetVmiNamespacedName())
+	err := o.configMapsManager.CreateFor(&newConfigMap, o.instance)
 	if err != nil {
 		return nil, err
 	}
@@ -1,8 +1,11 @@
 package ovirtprovider
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	otemplates "github.com/kubevirt/vm-import-operator/pkg/providers/ovirt/templates"
 	templates "github.com/kubevirt/vm-import-operator/pkg/templates"
 	templatev1 "github.com/openshift/api/template/v1"
@@ -135,6 +138,126 @@ func (t *mockTemplateProvider) Find(namespace *string, os *string, workload *str
 	return nil, nil
 }
 
+func (t *mockTemplateProvider) Get(namespace string, name string) (*templatev1.Template, error) {
+	return nil, nil
+}
+
 func (t *mockTemplateProvider) Process(namespace string, vmName *string, template *templatev1.Template) (*templatev1.Template, error) {
 	return process(namespace, vmName, template)
 }
+
+var _ = Describe("Deciding on guest conversion", func() {
+	var provider OvirtProvider
+
+	BeforeEach(func() {
+		nic := ovirtsdk.NewNicBuilder().Name("nic1").Interface(ovirtsdk.NICINTERFACE_E1000).MustBuild()
+		provider = OvirtProvider{
+			vm:       ovirtsdk.NewVmBuilder().NicsOfAny(nic).MustBuild(),
+			instance: &v2vv1.VirtualMachineImport{},
+		}
+	})
+
+	It("should convert guest using non-virtio devices", func() {
+		Expect(provider.NeedsGuestConversion()).To(BeTrue())
+	})
+
+	It("should not convert guest using virtio devices", func() {
+		provider.vm.MustNics().Slice()[0].SetInterface(ovirtsdk.NICINTERFACE_VIRTIO)
+
+		Expect(provider.NeedsGuestConversion()).To(BeFalse())
+	})
+
+	It("should not convert guest when disabled in spec", func() {
+		enabled := false
+		provider.instance.Spec.GuestConversion = &v2vv1.GuestConversionSpec{Enabled: &enabled}
+
+		Expect(provider.NeedsGuestConversion()).To(BeFalse())
+	})
+
+	It("should convert guest when enabled in spec", func() {
+		provider.vm.MustNics().Slice()[0].SetInterface(ovirtsdk.NICINTERFACE_VIRTIO)
+		enabled := true
+		provider.instance.Spec.GuestConversion = &v2vv1.GuestConversionSpec{Enabled: &enabled}
+
+		Expect(provider.NeedsGuestConversion()).To(BeTrue())
+	})
+
+	It("should fail when the VM can't be retrieved", func() {
+		vmID := "123"
+		provider.vm = nil
+		provider.instance.Spec.Source.Ovirt = &v2vv1.VirtualMachineImportOvirtSourceSpec{
+			VM: v2vv1.VirtualMachineImportOvirtSourceVMSpec{ID: &vmID},
+		}
+		provider.ovirtClient = &mockOvirtClient{}
+
+		_, err := provider.NeedsGuestConversion()
+
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+type mockOvirtClient struct{}
+
+func (c *mockOvirtClient) GetVM(_ context.Context, id *string, name *string, cluster *string, clusterID *string) (interface{}, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (c *mockOvirtClient) StopVM(_ context.Context, id string) error {
+	return nil
+}
+
+func (c *mockOvirtClient) StartVM(_ context.Context, id string) error {
+	return nil
+}
+
+func (c *mockOvirtClient) DeleteVM(_ context.Context, id string) error {
+	return nil
+}
+
+func (c *mockOvirtClient) MarkVMMigrated(_ context.Context, id string, target string) error {
+	return nil
+}
+
+func (c *mockOvirtClient) UnmarkVMMigrated(_ context.Context, id string, target string) error {
+	return nil
+}
+
+func (c *mockOvirtClient) TestConnection(_ context.Context) error {
+	return nil
+}
+
+func (c *mockOvirtClient) Close() error {
+	return nil
+}
+
+var _ = Describe("Reading the guest network", func() {
+	It("should read the addresses and gateways reported by the guest agent", func() {
+		ips := []*ovirtsdk.Ip{
+			ovirtsdk.NewIpBuilder().Address("10.0.0.5").Netmask("255.255.255.0").Gateway("10.0.0.1").Version(ovirtsdk.IPVERSION_V4).MustBuild(),
+			ovirtsdk.NewIpBuilder().Address("2001:db8::5").Netmask("64").Version(ovirtsdk.IPVERSION_V6).MustBuild(),
+			ovirtsdk.NewIpBuilder().Address("fe80::1").Netmask("64").Version(ovirtsdk.IPVERSION_V6).MustBuild(),
+		}
+		device := ovirtsdk.NewReportedDeviceBuilder().
+			Mac(ovirtsdk.NewMacBuilder().Address("56:6F:05:0F:00:05").MustBuild()).
+			IpsOfAny(ips...).
+			MustBuild()
+		provider := OvirtProvider{vm: ovirtsdk.NewVmBuilder().ReportedDevicesOfAny(device).MustBuild()}
+
+		network, err := provider.GetGuestNetwork()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(network).To(Equal(&v2vv1.GuestNetworkStatus{
+			Interfaces: []v2vv1.GuestNetworkInterface{{MAC: "56:6f:05:0f:00:05", IPAddresses: []string{"10.0.0.5/24", "2001:db8::5/64"}}},
+			Routes:     []v2vv1.GuestNetworkRoute{{Destination: "0.0.0.0/0", Gateway: "10.0.0.1", MAC: "56:6f:05:0f:00:05"}},
+		}))
+	})
+
+	It("should report nothing without a guest agent", func() {
+		provider := OvirtProvider{vm: ovirtsdk.NewVmBuilder().MustBuild()}
+
+		network, err := provider.GetGuestNetwork()
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(network).To(BeNil())
+	})
+})
@@ -2,9 +2,9 @@ package templates
 
 import (
 	"fmt"
-	"sort"
 	"strings"
 
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	"github.com/kubevirt/vm-import-operator/pkg/providers/ovirt/os"
 
 	"github.com/kubevirt/vm-import-operator/pkg/templates"
@@ -22,6 +22,10 @@ const (
 type TemplateFinder struct {
 	templateProvider templates.TemplateProvider
 	osFinder         os.OSFinder
+	// Namespace the templates are searched in, unless the spec of the import sets it
+	Namespace string
+	// Spec of the import, if any, pins the template or constrains its search
+	Spec *v2vv1.TemplateSpec
 }
 
 // NewTemplateFinder creates new TemplateFinder
@@ -29,17 +33,36 @@ func NewTemplateFinder(templateProvider templates.TemplateProvider, osFinder os.
 	return &TemplateFinder{
 		templateProvider: templateProvider,
 		osFinder:         osFinder,
+		Namespace:        TemplateNamespace,
 	}
 }
 
-// FindTemplate attempts to find best match for a template based on the source VM
-func (f *TemplateFinder) FindTemplate(vm *ovirtsdk.Vm) (*templatev1.Template, error) {
+// FindTemplate attempts to find best match for a template based on the source VM, and returns why it was chosen
+func (f *TemplateFinder) FindTemplate(vm *ovirtsdk.Vm) (*templatev1.Template, string, error) {
+	// the pinned template doesn't depend on the OS, whose lookup may fail
+	if templates.IsPinned(f.Spec) {
+		return templates.SelectPinned(f.templateProvider, f.Spec, f.Namespace)
+	}
 	os, err := f.osFinder.FindOperatingSystem(vm)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	workload := getWorkload(vm)
-	return f.getTemplate(os, workload)
+	// We update metadata from the source vm so we default to medium flavor
+	search := templates.Search{
+		Namespace: f.Namespace,
+		OS:        os,
+		Workloads: []string{workload},
+		Flavors:   []string{defaultFlavor},
+	}
+	template, reason, err := templates.Select(f.templateProvider, f.Spec, search)
+	if err != nil {
+		return nil, "", err
+	}
+	if template == nil {
+		return nil, "", fmt.Errorf("Template not found for %s OS and %s workload", os, workload)
+	}
+	return template, reason, nil
 }
 
 func getWorkload(vm *ovirtsdk.Vm) string {
@@ -49,39 +72,42 @@ func getWorkload(vm *ovirtsdk.Vm) string {
 	return strings.Replace(string(vmType), "_", "", -1)
 }
 
-func (f *TemplateFinder) getTemplate(os string, workload string) (*templatev1.Template, error) {
-	// We update metadata from the source vm so we default to medium flavor
-	namespace := TemplateNamespace
-	flavor := defaultFlavor
-	templates, err := f.templateProvider.Find(&namespace, &os, &workload, &flavor)
-	if err != nil {
-		return nil, err
-	}
-	if len(templates.Items) == 0 {
-		return nil, fmt.Errorf("Template not found for %s OS and %s workload", os, workload)
-	}
-	if len(templates.Items) > 1 {
-		sort.Slice(templates.Items, func(i, j int) bool {
-			return templates.Items[j].CreationTimestamp.Before(&templates.Items[i].CreationTimestamp)
-		})
-	}
-	// Take first which matches label selector
-	return &templates.Items[0], nil
-}
-
-// GetMetadata fetches OS and workload specific labels and annotations
+// GetMetadata fetches OS and workload specific labels and annotations. The OS ones are left out for a pinned template
+// when the OS can't be found.
 func (f *TemplateFinder) GetMetadata(template *templatev1.Template, vm *ovirtsdk.Vm) (map[string]string, map[string]string, error) {
+	var osName *string
+	annotations := map[string]string{}
 	os, err := f.osFinder.FindOperatingSystem(vm)
-	if err != nil {
+	if err == nil {
+		osName = &os
+		key := fmt.Sprintf(templates.TemplateNameOsAnnotation, os)
+		annotations[key] = template.GetAnnotations()[key]
+	} else if !templates.IsPinned(f.Spec) {
 		return map[string]string{}, map[string]string{}, err
 	}
+	// label the VM with the workload and flavor of the template, the ones of the spec being preferred
 	workload := getWorkload(vm)
+	if templateWorkload := templates.LabelValue(template, templates.TemplateWorkloadLabel, f.preferredWorkloads(workload)); templateWorkload != nil {
+		workload = *templateWorkload
+	}
 	flavor := defaultFlavor
-	labels := templates.OSLabelBuilder(&os, &workload, &flavor)
-
-	key := fmt.Sprintf(templates.TemplateNameOsAnnotation, os)
-	annotations := map[string]string{
-		key: template.GetAnnotations()[key],
+	if templateFlavor := templates.LabelValue(template, templates.TemplateFlavorLabel, f.preferredFlavors()); templateFlavor != nil {
+		flavor = *templateFlavor
 	}
+	labels := templates.OSLabelBuilder(osName, &workload, &flavor)
 	return labels, annotations, nil
 }
+
+func (f *TemplateFinder) preferredWorkloads(workload string) []string {
+	if f.Spec == nil {
+		return []string{workload}
+	}
+	return templates.Prefer(f.Spec.Workload, []string{workload})
+}
+
+func (f *TemplateFinder) preferredFlavors() []string {
+	if f.Spec == nil {
+		return []string{defaultFlavor}
+	}
+	return templates.Prefer(f.Spec.Flavor, []string{defaultFlavor})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	oos "github.com/kubevirt/vm-import-operator/pkg/providers/ovirt/os"
 	otemplates "github.com/kubevirt/vm-import-operator/pkg/providers/ovirt/templates"
 	"github.com/kubevirt/vm-import-operator/pkg/templates"
@@ -18,6 +19,7 @@ import (
 var (
 	findTemplatesMock func(name *string, os *string, workload *string, flavor *string) (*templatev1.TemplateList, error)
 	findOs            func(vm *ovirtsdk.Vm) (string, error)
+	getTemplateMock   func(namespace string, name string) (*templatev1.Template, error)
 )
 var _ = Describe("Finding a Template", func() {
 	templateFinder := otemplates.NewTemplateFinder(&mockTemplateProvider{}, &mockOsFinder{})
@@ -28,6 +30,10 @@ var _ = Describe("Finding a Template", func() {
 			templateList := createTemplatesList(template)
 			return templateList, nil
 		}
+		getTemplateMock = func(namespace string, name string) (*templatev1.Template, error) {
+			return nil, fmt.Errorf("template %s/%s not found", namespace, name)
+		}
+		templateFinder.Spec = nil
 		findOs = func(vm *ovirtsdk.Vm) (string, error) {
 			return "linux", nil
 		}
@@ -36,7 +42,7 @@ var _ = Describe("Finding a Template", func() {
 		vmOS := ovirtsdk.OperatingSystem{}
 		vmOS.SetType("rhel")
 		vm := ovirtsdk.NewVmBuilder().Os(&vmOS).MustBuild()
-		template, err := templateFinder.FindTemplate(vm)
+		template, _, err := templateFinder.FindTemplate(vm)
 
 		Expect(err).To(BeNil())
 		Expect(template).To(Not(BeNil()))
@@ -62,7 +68,7 @@ var _ = Describe("Finding a Template", func() {
 				ovirtsdk.NewVersionBuilder().FullVersion("8.0").MustBuild()).MustBuild()).
 			Os(&vmOS).MustBuild()
 
-		template, err := templateFinder.FindTemplate(vm)
+		template, _, err := templateFinder.FindTemplate(vm)
 
 		Expect(err).To(BeNil())
 		Expect(template).To(Not(BeNil()))
@@ -77,7 +83,7 @@ var _ = Describe("Finding a Template", func() {
 		vmOS := ovirtsdk.OperatingSystem{}
 		vmOS.SetType("rhel")
 		vm := ovirtsdk.NewVmBuilder().Os(&vmOS).MustBuild()
-		template, err := templateFinder.FindTemplate(vm)
+		template, _, err := templateFinder.FindTemplate(vm)
 
 		Expect(err).To(BeNil())
 		Expect(template).To(Not(BeNil()))
@@ -86,7 +92,7 @@ var _ = Describe("Finding a Template", func() {
 		findTemplatesMock = func(name *string, os *string, workload *string, flavor *string) (*templatev1.TemplateList, error) {
 			return nil, fmt.Errorf("boom")
 		}
-		template, err := templateFinder.FindTemplate(&ovirtsdk.Vm{})
+		template, _, err := templateFinder.FindTemplate(&ovirtsdk.Vm{})
 
 		Expect(err).To(Not(BeNil()))
 		Expect(template).To(BeNil())
@@ -108,11 +114,66 @@ var _ = Describe("Finding a Template", func() {
 		vmOS := ovirtsdk.OperatingSystem{}
 		vmOS.SetType("rhel")
 		vm := ovirtsdk.NewVmBuilder().Os(&vmOS).MustBuild()
-		template, err := templateFinder.FindTemplate(vm)
+		template, _, err := templateFinder.FindTemplate(vm)
 
 		Expect(err).To(BeNil())
 		Expect(template.CreationTimestamp).To(Equal(newer))
 	})
+	It("should use the template pinned by the spec:", func() {
+		name := "rhel8-custom"
+		templateFinder.Spec = &v2vv1.TemplateSpec{Name: &name}
+		getTemplateMock = func(namespace string, name string) (*templatev1.Template, error) {
+			template := &templatev1.Template{}
+			template.Namespace = namespace
+			template.Name = name
+			return template, nil
+		}
+
+		template, reason, err := templateFinder.FindTemplate(&ovirtsdk.Vm{})
+
+		Expect(err).To(BeNil())
+		Expect(template.Namespace).To(Equal(otemplates.TemplateNamespace))
+		Expect(template.Name).To(Equal("rhel8-custom"))
+		Expect(reason).To(ContainSubstring("Pinned"))
+	})
+	It("should use the pinned template when the OS can't be found:", func() {
+		name := "rhel8-custom"
+		templateFinder.Spec = &v2vv1.TemplateSpec{Name: &name}
+		getTemplateMock = func(namespace string, name string) (*templatev1.Template, error) {
+			template := &templatev1.Template{}
+			template.Namespace = namespace
+			template.Name = name
+			return template, nil
+		}
+		findOs = func(vm *ovirtsdk.Vm) (string, error) {
+			return "", fmt.Errorf("no OS")
+		}
+
+		template, _, err := templateFinder.FindTemplate(&ovirtsdk.Vm{})
+		Expect(err).To(BeNil())
+		Expect(template.Name).To(Equal("rhel8-custom"))
+
+		labels, annotations, err := templateFinder.GetMetadata(template, &ovirtsdk.Vm{})
+		Expect(err).To(BeNil())
+		Expect(annotations).To(BeEmpty())
+		for label := range labels {
+			Expect(label).ToNot(HavePrefix("os.template.kubevirt.io/"))
+		}
+	})
+	It("should search the flavor of the spec first:", func() {
+		flavor := "large"
+		templateFinder.Spec = &v2vv1.TemplateSpec{Flavor: &flavor}
+		var flavors []string
+		findTemplatesMock = func(name *string, os *string, workload *string, flavor *string) (*templatev1.TemplateList, error) {
+			flavors = append(flavors, *flavor)
+			return createTemplatesList(), nil
+		}
+
+		_, _, err := templateFinder.FindTemplate(ovirtsdk.NewVmBuilder().Type(ovirtsdk.VMTYPE_SERVER).MustBuild())
+
+		Expect(err).To(HaveOccurred())
+		Expect(flavors).To(Equal([]string{"large", "medium"}))
+	})
 })
 
 func createTemplate(name *string, os *string, workload *string, flavor *string) *templatev1.Template {
@@ -149,6 +210,11 @@ func (t *mockTemplateProvider) Find(
 	return findTemplatesMock(name, os, workload, flavor)
 }
 
+// Get mocks the behavior of the client for calling template API to get a template by name
+func (t *mockTemplateProvider) Get(namespace string, name string) (*templatev1.Template, error) {
+	return getTemplateMock(namespace, name)
+}
+
 // Process mocks the behavior of the client for calling process API
 func (t *mockTemplateProvider) Process(namespace string, vmName *string, template *templatev1.Template) (*templatev1.Template, error) {
 	return &templatev1.Template{}, nil
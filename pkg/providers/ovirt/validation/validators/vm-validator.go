@@ -141,7 +141,7 @@ func isValidCustomEmulatedMachine(vm *ovirtsdk.Vm) (ValidationFailure, bool) {
 }
 
 func isMemoryAboveRequests(vm *ovirtsdk.Vm, finder *otemplates.TemplateFinder) (ValidationFailure, bool) {
-	template, err := finder.FindTemplate(vm)
+	template, _, err := finder.FindTemplate(vm)
 	if err != nil {
 		// missing template is verified later
 		return ValidationFailure{}, true
@@ -653,3 +653,7 @@ func getAnnotations() map[string]string {
 func (t *mockTemplateProvider) Process(namespace string, vmName *string, template *templatev1.Template) (*templatev1.Template, error) {
 	return nil, nil
 }
+
+func (t *mockTemplateProvider) Get(namespace string, name string) (*templatev1.Template, error) {
+	return nil, nil
+}
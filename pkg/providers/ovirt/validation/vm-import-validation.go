@@ -7,7 +7,10 @@ import (
 	"github.com/kubevirt/vm-import-operator/pkg/utils"
 
 	"github.com/kubevirt/vm-import-operator/pkg/conditions"
+	"github.com/kubevirt/vm-import-operator/pkg/mappings"
+	"github.com/kubevirt/vm-import-operator/pkg/metrics"
 	otemplates "github.com/kubevirt/vm-import-operator/pkg/providers/ovirt/templates"
+	outils "github.com/kubevirt/vm-import-operator/pkg/providers/ovirt/utils"
 	validators "github.com/kubevirt/vm-import-operator/pkg/providers/ovirt/validation/validators"
 
 	ovirtsdk "github.com/ovirt/go-ovirt"
@@ -158,8 +161,8 @@ func (validator *VirtualMachineImportValidator) validateMappings(vm *ovirtsdk.Vm
 	var failures []validators.ValidationFailure
 
 	if nics, ok := vm.Nics(); ok {
-		nSlice := nics.Slice()
-		failures = append(failures, validator.Validator.ValidateNetworkMapping(nSlice, mappings.NetworkMappings, vmiCrName.Namespace)...)
+		nSlice, networkMappings := applyUnmappedNetworkPolicy(nics.Slice(), mappings)
+		failures = append(failures, validator.Validator.ValidateNetworkMapping(nSlice, networkMappings, vmiCrName.Namespace)...)
 	}
 	if attachments, ok := vm.DiskAttachments(); ok {
 		das := attachments.Slice()
@@ -169,6 +172,54 @@ func (validator *VirtualMachineImportValidator) validateMappings(vm *ovirtsdk.Vm
 	return validator.processMappingValidationFailures(failures, vmiCrName)
 }
 
+// applyUnmappedNetworkPolicy returns the NICs and the network mappings to validate once the NICs whose vNIC profile has
+// no mapping are handled according to the unmapped network policy: dropped NICs and the ones whose network attachment
+// definition is yet to be generated are left out, and the ones connected to the pod network or to a network attachment
+// definition get a mapping for their vNIC profile.
+func applyUnmappedNetworkPolicy(nics []*ovirtsdk.Nic, ovirtMappings *v2vv1.OvirtMappings) ([]*ovirtsdk.Nic, *[]v2vv1.NetworkResourceMappingItem) {
+	action := mappings.UnmappedNetworkAction(ovirtMappings.UnmappedNetworks)
+	if action == v2vv1.UnmappedNetworkFail {
+		return nics, ovirtMappings.NetworkMappings
+	}
+	unmappedNetworkMapping := mappings.UnmappedNetworkMapping(ovirtMappings.UnmappedNetworks)
+
+	var networkMappings []v2vv1.NetworkResourceMappingItem
+	if ovirtMappings.NetworkMappings != nil {
+		networkMappings = append(networkMappings, *ovirtMappings.NetworkMappings...)
+	}
+	mapByID, mapByName := utils.IndexNetworkByIDAndName(&networkMappings)
+	var mappedNics []*ovirtsdk.Nic
+	for _, nic := range nics {
+		vnicProfile, ok := nic.VnicProfile()
+		if !ok {
+			mappedNics = append(mappedNics, nic)
+			continue
+		}
+		id, _ := vnicProfile.Id()
+		network, _ := vnicProfile.Network()
+		networkName, _ := network.Name()
+		vnicProfileName, _ := vnicProfile.Name()
+		name := outils.GetNetworkMappingName(networkName, vnicProfileName)
+		if _, found := mapByID[id]; found {
+			mappedNics = append(mappedNics, nic)
+			continue
+		}
+		if _, found := mapByName[name]; found {
+			mappedNics = append(mappedNics, nic)
+			continue
+		}
+		if unmappedNetworkMapping == nil {
+			continue
+		}
+		mapping := *unmappedNetworkMapping
+		mapping.Source = v2vv1.Source{Name: &name}
+		networkMappings = append(networkMappings, mapping)
+		mapByName[name] = mapping
+		mappedNics = append(mappedNics, nic)
+	}
+	return mappedNics, &networkMappings
+}
+
 func (validator *VirtualMachineImportValidator) processMappingValidationFailures(failures []validators.ValidationFailure, vmiCrName *types.NamespacedName) v2vv1.VirtualMachineImportCondition {
 	warnMessage, errorMessage := validator.processFailures(failures, vmiCrName)
 	if errorMessage != "" {
@@ -192,6 +243,7 @@ func (validator *VirtualMachineImportValidator) processValidationFailures(failur
 func (validator *VirtualMachineImportValidator) processFailures(failures []validators.ValidationFailure, vmiCrName *types.NamespacedName) (string, string) {
 	var warnMessage, errorMessage string
 	for _, failure := range failures {
+		metrics.ImportMetrics.IncValidationFailure(metrics.ProviderOvirt, string(failure.ID))
 		switch checkToAction[failure.ID] {
 		case log:
 			logger.Info(fmt.Sprintf("Validation information for %v: %v", vmiCrName, failure))
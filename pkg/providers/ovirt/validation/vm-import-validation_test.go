@@ -3,6 +3,7 @@ package validation_test
 import (
 	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	"github.com/kubevirt/vm-import-operator/pkg/conditions"
+	"github.com/kubevirt/vm-import-operator/pkg/metrics"
 	otemplates "github.com/kubevirt/vm-import-operator/pkg/providers/ovirt/templates"
 	"github.com/kubevirt/vm-import-operator/pkg/providers/ovirt/validation"
 	"github.com/kubevirt/vm-import-operator/pkg/providers/ovirt/validation/validators"
@@ -337,6 +338,23 @@ var _ = Describe("Validating VirtualMachineImport Admitter", func() {
 		Expect(*condition.Message).To(ContainSubstring(vmFailure2.Message))
 		Expect(*condition.Reason).To(Equal(errorReason))
 	})
+	It("should count validation failures by check", func() {
+		vm := newVM()
+		crName := newNamespacedName()
+		validateVMMock = func(_ *ovirtsdk.Vm) []validators.ValidationFailure {
+			return []validators.ValidationFailure{
+				{ID: validators.VMUsbID, Message: "USB"},
+			}
+		}
+		countBefore, err := metrics.ImportMetrics.GetValidationFailures(metrics.ProviderOvirt, string(validators.VMUsbID))
+		Expect(err).To(BeNil())
+
+		vmImportValidator.Validate(vm, crName, newOvirtMappings(), newFinder())
+
+		countAfter, err := metrics.ImportMetrics.GetValidationFailures(metrics.ProviderOvirt, string(validators.VMUsbID))
+		Expect(err).To(BeNil())
+		Expect(countAfter).To(Equal(countBefore + 1))
+	})
 	table.DescribeTable("should reject VirtualMachineImport spec with failed network mapping check when ", func(checkId validators.CheckID) {
 		vm := newVM()
 		crName := newNamespacedName()
@@ -364,6 +382,48 @@ var _ = Describe("Validating VirtualMachineImport Admitter", func() {
 		table.Entry("target network does not exist", validators.NetworkTargetID),
 		table.Entry("network type is unsupported", validators.NetworkTypeID),
 	)
+	table.DescribeTable("should apply the unmapped network policy before checking the network mapping with ", func(action v2vv1.UnmappedNetworkAction, expectedNics int, expectedType *string) {
+		vm := newVM()
+		nicSlice := ovirtsdk.NicSlice{}
+		nicSlice.SetSlice([]*ovirtsdk.Nic{
+			ovirtsdk.NewNicBuilder().
+				Name("nic1").
+				VnicProfile(
+					ovirtsdk.NewVnicProfileBuilder().
+						Id("profile1-id").
+						Name("profile1").
+						Network(ovirtsdk.NewNetworkBuilder().Name("network1").MustBuild()).
+						MustBuild()).
+				MustBuild(),
+		})
+		vm.SetNics(&nicSlice)
+		ovirtMappings := newOvirtMappings()
+		target := v2vv1.ObjectIdentifier{Name: "default-net"}
+		ovirtMappings.UnmappedNetworks = &v2vv1.UnmappedNetworkPolicy{Action: action, Target: &target}
+		var validatedNics []*ovirtsdk.Nic
+		var validatedMapping *[]v2vv1.NetworkResourceMappingItem
+		validateNetworkMappingsMock = func(nics []*ovirtsdk.Nic, mapping *[]v2vv1.NetworkResourceMappingItem, crNamespace string) []validators.ValidationFailure {
+			validatedNics, validatedMapping = nics, mapping
+			return []validators.ValidationFailure{}
+		}
+
+		vmImportValidator.Validate(vm, newNamespacedName(), ovirtMappings, newFinder())
+
+		Expect(validatedNics).To(HaveLen(expectedNics))
+		if expectedType == nil {
+			Expect(validatedMapping == nil || len(*validatedMapping) == 0).To(BeTrue())
+		} else {
+			Expect(*validatedMapping).To(HaveLen(1))
+			Expect(*(*validatedMapping)[0].Source.Name).To(Equal("network1/profile1"))
+			Expect(*(*validatedMapping)[0].Type).To(Equal(*expectedType))
+		}
+	},
+		table.Entry("Fail", v2vv1.UnmappedNetworkFail, 1, nil),
+		table.Entry("Drop", v2vv1.UnmappedNetworkDrop, 0, nil),
+		table.Entry("Pod", v2vv1.UnmappedNetworkPod, 1, &podType),
+		table.Entry("Multus", v2vv1.UnmappedNetworkMultus, 1, &multusType),
+		table.Entry("Generate", v2vv1.UnmappedNetworkGenerate, 0, nil),
+	)
 	table.DescribeTable("should reject VirtualMachineImport spec with failed storage mapping check when", func(checkId validators.CheckID) {
 		vm := newVM()
 		crName := newNamespacedName()
@@ -431,6 +491,11 @@ func oneValidationFailure(checkID validators.CheckID, message string) []validato
 	}
 }
 
+var (
+	podType    = "pod"
+	multusType = "multus"
+)
+
 func newOvirtMappings() *v2vv1.OvirtMappings {
 	return &v2vv1.OvirtMappings{}
 }
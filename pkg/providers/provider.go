@@ -2,6 +2,8 @@ package provider
 
 import (
 	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/macaddress"
+	"github.com/kubevirt/vm-import-operator/pkg/propagation"
 	oapiv1 "github.com/openshift/api/template/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -28,13 +30,20 @@ type Provider interface {
 	ValidateDiskStatus(string) (bool, error)
 	StopVM(*v2vv1.VirtualMachineImport, rclient.Client) error
 	CreateMapper() (Mapper, error)
+	CreateValidationMapper() (Mapper, error)
 	GetVMStatus() (VMStatus, error)
 	GetVMName() (string, error)
+	GetGuestNetwork() (*v2vv1.GuestNetworkStatus, error)
 	StartVM() error
+	DeleteVM() error
+	MarkVMMigrated(string) error
+	UnmarkVMMigrated(string) error
+	GetSourceMetadata() (*propagation.SourceMetadata, error)
 	CleanUp(bool, *v2vv1.VirtualMachineImport, rclient.Client) error
-	FindTemplate() (*oapiv1.Template, error)
+	FindTemplate() (*oapiv1.Template, string, error)
 	ProcessTemplate(*oapiv1.Template, *string, string) (*kubevirtv1.VirtualMachine, error)
-	NeedsGuestConversion() bool
+	UpdateOperatingSystem(*kubevirtv1.VirtualMachine, *v2vv1.GuestInspectionStatus) error
+	NeedsGuestConversion() (bool, error)
 	GetGuestConversionPod() (*corev1.Pod, error)
 	LaunchGuestConversionPod(*kubevirtv1.VirtualMachine, map[string]cdiv1.DataVolume) (*corev1.Pod, error)
 	SupportsWarmMigration() bool
@@ -48,6 +57,8 @@ type Mapper interface {
 	MapVM(targetVMName *string, vmSpec *kubevirtv1.VirtualMachine) (*kubevirtv1.VirtualMachine, error)
 	MapDataVolumes(targetVMName *string, filesystemOverhead cdiv1.FilesystemOverhead) (map[string]cdiv1.DataVolume, error)
 	MapDisk(vmSpec *kubevirtv1.VirtualMachine, dv cdiv1.DataVolume)
+	MapMACAddresses(importPolicy v2vv1.MACPolicy) []macaddress.NIC
+	MapUnmappedNetworks() []v2vv1.UnmappedNetworkStatus
 }
 
 // VMStatus represents VM status
@@ -55,16 +66,17 @@ type VMStatus string
 
 // SecretsManager defines operations on secrets
 type SecretsManager interface {
-	FindFor(types.NamespacedName) (*corev1.Secret, error)
-	CreateFor(*corev1.Secret, types.NamespacedName) error
-	DeleteFor(types.NamespacedName) error
+	FindFor(*v2vv1.VirtualMachineImport) (*corev1.Secret, error)
+	CreateFor(*corev1.Secret, *v2vv1.VirtualMachineImport) error
+	DeleteFor(*v2vv1.VirtualMachineImport) error
 }
 
 // ConfigMapsManager defines operations on config maps
 type ConfigMapsManager interface {
-	FindFor(types.NamespacedName) (*corev1.ConfigMap, error)
-	CreateFor(*corev1.ConfigMap, types.NamespacedName) error
-	DeleteFor(types.NamespacedName) error
+	FindFor(*v2vv1.VirtualMachineImport) (*corev1.ConfigMap, error)
+	CreateFor(*corev1.ConfigMap, *v2vv1.VirtualMachineImport) error
+	Update(*corev1.ConfigMap) error
+	DeleteFor(*v2vv1.VirtualMachineImport) error
 }
 
 // DataVolumesManager defines operations on datavolumes
@@ -81,7 +93,7 @@ type VirtualMachineManager interface {
 
 // PodsManager defines operations on Pods
 type PodsManager interface {
-	FindFor(types.NamespacedName) (*corev1.Pod, error)
-	CreateFor(*corev1.Pod, types.NamespacedName) error
-	DeleteFor(types.NamespacedName) error
+	FindFor(*v2vv1.VirtualMachineImport) (*corev1.Pod, error)
+	CreateFor(*corev1.Pod, *v2vv1.VirtualMachineImport) error
+	DeleteFor(*v2vv1.VirtualMachineImport) error
 }
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kubevirt/vm-import-operator/pkg/tracing"
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/session"
@@ -15,6 +16,7 @@ import (
 	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/soap"
 	"github.com/vmware/govmomi/vim25/types"
+	"go.opentelemetry.io/otel/label"
 )
 
 const (
@@ -24,6 +26,8 @@ const (
 	pollInterval = 5 * time.Second
 	// timeout value in seconds for vmware api requests
 	timeout = 30 * time.Second
+	// migratedToField is the custom attribute holding where a VM was migrated to
+	migratedToField = "migrated-to"
 )
 
 // RichVmwareClient is responsible for retrieving VM data from the VMware API.
@@ -33,7 +37,8 @@ type RichVmwareClient struct {
 	sessionManager *session.Manager
 }
 
-// NewRichVMWareClient creates a new, connected rich VMWare client.
+// NewRichVMWareClient creates a new, connected rich VMWare client. The spans of the API calls are started from the
+// context given to each call.
 func NewRichVMWareClient(apiUrl, username, password string, thumbprint string) (*RichVmwareClient, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
@@ -68,12 +73,12 @@ func NewRichVMWareClient(apiUrl, username, password string, thumbprint string) (
 }
 
 // GetVM retrieves a VM from a vCenter or ESXI host by UUID or by name/inventory path.
-func (r RichVmwareClient) GetVM(id *string, name *string, _ *string, _ *string) (interface{}, error) {
+func (r RichVmwareClient) GetVM(ctx context.Context, id *string, name *string, _ *string, _ *string) (interface{}, error) {
 	if id != nil {
-		return r.getVMByUUID(*id)
+		return r.getVMByUUID(ctx, *id)
 	}
 	if name != nil {
-		return r.getVMByInventoryPath(*name)
+		return r.getVMByInventoryPath(ctx, *name)
 	}
 	return nil, errors.New("not found")
 }
@@ -87,9 +92,9 @@ func (r RichVmwareClient) getVMByMoRef(moRef string) *object.VirtualMachine {
 }
 
 // getVMByUUID gets a VM by its UUID
-func (r RichVmwareClient) getVMByUUID(id string) (*object.VirtualMachine, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+func (r RichVmwareClient) getVMByUUID(ctx context.Context, id string) (_ *object.VirtualMachine, err error) {
+	ctx, end := r.startCall(ctx, "vmware.FindByUuid", label.String("vm.id", id))
+	defer func() { end(err) }()
 	searchIndex := object.NewSearchIndex(r.client)
 	instanceUUID := false
 	vmRef, err := searchIndex.FindByUuid(ctx, nil, id, true, &instanceUUID)
@@ -104,9 +109,9 @@ func (r RichVmwareClient) getVMByUUID(id string) (*object.VirtualMachine, error)
 }
 
 // getVMByInventoryPath gets a VM by its complete inventory path or by name alone.
-func (r RichVmwareClient) getVMByInventoryPath(vmPath string) (*object.VirtualMachine, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+func (r RichVmwareClient) getVMByInventoryPath(ctx context.Context, vmPath string) (_ *object.VirtualMachine, err error) {
+	ctx, end := r.startCall(ctx, "vmware.FindVirtualMachine", label.String("vm.path", vmPath))
+	defer func() { end(err) }()
 	finder := find.NewFinder(r.client)
 
 	vm, err := finder.VirtualMachine(ctx, vmPath)
@@ -117,9 +122,9 @@ func (r RichVmwareClient) getVMByInventoryPath(vmPath string) (*object.VirtualMa
 }
 
 // CreateVMSnapshot creates a snapshot of the VM.
-func (r RichVmwareClient) CreateVMSnapshot(moRef string, name string, desc string, memory bool, quiesce bool) (*types.ManagedObjectReference, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+func (r RichVmwareClient) CreateVMSnapshot(ctx context.Context, moRef string, name string, desc string, memory bool, quiesce bool) (_ *types.ManagedObjectReference, err error) {
+	ctx, end := r.startCall(ctx, "vmware.CreateSnapshot", label.String("vm.moref", moRef))
+	defer func() { end(err) }()
 
 	vm := r.getVMByMoRef(moRef)
 	task, err := vm.CreateSnapshot(ctx, name, desc, memory, quiesce)
@@ -134,9 +139,9 @@ func (r RichVmwareClient) CreateVMSnapshot(moRef string, name string, desc strin
 	return &snapshotRef, nil
 }
 
-func (r RichVmwareClient) FindVMSnapshot(moRef string, snapshot string) (*types.ManagedObjectReference, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+func (r RichVmwareClient) FindVMSnapshot(ctx context.Context, moRef string, snapshot string) (_ *types.ManagedObjectReference, err error) {
+	ctx, end := r.startCall(ctx, "vmware.FindSnapshot", label.String("vm.moref", moRef))
+	defer func() { end(err) }()
 	vm := r.getVMByMoRef(moRef)
 	snapshotRef, err := vm.FindSnapshot(ctx, snapshot)
 	if err != nil {
@@ -149,9 +154,9 @@ func (r RichVmwareClient) FindVMSnapshot(moRef string, snapshot string) (*types.
 	return snapshotRef, nil
 }
 
-func (r RichVmwareClient) RemoveVMSnapshot(moRef string, snapshot string, removeChildren bool, consolidate *bool) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+func (r RichVmwareClient) RemoveVMSnapshot(ctx context.Context, moRef string, snapshot string, removeChildren bool, consolidate *bool) (err error) {
+	ctx, end := r.startCall(ctx, "vmware.RemoveSnapshot", label.String("vm.moref", moRef))
+	defer func() { end(err) }()
 	vm := r.getVMByMoRef(moRef)
 	task, err := vm.RemoveSnapshot(ctx, snapshot, removeChildren, consolidate)
 	if err != nil {
@@ -161,11 +166,11 @@ func (r RichVmwareClient) RemoveVMSnapshot(moRef string, snapshot string, remove
 }
 
 // GetVMProperties retrieves the Properties struct for the VM.
-func (r RichVmwareClient) GetVMProperties(vm *object.VirtualMachine) (*mo.VirtualMachine, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+func (r RichVmwareClient) GetVMProperties(ctx context.Context, vm *object.VirtualMachine) (_ *mo.VirtualMachine, err error) {
+	ctx, end := r.startCall(ctx, "vmware.RetrieveVMProperties", label.String("vm.moref", vm.Reference().Value))
+	defer func() { end(err) }()
 	vmProperties := &mo.VirtualMachine{}
-	err := vm.Properties(ctx, vm.Reference(), nil, vmProperties)
+	err = vm.Properties(ctx, vm.Reference(), nil, vmProperties)
 	if err != nil {
 		return nil, err
 	}
@@ -173,9 +178,9 @@ func (r RichVmwareClient) GetVMProperties(vm *object.VirtualMachine) (*mo.Virtua
 }
 
 // GetVMHostProperties retrieves the Properties struct for the HostSystem the VM is on.
-func (r RichVmwareClient) GetVMHostProperties(vm *object.VirtualMachine) (*mo.HostSystem, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+func (r RichVmwareClient) GetVMHostProperties(ctx context.Context, vm *object.VirtualMachine) (_ *mo.HostSystem, err error) {
+	ctx, end := r.startCall(ctx, "vmware.RetrieveHostProperties", label.String("vm.moref", vm.Reference().Value))
+	defer func() { end(err) }()
 
 	hostSystem, err := vm.HostSystem(ctx)
 	if err != nil {
@@ -183,7 +188,7 @@ func (r RichVmwareClient) GetVMHostProperties(vm *object.VirtualMachine) (*mo.Ho
 	}
 
 	hostProperties := &mo.HostSystem{}
-	err = hostSystem.Properties(context.TODO(), hostSystem.Reference(), nil, hostProperties)
+	err = hostSystem.Properties(ctx, hostSystem.Reference(), nil, hostProperties)
 	if err != nil {
 		return nil, err
 	}
@@ -191,10 +196,27 @@ func (r RichVmwareClient) GetVMHostProperties(vm *object.VirtualMachine) (*mo.Ho
 	return hostProperties, nil
 }
 
+// GetVMFolderPath retrieves the inventory path of the folder of the VM, e.g. /Datacenter/vm/prod.
+func (r RichVmwareClient) GetVMFolderPath(ctx context.Context, vm *object.VirtualMachine) (_ string, err error) {
+	ctx, end := r.startCall(ctx, "vmware.GetVMFolderPath", label.String("vm.moref", vm.Reference().Value))
+	defer func() { end(err) }()
+
+	ancestors, err := mo.Ancestors(ctx, r.client, r.client.ServiceContent.PropertyCollector, vm.Reference())
+	if err != nil {
+		return "", err
+	}
+	// skip the root folder and the VM itself
+	var names []string
+	for i := 1; i < len(ancestors)-1; i++ {
+		names = append(names, ancestors[i].Name)
+	}
+	return "/" + strings.Join(names, "/"), nil
+}
+
 // StartVM requests VM start and doesn't wait for it to complete.
-func (r RichVmwareClient) StartVM(moRef string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+func (r RichVmwareClient) StartVM(ctx context.Context, moRef string) (err error) {
+	ctx, end := r.startCall(ctx, "vmware.StartVM", label.String("vm.moref", moRef))
+	defer func() { end(err) }()
 
 	vm := r.getVMByMoRef(moRef)
 	powerState, err := vm.PowerState(ctx)
@@ -210,9 +232,123 @@ func (r RichVmwareClient) StartVM(moRef string) error {
 	return nil
 }
 
+// DeleteVM destroys the VM along with its disks.
+func (r RichVmwareClient) DeleteVM(ctx context.Context, moRef string) (err error) {
+	ctx, end := r.startCall(ctx, "vmware.DeleteVM", label.String("vm.moref", moRef))
+	defer func() { end(err) }()
+
+	vm := r.getVMByMoRef(moRef)
+	task, err := vm.Destroy(ctx)
+	if err != nil {
+		return err
+	}
+	return task.Wait(ctx)
+}
+
+// MarkVMMigrated records that the VM was migrated to the target in a custom attribute and in the notes of the VM.
+func (r RichVmwareClient) MarkVMMigrated(ctx context.Context, moRef string, target string) (err error) {
+	ctx, end := r.startCall(ctx, "vmware.MarkVMMigrated", label.String("vm.moref", moRef))
+	defer func() { end(err) }()
+
+	vm := r.getVMByMoRef(moRef)
+	fieldsManager, err := object.GetCustomFieldsManager(r.client)
+	if err != nil {
+		return err
+	}
+	key, err := fieldsManager.FindKey(ctx, migratedToField)
+	if err == object.ErrKeyNameNotFound {
+		field, addErr := fieldsManager.Add(ctx, migratedToField, "VirtualMachine", nil, nil)
+		if addErr != nil {
+			return addErr
+		}
+		key, err = field.Key, nil
+	}
+	if err != nil {
+		return err
+	}
+	err = fieldsManager.Set(ctx, vm.Reference(), key, target)
+	if err != nil {
+		return err
+	}
+
+	vmProperties := mo.VirtualMachine{}
+	err = vm.Properties(ctx, vm.Reference(), []string{"config.annotation"}, &vmProperties)
+	if err != nil {
+		return err
+	}
+	var annotation string
+	if vmProperties.Config != nil {
+		annotation = vmProperties.Config.Annotation
+	}
+	note := "Migrated to " + target
+	if strings.Contains(annotation, note) {
+		return nil
+	}
+	if annotation != "" {
+		annotation += "\n"
+	}
+	task, err := vm.Reconfigure(ctx, types.VirtualMachineConfigSpec{Annotation: annotation + note})
+	if err != nil {
+		return err
+	}
+	return task.Wait(ctx)
+}
+
+// UnmarkVMMigrated clears the custom attribute of MarkVMMigrated and notes in the notes of the VM that the migration
+// was rolled back.
+func (r RichVmwareClient) UnmarkVMMigrated(ctx context.Context, moRef string, target string) (err error) {
+	ctx, end := r.startCall(ctx, "vmware.UnmarkVMMigrated", label.String("vm.moref", moRef))
+	defer func() { end(err) }()
+
+	vm := r.getVMByMoRef(moRef)
+	fieldsManager, err := object.GetCustomFieldsManager(r.client)
+	if err != nil {
+		return err
+	}
+	key, err := fieldsManager.FindKey(ctx, migratedToField)
+	if err == nil {
+		err = fieldsManager.Set(ctx, vm.Reference(), key, "")
+	}
+	if err != nil && err != object.ErrKeyNameNotFound {
+		return err
+	}
+
+	vmProperties := mo.VirtualMachine{}
+	err = vm.Properties(ctx, vm.Reference(), []string{"config.annotation"}, &vmProperties)
+	if err != nil {
+		return err
+	}
+	if vmProperties.Config == nil {
+		return nil
+	}
+	annotation := replaceNote(vmProperties.Config.Annotation, "Migrated to "+target, "Migration to "+target+" rolled back")
+	if annotation == vmProperties.Config.Annotation {
+		return nil
+	}
+	task, err := vm.Reconfigure(ctx, types.VirtualMachineConfigSpec{Annotation: annotation})
+	if err != nil {
+		return err
+	}
+	return task.Wait(ctx)
+}
+
+// replaceNote replaces the lines holding the note in the text
+func replaceNote(text string, note string, replacement string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line == note {
+			lines[i] = replacement
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // StopVM stops the VM and waits for the vm to be stopped.
-func (r RichVmwareClient) StopVM(moRef string) error {
-	err := r.shutdownGuest(moRef)
+func (r RichVmwareClient) StopVM(ctx context.Context, moRef string) (err error) {
+	ctx, span := tracing.Start(ctx, "vmware.StopVM", label.String("vm.moref", moRef))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	err = r.shutdownGuest(ctx, moRef)
 	if err != nil {
 		return err
 	}
@@ -222,7 +358,7 @@ func (r RichVmwareClient) StopVM(moRef string) error {
 	go func() {
 		for {
 			time.Sleep(pollInterval)
-			powerState, err := r.powerState(moRef)
+			powerState, err := r.powerState(ctx, moRef)
 			if err != nil {
 				c <- false
 			}
@@ -244,9 +380,9 @@ func (r RichVmwareClient) StopVM(moRef string) error {
 	}
 }
 
-func (r RichVmwareClient) shutdownGuest(moRef string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+func (r RichVmwareClient) shutdownGuest(ctx context.Context, moRef string) (err error) {
+	ctx, end := r.startCall(ctx, "vmware.ShutdownGuest", label.String("vm.moref", moRef))
+	defer func() { end(err) }()
 
 	vm := r.getVMByMoRef(moRef)
 
@@ -262,9 +398,9 @@ func (r RichVmwareClient) shutdownGuest(moRef string) error {
 	return vm.ShutdownGuest(ctx)
 }
 
-func (r RichVmwareClient) powerState(moRef string) (types.VirtualMachinePowerState, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+func (r RichVmwareClient) powerState(ctx context.Context, moRef string) (_ types.VirtualMachinePowerState, err error) {
+	ctx, end := r.startCall(ctx, "vmware.PowerState", label.String("vm.moref", moRef))
+	defer func() { end(err) }()
 
 	vm := r.getVMByMoRef(moRef)
 
@@ -272,8 +408,10 @@ func (r RichVmwareClient) powerState(moRef string) (types.VirtualMachinePowerSta
 }
 
 // TestConnection checks the connectivity to the vCenter or ESXi host.
-func (r RichVmwareClient) TestConnection() error {
-	_, err := r.client.Get(r.client.URL().String())
+func (r RichVmwareClient) TestConnection(ctx context.Context) (err error) {
+	_, end := r.startCall(ctx, "vmware.TestConnection")
+	defer func() { end(err) }()
+	_, err = r.client.Get(r.client.URL().String())
 	return err
 }
 
@@ -285,3 +423,14 @@ func (r RichVmwareClient) Close() error {
 
 	return r.sessionManager.Logout(ctx)
 }
+
+// startCall starts the span of a vSphere API call and returns the context of the call, limited by the request timeout,
+// together with the function ending the call
+func (r RichVmwareClient) startCall(ctx context.Context, name string, attrs ...label.KeyValue) (context.Context, func(error)) {
+	ctx, span := tracing.Start(ctx, name, attrs...)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, func(err error) {
+		cancel()
+		tracing.EndSpan(span, err)
+	}
+}
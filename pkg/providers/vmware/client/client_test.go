@@ -1,12 +1,16 @@
 package client_test
 
 import (
+	"context"
+	"strings"
+
 	"github.com/kubevirt/vm-import-operator/pkg/providers/vmware/client"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25/types"
 )
 
 var _ = Describe("Test VMware rich client", func() {
@@ -36,7 +40,7 @@ var _ = Describe("Test VMware rich client", func() {
 		defer server.Close()
 		richClient, err := createRichClient(server)
 		Expect(err).To(BeNil())
-		err = richClient.TestConnection()
+		err = richClient.TestConnection(context.Background())
 		Expect(err).To(BeNil())
 	},
 		Entry("vCenter", simulator.VPX()),
@@ -52,7 +56,7 @@ var _ = Describe("Test VMware rich client", func() {
 		Expect(err).To(BeNil())
 		vmRef, uuid := getVMIdentifiers()
 
-		rawVm, err := richClient.GetVM(&uuid, nil, nil, nil)
+		rawVm, err := richClient.GetVM(context.Background(), &uuid, nil, nil, nil)
 
 		Expect(err).To(BeNil())
 		retrievedVm, ok := rawVm.(*object.VirtualMachine)
@@ -72,7 +76,7 @@ var _ = Describe("Test VMware rich client", func() {
 		Expect(err).To(BeNil())
 		uuid := "invalidUuid"
 
-		_, err = richClient.GetVM(&uuid, nil, nil, nil)
+		_, err = richClient.GetVM(context.Background(), &uuid, nil, nil, nil)
 		Expect(err).ToNot(BeNil())
 		Expect(err.Error()).To(Equal("vm 'invalidUuid' not found"))
 	},
@@ -89,7 +93,7 @@ var _ = Describe("Test VMware rich client", func() {
 		richClient, err := createRichClient(server)
 		Expect(err).To(BeNil())
 
-		rawVm, err := richClient.GetVM(nil, &vm.Name, nil, nil)
+		rawVm, err := richClient.GetVM(context.Background(), nil, &vm.Name, nil, nil)
 
 		Expect(err).To(BeNil())
 		retrievedVm, ok := rawVm.(*object.VirtualMachine)
@@ -109,7 +113,7 @@ var _ = Describe("Test VMware rich client", func() {
 		Expect(err).To(BeNil())
 		name := "invalidName"
 
-		_, err = richClient.GetVM(nil, &name, nil, nil)
+		_, err = richClient.GetVM(context.Background(), nil, &name, nil, nil)
 		Expect(err).ToNot(BeNil())
 		Expect(err.Error()).To(Equal("vm 'invalidName' not found"))
 	},
@@ -126,7 +130,7 @@ var _ = Describe("Test VMware rich client", func() {
 		Expect(err).To(BeNil())
 		moRef, _ := getVMIdentifiers()
 
-		snapshotRef, err := richClient.CreateVMSnapshot(moRef, "name", "description", false, true)
+		snapshotRef, err := richClient.CreateVMSnapshot(context.Background(), moRef, "name", "description", false, true)
 		Expect(err).To(BeNil())
 		Expect(snapshotRef).ToNot(BeNil())
 		Expect(snapshotRef.Type).To(Equal("VirtualMachineSnapshot"))
@@ -145,10 +149,10 @@ var _ = Describe("Test VMware rich client", func() {
 		Expect(err).To(BeNil())
 		moRef, _ := getVMIdentifiers()
 
-		err = richClient.StopVM(moRef)
+		err = richClient.StopVM(context.Background(), moRef)
 		Expect(err).To(BeNil())
 
-		err = richClient.StartVM(moRef)
+		err = richClient.StartVM(context.Background(), moRef)
 		Expect(err).To(BeNil())
 	},
 		Entry("vCenter", simulator.VPX()),
@@ -164,10 +168,10 @@ var _ = Describe("Test VMware rich client", func() {
 		Expect(err).To(BeNil())
 		moRef, _ := getVMIdentifiers()
 
-		err = richClient.StopVM(moRef)
+		err = richClient.StopVM(context.Background(), moRef)
 		Expect(err).To(BeNil())
 
-		err = richClient.StopVM(moRef)
+		err = richClient.StopVM(context.Background(), moRef)
 		Expect(err).To(BeNil())
 	},
 		Entry("vCenter", simulator.VPX()),
@@ -183,15 +187,81 @@ var _ = Describe("Test VMware rich client", func() {
 		Expect(err).To(BeNil())
 		moRef, _ := getVMIdentifiers()
 
-		err = richClient.StartVM(moRef)
+		err = richClient.StartVM(context.Background(), moRef)
+		Expect(err).To(BeNil())
+
+		err = richClient.StartVM(context.Background(), moRef)
+		Expect(err).To(BeNil())
+	},
+		Entry("vCenter", simulator.VPX()),
+		Entry("ESXi", simulator.ESX()),
+	)
+
+	DescribeTable("should delete a VM", func(model *simulator.Model) {
+		_ = model.Create()
+		server := model.Service.NewServer()
+		defer model.Remove()
+		defer server.Close()
+		richClient, err := createRichClient(server)
 		Expect(err).To(BeNil())
+		moRef, uuid := getVMIdentifiers()
+		err = richClient.StopVM(context.Background(), moRef)
+		Expect(err).To(BeNil())
+
+		err = richClient.DeleteVM(context.Background(), moRef)
 
-		err = richClient.StartVM(moRef)
 		Expect(err).To(BeNil())
+		_, err = richClient.GetVM(context.Background(), &uuid, nil, nil, nil)
+		Expect(err).ToNot(BeNil())
 	},
 		Entry("vCenter", simulator.VPX()),
 		Entry("ESXi", simulator.ESX()),
 	)
+
+	It("should mark a VM as migrated once", func() {
+		model := simulator.VPX()
+		_ = model.Create()
+		server := model.Service.NewServer()
+		defer model.Remove()
+		defer server.Close()
+		richClient, err := createRichClient(server)
+		Expect(err).To(BeNil())
+		moRef, uuid := getVMIdentifiers()
+
+		Expect(richClient.MarkVMMigrated(context.Background(), moRef, "cluster/default/vm")).To(Succeed())
+		Expect(richClient.MarkVMMigrated(context.Background(), moRef, "cluster/default/vm")).To(Succeed())
+
+		rawVm, err := richClient.GetVM(context.Background(), &uuid, nil, nil, nil)
+		Expect(err).To(BeNil())
+		vmProperties, err := richClient.GetVMProperties(context.Background(), rawVm.(*object.VirtualMachine))
+		Expect(err).To(BeNil())
+		Expect(strings.Count(vmProperties.Config.Annotation, "Migrated to cluster/default/vm")).To(Equal(1))
+		Expect(vmProperties.CustomValue).ToNot(BeEmpty())
+		Expect(vmProperties.CustomValue[0].(*types.CustomFieldStringValue).Value).To(Equal("cluster/default/vm"))
+	})
+
+	It("should unmark a VM marked as migrated", func() {
+		model := simulator.VPX()
+		_ = model.Create()
+		server := model.Service.NewServer()
+		defer model.Remove()
+		defer server.Close()
+		richClient, err := createRichClient(server)
+		Expect(err).To(BeNil())
+		moRef, uuid := getVMIdentifiers()
+		Expect(richClient.MarkVMMigrated(context.Background(), moRef, "cluster/default/vm")).To(Succeed())
+
+		Expect(richClient.UnmarkVMMigrated(context.Background(), moRef, "cluster/default/vm")).To(Succeed())
+
+		rawVm, err := richClient.GetVM(context.Background(), &uuid, nil, nil, nil)
+		Expect(err).To(BeNil())
+		vmProperties, err := richClient.GetVMProperties(context.Background(), rawVm.(*object.VirtualMachine))
+		Expect(err).To(BeNil())
+		Expect(vmProperties.Config.Annotation).To(Equal("Migration to cluster/default/vm rolled back"))
+		// the simulator appends the values set instead of replacing them
+		Expect(vmProperties.CustomValue).ToNot(BeEmpty())
+		Expect(vmProperties.CustomValue[len(vmProperties.CustomValue)-1].(*types.CustomFieldStringValue).Value).To(BeEmpty())
+	})
 })
 
 func createRichClient(server *simulator.Server) (*client.RichVmwareClient, error) {
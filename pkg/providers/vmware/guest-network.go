@@ -0,0 +1,89 @@
+package vmware
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+const dhcpOrigin = "dhcp"
+
+// GetGuestNetwork returns the IP configuration the VMware Tools report for the interfaces of the source VM, or nil
+// when the tools don't report it
+func (r *VmwareProvider) GetGuestNetwork() (*v1beta1.GuestNetworkStatus, error) {
+	vmProperties, err := r.getVmProperties()
+	if err != nil {
+		return nil, err
+	}
+	return makeGuestNetwork(vmProperties), nil
+}
+
+// makeGuestNetwork reads the NICs and the first IP stack reported by the VMware Tools
+func makeGuestNetwork(vmProperties *mo.VirtualMachine) *v1beta1.GuestNetworkStatus {
+	guest := vmProperties.Guest
+	if guest == nil || len(guest.Net) == 0 {
+		return nil
+	}
+	network := v1beta1.GuestNetworkStatus{}
+	for _, guestNic := range guest.Net {
+		nic := v1beta1.GuestNetworkInterface{MAC: strings.ToLower(guestNic.MacAddress)}
+		if config := guestNic.IpConfig; config != nil {
+			if config.Dhcp != nil && config.Dhcp.Ipv4 != nil {
+				nic.DHCP = config.Dhcp.Ipv4.Enable
+			}
+			for _, address := range config.IpAddress {
+				if address.Origin == dhcpOrigin {
+					nic.DHCP = true
+					continue
+				}
+				if !isRoutable(address.IpAddress) {
+					continue
+				}
+				nic.IPAddresses = append(nic.IPAddresses, address.IpAddress+"/"+strconv.Itoa(int(address.PrefixLength)))
+			}
+		}
+		network.Interfaces = append(network.Interfaces, nic)
+	}
+
+	if len(guest.IpStack) > 0 {
+		stack := guest.IpStack[0]
+		if stack.IpRouteConfig != nil {
+			network.Routes = makeRoutes(stack.IpRouteConfig.IpRoute, guest.Net)
+		}
+		if stack.DnsConfig != nil {
+			network.DNSServers = stack.DnsConfig.IpAddress
+			network.DNSSearchDomains = stack.DnsConfig.SearchDomain
+		}
+	}
+	return &network
+}
+
+// makeRoutes keeps the routes going through a gateway. The device of a route is the index of its NIC in the guest
+// NICs.
+func makeRoutes(ipRoutes []types.NetIpRouteConfigInfoIpRoute, nics []types.GuestNicInfo) []v1beta1.GuestNetworkRoute {
+	var routes []v1beta1.GuestNetworkRoute
+	for _, ipRoute := range ipRoutes {
+		gateway := ipRoute.Gateway.IpAddress
+		if gateway == "" || !isRoutable(gateway) {
+			continue
+		}
+		route := v1beta1.GuestNetworkRoute{
+			Destination: ipRoute.Network + "/" + strconv.Itoa(int(ipRoute.PrefixLength)),
+			Gateway:     gateway,
+		}
+		if device, err := strconv.Atoi(ipRoute.Gateway.Device); err == nil && device >= 0 && device < len(nics) {
+			route.MAC = strings.ToLower(nics[device].MacAddress)
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+func isRoutable(address string) bool {
+	ip := net.ParseIP(address)
+	return ip != nil && !ip.IsLinkLocalUnicast() && !ip.IsLoopback() && !ip.IsUnspecified()
+}
@@ -1,6 +1,7 @@
 package mapper
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"sort"
@@ -8,6 +9,10 @@ import (
 	"strings"
 
 	v1beta1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/customization"
+	"github.com/kubevirt/vm-import-operator/pkg/macaddress"
+	"github.com/kubevirt/vm-import-operator/pkg/mappings"
+	"github.com/kubevirt/vm-import-operator/pkg/naming"
 	vos "github.com/kubevirt/vm-import-operator/pkg/providers/vmware/os"
 	"github.com/kubevirt/vm-import-operator/pkg/utils"
 	"github.com/vmware/govmomi/object"
@@ -27,10 +32,14 @@ const (
 	labelTag                      = "tags"
 	vmNamePrefix                  = "vmware-"
 	vmwareDescription             = "vmware-description"
+	// trunkVLAN is the VLAN ID of the standard port groups passing all the VLANs to the guest
+	trunkVLAN = 4095
 )
 
 // bus types
 const (
+	busTypeSATA   = "sata"
+	busTypeSCSI   = "scsi"
 	busTypeUSB    = "usb"
 	busTypeVirtio = "virtio"
 )
@@ -56,6 +65,18 @@ var biosTypeMapping = map[string]*kubevirtv1.Bootloader{
 	"bios": {BIOS: &kubevirtv1.BIOS{}},
 }
 
+// interfaceModels are the interface models supported by KubeVirt
+var interfaceModels = []string{"e1000", "e1000e", "ne2k_pci", "pcnet", "rtl8139", busTypeVirtio}
+
+// diskBuses are the disk buses supported by KubeVirt
+var diskBuses = []string{busTypeSATA, busTypeSCSI, busTypeVirtio}
+
+// networkTypeBindings are the interface bindings supported by each network type. The first one is the default.
+var networkTypeBindings = map[string][]v1beta1.InterfaceBinding{
+	networkTypeMultus: {v1beta1.InterfaceBindingBridge, v1beta1.InterfaceBindingSRIOV},
+	networkTypePod:    {v1beta1.InterfaceBindingMasquerade, v1beta1.InterfaceBindingBridge},
+}
+
 // disk is an abstraction of a VMWare VirtualDisk
 type disk struct {
 	backingFileName string
@@ -70,6 +91,7 @@ type disk struct {
 // nic is an abstraction of a VMWare VirtualEthernetCard
 type nic struct {
 	name        string
+	label       string
 	network     string
 	mac         string
 	dvportgroup string
@@ -88,25 +110,34 @@ type DataVolumeCredentials struct {
 // VmwareMapper is a struct that holds attributes needed to map a vSphere VM to Kubevirt
 type VmwareMapper struct {
 	credentials    *DataVolumeCredentials
+	customization  *v1beta1.CustomizationSpec
 	disks          *[]disk
+	dvDisks        map[string]disk
+	dvDisksVMName  string
+	guestNetwork   *v1beta1.GuestNetworkStatus
 	hostProperties *mo.HostSystem
 	instanceUID    string
 	mappings       *v1beta1.VmwareMappings
 	namespace      string
+	naming         *v1beta1.NamingSpec
 	nics           *[]nic
 	osFinder       vos.OSFinder
 	vm             *object.VirtualMachine
 	vmProperties   *mo.VirtualMachine
 }
 
-// NewVmwareMapper creates a new VmwareMapper struct
-func NewVmwareMapper(vm *object.VirtualMachine, vmProperties *mo.VirtualMachine, hostProperties *mo.HostSystem, credentials *DataVolumeCredentials, mappings *v1beta1.VmwareMappings, instanceUID string, namespace string, osFinder vos.OSFinder) *VmwareMapper {
+// NewVmwareMapper creates a new VmwareMapper struct; the instance UID, target namespace, customization, guest network
+// and naming are taken from the import
+func NewVmwareMapper(vm *object.VirtualMachine, vmProperties *mo.VirtualMachine, hostProperties *mo.HostSystem, credentials *DataVolumeCredentials, mappings *v1beta1.VmwareMappings, osFinder vos.OSFinder, instance *v1beta1.VirtualMachineImport) *VmwareMapper {
 	return &VmwareMapper{
 		credentials:    credentials,
+		customization:  instance.Spec.Customization,
+		guestNetwork:   instance.Status.GuestNetwork,
 		hostProperties: hostProperties,
-		instanceUID:    instanceUID,
+		instanceUID:    string(instance.UID),
 		mappings:       mappings,
-		namespace:      namespace,
+		namespace:      utils.TargetNamespace(instance),
+		naming:         instance.Spec.Naming,
 		osFinder:       osFinder,
 		vm:             vm,
 		vmProperties:   vmProperties,
@@ -161,8 +192,15 @@ func (r *VmwareMapper) buildNics() {
 				}
 			}
 
+			var label string
+			if virtualNetwork.DeviceInfo != nil && virtualNetwork.DeviceInfo.GetDescription() != nil {
+				// e.g. "Network adapter 1"
+				label = virtualNetwork.DeviceInfo.GetDescription().Label
+			}
+
 			nic := nic{
 				name:        name,
+				label:       label,
 				mac:         virtualNetwork.MacAddress,
 				network:     network,
 				dvportgroup: dvportgroup,
@@ -288,16 +326,20 @@ func (r *VmwareMapper) getVolumeModeForDisk(mapping *v1beta1.StorageResourceMapp
 }
 
 // MapDataVolumes maps the VMware disks to CDI DataVolumes
-func (r *VmwareMapper) MapDataVolumes(_ *string, filesystemOverhead cdiv1.FilesystemOverhead) (map[string]cdiv1.DataVolume, error) {
+func (r *VmwareMapper) MapDataVolumes(targetVMName *string, filesystemOverhead cdiv1.FilesystemOverhead) (map[string]cdiv1.DataVolume, error) {
 	err := r.buildDisks()
 	if err != nil {
 		return nil, err
 	}
+	dvNames, err := r.dataVolumeNames(*targetVMName)
+	if err != nil {
+		return nil, err
+	}
 
 	dvs := make(map[string]cdiv1.DataVolume)
 
 	for _, disk := range *r.disks {
-		dvName := fmt.Sprintf("%s-%d", r.instanceUID, disk.key)
+		dvName := dvNames[disk.key]
 
 		mapping := r.getMappingForDisk(disk)
 
@@ -318,6 +360,10 @@ func (r *VmwareMapper) MapDataVolumes(_ *string, filesystemOverhead cdiv1.Filesy
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      dvName,
 				Namespace: r.namespace,
+				Annotations: map[string]string{
+					utils.SourceDiskIDAnnotation:   disk.id,
+					utils.SourceDiskNameAnnotation: disk.name,
+				},
 			},
 			Spec: cdiv1.DataVolumeSpec{
 				Source: cdiv1.DataVolumeSource{
@@ -347,8 +393,70 @@ func (r *VmwareMapper) MapDataVolumes(_ *string, filesystemOverhead cdiv1.Filesy
 	return dvs, nil
 }
 
+// defaultDataVolumeName returns the name of the data volume of the disk when there's no naming template
+func (r *VmwareMapper) defaultDataVolumeName(disk disk) string {
+	return fmt.Sprintf("%s-%d", r.instanceUID, disk.key)
+}
+
+// dataVolumeNames returns the name of the data volume of each disk of the source VM, by disk key
+func (r *VmwareMapper) dataVolumeNames(targetVMName string) (map[int32]string, error) {
+	namer := naming.NewDataVolumeNamer(r.naming)
+	names := make(map[int32]string, len(*r.disks))
+	for i, disk := range *r.disks {
+		name, err := namer.Name(naming.DataVolume{VMName: targetVMName, DiskName: disk.name, DiskID: disk.id, Index: i}, r.defaultDataVolumeName(disk))
+		if err != nil {
+			return nil, fmt.Errorf("cannot name the data volume of disk %s: %v", disk.name, err)
+		}
+		names[disk.key] = name
+	}
+	return names, nil
+}
+
+// dataVolumeDisks returns the disk of the source VM imported to each data volume, by data volume name. The names depend
+// on the target VM name, so they're only computed again when it changes.
+func (r *VmwareMapper) dataVolumeDisks(targetVMName string) map[string]disk {
+	if r.dvDisks != nil && r.dvDisksVMName == targetVMName {
+		return r.dvDisks
+	}
+	dvDisks := make(map[string]disk)
+	if err := r.buildDisks(); err != nil {
+		return dvDisks
+	}
+	dvNames, err := r.dataVolumeNames(targetVMName)
+	if err != nil {
+		return dvDisks
+	}
+	for _, disk := range *r.disks {
+		dvDisks[dvNames[disk.key]] = disk
+	}
+	r.dvDisks = dvDisks
+	r.dvDisksVMName = targetVMName
+	return dvDisks
+}
+
+// mapDiskBus returns the bus of the disk imported to the data volume: the one of its disk or storage mapping, or else
+// the VM level one, or else virtio
+func (r *VmwareMapper) mapDiskBus(dv cdiv1.DataVolume, dvDisks map[string]disk) string {
+	bus := busTypeVirtio
+	if r.mappings == nil {
+		return bus
+	}
+	if r.mappings.DiskBus != nil && *r.mappings.DiskBus != "" {
+		bus = *r.mappings.DiskBus
+	}
+	disk, found := dvDisks[dv.Name]
+	if !found {
+		return bus
+	}
+	if mapping := r.getMappingForDisk(disk); mapping != nil && mapping.DiskBus != nil && *mapping.DiskBus != "" {
+		bus = *mapping.DiskBus
+	}
+	return bus
+}
+
 // MapDisk maps a disk from the VMware VM to the Kubevirt VM.
 func (r *VmwareMapper) MapDisk(vmSpec *kubevirtv1.VirtualMachine, dv cdiv1.DataVolume) {
+	dvDisks := r.dataVolumeDisks(vmSpec.ObjectMeta.Name)
 	name := fmt.Sprintf("dv-%v", dv.Name)
 	name = utils.EnsureLabelValueLength(name)
 	volume := kubevirtv1.Volume{
@@ -364,7 +472,7 @@ func (r *VmwareMapper) MapDisk(vmSpec *kubevirtv1.VirtualMachine, dv cdiv1.DataV
 		Name: name,
 		DiskDevice: kubevirtv1.DiskDevice{
 			Disk: &kubevirtv1.DiskTarget{
-				Bus: busTypeVirtio,
+				Bus: r.mapDiskBus(dv, dvDisks),
 			},
 		},
 	}
@@ -375,13 +483,32 @@ func (r *VmwareMapper) MapDisk(vmSpec *kubevirtv1.VirtualMachine, dv cdiv1.DataV
 	// Since the import controller is iterating over a map of DVs,
 	// MapDisk gets called for each DV in a nondeterministic order which results
 	// in the disks being in an arbitrary order. This sort ensure the disks are
-	// attached in the same order as the devices on the source VM.
+	// attached in the same order as the devices on the source VM. The disks are
+	// sorted by their default names, which don't depend on the naming template.
+	defaultNames := r.defaultDiskNames(dvDisks)
+	sortName := func(disk kubevirtv1.Disk) string {
+		if defaultName, ok := defaultNames[disk.Name]; ok {
+			return defaultName
+		}
+		return disk.Name
+	}
 	sort.Slice(disks, func(i, j int) bool {
-		return disks[i].Name < disks[j].Name
+		return sortName(disks[i]) < sortName(disks[j])
 	})
 	vmSpec.Spec.Template.Spec.Domain.Devices.Disks = disks
 }
 
+// defaultDiskNames returns the name the VM disk of each data volume has when there's no naming template, by the name of
+// its VM disk
+func (r *VmwareMapper) defaultDiskNames(dvDisks map[string]disk) map[string]string {
+	defaultNames := make(map[string]string, len(dvDisks))
+	for dvName, disk := range dvDisks {
+		name := utils.EnsureLabelValueLength(fmt.Sprintf("dv-%v", dvName))
+		defaultNames[name] = utils.EnsureLabelValueLength(fmt.Sprintf("dv-%v", r.defaultDataVolumeName(disk)))
+	}
+	return defaultNames
+}
+
 // ResolveVMName resolves the target VM name
 func (r *VmwareMapper) ResolveVMName(targetVMName *string) *string {
 	vmNameBase := r.resolveVMNameBase(targetVMName)
@@ -481,7 +608,7 @@ func (r *VmwareMapper) MapVM(targetVmName *string, vmSpec *kubevirtv1.VirtualMac
 	vmSpec.Spec.Template.Spec.Networks = []kubevirtv1.Network{}
 	vmSpec.Spec.Template.Spec.Domain.Devices.Interfaces = []kubevirtv1.Interface{}
 
-	if r.mappings != nil && r.mappings.NetworkMappings != nil {
+	if r.mappings != nil {
 		// Map networks
 		vmSpec.Spec.Template.Spec.Networks, err = r.mapNetworks()
 		if err != nil {
@@ -506,6 +633,11 @@ func (r *VmwareMapper) MapVM(targetVmName *string, vmSpec *kubevirtv1.VirtualMac
 	os, _ := r.osFinder.FindOperatingSystem(r.vmProperties)
 	vmSpec.Spec.Template.Spec.Domain.Devices.Inputs = r.mapInputDevice(os)
 	vmSpec.Spec.Template.Spec.Domain.Devices.Disks = []kubevirtv1.Disk{}
+
+	// Attach the customization data
+	if err := customization.AddVolume(vmSpec, r.customization, r.guestNetwork); err != nil {
+		return nil, err
+	}
 	return vmSpec, nil
 }
 
@@ -588,52 +720,297 @@ func (r *VmwareMapper) mapInputDevice(os string) []kubevirtv1.Input {
 	return []kubevirtv1.Input{tablet}
 }
 
+// interfaceNames returns the name of the interface of each NIC of the source VM, in the order of the NICs
+func (r *VmwareMapper) interfaceNames() []string {
+	namer := naming.NewInterfaceNamer(r.naming)
+	names := make([]string, len(*r.nics))
+	for i, nic := range *r.nics {
+		nicName := nic.label
+		if nicName == "" {
+			nicName = nic.name
+		}
+		defaultName, _ := utils.NormalizeName(nic.name)
+		name, err := namer.Name(naming.Interface{NICName: nicName, Index: i}, defaultName)
+		if err != nil {
+			name = defaultName
+		}
+		names[i] = name
+	}
+	return names
+}
+
 func (r *VmwareMapper) mapNetworks() ([]kubevirtv1.Network, error) {
 	r.buildNics()
 
 	var kubevirtNetworks []kubevirtv1.Network
-	for _, nic := range *r.nics {
+	names := r.interfaceNames()
+	for i, nic := range *r.nics {
 		kubevirtNet := kubevirtv1.Network{}
-		for _, mapping := range *r.mappings.NetworkMappings {
-			if (mapping.Source.Name != nil && nic.name == *mapping.Source.Name) ||
-				(mapping.Source.ID != nil && (nic.network == *mapping.Source.ID || nic.dvportgroup == *mapping.Source.ID)) {
-				if mapping.Type == nil || *mapping.Type == networkTypePod {
-					kubevirtNet.Pod = &kubevirtv1.PodNetwork{}
-				} else if *mapping.Type == networkTypeMultus {
-					kubevirtNet.Multus = &kubevirtv1.MultusNetwork{
-						NetworkName: mapping.Target.Name,
-					}
+		nicMappings := r.getNetworkMappingsForNic(nic)
+		if len(nicMappings) == 0 {
+			// the NIC is handled according to the unmapped network policy
+			if unmappedNetworkMapping := mappings.UnmappedNetworkMapping(r.mappings.UnmappedNetworks); unmappedNetworkMapping != nil {
+				nicMappings = append(nicMappings, *unmappedNetworkMapping)
+			}
+		}
+		for _, mapping := range nicMappings {
+			if mapping.Type == nil || *mapping.Type == networkTypePod {
+				kubevirtNet.Pod = &kubevirtv1.PodNetwork{}
+			} else if *mapping.Type == networkTypeMultus {
+				kubevirtNet.Multus = &kubevirtv1.MultusNetwork{
+					NetworkName: mapping.Target.Name,
 				}
-				kubevirtNet.Name, _ = utils.NormalizeName(nic.name)
-				kubevirtNetworks = append(kubevirtNetworks, kubevirtNet)
 			}
+			kubevirtNet.Name = names[i]
+			kubevirtNetworks = append(kubevirtNetworks, kubevirtNet)
 		}
 	}
 
 	return kubevirtNetworks, nil
 }
 
+// getNetworkMappingsForNic returns the network mappings matching the network or the distributed port group of a NIC
+func (r *VmwareMapper) getNetworkMappingsForNic(nic nic) []v1beta1.NetworkResourceMappingItem {
+	var nicMappings []v1beta1.NetworkResourceMappingItem
+	if r.mappings == nil || r.mappings.NetworkMappings == nil {
+		return nicMappings
+	}
+	for _, mapping := range *r.mappings.NetworkMappings {
+		if nicMatchesMapping(nic, mapping) {
+			nicMappings = append(nicMappings, mapping)
+		}
+	}
+	return nicMappings
+}
+
+// MapUnmappedNetworks returns what happens to the NICs whose network has no mapping
+func (r *VmwareMapper) MapUnmappedNetworks() []v1beta1.UnmappedNetworkStatus {
+	r.buildNics()
+	var unmapped []v1beta1.UnmappedNetworkStatus
+	for _, nic := range *r.nics {
+		if len(r.getNetworkMappingsForNic(nic)) > 0 {
+			continue
+		}
+		nicName, network := nic.label, nic.name
+		if nic.dvportgroup != "" {
+			network = nic.dvportgroup
+		}
+		if nicName == "" {
+			nicName = nic.name
+		}
+		var policy *v1beta1.UnmappedNetworkPolicy
+		if r.mappings != nil {
+			policy = r.mappings.UnmappedNetworks
+		}
+		status := mappings.NewUnmappedNetworkStatus(nicName, network, policy)
+		if status.Action == v1beta1.UnmappedNetworkGenerate {
+			status.VLAN = r.getVLAN(nic)
+		}
+		unmapped = append(unmapped, status)
+	}
+	return unmapped
+}
+
+// getVLAN returns the VLAN ID of the port group of a NIC, 0 when it isn't tagged, or nil when it can't be determined,
+// e.g. for trunk port groups
+func (r *VmwareMapper) getVLAN(nic nic) *int32 {
+	if nic.dvportgroup != "" {
+		ref := types.ManagedObjectReference{Type: "DistributedVirtualPortgroup", Value: nic.dvportgroup}
+		portgroup := mo.DistributedVirtualPortgroup{}
+		err := object.NewDistributedVirtualPortgroup(r.vm.Client(), ref).Properties(context.TODO(), ref, []string{"config"}, &portgroup)
+		if err != nil {
+			return nil
+		}
+		setting, ok := portgroup.Config.DefaultPortConfig.(*types.VMwareDVSPortSetting)
+		if !ok {
+			return nil
+		}
+		vlan, ok := setting.Vlan.(*types.VmwareDistributedVirtualSwitchVlanIdSpec)
+		if !ok {
+			return nil
+		}
+		return &vlan.VlanId
+	}
+	if r.hostProperties == nil || r.hostProperties.Config == nil || r.hostProperties.Config.Network == nil {
+		return nil
+	}
+	for _, portgroup := range r.hostProperties.Config.Network.Portgroup {
+		if portgroup.Spec.Name == nic.name && portgroup.Spec.VlanId != trunkVLAN {
+			vlanID := portgroup.Spec.VlanId
+			return &vlanID
+		}
+	}
+	return nil
+}
+
+// MapMACAddresses returns the MAC address of each NIC of the source VM and the policy it is imported with
+func (r *VmwareMapper) MapMACAddresses(importPolicy v1beta1.MACPolicy) []macaddress.NIC {
+	r.buildNics()
+	var macs []macaddress.NIC
+	names := r.interfaceNames()
+	for i, nic := range *r.nics {
+		mapped := macaddress.NIC{MAC: nic.mac}
+		mapped.Name = names[i]
+		var mappingPolicy *v1beta1.MACPolicy
+		for _, mapping := range r.getNetworkMappingsForNic(nic) {
+			if mapping.MACPolicy != nil {
+				mappingPolicy = mapping.MACPolicy
+			}
+		}
+		mapped.Policy = macaddress.ResolvePolicy(importPolicy, mappingPolicy)
+		macs = append(macs, mapped)
+	}
+	return macs
+}
+
+// nicMatchesMapping tells whether a mapping applies to a NIC. A NIC backed by a distributed port group is also matched
+// by the name of the port group key, which identifies its network in the unmapped networks status.
+func nicMatchesMapping(nic nic, mapping v1beta1.NetworkResourceMappingItem) bool {
+	return (mapping.Source.Name != nil && (nic.name == *mapping.Source.Name || (nic.dvportgroup != "" && nic.dvportgroup == *mapping.Source.Name))) ||
+		(mapping.Source.ID != nil && (nic.network == *mapping.Source.ID || nic.dvportgroup == *mapping.Source.ID))
+}
+
 func (r *VmwareMapper) mapNetworkInterfaces(networkToType map[string]string) ([]kubevirtv1.Interface, error) {
 	r.buildNics()
 	var interfaces []kubevirtv1.Interface
-	for _, nic := range *r.nics {
+	names := r.interfaceNames()
+	for i, nic := range *r.nics {
 		kubevirtInterface := kubevirtv1.Interface{}
 		kubevirtInterface.MacAddress = nic.mac
-		kubevirtInterface.Name, _ = utils.NormalizeName(nic.name)
-		kubevirtInterface.Model = "virtio"
-		switch networkToType[kubevirtInterface.Name] {
-		case networkTypeMultus:
-			kubevirtInterface.Bridge = &kubevirtv1.InterfaceBridge{}
-			interfaces = append(interfaces, kubevirtInterface)
-		case networkTypePod:
+		kubevirtInterface.Name = names[i]
+		networkType := networkToType[kubevirtInterface.Name]
+		if _, known := networkTypeBindings[networkType]; !known {
+			continue
+		}
+		model, binding := r.mapInterfaceSettings(nic, networkType)
+		switch binding {
+		case v1beta1.InterfaceBindingSRIOV:
+			// the virtual function is passed through to the guest, so it has no model
+			kubevirtInterface.SRIOV = &kubevirtv1.InterfaceSRIOV{}
+		case v1beta1.InterfaceBindingMasquerade:
+			kubevirtInterface.Model = model
 			kubevirtInterface.Masquerade = &kubevirtv1.InterfaceMasquerade{}
-			interfaces = append(interfaces, kubevirtInterface)
+		default:
+			kubevirtInterface.Model = model
+			kubevirtInterface.Bridge = &kubevirtv1.InterfaceBridge{}
 		}
+		interfaces = append(interfaces, kubevirtInterface)
 	}
 
 	return interfaces, nil
 }
 
+// mapInterfaceSettings returns the model and the binding of the interface of the NIC: the ones of its network mapping,
+// or else the VM level ones, or else virtio and the default binding of the network type
+func (r *VmwareMapper) mapInterfaceSettings(nic nic, networkType string) (string, v1beta1.InterfaceBinding) {
+	model := busTypeVirtio
+	binding := networkTypeBindings[networkType][0]
+	if r.mappings.InterfaceModel != nil && *r.mappings.InterfaceModel != "" {
+		model = *r.mappings.InterfaceModel
+	}
+	if r.mappings.InterfaceBinding != nil && *r.mappings.InterfaceBinding != "" {
+		binding = *r.mappings.InterfaceBinding
+	}
+	for _, mapping := range r.getNetworkMappingsForNic(nic) {
+		if mapping.InterfaceModel != nil && *mapping.InterfaceModel != "" {
+			model = *mapping.InterfaceModel
+		}
+		if mapping.InterfaceBinding != nil && *mapping.InterfaceBinding != "" {
+			binding = *mapping.InterfaceBinding
+		}
+		break
+	}
+	return model, binding
+}
+
+// ValidateDeviceMappings returns why the interface models, interface bindings and disk buses of the mappings can't be
+// used by KubeVirt, or nil when they all can
+func ValidateDeviceMappings(mappings *v1beta1.VmwareMappings) []string {
+	if mappings == nil {
+		return nil
+	}
+	var failures []string
+	validateModel := func(model *string, owner string) {
+		if model != nil && *model != "" && !contains(interfaceModels, *model) {
+			failures = append(failures, fmt.Sprintf("%s uses interface model %s. Allowed values: %v", owner, *model, interfaceModels))
+		}
+	}
+	validateBus := func(bus *string, owner string) {
+		if bus != nil && *bus != "" && !contains(diskBuses, *bus) {
+			failures = append(failures, fmt.Sprintf("%s uses disk bus %s. Allowed values: %v", owner, *bus, diskBuses))
+		}
+	}
+
+	validateBinding := func(binding *v1beta1.InterfaceBinding, networkType string, owner string) {
+		if binding != nil && *binding != "" && !contains(bindingNames(networkTypeBindings[networkType]), string(*binding)) {
+			failures = append(failures, fmt.Sprintf("%s uses interface binding %s, which isn't supported on %s networks. Allowed values: %v", owner, *binding, networkType, networkTypeBindings[networkType]))
+		}
+	}
+
+	validateModel(mappings.InterfaceModel, "The VM")
+	validateBus(mappings.DiskBus, "The VM")
+	if mappings.NetworkMappings != nil {
+		for _, mapping := range *mappings.NetworkMappings {
+			owner := "Network mapping " + utils.ToLoggableID(mapping.Source.ID, mapping.Source.Name)
+			validateModel(mapping.InterfaceModel, owner)
+
+			networkType := networkTypePod
+			if mapping.Type != nil {
+				networkType = *mapping.Type
+			}
+			binding := mappings.InterfaceBinding
+			if mapping.InterfaceBinding != nil && *mapping.InterfaceBinding != "" {
+				binding = mapping.InterfaceBinding
+			}
+			validateBinding(binding, networkType, owner)
+		}
+	}
+	// the NICs of the networks without a mapping get the VM level binding on the network the policy connects them to
+	if networkType, connected := unmappedNetworkType(mappings.UnmappedNetworks); connected {
+		validateBinding(mappings.InterfaceBinding, networkType, "The VM, through the unmapped network policy,")
+	}
+	for _, storageMappings := range []*[]v1beta1.StorageResourceMappingItem{mappings.DiskMappings, mappings.StorageMappings} {
+		if storageMappings == nil {
+			continue
+		}
+		for _, mapping := range *storageMappings {
+			validateBus(mapping.DiskBus, "Storage mapping "+utils.ToLoggableID(mapping.Source.ID, mapping.Source.Name))
+		}
+	}
+	return failures
+}
+
+// unmappedNetworkType returns the type of the network the unmapped network policy connects NICs to, and whether it
+// connects them to any network
+func unmappedNetworkType(policy *v1beta1.UnmappedNetworkPolicy) (string, bool) {
+	if mappings.UnmappedNetworkAction(policy) == v1beta1.UnmappedNetworkGenerate {
+		// the generated networks are network attachment definitions
+		return networkTypeMultus, true
+	}
+	mapping := mappings.UnmappedNetworkMapping(policy)
+	if mapping == nil || mapping.Type == nil {
+		return "", false
+	}
+	return *mapping.Type, true
+}
+
+func bindingNames(bindings []v1beta1.InterfaceBinding) []string {
+	names := make([]string, 0, len(bindings))
+	for _, binding := range bindings {
+		names = append(names, string(binding))
+	}
+	return names
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 func (r *VmwareMapper) mapNetworksToTypes(networks []kubevirtv1.Network) map[string]string {
 	networkToType := make(map[string]string)
 	for _, network := range networks {
@@ -16,6 +16,8 @@ import (
 	"github.com/vmware/govmomi/vim25/types"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	kubevirtv1 "kubevirt.io/client-go/api/v1"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 )
@@ -125,7 +127,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 
 	It("should map name", func() {
 		mappings := createMinimalMapping()
-		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, instanceUID, "", osFinder)
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
 		vmSpec, err := vmMapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 		Expect(err).To(BeNil())
 
@@ -134,7 +136,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 
 	It("should map memory reservation", func() {
 		mappings := createMinimalMapping()
-		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, instanceUID, "", osFinder)
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
 		vmSpec, err := vmMapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 		Expect(err).To(BeNil())
 
@@ -144,7 +146,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 
 	It("should map machine type", func() {
 		mappings := createMinimalMapping()
-		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, instanceUID, "", osFinder)
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
 		vmSpec, err := vmMapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 		Expect(err).To(BeNil())
 
@@ -153,7 +155,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 
 	It("should map CPU topology", func() {
 		mappings := createMinimalMapping()
-		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, instanceUID, "", osFinder)
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
 		vmSpec, err := vmMapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 		Expect(err).To(BeNil())
 
@@ -162,9 +164,24 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 
 	})
 
+	It("should attach the customization data", func() {
+		mappings := createMinimalMapping()
+		customization := &v1beta1.CustomizationSpec{
+			Sysprep: &v1beta1.SysprepCustomization{ConfigMap: &v1.LocalObjectReference{Name: "unattend"}},
+		}
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{Customization: customization}))
+		vmSpec, err := vmMapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
+		Expect(err).To(BeNil())
+
+		Expect(vmSpec.Spec.Template.Spec.Volumes).To(HaveLen(1))
+		Expect(vmSpec.Spec.Template.Spec.Volumes[0].ConfigMap.Name).To(Equal("unattend"))
+		Expect(vmSpec.Spec.Template.Spec.Domain.Devices.Disks).To(HaveLen(1))
+		Expect(vmSpec.Spec.Template.Spec.Domain.Devices.Disks[0].CDRom).ToNot(BeNil())
+	})
+
 	It("should map timezone", func() {
 		mappings := createMinimalMapping()
-		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, instanceUID, "", osFinder)
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
 		vmSpec, err := vmMapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 		Expect(err).To(BeNil())
 
@@ -173,7 +190,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 
 	It("should map pod network by moref", func() {
 		mappings := createPodNetworkMapping(true)
-		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, instanceUID, "", osFinder)
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
 		vmSpec, err := vmMapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 		Expect(err).To(BeNil())
 
@@ -194,7 +211,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 
 	It("should map pod network by name", func() {
 		mappings := createPodNetworkMapping(false)
-		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, instanceUID, "", osFinder)
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
 		vmSpec, err := vmMapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 		Expect(err).To(BeNil())
 
@@ -215,7 +232,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 
 	It("should map multus network by network moref", func() {
 		mappings := createMultusNetworkMapping(true)
-		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, instanceUID, "", osFinder)
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
 		vmSpec, err := vmMapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 		Expect(err).To(BeNil())
 
@@ -236,7 +253,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 
 	It("should map multus network by name", func() {
 		mappings := createMultusNetworkMapping(false)
-		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, instanceUID, "", osFinder)
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
 		vmSpec, err := vmMapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 		Expect(err).To(BeNil())
 
@@ -255,9 +272,136 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 		Expect(*networkInterfaceMultiQueue).To(BeTrue())
 	})
 
+	It("should map MAC addresses with the policy of their network mapping", func() {
+		mappings := createMultusNetworkMapping(false)
+		regenerate := v1beta1.MACPolicyRegenerate
+		(*mappings.NetworkMappings)[0].MACPolicy = &regenerate
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
+
+		nics := vmMapper.MapMACAddresses(v1beta1.MACPolicyPreserve)
+
+		Expect(nics).To(HaveLen(1))
+		Expect(nics[0].Name).To(Equal(networkNormalizedName))
+		Expect(nics[0].MAC).To(Equal(macAddress))
+		Expect(nics[0].Policy).To(Equal(v1beta1.MACPolicyRegenerate))
+	})
+
+	It("should map MAC addresses with the policy of the import", func() {
+		mappings := createMultusNetworkMapping(true)
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
+
+		nics := vmMapper.MapMACAddresses(v1beta1.MACPolicyPreserveIfUnique)
+
+		Expect(nics).To(HaveLen(1))
+		Expect(nics[0].Policy).To(Equal(v1beta1.MACPolicyPreserveIfUnique))
+	})
+
+	It("should map the interface model and binding of the network mapping", func() {
+		mappings := createMultusNetworkMapping(false)
+		vmModel := "e1000"
+		mappingModel := "e1000e"
+		mappings.InterfaceModel = &vmModel
+		(*mappings.NetworkMappings)[0].InterfaceModel = &mappingModel
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
+		vmSpec, err := vmMapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
+		Expect(err).To(BeNil())
+
+		interfaces := vmSpec.Spec.Template.Spec.Domain.Devices.Interfaces
+		Expect(interfaces).To(HaveLen(1))
+		Expect(interfaces[0].Model).To(Equal(mappingModel))
+		Expect(interfaces[0].Bridge).To(Not(BeNil()))
+	})
+
+	It("should map the VM level interface model and binding", func() {
+		mappings := createMultusNetworkMapping(true)
+		vmModel := "e1000e"
+		sriov := v1beta1.InterfaceBindingSRIOV
+		mappings.InterfaceModel = &vmModel
+		mappings.InterfaceBinding = &sriov
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
+		vmSpec, err := vmMapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
+		Expect(err).To(BeNil())
+
+		interfaces := vmSpec.Spec.Template.Spec.Domain.Devices.Interfaces
+		Expect(interfaces).To(HaveLen(1))
+		Expect(interfaces[0].SRIOV).To(Not(BeNil()))
+		Expect(interfaces[0].Bridge).To(BeNil())
+		Expect(interfaces[0].Model).To(BeEmpty())
+		Expect(interfaces[0].MacAddress).To(Equal(macAddress))
+	})
+
+	It("should bridge the pod network when the network mapping says so", func() {
+		mappings := createPodNetworkMapping(false)
+		bridge := v1beta1.InterfaceBindingBridge
+		(*mappings.NetworkMappings)[0].InterfaceBinding = &bridge
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
+		vmSpec, err := vmMapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
+		Expect(err).To(BeNil())
+
+		interfaces := vmSpec.Spec.Template.Spec.Domain.Devices.Interfaces
+		Expect(interfaces).To(HaveLen(1))
+		Expect(interfaces[0].Bridge).To(Not(BeNil()))
+		Expect(interfaces[0].Masquerade).To(BeNil())
+		Expect(interfaces[0].Model).To(Equal("virtio"))
+	})
+
+	It("should connect the NICs whose network has no mapping to the pod network", func() {
+		mappings := createMinimalMapping()
+		mappings.UnmappedNetworks = &v1beta1.UnmappedNetworkPolicy{Action: v1beta1.UnmappedNetworkPod}
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
+		vmSpec, err := vmMapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
+		Expect(err).To(BeNil())
+
+		interfaces := vmSpec.Spec.Template.Spec.Domain.Devices.Interfaces
+		networks := vmSpec.Spec.Template.Spec.Networks
+		Expect(interfaces).To(HaveLen(1))
+		Expect(interfaces[0].Masquerade).To(Not(BeNil()))
+		Expect(networks[0].Pod).To(Not(BeNil()))
+
+		unmapped := vmMapper.MapUnmappedNetworks()
+		Expect(unmapped).To(HaveLen(1))
+		Expect(unmapped[0].NIC).To(Equal("ethernet--7"))
+		Expect(unmapped[0].Network).To(Equal(networkName))
+		Expect(unmapped[0].Action).To(Equal(v1beta1.UnmappedNetworkPod))
+	})
+
+	It("should record the VLAN of the port group of the networks to generate", func() {
+		mappings := createMinimalMapping()
+		mappings.UnmappedNetworks = &v1beta1.UnmappedNetworkPolicy{Action: v1beta1.UnmappedNetworkGenerate}
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
+
+		unmapped := vmMapper.MapUnmappedNetworks()
+		Expect(unmapped).To(HaveLen(1))
+		Expect(unmapped[0].Action).To(Equal(v1beta1.UnmappedNetworkGenerate))
+		Expect(unmapped[0].VLAN).To(Not(BeNil()))
+		Expect(*unmapped[0].VLAN).To(Equal(int32(0)))
+	})
+
+	It("should fail on the NICs whose network has no mapping by default", func() {
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, &v1beta1.VmwareMappings{}, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
+
+		unmapped := vmMapper.MapUnmappedNetworks()
+		Expect(unmapped).To(HaveLen(1))
+		Expect(unmapped[0].Action).To(Equal(v1beta1.UnmappedNetworkFail))
+	})
+
+	It("should name the interfaces and networks with the naming template", func() {
+		interfaceTemplate := "net{{.Index}}"
+		mappings := createPodNetworkMapping(true)
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{Naming: &v1beta1.NamingSpec{Interface: &interfaceTemplate}}))
+		vmSpec, err := vmMapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
+		Expect(err).To(BeNil())
+
+		interfaces := vmSpec.Spec.Template.Spec.Domain.Devices.Interfaces
+		networks := vmSpec.Spec.Template.Spec.Networks
+		Expect(interfaces[0].Name).To(Equal("net0"))
+		Expect(networks[0].Name).To(Equal("net0"))
+		Expect(vmMapper.MapMACAddresses(v1beta1.MACPolicyPreserve)[0].Name).To(Equal("net0"))
+	})
+
 	It("should disable NetworkInterfaceMultiQueue when there are no mapped interfaces", func() {
 		mappings := createMinimalMapping()
-		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, instanceUID, "", osFinder)
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
 		vmSpec, err := vmMapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{})
 		Expect(err).To(BeNil())
 
@@ -273,7 +417,7 @@ var _ = Describe("Test mapping virtual machine attributes", func() {
 
 	It("should remove any networks or interfaces from the template", func() {
 		mappings := &v1beta1.VmwareMappings{}
-		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, instanceUID, "", osFinder)
+		vmMapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
 		vmSpec, err := vmMapper.MapVM(&targetVMName, &kubevirtv1.VirtualMachine{
 			Spec: kubevirtv1.VirtualMachineSpec{
 				Template: &kubevirtv1.VirtualMachineInstanceTemplateSpec{
@@ -339,7 +483,7 @@ var _ = Describe("Test mapping disks", func() {
 				},
 			},
 		}
-		mapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, instanceUID, "", osFinder)
+		mapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
 		dvs, _ := mapper.MapDataVolumes(&targetVMName, filesystemOverhead)
 		Expect(dvs).To(HaveLen(expectedNumDisks))
 		Expect(dvs).To(HaveKey(expectedDiskName1))
@@ -349,6 +493,10 @@ var _ = Describe("Test mapping disks", func() {
 		Expect(dvs[expectedDiskName1].Spec.PVC.AccessModes[0]).To(Equal(accessModeRWM))
 		Expect(dvs[expectedDiskName1].Spec.PVC.StorageClassName).To(Equal(&storageClass))
 
+		// check that the source disk is recorded
+		Expect(dvs[expectedDiskName1].Annotations).To(HaveKeyWithValue(utils.SourceDiskNameAnnotation, diskName1))
+		Expect(dvs[expectedDiskName1].Annotations).To(HaveKey(utils.SourceDiskIDAnnotation))
+
 		// check that defaults are set correctly
 		Expect(dvs[expectedDiskName2].Spec.PVC.VolumeMode).To(Equal(&volumeModeFilesystem))
 		Expect(dvs[expectedDiskName2].Spec.PVC.AccessModes[0]).To(Equal(accessModeRWO))
@@ -363,6 +511,113 @@ var _ = Describe("Test mapping disks", func() {
 		storageResource = dvs[expectedDiskName2].Spec.PVC.Resources.Requests[v1.ResourceStorage]
 		Expect(storageResource.Value()).To(BeEquivalentTo(diskBytes2))
 	})
+
+	It("should map the disk buses", func() {
+		sata := "sata"
+		scsi := "scsi"
+		mappings := createMinimalMapping()
+		mappings.DiskBus = &sata
+		mappings.DiskMappings = &[]v1beta1.StorageResourceMappingItem{
+			{
+				Source: v1beta1.Source{
+					Name: &diskName1,
+				},
+				DiskBus: &scsi,
+			},
+		}
+		mapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{}))
+		dvs, _ := mapper.MapDataVolumes(&targetVMName, filesystemOverhead)
+		vmSpec := mapper.CreateEmptyVM(&targetVMName)
+		mapper.MapDisk(vmSpec, dvs[expectedDiskName1])
+		mapper.MapDisk(vmSpec, dvs[expectedDiskName2])
+
+		disks := vmSpec.Spec.Template.Spec.Domain.Devices.Disks
+		Expect(disks).To(HaveLen(2))
+		Expect(disks[0].Disk.Bus).To(Equal(scsi))
+		Expect(disks[1].Disk.Bus).To(Equal(sata))
+	})
+	It("should name the data volumes with the naming template", func() {
+		dvTemplate := "{{.VMName}}-{{.DiskName}}"
+		mappings := createMinimalMapping()
+		mapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{Naming: &v1beta1.NamingSpec{DataVolume: &dvTemplate}}))
+		dvs, err := mapper.MapDataVolumes(&targetVMName, filesystemOverhead)
+
+		Expect(err).To(BeNil())
+		Expect(dvs).To(HaveLen(expectedNumDisks))
+		Expect(dvs).To(HaveKey("basic-vm-disk-202-0"))
+		Expect(dvs).To(HaveKey("basic-vm-disk-202-1"))
+	})
+
+	It("should keep the order of the disks regardless of the naming template", func() {
+		// the names of the data volumes sort in the reverse order of the disks
+		dvTemplate := "disk{{if .Index}}a{{else}}b{{end}}"
+		mappings := createMinimalMapping()
+		mapper := mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, mappings, osFinder, newImport(v1beta1.VirtualMachineImportSpec{Naming: &v1beta1.NamingSpec{DataVolume: &dvTemplate}}))
+		dvs, _ := mapper.MapDataVolumes(&targetVMName, filesystemOverhead)
+		vmSpec := mapper.CreateEmptyVM(&targetVMName)
+		vmSpec.Name = targetVMName
+		mapper.MapDisk(vmSpec, dvs["diska"])
+		mapper.MapDisk(vmSpec, dvs["diskb"])
+
+		disks := vmSpec.Spec.Template.Spec.Domain.Devices.Disks
+		Expect(disks).To(HaveLen(2))
+		Expect(disks[0].Name).To(Equal("dv-diskb"))
+		Expect(disks[1].Name).To(Equal("dv-diska"))
+	})
+})
+
+var _ = Describe("Test validating device mappings", func() {
+	It("should accept supported models, bindings and buses", func() {
+		mappings := createMultusNetworkMapping(false)
+		model := "e1000e"
+		sriov := v1beta1.InterfaceBindingSRIOV
+		bus := "sata"
+		mappings.InterfaceModel = &model
+		mappings.InterfaceBinding = &sriov
+		mappings.DiskBus = &bus
+
+		Expect(mapper.ValidateDeviceMappings(mappings)).To(BeEmpty())
+	})
+
+	It("should reject unsupported models and buses", func() {
+		mappings := createMultusNetworkMapping(false)
+		model := "vmxnet3"
+		bus := "ide"
+		(*mappings.NetworkMappings)[0].InterfaceModel = &model
+		mappings.DiskMappings = &[]v1beta1.StorageResourceMappingItem{{Source: v1beta1.Source{Name: &diskName1}, DiskBus: &bus}}
+
+		failures := mapper.ValidateDeviceMappings(mappings)
+
+		Expect(failures).To(HaveLen(2))
+		Expect(failures[0]).To(ContainSubstring("interface model vmxnet3"))
+		Expect(failures[1]).To(ContainSubstring("disk bus ide"))
+	})
+
+	It("should reject SR-IOV on the pod network", func() {
+		mappings := createPodNetworkMapping(false)
+		sriov := v1beta1.InterfaceBindingSRIOV
+		mappings.InterfaceBinding = &sriov
+
+		failures := mapper.ValidateDeviceMappings(mappings)
+
+		Expect(failures).To(HaveLen(1))
+		Expect(failures[0]).To(ContainSubstring("interface binding sriov, which isn't supported on pod networks"))
+	})
+
+	It("should reject the VM level binding on the network of the unmapped network policy", func() {
+		mappings := createPodNetworkMapping(false)
+		masquerade := v1beta1.InterfaceBindingMasquerade
+		mappings.InterfaceBinding = &masquerade
+		mappings.UnmappedNetworks = &v1beta1.UnmappedNetworkPolicy{
+			Action: v1beta1.UnmappedNetworkMultus,
+			Target: &v1beta1.ObjectIdentifier{Name: "default-nad"},
+		}
+
+		failures := mapper.ValidateDeviceMappings(mappings)
+
+		Expect(failures).To(HaveLen(1))
+		Expect(failures[0]).To(ContainSubstring("unmapped network policy, uses interface binding masquerade, which isn't supported on multus networks"))
+	})
 })
 
 func createMinimalMapping() *v1beta1.VmwareMappings {
@@ -418,3 +673,10 @@ func createPodNetworkMapping(byMoRef bool) *v1beta1.VmwareMappings {
 		DiskMappings:    &[]v1beta1.StorageResourceMappingItem{},
 	}
 }
+
+func newImport(spec v1beta1.VirtualMachineImportSpec) *v1beta1.VirtualMachineImport {
+	return &v1beta1.VirtualMachineImport{
+		ObjectMeta: metav1.ObjectMeta{UID: k8stypes.UID(instanceUID)},
+		Spec:       spec,
+	}
+}
@@ -19,9 +19,23 @@ func MergeMappings(externalMappingSpec *v1beta1.ResourceMappingSpec, vmiMapping
 	diskMappings := primaryMappings.DiskMappings
 
 	vmwareMappings := v1beta1.VmwareMappings{
-		DiskMappings:    diskMappings,
-		NetworkMappings: networkMappings,
-		StorageMappings: storageMappings,
+		DiskMappings:     diskMappings,
+		NetworkMappings:  networkMappings,
+		StorageMappings:  storageMappings,
+		InterfaceModel:   primaryMappings.InterfaceModel,
+		InterfaceBinding: primaryMappings.InterfaceBinding,
+		DiskBus:          primaryMappings.DiskBus,
+		UnmappedNetworks: mappings.MergeUnmappedNetworkPolicy(primaryMappings.UnmappedNetworks, secondaryMappings.UnmappedNetworks),
+	}
+	// the VM level device settings of the import CR take precedence over the ones of the external mapping
+	if vmwareMappings.InterfaceModel == nil {
+		vmwareMappings.InterfaceModel = secondaryMappings.InterfaceModel
+	}
+	if vmwareMappings.InterfaceBinding == nil {
+		vmwareMappings.InterfaceBinding = secondaryMappings.InterfaceBinding
+	}
+	if vmwareMappings.DiskBus == nil {
+		vmwareMappings.DiskBus = secondaryMappings.DiskBus
 	}
 	return &vmwareMappings
 }
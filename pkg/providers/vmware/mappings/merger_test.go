@@ -392,6 +392,30 @@ var _ = Describe("Mappings merging ", func() {
 		Expect(*result.NetworkMappings).To(ConsistOf(i(&id1, &name1, &type1), i(&id3, &name3, &type1)))
 		Expect(*result.StorageMappings).To(ConsistOf(si(&id2, &name2, &type1), si(&id4, &name4, &type2)))
 	})
+	It("Should merge and override the VM level device settings", func() {
+		e1000e := "e1000e"
+		virtio := "virtio"
+		sata := "sata"
+		sriov := v2vv1.InterfaceBindingSRIOV
+		mapping := v2vv1.VmwareMappings{
+			InterfaceModel: &e1000e,
+		}
+		externalMapping := v2vv1.VmwareMappings{
+			InterfaceModel:   &virtio,
+			InterfaceBinding: &sriov,
+			DiskBus:          &sata,
+		}
+
+		spec := v2vv1.ResourceMappingSpec{
+			VmwareMappings: &externalMapping,
+		}
+		result := mappings.MergeMappings(&spec, &mapping)
+
+		Expect(result).To(Not(BeNil()))
+		Expect(*result.InterfaceModel).To(Equal(e1000e))
+		Expect(*result.InterfaceBinding).To(Equal(sriov))
+		Expect(*result.DiskBus).To(Equal(sata))
+	})
 })
 
 func i(id *string, name *string, tp *string) v2vv1.NetworkResourceMappingItem {
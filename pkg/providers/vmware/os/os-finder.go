@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	"github.com/kubevirt/vm-import-operator/pkg/os"
 	"github.com/vmware/govmomi/vim25/mo"
 )
@@ -22,10 +23,15 @@ type OSFinder interface {
 // VmwareOSFinder provides Vmware VM OS information
 type VmwareOSFinder struct {
 	OsMapProvider os.OSMapProvider
+	// Inspection of the guest, if any, takes precedence over the OS information of the VM
+	Inspection *v1beta1.GuestInspectionStatus
 }
 
 // FindOperatingSystem tries to find the guest operating system name of the given Vmware VM
 func (r VmwareOSFinder) FindOperatingSystem(vm *mo.VirtualMachine) (string, error) {
+	if inspected, found := os.InspectedOperatingSystem(r.Inspection); found {
+		return inspected, nil
+	}
 	_, osInfoToCommon, err := r.OsMapProvider.GetOSMaps()
 	if err != nil {
 		return "", err
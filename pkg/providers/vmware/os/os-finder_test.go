@@ -3,6 +3,7 @@ package os_test
 import (
 	"fmt"
 
+	"github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	"github.com/kubevirt/vm-import-operator/pkg/providers/vmware/os"
 	"github.com/onsi/ginkgo/extensions/table"
 	"github.com/vmware/govmomi/simulator"
@@ -41,6 +42,26 @@ var _ = Describe("OS finder ", func() {
 		Expect(os).To(BeEquivalentTo("rhel6.9"))
 	})
 
+	It("should prefer the OS found by the inspection of the guest", func() {
+		model := simulator.VPX()
+		_ = model.Create()
+		server := model.Service.NewServer()
+		defer model.Remove()
+		defer server.Close()
+
+		vm := simulator.Map.Any("VirtualMachine").(*simulator.VirtualMachine)
+		vm.VirtualMachine.Summary.Guest.GuestId = "rhel6Guest"
+		inspectedFinder := os.VmwareOSFinder{
+			OsMapProvider: &mockOsMapProvider{},
+			Inspection:    &v1beta1.GuestInspectionStatus{OperatingSystem: "rhel6.10"},
+		}
+
+		os, err := inspectedFinder.FindOperatingSystem(&vm.VirtualMachine)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os).To(BeEquivalentTo("rhel6.10"))
+	})
+
 	It("should find OS from Config.GuestId if Guest.GuestId isn't available", func() {
 		model := simulator.VPX()
 		_ = model.Create()
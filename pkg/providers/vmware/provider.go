@@ -1,16 +1,20 @@
 package vmware
 
 import (
+	"context"
 	"encoding/xml"
 	"fmt"
+	"strings"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 
 	"github.com/kubevirt/vm-import-operator/pkg/pods"
+	"github.com/kubevirt/vm-import-operator/pkg/propagation"
 
 	"github.com/kubevirt/vm-import-operator/pkg/conditions"
 	"github.com/kubevirt/vm-import-operator/pkg/configmaps"
+	"github.com/kubevirt/vm-import-operator/pkg/customization"
 	"github.com/kubevirt/vm-import-operator/pkg/guestconversion"
 	oapiv1 "github.com/openshift/api/template/v1"
 	tempclient "github.com/openshift/client-go/template/clientset/versioned/typed/template/v1"
@@ -38,6 +42,7 @@ import (
 	vtemplates "github.com/kubevirt/vm-import-operator/pkg/providers/vmware/templates"
 	"github.com/kubevirt/vm-import-operator/pkg/secrets"
 	"github.com/kubevirt/vm-import-operator/pkg/templates"
+	"github.com/kubevirt/vm-import-operator/pkg/tracing"
 	"github.com/kubevirt/vm-import-operator/pkg/utils"
 	"github.com/kubevirt/vm-import-operator/pkg/virtualmachines"
 )
@@ -83,8 +88,10 @@ func NewVmwareProvider(vmiObjectMeta metav1.ObjectMeta, vmiTypeMeta metav1.TypeM
 	dataVolumesManager := datavolumes.NewManager(client)
 	virtualMachineManager := virtualmachines.NewManager(client)
 	podsManager := pods.NewManager(client)
-	templateProvider := templates.NewTemplateProvider(tempClient)
+	templateProvider := templates.NewTemplateProviderFor(ctrlConfig.TemplatesBackend(), client, tempClient)
 	osFinder := vos.VmwareOSFinder{OsMapProvider: os.NewOSMapProvider(client, ctrlConfig.OsConfigMapName(), ctrlConfig.OsConfigMapNamespace())}
+	templateFinder := vtemplates.NewTemplateFinder(templateProvider, &osFinder)
+	templateFinder.Namespace = ctrlConfig.TemplatesNamespace()
 	return VmwareProvider{
 		vmiObjectMeta:         vmiObjectMeta,
 		vmiTypeMeta:           vmiTypeMeta,
@@ -96,7 +103,7 @@ func NewVmwareProvider(vmiObjectMeta metav1.ObjectMeta, vmiTypeMeta metav1.TypeM
 		podsManager:           &podsManager,
 		osFinder:              &osFinder,
 		templateHandler:       templates.NewTemplateHandler(templateProvider),
-		templateFinder:        vtemplates.NewTemplateFinder(templateProvider, osFinder),
+		templateFinder:        templateFinder,
 	}
 }
 
@@ -126,15 +133,37 @@ func (r *VmwareProvider) Init(secret *corev1.Secret, instance *v1beta1.VirtualMa
 		return fmt.Errorf("vmware secret password cannot be empty")
 	}
 	r.instance = instance
+	if r.templateFinder != nil {
+		r.templateFinder.Spec = instance.Spec.Template
+	}
+	r.setInspection(instance.Status.GuestInspection)
 	return nil
 }
 
-// CreateMapper creates a VM mapper for this provider.
+// setInspection feeds the inspection of the guest to the OS finder, shared by the template finder and the mapper
+func (r *VmwareProvider) setInspection(inspection *v1beta1.GuestInspectionStatus) {
+	if r.osFinder != nil {
+		r.osFinder.Inspection = inspection
+	}
+}
+
+// CreateMapper creates a VM mapper for this provider, along with the credentials its data volumes read in the target
+// namespace.
 func (r *VmwareProvider) CreateMapper() (provider.Mapper, error) {
 	credentials, err := r.prepareDataVolumeCredentials()
 	if err != nil {
 		return nil, err
 	}
+	return r.createMapper(credentials)
+}
+
+// CreateValidationMapper creates the mapper validating the import. The credentials of the data volumes are left out,
+// so they aren't copied into the target namespace of an import that may be blocked.
+func (r *VmwareProvider) CreateValidationMapper() (provider.Mapper, error) {
+	return r.createMapper(&mapper.DataVolumeCredentials{})
+}
+
+func (r *VmwareProvider) createMapper(credentials *mapper.DataVolumeCredentials) (provider.Mapper, error) {
 	vmwareClient, err := r.getClient()
 	if err != nil {
 		return nil, err
@@ -147,18 +176,18 @@ func (r *VmwareProvider) CreateMapper() (provider.Mapper, error) {
 	if err != nil {
 		return nil, err
 	}
-	hostProperties, err := vmwareClient.GetVMHostProperties(vm)
+	hostProperties, err := vmwareClient.GetVMHostProperties(r.traceContext(), vm)
 	if err != nil {
 		return nil, err
 	}
-	return mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, r.resourceMapping, string(r.vmiObjectMeta.UID), r.vmiObjectMeta.Namespace, r.osFinder), nil
+	return mapper.NewVmwareMapper(vm, vmProperties, hostProperties, credentials, r.resourceMapping, r.osFinder, r.instance), nil
 }
 
-// FindTemplate attempts to find best match for a template based on the source VM
-func (r *VmwareProvider) FindTemplate() (*oapiv1.Template, error) {
+// FindTemplate attempts to find best match for a template based on the source VM, and returns why it was chosen
+func (r *VmwareProvider) FindTemplate() (*oapiv1.Template, string, error) {
 	vm, err := r.getVmProperties()
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	return r.templateFinder.FindTemplate(vm)
 }
@@ -182,6 +211,26 @@ func (r *VmwareProvider) ProcessTemplate(template *oapiv1.Template, vmName *stri
 	return vm, nil
 }
 
+// UpdateOperatingSystem relabels the VM after the operating system found by the inspection of its guest, using the
+// metadata of the template matching that operating system
+func (r *VmwareProvider) UpdateOperatingSystem(vm *v1.VirtualMachine, inspection *v1beta1.GuestInspectionStatus) error {
+	r.setInspection(inspection)
+	vmProperties, err := r.getVmProperties()
+	if err != nil {
+		return err
+	}
+	template, _, err := r.templateFinder.FindTemplate(vmProperties)
+	if err != nil {
+		return err
+	}
+	labels, annotations, err := r.templateFinder.GetMetadata(template, vmProperties)
+	if err != nil {
+		return err
+	}
+	templates.UpdateMetadata(vm, template, labels, annotations)
+	return nil
+}
+
 // PrepareResourceMapping merges the external resource mapping with the mapping provided in the VirtualMachineImport spec
 func (r *VmwareProvider) PrepareResourceMapping(externalResourceMapping *v1beta1.ResourceMappingSpec, vmiSpec v1beta1.VirtualMachineImportSourceSpec) {
 	r.resourceMapping = mappings.MergeMappings(externalResourceMapping, vmiSpec.Vmware.Mappings)
@@ -193,7 +242,7 @@ func (r *VmwareProvider) LoadVM(sourceSpec v1beta1.VirtualMachineImportSourceSpe
 	if err != nil {
 		return err
 	}
-	vm, err := vmwareClient.GetVM(sourceSpec.Vmware.VM.ID, sourceSpec.Vmware.VM.Name, nil, nil)
+	vm, err := vmwareClient.GetVM(r.traceContext(), sourceSpec.Vmware.VM.ID, sourceSpec.Vmware.VM.Name, nil, nil)
 	if err != nil {
 		return err
 	}
@@ -240,7 +289,88 @@ func (r *VmwareProvider) StartVM() error {
 	if err != nil {
 		return err
 	}
-	return vmwareClient.StartVM(vm.Reference().Value)
+	return vmwareClient.StartVM(r.traceContext(), vm.Reference().Value)
+}
+
+// DeleteVM destroys the source VM.
+func (r *VmwareProvider) DeleteVM() error {
+	vmwareClient, err := r.getClient()
+	if err != nil {
+		return err
+	}
+	vm, err := r.getVM()
+	if err != nil {
+		return err
+	}
+	return vmwareClient.DeleteVM(r.traceContext(), vm.Reference().Value)
+}
+
+// MarkVMMigrated records in a custom attribute and in the notes of the source VM that it was migrated to the target.
+func (r *VmwareProvider) MarkVMMigrated(target string) error {
+	vmwareClient, err := r.getClient()
+	if err != nil {
+		return err
+	}
+	vm, err := r.getVM()
+	if err != nil {
+		return err
+	}
+	return vmwareClient.MarkVMMigrated(r.traceContext(), vm.Reference().Value, target)
+}
+
+// UnmarkVMMigrated clears the custom attribute of MarkVMMigrated and notes in the notes of the source VM that the
+// migration was rolled back.
+func (r *VmwareProvider) UnmarkVMMigrated(target string) error {
+	vmwareClient, err := r.getClient()
+	if err != nil {
+		return err
+	}
+	vm, err := r.getVM()
+	if err != nil {
+		return err
+	}
+	return vmwareClient.UnmarkVMMigrated(r.traceContext(), vm.Reference().Value, target)
+}
+
+// GetSourceMetadata provides the custom attributes and the folder path of the source VM. vSphere tags aren't read.
+func (r *VmwareProvider) GetSourceMetadata() (*propagation.SourceMetadata, error) {
+	vmwareClient, err := r.getClient()
+	if err != nil {
+		return nil, err
+	}
+	vm, err := r.getVM()
+	if err != nil {
+		return nil, err
+	}
+	vmProperties, err := r.getVmProperties()
+	if err != nil {
+		return nil, err
+	}
+	folder, err := vmwareClient.GetVMFolderPath(r.traceContext(), vm)
+	if err != nil {
+		return nil, err
+	}
+	return &propagation.SourceMetadata{
+		CustomAttributes: customAttributes(vmProperties),
+		Folder:           folder,
+	}, nil
+}
+
+// customAttributes returns the values of the custom attributes of the VM by name
+func customAttributes(vmProperties *mo.VirtualMachine) map[string]string {
+	names := make(map[int32]string)
+	for _, field := range vmProperties.AvailableField {
+		names[field.Key] = field.Name
+	}
+	attributes := make(map[string]string)
+	for _, value := range vmProperties.CustomValue {
+		if stringValue, ok := value.(*types.CustomFieldStringValue); ok {
+			if name, ok := names[stringValue.Key]; ok {
+				attributes[name] = stringValue.Value
+			}
+		}
+	}
+	return attributes
 }
 
 // StopVM powers off the source VM.
@@ -259,7 +389,7 @@ func (r *VmwareProvider) StopVM(instance *v1beta1.VirtualMachineImport, client c
 	}
 
 	if vmProperties.Runtime.PowerState != types.VirtualMachinePowerStatePoweredOff {
-		err = vmwareClient.StopVM(vm.Reference().Value)
+		err = vmwareClient.StopVM(r.traceContext(), vm.Reference().Value)
 		if err != nil {
 			return err
 		}
@@ -280,7 +410,7 @@ func (r *VmwareProvider) CreateVMSnapshot() (string, error) {
 		return "", err
 	}
 
-	snapshotRef, err := r.vmwareClient.CreateVMSnapshot(vm.Reference().Value, warmMigrationSnapshotName, warmMigrationSnapshotDescription, false, true)
+	snapshotRef, err := r.vmwareClient.CreateVMSnapshot(r.traceContext(), vm.Reference().Value, warmMigrationSnapshotName, warmMigrationSnapshotDescription, false, true)
 	if err != nil {
 		return "", err
 	}
@@ -304,13 +434,12 @@ func (r *VmwareProvider) CleanUp(failure bool, cr *v1beta1.VirtualMachineImport,
 		Name:      r.vmiObjectMeta.Name,
 		Namespace: r.vmiObjectMeta.Namespace,
 	}
-
-	err = r.secretsManager.DeleteFor(vmiName)
+	err = r.secretsManager.DeleteFor(cr)
 	if err != nil {
 		errs = append(errs, err)
 	}
 
-	err = r.configMapsManager.DeleteFor(vmiName)
+	err = r.configMapsManager.DeleteFor(cr)
 	if err != nil {
 		errs = append(errs, err)
 	}
@@ -321,16 +450,16 @@ func (r *VmwareProvider) CleanUp(failure bool, cr *v1beta1.VirtualMachineImport,
 	}
 	// remove all the snapshots that were created for warm import
 	if cr.Status.WarmImport.RootSnapshot != nil {
-		err = r.vmwareClient.RemoveVMSnapshot(vm.Reference().Value, *cr.Status.WarmImport.RootSnapshot, true, nil)
+		err = r.vmwareClient.RemoveVMSnapshot(r.traceContext(), vm.Reference().Value, *cr.Status.WarmImport.RootSnapshot, true, nil)
 		if err != nil {
 			errs = append(errs, err)
 		}
 	}
 
-	// only clean up the pod on success,
-	// since the pod log is important for debugging
-	if !failure {
-		err = r.podsManager.DeleteFor(vmiName)
+	// on failure, only clean up the pod once its log is stored
+	// in the status, since the log is important for debugging
+	if !failure || guestconversion.IsLogStored(cr.Status.GuestConversion) {
+		err = r.podsManager.DeleteFor(cr)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -362,7 +491,7 @@ func (r *VmwareProvider) TestConnection() error {
 	if err != nil {
 		return err
 	}
-	return vmwareClient.TestConnection()
+	return vmwareClient.TestConnection(r.traceContext())
 }
 
 // Validate checks whether the source VM and resource mapping is valid.
@@ -370,6 +499,10 @@ func (r *VmwareProvider) Validate() ([]v1beta1.VirtualMachineImportCondition, er
 	validCondition := conditions.NewCondition(v1beta1.Valid, string(v1beta1.ValidationCompleted), "Validation completed successfully", corev1.ConditionTrue)
 	mappingCondition := conditions.NewCondition(v1beta1.MappingRulesVerified, string(v1beta1.MappingRulesVerificationCompleted), "All mapping rules checks passed", corev1.ConditionTrue)
 
+	if failures := mapper.ValidateDeviceMappings(r.resourceMapping); len(failures) > 0 {
+		validCondition = conditions.NewCondition(v1beta1.Valid, string(v1beta1.IncompleteMappingRules), strings.Join(failures, ", "), corev1.ConditionFalse)
+	}
+
 	if r.instance.Spec.Warm {
 		vmProperties, err := r.getVmProperties()
 		if err != nil {
@@ -406,6 +539,14 @@ func (r *VmwareProvider) getClient() (*vclient.RichVmwareClient, error) {
 	return r.vmwareClient, nil
 }
 
+// traceContext returns the context the spans of the calls to vCenter are started from
+func (r *VmwareProvider) traceContext() context.Context {
+	if r.instance == nil {
+		return context.Background()
+	}
+	return tracing.ImportContext(r.instance.UID)
+}
+
 func (r *VmwareProvider) getVM() (*object.VirtualMachine, error) {
 	if r.vm == nil {
 		err := r.LoadVM(r.instance.Spec.Source)
@@ -426,7 +567,7 @@ func (r *VmwareProvider) getVmProperties() (*mo.VirtualMachine, error) {
 		return nil, err
 	}
 	if r.vmProperties == nil {
-		properties, err := vmwareClient.GetVMProperties(vm)
+		properties, err := vmwareClient.GetVMProperties(r.traceContext(), vm)
 		if err != nil {
 			return nil, err
 		}
@@ -454,8 +595,7 @@ func (r *VmwareProvider) prepareDataVolumeCredentials() (*mapper.DataVolumeCrede
 }
 
 func (r *VmwareProvider) ensureSecretIsPresent(keyAccess, keySecret string) (*corev1.Secret, error) {
-	vmiName := r.getNamespacedName()
-	secret, err := r.secretsManager.FindFor(vmiName)
+	secret, err := r.secretsManager.FindFor(r.instance)
 	if err != nil {
 		return nil, err
 	}
@@ -468,31 +608,29 @@ func (r *VmwareProvider) ensureSecretIsPresent(keyAccess, keySecret string) (*co
 	return secret, nil
 }
 
+// createSecret copies the credentials of the provider into the target namespace, limited to the access and secret keys
+// CDI reads
 func (r *VmwareProvider) createSecret(username, password string) (*corev1.Secret, error) {
-	vmiName := r.getNamespacedName()
 	newSecret := corev1.Secret{
 		Data: map[string][]byte{
 			keyAccessKey: []byte(username),
 			keySecretKey: []byte(password),
 		},
 	}
-	newSecret.OwnerReferences = []metav1.OwnerReference{
-		ownerreferences.NewVMImportOwnerReference(r.vmiTypeMeta, r.vmiObjectMeta),
-	}
-	err := r.secretsManager.CreateFor(&newSecret, vmiName)
+	ownerreferences.SetVMImportReference(&newSecret, r.vmiTypeMeta, r.instance, ownerreferences.NewVMImportOwnerReference)
+	err := r.secretsManager.CreateFor(&newSecret, r.instance)
 	if err != nil {
 		return nil, err
 	}
 	return &newSecret, nil
 }
 
-func (r *VmwareProvider) NeedsGuestConversion() bool {
-	return true
+func (r *VmwareProvider) NeedsGuestConversion() (bool, error) {
+	return true, nil
 }
 
 func (r *VmwareProvider) GetGuestConversionPod() (*corev1.Pod, error) {
-	vmiName := r.getNamespacedName()
-	pod, err := r.podsManager.FindFor(vmiName)
+	pod, err := r.podsManager.FindFor(r.instance)
 	if err != nil {
 		return nil, err
 	}
@@ -508,8 +646,7 @@ func (r *VmwareProvider) LaunchGuestConversionPod(vmSpec *v1.VirtualMachine, dat
 }
 
 func (r *VmwareProvider) ensureConfigMapIsPresent(vmSpec *v1.VirtualMachine, dataVolumes map[string]cdiv1.DataVolume) (*corev1.ConfigMap, error) {
-	vmiName := r.getNamespacedName()
-	configMap, err := r.configMapsManager.FindFor(vmiName)
+	configMap, err := r.configMapsManager.FindFor(r.instance)
 	if err != nil {
 		return nil, err
 	}
@@ -523,7 +660,6 @@ func (r *VmwareProvider) ensureConfigMapIsPresent(vmSpec *v1.VirtualMachine, dat
 }
 
 func (r *VmwareProvider) createConfigMap(vmSpec *v1.VirtualMachine, dataVolumes map[string]cdiv1.DataVolume) (*corev1.ConfigMap, error) {
-	vmiName := r.getNamespacedName()
 	domain := guestconversion.MakeLibvirtDomain(vmSpec, dataVolumes)
 	domXML, err := xml.Marshal(domain)
 	if err != nil {
@@ -534,10 +670,11 @@ func (r *VmwareProvider) createConfigMap(vmSpec *v1.VirtualMachine, dataVolumes
 			"input.xml": domXML,
 		},
 	}
-	newConfigMap.OwnerReferences = []metav1.OwnerReference{
-		ownerreferences.NewVMImportOwnerReference(r.vmiTypeMeta, r.vmiObjectMeta),
+	if script := customization.RestoreNetworkScript(r.instance.Spec.Customization, r.instance.Status.GuestNetwork); script != nil {
+		newConfigMap.BinaryData[guestconversion.RestoreNetworkScriptKey] = script
 	}
-	err = r.configMapsManager.CreateFor(newConfigMap, vmiName)
+	ownerreferences.SetVMImportReference(newConfigMap, r.vmiTypeMeta, r.instance, ownerreferences.NewVMImportOwnerReference)
+	err = r.configMapsManager.CreateFor(newConfigMap, r.instance)
 	if err != nil {
 		return nil, err
 	}
@@ -545,8 +682,7 @@ func (r *VmwareProvider) createConfigMap(vmSpec *v1.VirtualMachine, dataVolumes
 }
 
 func (r *VmwareProvider) ensureGuestConversionPodIsPresent(vmSpec *v1.VirtualMachine, dataVolumes map[string]cdiv1.DataVolume, libvirtConfigMap *corev1.ConfigMap) (*corev1.Pod, error) {
-	vmiName := r.getNamespacedName()
-	pod, err := r.podsManager.FindFor(vmiName)
+	pod, err := r.podsManager.FindFor(r.instance)
 	if err != nil {
 		return nil, err
 	}
@@ -560,21 +696,11 @@ func (r *VmwareProvider) ensureGuestConversionPodIsPresent(vmSpec *v1.VirtualMac
 }
 
 func (r *VmwareProvider) createGuestConversionPod(vmSpec *v1.VirtualMachine, dataVolumes map[string]cdiv1.DataVolume, libvirtConfigMap *corev1.ConfigMap) (*corev1.Pod, error) {
-	vmiName := r.getNamespacedName()
-	pod := guestconversion.MakeGuestConversionPodSpec(vmSpec, dataVolumes, libvirtConfigMap)
-	pod.OwnerReferences = []metav1.OwnerReference{
-		ownerreferences.NewVMImportControllerReference(r.vmiTypeMeta, r.vmiObjectMeta),
-	}
-	err := r.podsManager.CreateFor(pod, vmiName)
+	pod := guestconversion.MakeGuestConversionPodSpec(vmSpec, dataVolumes, libvirtConfigMap, r.instance.Spec.GuestConversion)
+	ownerreferences.SetVMImportReference(pod, r.vmiTypeMeta, r.instance, ownerreferences.NewVMImportControllerReference)
+	err := r.podsManager.CreateFor(pod, r.instance)
 	if err != nil {
 		return nil, err
 	}
 	return pod, nil
 }
-
-func (r *VmwareProvider) getNamespacedName() k8stypes.NamespacedName {
-	return k8stypes.NamespacedName{
-		Name:      r.vmiObjectMeta.Name,
-		Namespace: r.vmiObjectMeta.Namespace,
-	}
-}
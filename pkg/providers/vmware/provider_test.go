@@ -599,6 +599,10 @@ func (t *mockTemplateProvider) Find(_ *string, _ *string, _ *string, _ *string)
 	return nil, nil
 }
 
+func (t *mockTemplateProvider) Get(_ string, _ string) (*templatev1.Template, error) {
+	return nil, nil
+}
+
 func (t *mockTemplateProvider) Process(_ string, _ *string, _ *templatev1.Template) (*templatev1.Template, error) {
 	vm := kubevirtv1.VirtualMachine{
 		TypeMeta: metav1.TypeMeta{
@@ -681,3 +685,54 @@ func (c *mockClient) List(ctx context.Context, objectList runtime.Object, opts .
 func (c *mockClient) Status() client.StatusWriter {
 	return c
 }
+
+var _ = Describe("Reading the guest network", func() {
+	It("should read the NICs, routes and name servers reported by the VMware Tools", func() {
+		vmProperties := &mo.VirtualMachine{
+			Guest: &types.GuestInfo{
+				Net: []types.GuestNicInfo{
+					{
+						MacAddress: "00:50:56:AA:00:01",
+						IpConfig: &types.NetIpConfigInfo{
+							IpAddress: []types.NetIpConfigInfoIpAddress{
+								{IpAddress: "10.0.0.5", PrefixLength: 24, Origin: "manual"},
+								{IpAddress: "fe80::250:56ff:feaa:1", PrefixLength: 64, Origin: "linklayer"},
+							},
+						},
+					},
+					{
+						MacAddress: "00:50:56:aa:00:02",
+						IpConfig: &types.NetIpConfigInfo{
+							IpAddress: []types.NetIpConfigInfoIpAddress{{IpAddress: "192.168.1.7", PrefixLength: 24, Origin: "dhcp"}},
+						},
+					},
+				},
+				IpStack: []types.GuestStackInfo{{
+					DnsConfig: &types.NetDnsConfigInfo{IpAddress: []string{"10.0.0.2"}, SearchDomain: []string{"example.com"}},
+					IpRouteConfig: &types.NetIpRouteConfigInfo{
+						IpRoute: []types.NetIpRouteConfigInfoIpRoute{
+							{Network: "0.0.0.0", PrefixLength: 0, Gateway: types.NetIpRouteConfigInfoGateway{IpAddress: "10.0.0.1", Device: "0"}},
+							{Network: "10.0.0.0", PrefixLength: 24, Gateway: types.NetIpRouteConfigInfoGateway{Device: "0"}},
+						},
+					},
+				}},
+			},
+		}
+
+		network := makeGuestNetwork(vmProperties)
+
+		Expect(network).To(Equal(&v1beta1.GuestNetworkStatus{
+			Interfaces: []v1beta1.GuestNetworkInterface{
+				{MAC: "00:50:56:aa:00:01", IPAddresses: []string{"10.0.0.5/24"}},
+				{MAC: "00:50:56:aa:00:02", DHCP: true},
+			},
+			Routes:           []v1beta1.GuestNetworkRoute{{Destination: "0.0.0.0/0", Gateway: "10.0.0.1", MAC: "00:50:56:aa:00:01"}},
+			DNSServers:       []string{"10.0.0.2"},
+			DNSSearchDomains: []string{"example.com"},
+		}))
+	})
+
+	It("should report nothing without the VMware Tools", func() {
+		Expect(makeGuestNetwork(&mo.VirtualMachine{})).To(BeNil())
+	})
+})
@@ -2,8 +2,8 @@ package templates
 
 import (
 	"fmt"
-	"sort"
 
+	"github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	"github.com/kubevirt/vm-import-operator/pkg/providers/vmware/os"
 	"github.com/kubevirt/vm-import-operator/pkg/templates"
 	templatev1 "github.com/openshift/api/template/v1"
@@ -22,6 +22,10 @@ var (
 type TemplateFinder struct {
 	templateProvider templates.TemplateProvider
 	osFinder         os.OSFinder
+	// Namespace the templates are searched in, unless the spec of the import sets it
+	Namespace string
+	// Spec of the import, if any, pins the template or constrains its search
+	Spec *v1beta1.TemplateSpec
 }
 
 // NewTemplateFinder creates new TemplateFinder
@@ -29,76 +33,65 @@ func NewTemplateFinder(templateProvider templates.TemplateProvider, osFinder os.
 	return &TemplateFinder{
 		templateProvider: templateProvider,
 		osFinder:         osFinder,
+		Namespace:        templateNamespace,
 	}
 }
 
-// FindTemplate attempts to find best match for a template based on the source VM
-func (f *TemplateFinder) FindTemplate(vm *mo.VirtualMachine) (*templatev1.Template, error) {
+// FindTemplate attempts to find best match for a template based on the source VM, and returns why it was chosen
+func (f *TemplateFinder) FindTemplate(vm *mo.VirtualMachine) (*templatev1.Template, string, error) {
+	// the pinned template doesn't depend on the OS, whose lookup may fail
+	if templates.IsPinned(f.Spec) {
+		return templates.SelectPinned(f.templateProvider, f.Spec, f.Namespace)
+	}
 	os, err := f.osFinder.FindOperatingSystem(vm)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	// look for a small template first, then look for a medium template
 	// if neither a small server nor desktop template can be found
-	var template *templatev1.Template
-
-loop:
-	for _, flavor := range []string{smallFlavor, mediumFlavor} {
-		for _, workload := range []string{serverWorkload, desktopWorkload} {
-			tmpls, err := f.templateProvider.Find(&templateNamespace, &os, &workload, &flavor)
-			if err != nil {
-				return nil, err
-			}
-
-			if len(tmpls.Items) == 0 {
-				continue
-			} else {
-				// Take first which matches label selector
-				sort.Slice(tmpls.Items, func(i, j int) bool {
-					return tmpls.Items[j].CreationTimestamp.Before(&tmpls.Items[i].CreationTimestamp)
-				})
-				template = &tmpls.Items[0]
-				break loop
-			}
-		}
+	search := templates.Search{
+		Namespace: f.Namespace,
+		OS:        os,
+		Workloads: []string{serverWorkload, desktopWorkload},
+		Flavors:   []string{smallFlavor, mediumFlavor},
+	}
+	template, reason, err := templates.Select(f.templateProvider, f.Spec, search)
+	if err != nil {
+		return nil, "", err
 	}
-
 	if template == nil {
-		return nil, fmt.Errorf("template not found for %s OS", os)
+		return nil, "", fmt.Errorf("template not found for %s OS", os)
 	}
 
-	return template, nil
+	return template, reason, nil
 }
 
-// GetMetadata fetches OS and workload specific labels and annotations
+// GetMetadata fetches OS and workload specific labels and annotations. The OS ones are left out for a pinned template
+// when the OS can't be found.
 func (f *TemplateFinder) GetMetadata(template *templatev1.Template, vm *mo.VirtualMachine) (map[string]string, map[string]string, error) {
+	var osName *string
+	annotations := map[string]string{}
 	os, err := f.osFinder.FindOperatingSystem(vm)
-	if err != nil {
+	if err == nil {
+		osName = &os
+		key := fmt.Sprintf(templates.TemplateNameOsAnnotation, os)
+		annotations[key] = template.GetAnnotations()[key]
+	} else if !templates.IsPinned(f.Spec) {
 		return map[string]string{}, map[string]string{}, err
 	}
-	key := fmt.Sprintf(templates.TemplateNameOsAnnotation, os)
-	annotations := map[string]string{
-		key: template.GetAnnotations()[key],
-	}
-
-	// get workload label from the template
-	var workload *string
-	if _, ok := template.Labels[fmt.Sprintf(templates.TemplateWorkloadLabel, serverWorkload)]; ok {
-		workload = &serverWorkload
-	} else if _, ok := template.Labels[fmt.Sprintf(templates.TemplateWorkloadLabel, desktopWorkload)]; ok {
-		workload = &desktopWorkload
-	}
 
-	// get flavor label from the template
-	var flavor *string
-	if _, ok := template.Labels[fmt.Sprintf(templates.TemplateFlavorLabel, smallFlavor)]; ok {
-		flavor = &smallFlavor
-	} else if _, ok := template.Labels[fmt.Sprintf(templates.TemplateFlavorLabel, mediumFlavor)]; ok {
-		flavor = &mediumFlavor
+	// get the workload and flavor labels from the template, the ones of the spec being preferred
+	workloads := []string{serverWorkload, desktopWorkload}
+	flavors := []string{smallFlavor, mediumFlavor}
+	if f.Spec != nil {
+		workloads = templates.Prefer(f.Spec.Workload, workloads)
+		flavors = templates.Prefer(f.Spec.Flavor, flavors)
 	}
+	workload := templates.LabelValue(template, templates.TemplateWorkloadLabel, workloads)
+	flavor := templates.LabelValue(template, templates.TemplateFlavorLabel, flavors)
 
-	labels := templates.OSLabelBuilder(&os, workload, flavor)
+	labels := templates.OSLabelBuilder(osName, workload, flavor)
 
 	return labels, annotations, nil
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/vmware/govmomi/vim25/mo"
 
+	"github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	vtemplates "github.com/kubevirt/vm-import-operator/pkg/providers/vmware/templates"
 	"github.com/kubevirt/vm-import-operator/pkg/templates"
 	templatev1 "github.com/openshift/api/template/v1"
@@ -18,6 +19,7 @@ import (
 var (
 	findTemplatesMock func(name *string, os *string, workload *string, flavor *string) (*templatev1.TemplateList, error)
 	findOs            func(vm *mo.VirtualMachine) (string, error)
+	getTemplateMock   func(namespace string, name string) (*templatev1.Template, error)
 )
 var _ = Describe("Finding a Template", func() {
 	templateFinder := vtemplates.NewTemplateFinder(&mockTemplateProvider{}, &mockOsFinder{})
@@ -28,13 +30,17 @@ var _ = Describe("Finding a Template", func() {
 			templateList := createTemplatesList(template)
 			return templateList, nil
 		}
+		getTemplateMock = func(namespace string, name string) (*templatev1.Template, error) {
+			return nil, fmt.Errorf("template %s/%s not found", namespace, name)
+		}
+		templateFinder.Spec = nil
 		findOs = func(vm *mo.VirtualMachine) (string, error) {
 			return "linux", nil
 		}
 	})
 	It("should find a template for given OS: ", func() {
 		vm := &mo.VirtualMachine{}
-		template, err := templateFinder.FindTemplate(vm)
+		template, _, err := templateFinder.FindTemplate(vm)
 
 		Expect(err).To(BeNil())
 		Expect(template).To(Not(BeNil()))
@@ -48,7 +54,7 @@ var _ = Describe("Finding a Template", func() {
 		}
 
 		vm := &mo.VirtualMachine{}
-		template, err := templateFinder.FindTemplate(vm)
+		template, _, err := templateFinder.FindTemplate(vm)
 
 		Expect(err).To(BeNil())
 		Expect(template).To(Not(BeNil()))
@@ -57,7 +63,7 @@ var _ = Describe("Finding a Template", func() {
 		findTemplatesMock = func(name *string, os *string, workload *string, flavor *string) (*templatev1.TemplateList, error) {
 			return nil, fmt.Errorf("boom")
 		}
-		template, err := templateFinder.FindTemplate(&mo.VirtualMachine{})
+		template, _, err := templateFinder.FindTemplate(&mo.VirtualMachine{})
 
 		Expect(err).To(Not(BeNil()))
 		Expect(template).To(BeNil())
@@ -77,7 +83,7 @@ var _ = Describe("Finding a Template", func() {
 		}
 
 		vm := &mo.VirtualMachine{}
-		template, err := templateFinder.FindTemplate(vm)
+		template, _, err := templateFinder.FindTemplate(vm)
 
 		Expect(err).To(BeNil())
 		Expect(template.CreationTimestamp).To(Equal(newer))
@@ -89,7 +95,7 @@ var _ = Describe("Finding a Template", func() {
 		}
 
 		vm := &mo.VirtualMachine{}
-		template, err := templateFinder.FindTemplate(vm)
+		template, _, err := templateFinder.FindTemplate(vm)
 		Expect(err).To(BeNil())
 		Expect(template).ToNot(BeNil())
 		Expect(template.Labels[fmt.Sprintf(templates.TemplateWorkloadLabel, "server")]).To(Equal("true"))
@@ -105,7 +111,7 @@ var _ = Describe("Finding a Template", func() {
 		}
 
 		vm := &mo.VirtualMachine{}
-		template, err := templateFinder.FindTemplate(vm)
+		template, _, err := templateFinder.FindTemplate(vm)
 		Expect(err).To(BeNil())
 		Expect(template).ToNot(BeNil())
 		Expect(template.Labels[fmt.Sprintf(templates.TemplateWorkloadLabel, "desktop")]).To(Equal("true"))
@@ -117,7 +123,7 @@ var _ = Describe("Finding a Template", func() {
 		}
 
 		vm := &mo.VirtualMachine{}
-		template, err := templateFinder.FindTemplate(vm)
+		template, _, err := templateFinder.FindTemplate(vm)
 		Expect(err).To(BeNil())
 		Expect(template).ToNot(BeNil())
 		Expect(template.Labels[fmt.Sprintf(templates.TemplateFlavorLabel, "small")]).To(Equal("true"))
@@ -133,11 +139,107 @@ var _ = Describe("Finding a Template", func() {
 		}
 
 		vm := &mo.VirtualMachine{}
-		template, err := templateFinder.FindTemplate(vm)
+		template, _, err := templateFinder.FindTemplate(vm)
 		Expect(err).To(BeNil())
 		Expect(template).ToNot(BeNil())
 		Expect(template.Labels[fmt.Sprintf(templates.TemplateFlavorLabel, "medium")]).To(Equal("true"))
 	})
+	It("should use the template pinned by the spec:", func() {
+		namespace := "templates"
+		name := "rhel8-custom"
+		templateFinder.Spec = &v1beta1.TemplateSpec{Namespace: &namespace, Name: &name}
+		getTemplateMock = func(namespace string, name string) (*templatev1.Template, error) {
+			template := &templatev1.Template{}
+			template.Namespace = namespace
+			template.Name = name
+			return template, nil
+		}
+
+		template, reason, err := templateFinder.FindTemplate(&mo.VirtualMachine{})
+
+		Expect(err).To(BeNil())
+		Expect(template.Namespace).To(Equal("templates"))
+		Expect(template.Name).To(Equal("rhel8-custom"))
+		Expect(reason).To(ContainSubstring("Pinned"))
+	})
+	It("should use the pinned template when the OS can't be found:", func() {
+		name := "rhel8-custom"
+		templateFinder.Spec = &v1beta1.TemplateSpec{Name: &name}
+		getTemplateMock = func(namespace string, name string) (*templatev1.Template, error) {
+			template := &templatev1.Template{}
+			template.Namespace = namespace
+			template.Name = name
+			return template, nil
+		}
+		findOs = func(vm *mo.VirtualMachine) (string, error) {
+			return "", fmt.Errorf("no OS")
+		}
+
+		template, _, err := templateFinder.FindTemplate(&mo.VirtualMachine{})
+		Expect(err).To(BeNil())
+		Expect(template.Name).To(Equal("rhel8-custom"))
+
+		labels, annotations, err := templateFinder.GetMetadata(template, &mo.VirtualMachine{})
+		Expect(err).To(BeNil())
+		Expect(annotations).To(BeEmpty())
+		for label := range labels {
+			Expect(label).ToNot(HavePrefix("os.template.kubevirt.io/"))
+		}
+	})
+	It("should fail when the pinned template doesn't exist:", func() {
+		name := "rhel8-custom"
+		templateFinder.Spec = &v1beta1.TemplateSpec{Name: &name}
+
+		template, _, err := templateFinder.FindTemplate(&mo.VirtualMachine{})
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("openshift/rhel8-custom"))
+		Expect(template).To(BeNil())
+	})
+	It("should search the workload and flavor of the spec first:", func() {
+		workload := "desktop"
+		flavor := "large"
+		templateFinder.Spec = &v1beta1.TemplateSpec{Workload: &workload, Flavor: &flavor}
+		findTemplatesMock = func(name *string, os *string, workload *string, flavor *string) (*templatev1.TemplateList, error) {
+			template := createTemplate(name, os, workload, flavor)
+			return createTemplatesList(template), nil
+		}
+
+		template, reason, err := templateFinder.FindTemplate(&mo.VirtualMachine{})
+
+		Expect(err).To(BeNil())
+		Expect(template.Labels[fmt.Sprintf(templates.TemplateWorkloadLabel, "desktop")]).To(Equal("true"))
+		Expect(template.Labels[fmt.Sprintf(templates.TemplateFlavorLabel, "large")]).To(Equal("true"))
+		Expect(reason).To(Equal("Newest template for the linux OS with the desktop workload and the large flavor"))
+
+		labels, _, err := templateFinder.GetMetadata(template, &mo.VirtualMachine{})
+		Expect(err).To(BeNil())
+		Expect(labels).To(HaveKey(fmt.Sprintf(templates.TemplateWorkloadLabel, "desktop")))
+		Expect(labels).To(HaveKey(fmt.Sprintf(templates.TemplateFlavorLabel, "large")))
+	})
+	It("should only select templates with the labels of the spec:", func() {
+		templateFinder.Spec = &v1beta1.TemplateSpec{Labels: map[string]string{"release": "2.5"}}
+		now := metav1.Now()
+		older := metav1.NewTime(now.Add(-time.Minute))
+		findTemplatesMock = func(name *string, os *string, workload *string, flavor *string) (*templatev1.TemplateList, error) {
+			if *workload == "server" {
+				template := createTemplate(name, os, workload, flavor)
+				return createTemplatesList(template), nil
+			}
+			newest := createTemplate(name, os, workload, flavor)
+			newest.CreationTimestamp = now
+			labeled := createTemplate(name, os, workload, flavor)
+			labeled.CreationTimestamp = older
+			labeled.Labels["release"] = "2.5"
+			return createTemplatesList(newest, labeled), nil
+		}
+
+		template, _, err := templateFinder.FindTemplate(&mo.VirtualMachine{})
+
+		Expect(err).To(BeNil())
+		Expect(template.Labels["release"]).To(Equal("2.5"))
+		Expect(template.Labels[fmt.Sprintf(templates.TemplateWorkloadLabel, "desktop")]).To(Equal("true"))
+	})
 })
 
 func createTemplate(name *string, os *string, workload *string, flavor *string) *templatev1.Template {
@@ -174,6 +276,11 @@ func (t *mockTemplateProvider) Find(
 	return findTemplatesMock(name, os, workload, flavor)
 }
 
+// Get mocks the behavior of the client for calling template API to get a template by name
+func (t *mockTemplateProvider) Get(namespace string, name string) (*templatev1.Template, error) {
+	return getTemplateMock(namespace, name)
+}
+
 // Process mocks the behavior of the client for calling process API
 func (t *mockTemplateProvider) Process(namespace string, vmName *string, template *templatev1.Template) (*templatev1.Template, error) {
 	return &templatev1.Template{}, nil
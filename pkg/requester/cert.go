@@ -0,0 +1,101 @@
+package requester
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/cert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SelfSignedCertDir is the directory the controller writes the serving certificate it signs itself to
+	SelfSignedCertDir = "/tmp/vm-import-controller/webhook-certs"
+
+	certFile = "tls.crt"
+	keyFile  = "tls.key"
+	// certTimeout bounds the wait for the serving certificate issued by the service CA and for the webhook
+	// configuration created by the operator
+	certTimeout = 2 * time.Minute
+)
+
+// ServingCertDir returns the directory of the serving certificate of the admission webhook. On OpenShift, the
+// certificate is issued by the service CA into the secret mounted at CertDir, which may be populated after the
+// controller started. Elsewhere, the controller signs the certificate itself and injects its CA into the webhook
+// configuration.
+func ServingCertDir(cfg *rest.Config, namespace string) (string, error) {
+	if hasCert(CertDir) {
+		return CertDir, nil
+	}
+	dc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return "", err
+	}
+	openShift, err := k8sutil.ResourceExists(dc, "security.openshift.io/v1", "SecurityContextConstraints")
+	if err != nil {
+		return "", err
+	}
+	if openShift {
+		err = wait.PollImmediate(time.Second, certTimeout, func() (bool, error) {
+			return hasCert(CertDir), nil
+		})
+		return CertDir, err
+	}
+
+	c, err := client.New(cfg, client.Options{})
+	if err != nil {
+		return "", err
+	}
+	return SelfSignedCertDir, SelfSign(c, namespace, SelfSignedCertDir)
+}
+
+// SelfSign writes a serving certificate for the service of the admission webhook to the directory, and injects the CA
+// that signed it into the webhook configuration once the operator created it. A new certificate is signed on every
+// start of the controller.
+func SelfSign(c client.Client, namespace string, dir string) error {
+	service := WebhookName + "." + namespace + ".svc"
+	certPEM, keyPEM, err := cert.GenerateSelfSignedCertKey(service, nil, []string{WebhookName + "." + namespace, service + ".cluster.local"})
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, certFile), certPEM, 0600); err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(filepath.Join(dir, keyFile), keyPEM, 0600); err != nil {
+		return err
+	}
+
+	// the certificate is followed by the CA that signed it, which the API server trusts through the bundle
+	return wait.PollImmediate(time.Second, certTimeout, func() (bool, error) {
+		configuration := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		err := c.Get(context.TODO(), types.NamespacedName{Name: WebhookName}, configuration)
+		if err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		for i := range configuration.Webhooks {
+			configuration.Webhooks[i].ClientConfig.CABundle = certPEM
+		}
+		return true, c.Update(context.TODO(), configuration)
+	})
+}
+
+func hasCert(dir string) bool {
+	for _, file := range []string{certFile, keyFile} {
+		if _, err := os.Stat(filepath.Join(dir, file)); err != nil {
+			return false
+		}
+	}
+	return true
+}
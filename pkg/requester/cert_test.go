@@ -0,0 +1,54 @@
+package requester_test
+
+import (
+	"context"
+	"crypto/tls"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/kubevirt/vm-import-operator/pkg/requester"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SelfSign", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "webhook-certs")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should write the serving certificate and inject its CA into the webhook configuration", func() {
+		scheme := runtime.NewScheme()
+		Expect(admissionregistrationv1.AddToScheme(scheme)).To(Succeed())
+		configuration := &admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: requester.WebhookName},
+			Webhooks:   []admissionregistrationv1.MutatingWebhook{{Name: "requester.virtualmachineimports.v2v.kubevirt.io"}},
+		}
+		c := fake.NewFakeClientWithScheme(scheme, configuration)
+
+		err := requester.SelfSign(c, "kubevirt-hyperconverged", dir)
+
+		Expect(err).ToNot(HaveOccurred())
+		_, err = tls.LoadX509KeyPair(filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"))
+		Expect(err).ToNot(HaveOccurred())
+		certPEM, err := ioutil.ReadFile(filepath.Join(dir, "tls.crt"))
+		Expect(err).ToNot(HaveOccurred())
+		updated := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Name: requester.WebhookName}, updated)).To(Succeed())
+		Expect(updated.Webhooks[0].ClientConfig.CABundle).To(Equal(certPEM))
+	})
+})
@@ -0,0 +1,101 @@
+package requester
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// Annotation holds the user who created the import, as recorded by the admission webhook
+	Annotation = "vmimport.v2v.kubevirt.io/requester"
+	// Label makes the admission webhook record the user who creates the import. Only the labeled imports go through
+	// the webhook, so that the other imports don't depend on the controller to be admitted.
+	Label = "vmimport.v2v.kubevirt.io/record-requester"
+
+	// WebhookName is the name of the mutating webhook configuration and of the service of the admission webhook
+	WebhookName = "vm-import-controller-webhook"
+
+	// WebhookPath is the path the controller serves the admission webhook at
+	WebhookPath = "/mutate-v2v-kubevirt-io-v1beta1-virtualmachineimport"
+	// WebhookPort is the port the controller serves the admission webhook at
+	WebhookPort = 9443
+	// CertDir is the directory the serving certificate of the admission webhook is mounted at
+	CertDir = "/etc/vm-import-controller/webhook-certs"
+)
+
+// Get returns the user who created the import, or nil when the admission webhook didn't record it. The annotation of
+// an import without the label is never trusted, since the import didn't go through the webhook.
+func Get(obj metav1.Object) (*authenticationv1.UserInfo, error) {
+	if _, labeled := obj.GetLabels()[Label]; !labeled {
+		return nil, nil
+	}
+	value, ok := obj.GetAnnotations()[Annotation]
+	if !ok {
+		return nil, nil
+	}
+	userInfo := &authenticationv1.UserInfo{}
+	if err := json.Unmarshal([]byte(value), userInfo); err != nil {
+		return nil, fmt.Errorf("annotation %s is invalid: %v", Annotation, err)
+	}
+	return userInfo, nil
+}
+
+// Annotator records the user who creates an import in its annotation. The annotation is overwritten on creation and
+// restored on update, so it can't be forged by the requester nor changed afterwards. An import labeled on update didn't
+// go through the webhook on creation, so its annotation is removed instead.
+type Annotator struct{}
+
+// Handle implements admission.Handler
+func (a *Annotator) Handle(_ context.Context, req admission.Request) admission.Response {
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(req.Object.Raw); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var value *string
+	switch req.Operation {
+	case admissionv1beta1.Create:
+		userInfo, err := json.Marshal(req.UserInfo)
+		if err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		recorded := string(userInfo)
+		value = &recorded
+	case admissionv1beta1.Update:
+		old := &unstructured.Unstructured{}
+		if err := old.UnmarshalJSON(req.OldObject.Raw); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		_, labeled := old.GetLabels()[Label]
+		if recorded, ok := old.GetAnnotations()[Annotation]; ok && labeled {
+			value = &recorded
+		}
+	default:
+		return admission.Allowed("")
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	if value != nil {
+		annotations[Annotation] = *value
+	} else {
+		delete(annotations, Annotation)
+	}
+	obj.SetAnnotations(annotations)
+
+	mutated, err := obj.MarshalJSON()
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, mutated)
+}
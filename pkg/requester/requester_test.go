@@ -0,0 +1,169 @@
+package requester_test
+
+import (
+	"context"
+	"encoding/json"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/requester"
+	jsonpatch "gomodules.xyz/jsonpatch/v2"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var alice = authenticationv1.UserInfo{
+	Username: "alice",
+	UID:      "1",
+	Groups:   []string{"migrators", "system:authenticated"},
+}
+
+var _ = Describe("Annotator", func() {
+	annotator := requester.Annotator{}
+
+	It("should record the user who creates the import", func() {
+		response := annotator.Handle(context.TODO(), request(admissionv1beta1.Create, alice, newImport(nil), nil))
+
+		Expect(response.Allowed).To(BeTrue())
+		Expect(response.Patches).To(ConsistOf(jsonpatch.JsonPatchOperation{
+			Operation: "add",
+			Path:      "/metadata/annotations",
+			Value:     map[string]interface{}{requester.Annotation: marshal(alice)},
+		}))
+	})
+
+	It("should overwrite a requester forged on creation", func() {
+		forged := newImport(map[string]string{requester.Annotation: `{"username":"admin"}`})
+
+		response := annotator.Handle(context.TODO(), request(admissionv1beta1.Create, alice, forged, nil))
+
+		Expect(response.Allowed).To(BeTrue())
+		Expect(response.Patches).To(ConsistOf(jsonpatch.JsonPatchOperation{
+			Operation: "replace",
+			Path:      "/metadata/annotations/vmimport.v2v.kubevirt.io~1requester",
+			Value:     marshal(alice),
+		}))
+	})
+
+	It("should restore the recorded requester on update", func() {
+		old := newImport(map[string]string{requester.Annotation: marshal(alice)})
+		changed := newImport(map[string]string{requester.Annotation: `{"username":"admin"}`})
+
+		response := annotator.Handle(context.TODO(), request(admissionv1beta1.Update, authenticationv1.UserInfo{Username: "bob"}, changed, old))
+
+		Expect(response.Allowed).To(BeTrue())
+		Expect(response.Patches).To(ConsistOf(jsonpatch.JsonPatchOperation{
+			Operation: "replace",
+			Path:      "/metadata/annotations/vmimport.v2v.kubevirt.io~1requester",
+			Value:     marshal(alice),
+		}))
+	})
+
+	It("should not let a requester be added on update", func() {
+		added := newImport(map[string]string{requester.Annotation: `{"username":"admin"}`})
+
+		response := annotator.Handle(context.TODO(), request(admissionv1beta1.Update, authenticationv1.UserInfo{Username: "bob"}, added, newImport(nil)))
+
+		Expect(response.Allowed).To(BeTrue())
+		Expect(response.Patches).To(ConsistOf(jsonpatch.JsonPatchOperation{
+			Operation: "remove",
+			Path:      "/metadata/annotations/vmimport.v2v.kubevirt.io~1requester",
+		}))
+	})
+
+	It("should leave an unchanged requester as is on update", func() {
+		recorded := newImport(map[string]string{requester.Annotation: marshal(alice)})
+
+		response := annotator.Handle(context.TODO(), request(admissionv1beta1.Update, authenticationv1.UserInfo{Username: "bob"}, recorded, recorded))
+
+		Expect(response.Allowed).To(BeTrue())
+		Expect(response.Patches).To(BeEmpty())
+	})
+
+	It("should remove a requester of an import labeled on update", func() {
+		unlabeled := newImport(map[string]string{requester.Annotation: marshal(alice)})
+		unlabeled.Labels = nil
+
+		response := annotator.Handle(context.TODO(), request(admissionv1beta1.Update, alice, newImport(map[string]string{requester.Annotation: marshal(alice)}), unlabeled))
+
+		Expect(response.Allowed).To(BeTrue())
+		Expect(response.Patches).To(ConsistOf(jsonpatch.JsonPatchOperation{
+			Operation: "remove",
+			Path:      "/metadata/annotations/vmimport.v2v.kubevirt.io~1requester",
+		}))
+	})
+})
+
+var _ = Describe("Get", func() {
+	It("should return the recorded requester", func() {
+		instance := newImport(map[string]string{requester.Annotation: marshal(alice)})
+
+		userInfo, err := requester.Get(instance)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*userInfo).To(Equal(alice))
+	})
+
+	It("should return nil when the requester isn't recorded", func() {
+		userInfo, err := requester.Get(newImport(nil))
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(userInfo).To(BeNil())
+	})
+
+	It("should not trust the requester of an unlabeled import", func() {
+		instance := newImport(map[string]string{requester.Annotation: marshal(alice)})
+		instance.Labels = nil
+
+		userInfo, err := requester.Get(instance)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(userInfo).To(BeNil())
+	})
+
+	It("should fail when the requester is invalid", func() {
+		_, err := requester.Get(newImport(map[string]string{requester.Annotation: "alice"}))
+
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+func newImport(annotations map[string]string) *v2vv1.VirtualMachineImport {
+	return &v2vv1.VirtualMachineImport{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v2v.kubevirt.io/v1beta1",
+			Kind:       "VirtualMachineImport",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "db-import",
+			Namespace:   "migration",
+			Labels:      map[string]string{requester.Label: ""},
+			Annotations: annotations,
+		},
+	}
+}
+
+func request(operation admissionv1beta1.Operation, userInfo authenticationv1.UserInfo, obj, old *v2vv1.VirtualMachineImport) admission.Request {
+	req := admission.Request{
+		AdmissionRequest: admissionv1beta1.AdmissionRequest{
+			Operation: operation,
+			UserInfo:  userInfo,
+			Object:    runtime.RawExtension{Raw: []byte(marshal(obj))},
+		},
+	}
+	if old != nil {
+		req.OldObject = runtime.RawExtension{Raw: []byte(marshal(old))}
+	}
+	return req
+}
+
+func marshal(obj interface{}) string {
+	raw, err := json.Marshal(obj)
+	Expect(err).ToNot(HaveOccurred())
+	return string(raw)
+}
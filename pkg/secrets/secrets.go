@@ -4,11 +4,11 @@ import (
 	"context"
 	"fmt"
 
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	"github.com/kubevirt/vm-import-operator/pkg/utils"
 
-	"k8s.io/apimachinery/pkg/types"
-
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -27,14 +27,13 @@ func NewManager(client client.Client) Manager {
 	return Manager{client: client}
 }
 
-// FindFor retrieves secret associated with vmiCrName. If none can be found, both error and pointer will be nil. When there is more than 1 matching secret, error will be returned.
-func (m *Manager) FindFor(vmiCrName types.NamespacedName) (*corev1.Secret, error) {
+// FindFor retrieves the secret of the VM import in its target namespace. If none can be found, both error and pointer
+// will be nil. When there is more than 1 matching secret, error will be returned.
+func (m *Manager) FindFor(cr *v2vv1.VirtualMachineImport) (*corev1.Secret, error) {
 	secretList := corev1.SecretList{}
-	labels := client.MatchingLabels{
-		vmiNameLabel: utils.EnsureLabelValueLength(vmiCrName.Name),
-	}
+	selector := utils.ImportSelector(cr, map[string]string{vmiNameLabel: utils.EnsureLabelValueLength(cr.Name)})
 
-	err := m.client.List(context.TODO(), &secretList, labels, client.InNamespace(vmiCrName.Namespace))
+	err := m.client.List(context.TODO(), &secretList, client.MatchingLabelsSelector{Selector: selector}, client.InNamespace(utils.TargetNamespace(cr)))
 	if err != nil {
 		return nil, err
 	}
@@ -44,13 +43,14 @@ func (m *Manager) FindFor(vmiCrName types.NamespacedName) (*corev1.Secret, error
 	case 0:
 		return nil, nil
 	default:
-		return nil, fmt.Errorf("too many secrets matching given labels: %v", labels)
+		return nil, fmt.Errorf("too many secrets matching given labels: %v", selector)
 	}
 }
 
-// CreateFor creates given secret, overriding given Name with a generated one. The secret will be associated with vmiCrName.
-func (m *Manager) CreateFor(secret *corev1.Secret, vmiCrName types.NamespacedName) error {
-	secret.Namespace = vmiCrName.Namespace
+// CreateFor creates given secret in the target namespace of the VM import, overriding given Name with a generated one.
+// The secret will be associated with the VM import.
+func (m *Manager) CreateFor(secret *corev1.Secret, cr *v2vv1.VirtualMachineImport) error {
+	secret.Namespace = utils.TargetNamespace(cr)
 	// Force generation
 	secret.GenerateName = prefix
 	secret.Name = ""
@@ -58,19 +58,34 @@ func (m *Manager) CreateFor(secret *corev1.Secret, vmiCrName types.NamespacedNam
 	if secret.Labels == nil {
 		secret.Labels = make(map[string]string)
 	}
-	secret.Labels[vmiNameLabel] = utils.EnsureLabelValueLength(vmiCrName.Name)
+	utils.AppendMap(secret.Labels, importLabels(cr))
 
 	return m.client.Create(context.TODO(), secret)
 }
 
-// DeleteFor removes secret associated with vmiCrName.
-func (m *Manager) DeleteFor(vmiCrName types.NamespacedName) error {
-	secret, err := m.FindFor(vmiCrName)
+// DeleteFor removes the secret of the VM import.
+func (m *Manager) DeleteFor(cr *v2vv1.VirtualMachineImport) error {
+	secret, err := m.FindFor(cr)
 	if err != nil {
 		return err
 	}
 	if secret != nil {
-		return m.client.Delete(context.TODO(), secret)
+		err = m.client.Delete(context.TODO(), secret)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
 	}
 	return nil
 }
+
+// importLabels identify the secret of the VM import. A secret in another namespace is also labeled with the namespace of
+// the import, since imports of the same name from different namespaces may share the target namespace.
+func importLabels(cr *v2vv1.VirtualMachineImport) client.MatchingLabels {
+	labels := client.MatchingLabels{
+		vmiNameLabel: utils.EnsureLabelValueLength(cr.Name),
+	}
+	if utils.IsCrossNamespace(cr) {
+		utils.AppendMap(labels, utils.ImportTrackingLabels(cr))
+	}
+	return labels
+}
@@ -0,0 +1,76 @@
+package secrets_test
+
+import (
+	"context"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/secrets"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Secrets Manager", func() {
+	var (
+		rclient client.Client
+		manager secrets.Manager
+	)
+	targetNamespace := "db"
+
+	newImport := func(namespace string) *v2vv1.VirtualMachineImport {
+		return &v2vv1.VirtualMachineImport{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-import", Namespace: namespace},
+			Spec:       v2vv1.VirtualMachineImportSpec{TargetNamespace: &targetNamespace},
+		}
+	}
+
+	BeforeEach(func() {
+		rclient = fake.NewFakeClient()
+		manager = secrets.NewManager(rclient)
+	})
+
+	It("should create the secret in the target namespace", func() {
+		instance := newImport("migration")
+
+		err := manager.CreateFor(&corev1.Secret{}, instance)
+		Expect(err).ToNot(HaveOccurred())
+
+		secret, err := manager.FindFor(instance)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret).ToNot(BeNil())
+		Expect(secret.Namespace).To(Equal(targetNamespace))
+		Expect(secret.Labels).To(HaveKeyWithValue("vmimport.v2v.kubevirt.io/import-namespace", "migration"))
+	})
+
+	It("should not find the secret of an import of the same name from another namespace", func() {
+		err := manager.CreateFor(&corev1.Secret{}, newImport("migration"))
+		Expect(err).ToNot(HaveOccurred())
+
+		secret, err := manager.FindFor(newImport("staging"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret).To(BeNil())
+
+		secret, err = manager.FindFor(newImport(targetNamespace))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret).To(BeNil())
+	})
+
+	It("should only delete the secret of the import", func() {
+		instance := newImport("migration")
+		other := newImport("staging")
+		Expect(manager.CreateFor(&corev1.Secret{}, instance)).To(Succeed())
+		Expect(manager.CreateFor(&corev1.Secret{}, other)).To(Succeed())
+
+		err := manager.DeleteFor(instance)
+		Expect(err).ToNot(HaveOccurred())
+
+		secretList := corev1.SecretList{}
+		Expect(rclient.List(context.TODO(), &secretList, client.InNamespace(targetNamespace))).To(Succeed())
+		Expect(secretList.Items).To(HaveLen(1))
+		Expect(secretList.Items[0].Labels).To(HaveKeyWithValue("vmimport.v2v.kubevirt.io/import-namespace", "staging"))
+	})
+})
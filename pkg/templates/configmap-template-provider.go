@@ -0,0 +1,121 @@
+package templates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	templatev1 "github.com/openshift/api/template/v1"
+	tempclient "github.com/openshift/client-go/template/clientset/versioned/typed/template/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kubevirtv1 "kubevirt.io/client-go/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// BackendOpenShift reads the templates from the OpenShift template API
+	BackendOpenShift = "openshift"
+
+	// BackendConfigMap reads the templates from config maps holding VM base specs
+	BackendConfigMap = "configMap"
+
+	// BaseSpecKey is the config map key holding the VM base spec
+	BaseSpecKey = "vm"
+)
+
+// ConfigMapTemplates is responsible for finding and processing VM base specs stored in config maps. It doesn't need
+// OpenShift: the config maps carry the OS, workload and flavor labels of the templates, and the base spec is a
+// VirtualMachine manifest under the vm key.
+type ConfigMapTemplates struct {
+	Client client.Client
+}
+
+// NewConfigMapTemplateProvider creates new TemplateProvider reading VM base specs from config maps
+func NewConfigMapTemplateProvider(client client.Client) *ConfigMapTemplates {
+	return &ConfigMapTemplates{
+		Client: client,
+	}
+}
+
+// NewTemplateProviderFor creates the TemplateProvider of the given backend
+func NewTemplateProviderFor(backend string, client client.Client, tempClient *tempclient.TemplateV1Client) TemplateProvider {
+	if backend == BackendConfigMap {
+		return NewConfigMapTemplateProvider(client)
+	}
+	return NewTemplateProvider(tempClient)
+}
+
+// Find looks for the config maps with the base specs based on given namespace and options
+func (t *ConfigMapTemplates) Find(namespace *string, os *string, workload *string, flavor *string) (*templatev1.TemplateList, error) {
+	configMaps := corev1.ConfigMapList{}
+	err := t.Client.List(context.TODO(), &configMaps, client.InNamespace(*namespace), client.MatchingLabels(OSLabelBuilder(os, workload, flavor)))
+	if err != nil {
+		return nil, err
+	}
+	list := &templatev1.TemplateList{}
+	for i := range configMaps.Items {
+		list.Items = append(list.Items, *toTemplate(&configMaps.Items[i]))
+	}
+	return list, nil
+}
+
+// Get fetches the config map with the base spec by namespace and name
+func (t *ConfigMapTemplates) Get(namespace string, name string) (*templatev1.Template, error) {
+	configMap := corev1.ConfigMap{}
+	err := t.Client.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: name}, &configMap)
+	if err != nil {
+		return nil, err
+	}
+	return toTemplate(&configMap), nil
+}
+
+// Process names the VM of the base spec, which has no parameters to process
+func (t *ConfigMapTemplates) Process(namespace string, vmName *string, template *templatev1.Template) (*templatev1.Template, error) {
+	if len(template.Objects) == 0 {
+		return nil, fmt.Errorf("config map %s/%s has no VM base spec under the %s key", template.Namespace, template.Name, BaseSpecKey)
+	}
+	vm := &kubevirtv1.VirtualMachine{}
+	err := yaml.Unmarshal(template.Objects[0].Raw, vm)
+	if err != nil {
+		return nil, fmt.Errorf("config map %s/%s has an invalid VM base spec: %v", template.Namespace, template.Name, err)
+	}
+	if vm.Spec.Template == nil {
+		return nil, fmt.Errorf("config map %s/%s has a VM base spec without a VM instance template", template.Namespace, template.Name)
+	}
+
+	vm.APIVersion = kubevirtv1.GroupVersion.String()
+	vm.Kind = "VirtualMachine"
+	vm.Namespace = namespace
+	if vmName != nil {
+		vm.Name = *vmName
+	}
+	raw, err := json.Marshal(vm)
+	if err != nil {
+		return nil, err
+	}
+
+	result := template.DeepCopy()
+	result.Objects = []runtime.RawExtension{{Raw: raw}}
+	return result, nil
+}
+
+// toTemplate wraps the base spec of the config map in a template with the metadata of the config map
+func toTemplate(configMap *corev1.ConfigMap) *templatev1.Template {
+	template := &templatev1.Template{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              configMap.Name,
+			Namespace:         configMap.Namespace,
+			Labels:            configMap.Labels,
+			Annotations:       configMap.Annotations,
+			CreationTimestamp: configMap.CreationTimestamp,
+		},
+	}
+	if spec, ok := configMap.Data[BaseSpecKey]; ok {
+		template.Objects = []runtime.RawExtension{{Raw: []byte(spec)}}
+	}
+	return template
+}
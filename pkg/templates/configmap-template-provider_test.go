@@ -0,0 +1,109 @@
+package templates_test
+
+import (
+	"github.com/kubevirt/vm-import-operator/pkg/templates"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const baseSpec = `apiVersion: kubevirt.io/v1alpha3
+kind: VirtualMachine
+metadata:
+  name: rhel8-base
+spec:
+  template:
+    spec:
+      domain:
+        cpu:
+          cores: 2
+        devices:
+          interfaces:
+          - name: default
+            masquerade: {}
+      networks:
+      - name: default
+        pod: {}
+`
+
+var _ = Describe("Config map templates", func() {
+	var provider *templates.ConfigMapTemplates
+
+	newConfigMap := func(name string, labels map[string]string, data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "vm-templates",
+				Labels:      labels,
+				Annotations: map[string]string{"name.os.template.kubevirt.io/rhel8.2": "Red Hat Enterprise Linux 8.2"},
+			},
+			Data: data,
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		provider = templates.NewConfigMapTemplateProvider(fake.NewFakeClientWithScheme(scheme,
+			newConfigMap("rhel8-server-medium", map[string]string{
+				"os.template.kubevirt.io/rhel8.2":      "true",
+				"workload.template.kubevirt.io/server": "true",
+				"flavor.template.kubevirt.io/medium":   "true",
+			}, map[string]string{"vm": baseSpec}),
+			newConfigMap("rhel8-desktop-medium", map[string]string{
+				"os.template.kubevirt.io/rhel8.2":       "true",
+				"workload.template.kubevirt.io/desktop": "true",
+				"flavor.template.kubevirt.io/medium":    "true",
+			}, map[string]string{"vm": baseSpec}),
+			newConfigMap("empty", nil, nil),
+		))
+	})
+
+	It("should find the config maps by OS, workload and flavor labels", func() {
+		os, workload, flavor, namespace := "rhel8.2", "server", "medium", "vm-templates"
+
+		list, err := provider.Find(&namespace, &os, &workload, &flavor)
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list.Items).To(HaveLen(1))
+		Expect(list.Items[0].Name).To(Equal("rhel8-server-medium"))
+		Expect(list.Items[0].Namespace).To(Equal("vm-templates"))
+		Expect(list.Items[0].Annotations).To(HaveKeyWithValue("name.os.template.kubevirt.io/rhel8.2", "Red Hat Enterprise Linux 8.2"))
+	})
+
+	It("should process the base spec into a VM", func() {
+		template, err := provider.Get("vm-templates", "rhel8-server-medium")
+		Expect(err).ToNot(HaveOccurred())
+		vmName := "imported"
+
+		vm, err := templates.NewTemplateHandler(provider).ProcessTemplate(template, &vmName, "prod")
+
+		Expect(err).ToNot(HaveOccurred())
+		Expect(vm.Name).To(Equal("imported"))
+		Expect(vm.Namespace).To(Equal("prod"))
+		Expect(vm.Spec.Template.Spec.Domain.CPU.Cores).To(BeEquivalentTo(2))
+		Expect(vm.Spec.Template.Spec.Networks).To(BeEmpty())
+		Expect(vm.Labels).To(HaveKeyWithValue("vm.kubevirt.io/template", "rhel8-server-medium"))
+		Expect(vm.Labels).To(HaveKeyWithValue("vm.kubevirt.io/template.namespace", "vm-templates"))
+		Expect(vm.Spec.Template.ObjectMeta.Labels).To(HaveKeyWithValue("vm.kubevirt.io/name", "imported"))
+	})
+
+	It("should fail to process a config map without a base spec", func() {
+		template, err := provider.Get("vm-templates", "empty")
+		Expect(err).ToNot(HaveOccurred())
+		vmName := "imported"
+
+		_, err = provider.Process("prod", &vmName, template)
+
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail to get a missing config map", func() {
+		_, err := provider.Get("vm-templates", "missing")
+
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -1,6 +1,8 @@
 package templates
 
 import (
+	"strings"
+
 	templatev1 "github.com/openshift/api/template/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kubevirtv1 "kubevirt.io/client-go/api/v1"
@@ -62,6 +64,51 @@ func (f *TemplateHandler) ProcessTemplate(template *templatev1.Template, vmName
 	return vm, nil
 }
 
+// UpdateMetadata replaces the OS, workload and flavor labels and the OS name annotation of the VM with the given
+// ones, and points the template labels of the VM at the template
+func UpdateMetadata(vm *kubevirtv1.VirtualMachine, template *templatev1.Template, labels map[string]string, annotations map[string]string) {
+	vmLabels := vm.GetLabels()
+	for key := range vmLabels {
+		if hasAnyPrefix(key, TemplateOsLabel, TemplateWorkloadLabel, TemplateFlavorLabel) {
+			delete(vmLabels, key)
+		}
+	}
+	vmAnnotations := vm.GetAnnotations()
+	for key := range vmAnnotations {
+		if hasAnyPrefix(key, TemplateNameOsAnnotation) {
+			delete(vmAnnotations, key)
+		}
+	}
+
+	if vmLabels == nil {
+		vmLabels = make(map[string]string)
+		vm.SetLabels(vmLabels)
+	}
+	for key, value := range labels {
+		vmLabels[key] = value
+	}
+	vmLabels[templateNameLabel] = template.GetName()
+	vmLabels[templateNamespace] = template.GetNamespace()
+
+	if vmAnnotations == nil {
+		vmAnnotations = make(map[string]string)
+		vm.SetAnnotations(vmAnnotations)
+	}
+	for key, value := range annotations {
+		vmAnnotations[key] = value
+	}
+}
+
+// hasAnyPrefix checks whether the key is formatted with any of the label or annotation formats
+func hasAnyPrefix(key string, formats ...string) bool {
+	for _, format := range formats {
+		if strings.HasPrefix(key, strings.TrimSuffix(format, "%s")) {
+			return true
+		}
+	}
+	return false
+}
+
 func addLabels(vm *kubevirtv1.VirtualMachine, template *templatev1.Template) {
 	labels := vm.ObjectMeta.GetLabels()
 	if labels == nil {
@@ -71,5 +118,9 @@ func addLabels(vm *kubevirtv1.VirtualMachine, template *templatev1.Template) {
 	labels[templateNameLabel] = template.GetObjectMeta().GetName()
 	labels[templateNamespace] = template.GetObjectMeta().GetNamespace()
 	tempLabels := vm.Spec.Template.ObjectMeta.GetLabels()
+	if tempLabels == nil {
+		tempLabels = make(map[string]string)
+		vm.Spec.Template.ObjectMeta.SetLabels(tempLabels)
+	}
 	tempLabels[vmNameLabel] = vm.GetName()
 }
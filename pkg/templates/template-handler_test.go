@@ -63,6 +63,43 @@ var _ = Describe("Processing a template", func() {
 	})
 })
 
+var _ = Describe("Updating the metadata of a VM", func() {
+	It("should replace the OS metadata and the template labels", func() {
+		vm := createVM("default", "testName")
+		vm.SetLabels(map[string]string{
+			"os.template.kubevirt.io/rhel8":        "true",
+			"workload.template.kubevirt.io/server": "true",
+			"flavor.template.kubevirt.io/medium":   "true",
+			"vm.kubevirt.io/template":              "rhel8-server-medium",
+			"app":                                  "db",
+		})
+		vm.SetAnnotations(map[string]string{
+			"name.os.template.kubevirt.io/rhel8": "Red Hat Enterprise Linux 8.0 or higher",
+			"description":                        "database",
+		})
+		name := "win2k19-server-medium"
+		os := "win2k19"
+		workload := "server"
+		flavor := "medium"
+		template := createTemplate(&name, &os, &workload, &flavor)
+
+		templates.UpdateMetadata(vm, template, template.Labels, map[string]string{"name.os.template.kubevirt.io/win2k19": "Microsoft Windows Server 2019"})
+
+		Expect(vm.GetLabels()).To(Equal(map[string]string{
+			"os.template.kubevirt.io/win2k19":      "true",
+			"workload.template.kubevirt.io/server": "true",
+			"flavor.template.kubevirt.io/medium":   "true",
+			"vm.kubevirt.io/template":              "win2k19-server-medium",
+			"vm.kubevirt.io/template.namespace":    "testns",
+			"app":                                  "db",
+		}))
+		Expect(vm.GetAnnotations()).To(Equal(map[string]string{
+			"name.os.template.kubevirt.io/win2k19": "Microsoft Windows Server 2019",
+			"description":                          "database",
+		}))
+	})
+})
+
 func createTemplate(name *string, os *string, workload *string, flavor *string) *templatev1.Template {
 	template := templatev1.Template{
 		ObjectMeta: metav1.ObjectMeta{
@@ -86,6 +123,11 @@ func (t *mockTemplateProvider) Find(
 	return &templatev1.TemplateList{}, nil
 }
 
+// Get mocks the behavior of the client for calling template API to get a template by name
+func (t *mockTemplateProvider) Get(namespace string, name string) (*templatev1.Template, error) {
+	return nil, fmt.Errorf("template %s/%s not found", namespace, name)
+}
+
 func createVM(namespace string, name string) *v1.VirtualMachine {
 	labels := map[string]string{"name": name}
 	running := false
@@ -37,6 +37,7 @@ type Templates struct {
 // TemplateProvider searches for and processes templates in Openshift
 type TemplateProvider interface {
 	Find(namespace *string, os *string, workload *string, flavor *string) (*templatev1.TemplateList, error)
+	Get(namespace string, name string) (*templatev1.Template, error)
 	Process(namespace string, vmName *string, template *templatev1.Template) (*templatev1.Template, error)
 }
 
@@ -56,6 +57,11 @@ func (t *Templates) Find(namespace *string, os *string, workload *string, flavor
 	return t.Client.Templates(*namespace).List(context.TODO(), options)
 }
 
+// Get fetches a template by namespace and name
+func (t *Templates) Get(namespace string, name string) (*templatev1.Template, error) {
+	return t.Client.Templates(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+}
+
 // Process calls the openshift api to process parameters
 func (t *Templates) Process(namespace string, vmName *string, template *templatev1.Template) (*templatev1.Template, error) {
 	temp := template.DeepCopy()
@@ -0,0 +1,107 @@
+package templates
+
+import (
+	"fmt"
+	"sort"
+
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	templatev1 "github.com/openshift/api/template/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Search defines the templates matching a source VM, the workloads and flavors in order of preference
+type Search struct {
+	Namespace string
+	OS        string
+	Workloads []string
+	Flavors   []string
+}
+
+// IsPinned returns whether the spec of the import pins the template
+func IsPinned(spec *v2vv1.TemplateSpec) bool {
+	return spec != nil && spec.Name != nil
+}
+
+// SelectPinned returns the template the spec of the import pins, looked up in the namespace of the spec or else the
+// given one, and the reason it was chosen. It doesn't depend on the source VM, so it's done before anything about the
+// VM is looked up.
+func SelectPinned(provider TemplateProvider, spec *v2vv1.TemplateSpec, namespace string) (*templatev1.Template, string, error) {
+	if spec.Namespace != nil {
+		namespace = *spec.Namespace
+	}
+	template, err := provider.Get(namespace, *spec.Name)
+	if err != nil {
+		return nil, "", err
+	}
+	return template, "Pinned by the import spec", nil
+}
+
+// Select returns the template the spec of the import pins, or else the newest template matching the operating system,
+// the first flavor and workload with any template, in turn, and the labels of the spec. The workload and flavor of the
+// spec are searched first. It also returns the reason the template was chosen, and a nil template when none matches.
+func Select(provider TemplateProvider, spec *v2vv1.TemplateSpec, search Search) (*templatev1.Template, string, error) {
+	if spec == nil {
+		spec = &v2vv1.TemplateSpec{}
+	}
+	if IsPinned(spec) {
+		return SelectPinned(provider, spec, search.Namespace)
+	}
+	namespace := search.Namespace
+	if spec.Namespace != nil {
+		namespace = *spec.Namespace
+	}
+
+	selector := labels.SelectorFromSet(spec.Labels)
+	for _, flavor := range Prefer(spec.Flavor, search.Flavors) {
+		for _, workload := range Prefer(spec.Workload, search.Workloads) {
+			os, workload, flavor := search.OS, workload, flavor
+			list, err := provider.Find(&namespace, &os, &workload, &flavor)
+			if err != nil {
+				return nil, "", err
+			}
+			var matching []templatev1.Template
+			for _, template := range list.Items {
+				if selector.Matches(labels.Set(template.Labels)) {
+					matching = append(matching, template)
+				}
+			}
+			if len(matching) == 0 {
+				continue
+			}
+			// Take the newest
+			sort.Slice(matching, func(i, j int) bool {
+				return matching[j].CreationTimestamp.Before(&matching[i].CreationTimestamp)
+			})
+			reason := fmt.Sprintf("Newest template for the %s OS with the %s workload and the %s flavor", os, workload, flavor)
+			if len(spec.Labels) > 0 {
+				reason += " carrying the labels of the import spec"
+			}
+			return &matching[0], reason, nil
+		}
+	}
+	return nil, "", nil
+}
+
+// Prefer returns the values with the preferred one, if any, moved first
+func Prefer(preferred *string, values []string) []string {
+	if preferred == nil {
+		return values
+	}
+	result := []string{*preferred}
+	for _, value := range values {
+		if value != *preferred {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// LabelValue returns the first of the values the template carries the label for, or nil
+func LabelValue(template *templatev1.Template, label string, values []string) *string {
+	for i := range values {
+		if _, ok := template.Labels[fmt.Sprintf(label, values[i])]; ok {
+			return &values[i]
+		}
+	}
+	return nil
+}
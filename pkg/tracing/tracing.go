@@ -0,0 +1,154 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"google.golang.org/grpc/credentials"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// EndpointEnvVar is the environment variable holding the host:port of the OTLP gRPC receiver the spans are exported to
+	EndpointEnvVar = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	// InsecureEnvVar is the environment variable disabling TLS of the connection to the OTLP receiver
+	InsecureEnvVar = "OTEL_EXPORTER_OTLP_INSECURE"
+
+	tracerName  = "github.com/kubevirt/vm-import-operator"
+	serviceName = "vm-import-controller"
+)
+
+var (
+	// reconciles holds the contexts of the reconciles in progress, keyed by UID of the import
+	reconciles     = make(map[types.UID]context.Context)
+	reconcilesLock sync.Mutex
+)
+
+// Init sets up the export of the spans to the OTLP receiver configured by EndpointEnvVar. When no receiver is
+// configured, the spans are not recorded. The returned function flushes the pending spans and closes the exporter.
+func Init() (func(), error) {
+	endpoint := os.Getenv(EndpointEnvVar)
+	if endpoint == "" {
+		return func() {}, nil
+	}
+
+	options := []otlp.ExporterOption{otlp.WithAddress(endpoint)}
+	if insecure, _ := strconv.ParseBool(os.Getenv(InsecureEnvVar)); insecure {
+		options = append(options, otlp.WithInsecure())
+	} else {
+		options = append(options, otlp.WithTLSCredentials(credentials.NewClientTLSFromCert(nil, "")))
+	}
+	exporter, err := otlp.NewExporter(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	processor := sdktrace.NewBatchSpanProcessor(exporter)
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithResource(resource.New(semconv.ServiceNameKey.String(serviceName))),
+	)
+	global.SetTracerProvider(provider)
+
+	return func() {
+		// Unregistering the processor flushes the spans that haven't been exported yet
+		provider.UnregisterSpanProcessor(processor)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		exporter.Shutdown(ctx)
+	}, nil
+}
+
+// StartReconcile starts the span of a reconcile of the import. Until EndReconcile is called, the spans started for the
+// import become children of the reconcile span.
+func StartReconcile(uid types.UID, attrs ...label.KeyValue) trace.Span {
+	ctx, span := global.Tracer(tracerName).Start(importContext(uid), "Reconcile", trace.WithAttributes(attrs...))
+
+	reconcilesLock.Lock()
+	defer reconcilesLock.Unlock()
+	reconciles[uid] = ctx
+
+	return span
+}
+
+// EndReconcile ends the span of a reconcile of the import
+func EndReconcile(uid types.UID, span trace.Span, err error) {
+	reconcilesLock.Lock()
+	delete(reconciles, uid)
+	reconcilesLock.Unlock()
+
+	EndSpan(span, err)
+}
+
+// ImportContext returns the context the spans of the import are started from: the context of the reconcile in progress
+// or, when there's none, the context of the import trace.
+func ImportContext(uid types.UID) context.Context {
+	reconcilesLock.Lock()
+	defer reconcilesLock.Unlock()
+
+	if ctx, found := reconciles[uid]; found {
+		return ctx
+	}
+	return importContext(uid)
+}
+
+// StartSpan starts a span of an operation within the import
+func StartSpan(uid types.UID, name string, attrs ...label.KeyValue) (context.Context, trace.Span) {
+	return Start(ImportContext(uid), name, attrs...)
+}
+
+// Start starts a span as a child of the span in the context, or a new trace when there's no context
+func Start(ctx context.Context, name string, attrs ...label.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return global.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// RecordSpan records an operation within the import which has already finished, e.g. a disk import observed over
+// several reconciles
+func RecordSpan(uid types.UID, name string, start time.Time, end time.Time, err error, attrs ...label.KeyValue) {
+	_, span := global.Tracer(tracerName).Start(ImportContext(uid), name, trace.WithAttributes(attrs...), trace.WithTimestamp(start))
+	if err != nil {
+		span.RecordError(context.Background(), err, trace.WithErrorTime(end))
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End(trace.WithTimestamp(end))
+}
+
+// EndSpan records the error, if any, and ends the span
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(context.Background(), err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// importContext returns a context carrying the trace of the import. The trace ID is the UID of the import,
+// so the spans of all the reconciles of the import belong to the same trace.
+func importContext(uid types.UID) context.Context {
+	traceID, err := trace.IDFromHex(strings.ReplaceAll(string(uid), "-", ""))
+	if err != nil {
+		return context.Background()
+	}
+	var spanID trace.SpanID
+	copy(spanID[:], traceID[8:])
+	return trace.ContextWithRemoteSpanContext(context.Background(), trace.SpanContext{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+}
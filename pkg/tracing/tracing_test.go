@@ -0,0 +1,56 @@
+package tracing_test
+
+import (
+	"github.com/kubevirt/vm-import-operator/pkg/tracing"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"k8s.io/apimachinery/pkg/types"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Import tracing", func() {
+	const uid = types.UID("4ba4a9e6-0d1f-4b5d-9b0e-2a3f5a7c8d91")
+	const traceID = "4ba4a9e60d1f4b5d9b0e2a3f5a7c8d91"
+
+	BeforeEach(func() {
+		global.SetTracerProvider(sdktrace.NewTracerProvider())
+	})
+
+	It("should use the import UID as the trace ID", func() {
+		ctx := tracing.ImportContext(uid)
+
+		Expect(trace.RemoteSpanContextFromContext(ctx).TraceID.String()).To(Equal(traceID))
+	})
+
+	It("should start the spans of the import as children of the reconcile in progress", func() {
+		reconcileSpan := tracing.StartReconcile(uid)
+		defer tracing.EndReconcile(uid, reconcileSpan, nil)
+
+		_, span := tracing.StartSpan(uid, "CreateDataVolume")
+		defer tracing.EndSpan(span, nil)
+
+		Expect(reconcileSpan.SpanContext().TraceID.String()).To(Equal(traceID))
+		Expect(span.SpanContext().TraceID.String()).To(Equal(traceID))
+		Expect(trace.SpanFromContext(tracing.ImportContext(uid)).SpanContext().SpanID).To(Equal(reconcileSpan.SpanContext().SpanID))
+	})
+
+	It("should keep the trace of the import after the reconcile ends", func() {
+		reconcileSpan := tracing.StartReconcile(uid)
+		tracing.EndReconcile(uid, reconcileSpan, nil)
+
+		_, span := tracing.StartSpan(uid, "ImportDisk")
+		defer tracing.EndSpan(span, nil)
+
+		Expect(span.SpanContext().TraceID.String()).To(Equal(traceID))
+		Expect(trace.SpanFromContext(tracing.ImportContext(uid)).SpanContext().IsValid()).To(BeFalse())
+	})
+
+	It("should start a new trace when the UID isn't valid", func() {
+		ctx := tracing.ImportContext(types.UID("invalid"))
+
+		Expect(trace.RemoteSpanContextFromContext(ctx).IsValid()).To(BeFalse())
+	})
+})
@@ -11,6 +11,9 @@ import (
 
 	cdiv1 "kubevirt.io/containerized-data-importer/pkg/apis/core/v1alpha1"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	v1 "kubevirt.io/client-go/api/v1"
 
@@ -26,6 +29,22 @@ const (
 
 	// Finalaizer for handling cancelled import
 	CancelledImportFinalizer = "vmimport.v2v.kubevirt.io/cancelled-import"
+
+	// SourceDiskIDAnnotation stores the ID of the source disk imported to a data volume
+	SourceDiskIDAnnotation = "vmimport.v2v.kubevirt.io/source-disk-id"
+
+	// SourceDiskNameAnnotation stores the name of the source disk imported to a data volume
+	SourceDiskNameAnnotation = "vmimport.v2v.kubevirt.io/source-disk-name"
+
+	// ImportNamespaceLabel selects the objects created by the imports of a namespace in another namespace, where
+	// owner references to the imports are not allowed
+	ImportNamespaceLabel = "vmimport.v2v.kubevirt.io/import-namespace"
+
+	// ImportNameLabel selects, along with ImportNamespaceLabel, the objects created by an import in another namespace
+	ImportNameLabel = "vmimport.v2v.kubevirt.io/import-name"
+
+	// ImportAnnotation stores the namespaced name of the import that created an object in another namespace
+	ImportAnnotation = "vmimport.v2v.kubevirt.io/import"
 )
 
 var (
@@ -342,6 +361,97 @@ func FoldCleanUpErrors(errs []error, vmiName k8stypes.NamespacedName) error {
 	return fmt.Errorf("clean-up for %v failed: %s", ToLoggableResourceName(vmiName.Name, &vmiName.Namespace), message)
 }
 
+// TargetNamespace returns the namespace the VM, the data volumes and the pods of the import are created in
+func TargetNamespace(cr *v2vv1.VirtualMachineImport) string {
+	if cr.Spec.TargetNamespace != nil && *cr.Spec.TargetNamespace != "" {
+		return *cr.Spec.TargetNamespace
+	}
+	return cr.Namespace
+}
+
+// TargetNamespacedName returns the name of the import in its target namespace
+func TargetNamespacedName(cr *v2vv1.VirtualMachineImport) k8stypes.NamespacedName {
+	return k8stypes.NamespacedName{Name: cr.Name, Namespace: TargetNamespace(cr)}
+}
+
+// IsCrossNamespace checks whether the objects of the import are created outside of its namespace, where they are
+// tracked by labels instead of owner references
+func IsCrossNamespace(cr *v2vv1.VirtualMachineImport) bool {
+	return TargetNamespace(cr) != cr.Namespace
+}
+
+// ImportTrackingLabels returns the labels selecting the objects created by the import in another namespace
+func ImportTrackingLabels(cr *v2vv1.VirtualMachineImport) map[string]string {
+	return map[string]string{
+		ImportNamespaceLabel: cr.Namespace,
+		ImportNameLabel:      EnsureLabelValueLength(cr.Name),
+	}
+}
+
+// ImportSelector selects the objects of the import labeled with the given labels. Imports of the same name from
+// different namespaces may share the target namespace, so the objects of the import are told apart by its tracking
+// labels, which the objects of the imports in the target namespace itself don't have.
+func ImportSelector(cr *v2vv1.VirtualMachineImport, set map[string]string) labels.Selector {
+	if IsCrossNamespace(cr) {
+		tracked := make(map[string]string)
+		AppendMap(tracked, set)
+		AppendMap(tracked, ImportTrackingLabels(cr))
+		return labels.SelectorFromSet(tracked)
+	}
+	untracked, _ := labels.NewRequirement(ImportNamespaceLabel, selection.DoesNotExist, nil)
+	return labels.SelectorFromSet(set).Add(*untracked)
+}
+
+// SetImportTracking labels and annotates the object as created by the import
+func SetImportTracking(obj metav1.Object, cr *v2vv1.VirtualMachineImport) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	AppendMap(labels, ImportTrackingLabels(cr))
+	obj.SetLabels(labels)
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[ImportAnnotation] = ToLoggableResourceName(cr.Name, &cr.Namespace)
+	obj.SetAnnotations(annotations)
+}
+
+// RemoveImportTracking removes the labels and the annotation set by SetImportTracking. It returns whether the object
+// changed.
+func RemoveImportTracking(obj metav1.Object) bool {
+	labels := obj.GetLabels()
+	annotations := obj.GetAnnotations()
+	_, hasNamespace := labels[ImportNamespaceLabel]
+	_, hasName := labels[ImportNameLabel]
+	_, hasAnnotation := annotations[ImportAnnotation]
+	if !hasNamespace && !hasName && !hasAnnotation {
+		return false
+	}
+	delete(labels, ImportNamespaceLabel)
+	delete(labels, ImportNameLabel)
+	delete(annotations, ImportAnnotation)
+	obj.SetLabels(labels)
+	obj.SetAnnotations(annotations)
+	return true
+}
+
+// TrackedImport returns the namespaced name of the import that created the object in another namespace, or nil when
+// the object isn't tracked
+func TrackedImport(obj metav1.Object) *k8stypes.NamespacedName {
+	value, ok := obj.GetAnnotations()[ImportAnnotation]
+	if !ok {
+		return nil
+	}
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil
+	}
+	return &k8stypes.NamespacedName{Namespace: parts[0], Name: parts[1]}
+}
+
 // UpdateLabels updates a VirtualMachine's labels with values from a provided map, overwriting any duplicates
 func UpdateLabels(vm *v1.VirtualMachine, labels map[string]string) {
 	AppendMap(vm.ObjectMeta.GetLabels(), labels)
@@ -4,10 +4,15 @@ import (
 	"strings"
 
 	"github.com/alecthomas/units"
+	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
 	"github.com/kubevirt/vm-import-operator/pkg/utils"
 	. "github.com/onsi/ginkgo"
 	"github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 )
 
@@ -184,6 +189,62 @@ var _ = Describe("Label generation", func() {
 	)
 })
 
+var _ = Describe("Target namespace", func() {
+	var instance *v2vv1.VirtualMachineImport
+
+	BeforeEach(func() {
+		instance = &v2vv1.VirtualMachineImport{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "migration"}}
+	})
+
+	It("should default to the namespace of the import", func() {
+		Expect(utils.TargetNamespace(instance)).To(Equal("migration"))
+		Expect(utils.IsCrossNamespace(instance)).To(BeFalse())
+	})
+
+	It("should be the namespace of the spec", func() {
+		apps := "apps"
+		instance.Spec.TargetNamespace = &apps
+
+		Expect(utils.TargetNamespacedName(instance)).To(Equal(types.NamespacedName{Name: "db", Namespace: "apps"}))
+		Expect(utils.IsCrossNamespace(instance)).To(BeTrue())
+	})
+
+	It("should track the import on the object and stop tracking it", func() {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "db"}}}
+
+		utils.SetImportTracking(pod, instance)
+
+		Expect(pod.Labels).To(Equal(map[string]string{
+			"app":                      "db",
+			utils.ImportNamespaceLabel: "migration",
+			utils.ImportNameLabel:      "db",
+		}))
+		Expect(utils.TrackedImport(pod)).To(Equal(&types.NamespacedName{Name: "db", Namespace: "migration"}))
+
+		Expect(utils.RemoveImportTracking(pod)).To(BeTrue())
+		Expect(pod.Labels).To(Equal(map[string]string{"app": "db"}))
+		Expect(utils.TrackedImport(pod)).To(BeNil())
+		Expect(utils.RemoveImportTracking(pod)).To(BeFalse())
+	})
+
+	It("should select the objects of the import only", func() {
+		apps := "apps"
+		other := instance.DeepCopy()
+		other.Namespace = "staging"
+		other.Spec.TargetNamespace = &apps
+		instance.Spec.TargetNamespace = &apps
+		local := instance.DeepCopy()
+		local.Namespace = apps
+		set := map[string]string{"app": "db"}
+		tracked := labels.Set{"app": "db", utils.ImportNamespaceLabel: "migration", utils.ImportNameLabel: "db"}
+
+		Expect(utils.ImportSelector(instance, set).Matches(tracked)).To(BeTrue())
+		Expect(utils.ImportSelector(other, set).Matches(tracked)).To(BeFalse())
+		Expect(utils.ImportSelector(local, set).Matches(tracked)).To(BeFalse())
+		Expect(utils.ImportSelector(local, set).Matches(labels.Set(set))).To(BeTrue())
+	})
+})
+
 func createStringOfLength(n int) string {
 	return strings.Repeat("x", n)
 }
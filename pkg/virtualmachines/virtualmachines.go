@@ -4,6 +4,7 @@ import (
 	"context"
 
 	v2vv1 "github.com/kubevirt/vm-import-operator/pkg/apis/v2v/v1beta1"
+	"github.com/kubevirt/vm-import-operator/pkg/utils"
 	"k8s.io/apimachinery/pkg/types"
 
 	kubevirtv1 "kubevirt.io/client-go/api/v1"
@@ -37,7 +38,7 @@ func (m *Manager) FindFor(vmiCrName types.NamespacedName) (*kubevirtv1.VirtualMa
 	}
 
 	vm := &kubevirtv1.VirtualMachine{}
-	if err := m.client.Get(context.TODO(), types.NamespacedName{Name: instance.Status.TargetVMName, Namespace: instance.Namespace}, vm); err != nil {
+	if err := m.client.Get(context.TODO(), types.NamespacedName{Name: instance.Status.TargetVMName, Namespace: utils.TargetNamespace(instance)}, vm); err != nil {
 		return nil, err
 	}
 